@@ -132,6 +132,29 @@ func (c *Client) MeContext(ctx context.Context) (*User, error) {
 	return user, nil
 }
 
+// MeStats returns the reading statistics of the logged user.
+func (c *Client) MeStats() (*UserStats, error) {
+	ctx, cancel := withDefaultTimeout()
+	defer cancel()
+	return c.MeStatsContext(ctx)
+}
+
+// MeStatsContext returns the reading statistics of the logged user.
+func (c *Client) MeStatsContext(ctx context.Context) (*UserStats, error) {
+	body, err := c.request.Get(ctx, "/v1/me/stats")
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var stats *UserStats
+	if err := json.NewDecoder(body).Decode(&stats); err != nil {
+		return nil, fmt.Errorf("miniflux: json error (%v)", err)
+	}
+
+	return stats, nil
+}
+
 // Users returns all users.
 func (c *Client) Users() (Users, error) {
 	ctx, cancel := withDefaultTimeout()
@@ -697,6 +720,272 @@ func (c *Client) UserTagEntriesContext(ctx context.Context, tagID int64, filter
 	return &result, nil
 }
 
+// SavedSearches gets all saved searches.
+func (c *Client) SavedSearches() (SavedSearches, error) {
+	ctx, cancel := withDefaultTimeout()
+	defer cancel()
+	return c.SavedSearchesContext(ctx)
+}
+
+// SavedSearchesContext gets all saved searches.
+func (c *Client) SavedSearchesContext(ctx context.Context) (SavedSearches, error) {
+	return c.fetchSavedSearches(ctx, "/v1/saved-searches")
+}
+
+// SavedSearchesWithCounters gets all saved searches with their unread counters.
+func (c *Client) SavedSearchesWithCounters() (SavedSearches, error) {
+	ctx, cancel := withDefaultTimeout()
+	defer cancel()
+	return c.SavedSearchesWithCountersContext(ctx)
+}
+
+// SavedSearchesWithCountersContext gets all saved searches with their unread counters.
+func (c *Client) SavedSearchesWithCountersContext(ctx context.Context) (SavedSearches, error) {
+	return c.fetchSavedSearches(ctx, "/v1/saved-searches?counts=true")
+}
+
+func (c *Client) fetchSavedSearches(ctx context.Context, path string) (SavedSearches, error) {
+	body, err := c.request.Get(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var savedSearches SavedSearches
+	if err := json.NewDecoder(body).Decode(&savedSearches); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return savedSearches, nil
+}
+
+// CreateSavedSearch creates a new saved search.
+func (c *Client) CreateSavedSearch(title, query string) (*SavedSearch, error) {
+	ctx, cancel := withDefaultTimeout()
+	defer cancel()
+	return c.CreateSavedSearchContext(ctx, title, query)
+}
+
+// CreateSavedSearchContext creates a new saved search.
+func (c *Client) CreateSavedSearchContext(ctx context.Context, title, query string) (*SavedSearch, error) {
+	body, err := c.request.Post(ctx, "/v1/saved-searches", &SavedSearchCreationRequest{
+		Title: title,
+		Query: query,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var savedSearch *SavedSearch
+	if err := json.NewDecoder(body).Decode(&savedSearch); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return savedSearch, nil
+}
+
+// UpdateSavedSearch updates a saved search.
+func (c *Client) UpdateSavedSearch(savedSearchID int64, savedSearchChanges *SavedSearchModificationRequest) (*SavedSearch, error) {
+	ctx, cancel := withDefaultTimeout()
+	defer cancel()
+	return c.UpdateSavedSearchContext(ctx, savedSearchID, savedSearchChanges)
+}
+
+// UpdateSavedSearchContext updates a saved search.
+func (c *Client) UpdateSavedSearchContext(ctx context.Context, savedSearchID int64, savedSearchChanges *SavedSearchModificationRequest) (*SavedSearch, error) {
+	body, err := c.request.Put(ctx, fmt.Sprintf("/v1/saved-searches/%d", savedSearchID), savedSearchChanges)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var savedSearch *SavedSearch
+	if err := json.NewDecoder(body).Decode(&savedSearch); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return savedSearch, nil
+}
+
+// DeleteSavedSearch removes a saved search.
+func (c *Client) DeleteSavedSearch(savedSearchID int64) error {
+	ctx, cancel := withDefaultTimeout()
+	defer cancel()
+	return c.DeleteSavedSearchContext(ctx, savedSearchID)
+}
+
+// DeleteSavedSearchContext removes a saved search.
+func (c *Client) DeleteSavedSearchContext(ctx context.Context, savedSearchID int64) error {
+	return c.request.Delete(ctx, fmt.Sprintf("/v1/saved-searches/%d", savedSearchID))
+}
+
+// SavedSearchEntries fetches entries matching a saved search.
+func (c *Client) SavedSearchEntries(savedSearchID int64, filter *Filter) (*EntryResultSet, error) {
+	ctx, cancel := withDefaultTimeout()
+	defer cancel()
+	return c.SavedSearchEntriesContext(ctx, savedSearchID, filter)
+}
+
+// SavedSearchEntriesContext fetches entries matching a saved search.
+func (c *Client) SavedSearchEntriesContext(ctx context.Context, savedSearchID int64, filter *Filter) (*EntryResultSet, error) {
+	path := buildFilterQueryString(fmt.Sprintf("/v1/saved-searches/%d/entries", savedSearchID), filter)
+
+	body, err := c.request.Get(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var result EntryResultSet
+	if err := json.NewDecoder(body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return &result, nil
+}
+
+// PreviewRules evaluates candidate rules against the most recent stored entries.
+func (c *Client) PreviewRules(rulePreviewRequest *RulePreviewRequest) (*RulePreview, error) {
+	ctx, cancel := withDefaultTimeout()
+	defer cancel()
+	return c.PreviewRulesContext(ctx, rulePreviewRequest)
+}
+
+// PreviewRulesContext evaluates candidate rules against the most recent stored entries.
+func (c *Client) PreviewRulesContext(ctx context.Context, rulePreviewRequest *RulePreviewRequest) (*RulePreview, error) {
+	body, err := c.request.Post(ctx, "/v1/rules/preview", rulePreviewRequest)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var rulePreview *RulePreview
+	if err := json.NewDecoder(body).Decode(&rulePreview); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return rulePreview, nil
+}
+
+// ApplyRules starts applying the rules to the stored unread entries in the background.
+func (c *Client) ApplyRules(ruleJobRequest *RuleJobRequest) (*RuleJob, error) {
+	ctx, cancel := withDefaultTimeout()
+	defer cancel()
+	return c.ApplyRulesContext(ctx, ruleJobRequest)
+}
+
+// ApplyRulesContext starts applying the rules to the stored unread entries in the background.
+func (c *Client) ApplyRulesContext(ctx context.Context, ruleJobRequest *RuleJobRequest) (*RuleJob, error) {
+	body, err := c.request.Post(ctx, "/v1/rules/jobs", ruleJobRequest)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var ruleJob *RuleJob
+	if err := json.NewDecoder(body).Decode(&ruleJob); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return ruleJob, nil
+}
+
+// RuleJob gets the status and the progress of a rule job.
+func (c *Client) RuleJob(jobID int64) (*RuleJob, error) {
+	ctx, cancel := withDefaultTimeout()
+	defer cancel()
+	return c.RuleJobContext(ctx, jobID)
+}
+
+// RuleJobContext gets the status and the progress of a rule job.
+func (c *Client) RuleJobContext(ctx context.Context, jobID int64) (*RuleJob, error) {
+	body, err := c.request.Get(ctx, fmt.Sprintf("/v1/rules/jobs/%d", jobID))
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var ruleJob *RuleJob
+	if err := json.NewDecoder(body).Decode(&ruleJob); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return ruleJob, nil
+}
+
+// FeedRuleAudit gets the hit counts of the rules applied to a feed and its recently blocked entries.
+func (c *Client) FeedRuleAudit(feedID int64) (*RuleAudit, error) {
+	ctx, cancel := withDefaultTimeout()
+	defer cancel()
+	return c.FeedRuleAuditContext(ctx, feedID)
+}
+
+// FeedRuleAuditContext gets the hit counts of the rules applied to a feed and its recently blocked entries.
+func (c *Client) FeedRuleAuditContext(ctx context.Context, feedID int64) (*RuleAudit, error) {
+	body, err := c.request.Get(ctx, fmt.Sprintf("/v1/feeds/%d/rules/audit", feedID))
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var ruleAudit *RuleAudit
+	if err := json.NewDecoder(body).Decode(&ruleAudit); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return ruleAudit, nil
+}
+
+// RescueBlockedEntry stores a blocked entry as unread and returns the entry ID.
+func (c *Client) RescueBlockedEntry(feedID, blockedEntryID int64) (int64, error) {
+	ctx, cancel := withDefaultTimeout()
+	defer cancel()
+	return c.RescueBlockedEntryContext(ctx, feedID, blockedEntryID)
+}
+
+// RescueBlockedEntryContext stores a blocked entry as unread and returns the entry ID.
+func (c *Client) RescueBlockedEntryContext(ctx context.Context, feedID, blockedEntryID int64) (int64, error) {
+	body, err := c.request.Post(ctx, fmt.Sprintf("/v1/feeds/%d/blocked-entries/%d/rescue", feedID, blockedEntryID), nil)
+	if err != nil {
+		return 0, err
+	}
+	defer body.Close()
+
+	var response struct {
+		ID int64 `json:"id"`
+	}
+
+	if err := json.NewDecoder(body).Decode(&response); err != nil {
+		return 0, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return response.ID, nil
+}
+
+// PreviewScraper scrapes a web page with candidate scraper and rewrite rules, without storing anything.
+func (c *Client) PreviewScraper(scraperPreviewRequest *ScraperPreviewRequest) (*ScraperPreview, error) {
+	ctx, cancel := withDefaultTimeout()
+	defer cancel()
+	return c.PreviewScraperContext(ctx, scraperPreviewRequest)
+}
+
+// PreviewScraperContext scrapes a web page with candidate scraper and rewrite rules, without storing anything.
+func (c *Client) PreviewScraperContext(ctx context.Context, scraperPreviewRequest *ScraperPreviewRequest) (*ScraperPreview, error) {
+	body, err := c.request.Post(ctx, "/v1/scraper/preview", scraperPreviewRequest)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var scraperPreview *ScraperPreview
+	if err := json.NewDecoder(body).Decode(&scraperPreview); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return scraperPreview, nil
+}
+
 // SetEntryUserTags sets the user tags for an entry.
 func (c *Client) SetEntryUserTags(entryID int64, userTagIDs []int64) error {
 	ctx, cancel := withDefaultTimeout()
@@ -944,6 +1233,29 @@ func (c *Client) FeedIconContext(ctx context.Context, feedID int64) (*FeedIcon,
 	return feedIcon, nil
 }
 
+// RefreshFeedIcon downloads the icon of a feed again.
+func (c *Client) RefreshFeedIcon(feedID int64) (*FeedIcon, error) {
+	ctx, cancel := withDefaultTimeout()
+	defer cancel()
+	return c.RefreshFeedIconContext(ctx, feedID)
+}
+
+// RefreshFeedIconContext downloads the icon of a feed again.
+func (c *Client) RefreshFeedIconContext(ctx context.Context, feedID int64) (*FeedIcon, error) {
+	body, err := c.request.Put(ctx, fmt.Sprintf("/v1/feeds/%d/icon/refresh", feedID), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var feedIcon *FeedIcon
+	if err := json.NewDecoder(body).Decode(&feedIcon); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return feedIcon, nil
+}
+
 // FeedEntry gets a single feed entry.
 func (c *Client) FeedEntry(feedID, entryID int64) (*Entry, error) {
 	ctx, cancel := withDefaultTimeout()
@@ -1013,6 +1325,87 @@ func (c *Client) EntryContext(ctx context.Context, entryID int64) (*Entry, error
 	return entry, nil
 }
 
+// EntryRevisions gets the previous revisions of an entry, the most recent first.
+func (c *Client) EntryRevisions(entryID int64) (EntryRevisions, error) {
+	ctx, cancel := withDefaultTimeout()
+	defer cancel()
+	return c.EntryRevisionsContext(ctx, entryID)
+}
+
+// EntryRevisionsContext gets the previous revisions of an entry, the most recent first.
+func (c *Client) EntryRevisionsContext(ctx context.Context, entryID int64) (EntryRevisions, error) {
+	body, err := c.request.Get(ctx, fmt.Sprintf("/v1/entries/%d/revisions", entryID))
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var revisions EntryRevisions
+	if err := json.NewDecoder(body).Decode(&revisions); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return revisions, nil
+}
+
+// EntrySnapshot gets the offline snapshot of an entry, with its HTML document.
+func (c *Client) EntrySnapshot(entryID int64) (*EntrySnapshot, error) {
+	ctx, cancel := withDefaultTimeout()
+	defer cancel()
+	return c.EntrySnapshotContext(ctx, entryID)
+}
+
+// EntrySnapshotContext gets the offline snapshot of an entry, with its HTML document.
+func (c *Client) EntrySnapshotContext(ctx context.Context, entryID int64) (*EntrySnapshot, error) {
+	body, err := c.request.Get(ctx, fmt.Sprintf("/v1/entries/%d/snapshot", entryID))
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var snapshot *EntrySnapshot
+	if err := json.NewDecoder(body).Decode(&snapshot); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return snapshot, nil
+}
+
+// CreateEntrySnapshot downloads the web page of an entry with its images and stylesheets, and stores it.
+func (c *Client) CreateEntrySnapshot(entryID int64) (*EntrySnapshot, error) {
+	ctx, cancel := withDefaultTimeout()
+	defer cancel()
+	return c.CreateEntrySnapshotContext(ctx, entryID)
+}
+
+// CreateEntrySnapshotContext downloads the web page of an entry with its images and stylesheets, and stores it.
+func (c *Client) CreateEntrySnapshotContext(ctx context.Context, entryID int64) (*EntrySnapshot, error) {
+	body, err := c.request.Post(ctx, fmt.Sprintf("/v1/entries/%d/snapshot", entryID), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var snapshot *EntrySnapshot
+	if err := json.NewDecoder(body).Decode(&snapshot); err != nil {
+		return nil, fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return snapshot, nil
+}
+
+// RemoveEntrySnapshot deletes the offline snapshot of an entry.
+func (c *Client) RemoveEntrySnapshot(entryID int64) error {
+	ctx, cancel := withDefaultTimeout()
+	defer cancel()
+	return c.RemoveEntrySnapshotContext(ctx, entryID)
+}
+
+// RemoveEntrySnapshotContext deletes the offline snapshot of an entry.
+func (c *Client) RemoveEntrySnapshotContext(ctx context.Context, entryID int64) error {
+	return c.request.Delete(ctx, fmt.Sprintf("/v1/entries/%d/snapshot", entryID))
+}
+
 // Entries fetches entries using the given filter.
 func (c *Client) Entries(filter *Filter) (*EntryResultSet, error) {
 	ctx, cancel := withDefaultTimeout()
@@ -1195,6 +1588,32 @@ func (c *Client) FetchEntryOriginalContentContext(ctx context.Context, entryID i
 	return response.Content, nil
 }
 
+// SummarizeEntry summarizes an entry with the configured summary API and stores the summary.
+func (c *Client) SummarizeEntry(entryID int64) (string, error) {
+	ctx, cancel := withDefaultTimeout()
+	defer cancel()
+	return c.SummarizeEntryContext(ctx, entryID)
+}
+
+// SummarizeEntryContext summarizes an entry with the configured summary API and stores the summary.
+func (c *Client) SummarizeEntryContext(ctx context.Context, entryID int64) (string, error) {
+	body, err := c.request.Post(ctx, fmt.Sprintf("/v1/entries/%d/summary", entryID), nil)
+	if err != nil {
+		return "", err
+	}
+	defer body.Close()
+
+	var response struct {
+		Summary string `json:"summary"`
+	}
+
+	if err := json.NewDecoder(body).Decode(&response); err != nil {
+		return "", fmt.Errorf("miniflux: response error (%v)", err)
+	}
+
+	return response.Summary, nil
+}
+
 // FetchCounters fetches feed counters.
 func (c *Client) FetchCounters() (*FeedCounters, error) {
 	ctx, cancel := withDefaultTimeout()
@@ -1354,6 +1773,10 @@ func buildFilterQueryString(path string, filter *Filter) string {
 			values.Set("search", filter.Search)
 		}
 
+		if filter.Language != "" {
+			values.Set("language", filter.Language)
+		}
+
 		if filter.CategoryID > 0 {
 			values.Set("category_id", strconv.FormatInt(filter.CategoryID, 10))
 		}
@@ -941,6 +941,28 @@ func TestFeedIcon(t *testing.T) {
 	}
 }
 
+func TestRefreshFeedIcon(t *testing.T) {
+	expected := &FeedIcon{
+		ID:       2,
+		MimeType: "image/png",
+		Data:     "image/png;base64,data",
+	}
+	client := NewClientWithOptions(
+		"http://mf",
+		WithHTTPClient(
+			newFakeHTTPClient(t, func(t *testing.T, req *http.Request) *http.Response {
+				expectRequest(t, http.MethodPut, "http://mf/v1/feeds/1/icon/refresh", nil, req)
+				return jsonResponseFrom(t, http.StatusOK, http.Header{}, expected)
+			})))
+	res, err := client.RefreshFeedIconContext(t.Context(), 1)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual(res, expected) {
+		t.Fatalf("Expected %s, got %s", asJSON(expected), asJSON(res))
+	}
+}
+
 func TestFeedEntry(t *testing.T) {
 	expected := &Entry{
 		ID:    1,
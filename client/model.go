@@ -50,6 +50,8 @@ type User struct {
 	OpenExternalLinksInNewTab bool       `json:"open_external_links_in_new_tab"`
 	ShowVotingButtons         bool       `json:"show_voting_buttons"`
 	ShowFeedTags              bool       `json:"show_feed_tags"`
+	DuplicateEntriesAction    string     `json:"duplicate_entries_action"`
+	EntryRules                string     `json:"entry_rules"`
 }
 
 func (u User) String() string {
@@ -99,6 +101,8 @@ type UserModificationRequest struct {
 	OpenExternalLinksInNewTab *bool    `json:"open_external_links_in_new_tab"`
 	ShowVotingButtons         *bool    `json:"show_voting_buttons"`
 	ShowFeedTags              *bool    `json:"show_feed_tags"`
+	DuplicateEntriesAction    *string  `json:"duplicate_entries_action"`
+	EntryRules                *string  `json:"entry_rules"`
 }
 
 // Users represents a list of users.
@@ -106,12 +110,27 @@ type Users []User
 
 // Category represents a feed category.
 type Category struct {
-	ID           int64  `json:"id"`
-	Title        string `json:"title"`
-	UserID       int64  `json:"user_id,omitempty"`
-	HideGlobally bool   `json:"hide_globally,omitempty"`
-	FeedCount    *int   `json:"feed_count,omitempty"`
-	TotalUnread  *int   `json:"total_unread,omitempty"`
+	ID                    int64  `json:"id"`
+	Title                 string `json:"title"`
+	UserID                int64  `json:"user_id,omitempty"`
+	HideGlobally          bool   `json:"hide_globally,omitempty"`
+	EntryRules            string `json:"entry_rules,omitempty"`
+	ScraperRules          string `json:"scraper_rules,omitempty"`
+	RewriteRules          string `json:"rewrite_rules,omitempty"`
+	UrlRewriteRules       string `json:"urlrewrite_rules,omitempty"`
+	BlocklistRules        string `json:"blocklist_rules,omitempty"`
+	KeeplistRules         string `json:"keeplist_rules,omitempty"`
+	BlockFilterEntryRules string `json:"block_filter_entry_rules,omitempty"`
+	KeepFilterEntryRules  string `json:"keep_filter_entry_rules,omitempty"`
+	Crawler               bool   `json:"crawler,omitempty"`
+	UserAgent             string `json:"user_agent,omitempty"`
+	FetchViaProxy         bool   `json:"fetch_via_proxy,omitempty"`
+	ProxyURL              string `json:"proxy_url,omitempty"`
+	EnrichmentProcessors  string `json:"enrichment_processors,omitempty"`
+	SummarizeEntries      bool   `json:"summarize_entries,omitempty"`
+	SummaryPrompt         string `json:"summary_prompt,omitempty"`
+	FeedCount             *int   `json:"feed_count,omitempty"`
+	TotalUnread           *int   `json:"total_unread,omitempty"`
 }
 
 func (c Category) String() string {
@@ -123,14 +142,44 @@ type Categories []*Category
 
 // CategoryCreationRequest represents the request to create a category.
 type CategoryCreationRequest struct {
-	Title        string `json:"title"`
-	HideGlobally bool   `json:"hide_globally"`
+	Title                 string `json:"title"`
+	HideGlobally          bool   `json:"hide_globally"`
+	EntryRules            string `json:"entry_rules,omitempty"`
+	ScraperRules          string `json:"scraper_rules,omitempty"`
+	RewriteRules          string `json:"rewrite_rules,omitempty"`
+	UrlRewriteRules       string `json:"urlrewrite_rules,omitempty"`
+	BlocklistRules        string `json:"blocklist_rules,omitempty"`
+	KeeplistRules         string `json:"keeplist_rules,omitempty"`
+	BlockFilterEntryRules string `json:"block_filter_entry_rules,omitempty"`
+	KeepFilterEntryRules  string `json:"keep_filter_entry_rules,omitempty"`
+	Crawler               bool   `json:"crawler,omitempty"`
+	UserAgent             string `json:"user_agent,omitempty"`
+	FetchViaProxy         bool   `json:"fetch_via_proxy,omitempty"`
+	ProxyURL              string `json:"proxy_url,omitempty"`
+	EnrichmentProcessors  string `json:"enrichment_processors,omitempty"`
+	SummarizeEntries      bool   `json:"summarize_entries,omitempty"`
+	SummaryPrompt         string `json:"summary_prompt,omitempty"`
 }
 
 // CategoryModificationRequest represents the request to update a category.
 type CategoryModificationRequest struct {
-	Title        *string `json:"title"`
-	HideGlobally *bool   `json:"hide_globally"`
+	Title                 *string `json:"title"`
+	HideGlobally          *bool   `json:"hide_globally"`
+	EntryRules            *string `json:"entry_rules,omitempty"`
+	ScraperRules          *string `json:"scraper_rules,omitempty"`
+	RewriteRules          *string `json:"rewrite_rules,omitempty"`
+	UrlRewriteRules       *string `json:"urlrewrite_rules,omitempty"`
+	BlocklistRules        *string `json:"blocklist_rules,omitempty"`
+	KeeplistRules         *string `json:"keeplist_rules,omitempty"`
+	BlockFilterEntryRules *string `json:"block_filter_entry_rules,omitempty"`
+	KeepFilterEntryRules  *string `json:"keep_filter_entry_rules,omitempty"`
+	Crawler               *bool   `json:"crawler,omitempty"`
+	UserAgent             *string `json:"user_agent,omitempty"`
+	FetchViaProxy         *bool   `json:"fetch_via_proxy,omitempty"`
+	ProxyURL              *string `json:"proxy_url,omitempty"`
+	EnrichmentProcessors  *string `json:"enrichment_processors,omitempty"`
+	SummarizeEntries      *bool   `json:"summarize_entries,omitempty"`
+	SummaryPrompt         *string `json:"summary_prompt,omitempty"`
 }
 
 // Subscription represents a feed subscription.
@@ -147,6 +196,13 @@ func (s Subscription) String() string {
 // Subscriptions represents a list of subscriptions.
 type Subscriptions []*Subscription
 
+// Overrides of the feed settings inherited from the category.
+const (
+	FeedSettingInherit = "inherit"
+	FeedSettingOn      = "on"
+	FeedSettingOff     = "off"
+)
+
 // Feed represents a Miniflux feed.
 type Feed struct {
 	ID                          int64     `json:"id"`
@@ -163,6 +219,7 @@ type Feed struct {
 	IgnoreHTTPCache             bool      `json:"ignore_http_cache"`
 	AllowSelfSignedCertificates bool      `json:"allow_self_signed_certificates"`
 	FetchViaProxy               bool      `json:"fetch_via_proxy"`
+	FetchViaProxyOverride       string    `json:"fetch_via_proxy_override"`
 	ScraperRules                string    `json:"scraper_rules"`
 	RewriteRules                string    `json:"rewrite_rules"`
 	UrlRewriteRules             string    `json:"urlrewrite_rules"`
@@ -171,7 +228,14 @@ type Feed struct {
 	BlockFilterEntryRules       string    `json:"block_filter_entry_rules"`
 	KeepFilterEntryRules        string    `json:"keep_filter_entry_rules"`
 	Crawler                     bool      `json:"crawler"`
+	CrawlerOverride             string    `json:"crawler_override"`
 	IgnoreEntryUpdates          bool      `json:"ignore_entry_updates"`
+	EntryRules                  string    `json:"entry_rules"`
+	MarkUnreadOnEntryRevision   bool      `json:"mark_unread_on_entry_revision"`
+	SnapshotEntries             bool      `json:"snapshot_entries"`
+	MirrorEnclosures            bool      `json:"mirror_enclosures"`
+	MirrorEnclosuresLimit       int       `json:"mirror_enclosures_limit"`
+	EnrichmentProcessors        string    `json:"enrichment_processors"`
 	UserAgent                   string    `json:"user_agent"`
 	Cookie                      string    `json:"cookie"`
 	Username                    string    `json:"username"`
@@ -191,11 +255,13 @@ type FeedCreationRequest struct {
 	Username                    string `json:"username"`
 	Password                    string `json:"password"`
 	Crawler                     bool   `json:"crawler"`
+	CrawlerOverride             string `json:"crawler_override,omitempty"`
 	IgnoreEntryUpdates          bool   `json:"ignore_entry_updates"`
 	Disabled                    bool   `json:"disabled"`
 	IgnoreHTTPCache             bool   `json:"ignore_http_cache"`
 	AllowSelfSignedCertificates bool   `json:"allow_self_signed_certificates"`
 	FetchViaProxy               bool   `json:"fetch_via_proxy"`
+	FetchViaProxyOverride       string `json:"fetch_via_proxy_override,omitempty"`
 	ScraperRules                string `json:"scraper_rules"`
 	RewriteRules                string `json:"rewrite_rules"`
 	UrlRewriteRules             string `json:"urlrewrite_rules"`
@@ -221,7 +287,14 @@ type FeedModificationRequest struct {
 	BlockFilterEntryRules       *string `json:"block_filter_entry_rules"`
 	KeepFilterEntryRules        *string `json:"keep_filter_entry_rules"`
 	Crawler                     *bool   `json:"crawler"`
+	CrawlerOverride             *string `json:"crawler_override"`
 	IgnoreEntryUpdates          *bool   `json:"ignore_entry_updates"`
+	EntryRules                  *string `json:"entry_rules"`
+	MarkUnreadOnEntryRevision   *bool   `json:"mark_unread_on_entry_revision"`
+	SnapshotEntries             *bool   `json:"snapshot_entries"`
+	MirrorEnclosures            *bool   `json:"mirror_enclosures"`
+	MirrorEnclosuresLimit       *int    `json:"mirror_enclosures_limit"`
+	EnrichmentProcessors        *string `json:"enrichment_processors"`
 	UserAgent                   *string `json:"user_agent"`
 	Cookie                      *string `json:"cookie"`
 	Username                    *string `json:"username"`
@@ -231,6 +304,7 @@ type FeedModificationRequest struct {
 	IgnoreHTTPCache             *bool   `json:"ignore_http_cache"`
 	AllowSelfSignedCertificates *bool   `json:"allow_self_signed_certificates"`
 	FetchViaProxy               *bool   `json:"fetch_via_proxy"`
+	FetchViaProxyOverride       *string `json:"fetch_via_proxy_override"`
 	HideGlobally                *bool   `json:"hide_globally"`
 	DisableHTTP2                *bool   `json:"disable_http2"`
 	ProxyURL                    *string `json:"proxy_url"`
@@ -257,6 +331,9 @@ type Entry struct {
 	Date        time.Time  `json:"published_at"`
 	ChangedAt   time.Time  `json:"changed_at"`
 	CreatedAt   time.Time  `json:"created_at"`
+	RevisedAt   *time.Time `json:"revised_at"`
+	ReadAt      *time.Time `json:"read_at"`
+	SnapshotAt  *time.Time `json:"snapshot_at"`
 	Feed        *Feed      `json:"feed,omitempty"`
 	Hash        string     `json:"hash"`
 	URL         string     `json:"url"`
@@ -264,6 +341,8 @@ type Entry struct {
 	Title       string     `json:"title"`
 	Status      string     `json:"status"`
 	Content     string     `json:"content"`
+	Summary     string     `json:"summary"`
+	Language    string     `json:"language"`
 	Author      string     `json:"author"`
 	ShareCode   string     `json:"share_code"`
 	Enclosures  Enclosures `json:"enclosures,omitempty"`
@@ -273,6 +352,18 @@ type Entry struct {
 	FeedID      int64      `json:"feed_id"`
 	Starred     bool       `json:"starred"`
 	Vote        int        `json:"vote"`
+	Snippet     string     `json:"snippet,omitempty"`
+	SearchRank  float64    `json:"search_rank,omitempty"`
+
+	// AlsoIn lists the other feeds where the same story was published.
+	AlsoIn []*EntryDuplicate `json:"also_in,omitempty"`
+}
+
+// EntryDuplicate represents another feed where the same story was published.
+type EntryDuplicate struct {
+	FeedID    int64  `json:"feed_id"`
+	FeedTitle string `json:"feed_title"`
+	URL       string `json:"url"`
 }
 
 // EntryModificationRequest represents a request to modify an entry.
@@ -284,6 +375,84 @@ type EntryModificationRequest struct {
 // Entries represents a list of entries.
 type Entries []*Entry
 
+// EntryRevision represents a previous version of an entry title and content.
+type EntryRevision struct {
+	ID        int64     `json:"id"`
+	EntryID   int64     `json:"entry_id"`
+	Title     string    `json:"title"`
+	Content   string    `json:"content"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// EntryRevisions represents a list of entry revisions.
+type EntryRevisions []*EntryRevision
+
+// EntrySnapshot represents an offline copy of the web page of an entry.
+type EntrySnapshot struct {
+	EntryID   int64     `json:"entry_id"`
+	UserID    int64     `json:"user_id"`
+	URL       string    `json:"url"`
+	Content   string    `json:"content,omitempty"`
+	Size      int64     `json:"size"`
+	Error     string    `json:"error,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// UserStats represents the reading statistics of a user.
+type UserStats struct {
+	Days          []*UserStatsPeriod   `json:"days"`
+	Weeks         []*UserStatsPeriod   `json:"weeks"`
+	Months        []*UserStatsPeriod   `json:"months"`
+	TopFeeds      []*UserStatsFeed     `json:"top_feeds"`
+	TopCategories []*UserStatsCategory `json:"top_categories"`
+	Votes         UserStatsVotes       `json:"votes"`
+	UnreadAge     UserStatsUnreadAge   `json:"unread_age"`
+}
+
+// UserStatsPeriod represents the reading activity of a day, a week or a month.
+// The reading time is in minutes.
+type UserStatsPeriod struct {
+	Date          string `json:"date"`
+	Read          int    `json:"read"`
+	ReadingTime   int    `json:"reading_time"`
+	Unread        int    `json:"unread"`
+	NewEntries    int    `json:"new_entries"`
+	BacklogGrowth int    `json:"backlog_growth"`
+}
+
+// UserStatsFeed represents the number of entries read and starred in a feed.
+type UserStatsFeed struct {
+	FeedID        int64  `json:"feed_id"`
+	FeedTitle     string `json:"feed_title"`
+	CategoryID    int64  `json:"category_id"`
+	CategoryTitle string `json:"category_title"`
+	Read          int    `json:"read"`
+	Starred       int    `json:"starred"`
+}
+
+// UserStatsCategory represents the number of entries read and starred in a category.
+type UserStatsCategory struct {
+	CategoryID    int64  `json:"category_id"`
+	CategoryTitle string `json:"category_title"`
+	Read          int    `json:"read"`
+	Starred       int    `json:"starred"`
+}
+
+// UserStatsVotes represents the number of entries by vote.
+type UserStatsVotes struct {
+	Upvoted   int `json:"upvoted"`
+	Downvoted int `json:"downvoted"`
+	NotVoted  int `json:"not_voted"`
+}
+
+// UserStatsUnreadAge represents the percentiles of the age of the unread entries, in seconds.
+type UserStatsUnreadAge struct {
+	Count int   `json:"count"`
+	P50   int64 `json:"p50"`
+	P90   int64 `json:"p90"`
+	P99   int64 `json:"p99"`
+}
+
 // Enclosure represents an attachment.
 type Enclosure struct {
 	ID               int64  `json:"id"`
@@ -293,6 +462,11 @@ type Enclosure struct {
 	MimeType         string `json:"mime_type"`
 	Size             int    `json:"size"`
 	MediaProgression int64  `json:"media_progression"`
+	Mirrored         bool   `json:"mirrored"`
+	OriginalURL      string `json:"original_url,omitempty"`
+	ThumbnailURL     string `json:"thumbnail_url,omitempty"`
+	Duration         int    `json:"duration,omitempty"`
+	ChannelName      string `json:"channel_name,omitempty"`
 }
 
 type EnclosureUpdateRequest struct {
@@ -324,6 +498,7 @@ type Filter struct {
 	BeforeEntryID   int64
 	AfterEntryID    int64
 	Search          string
+	Language        string
 	CategoryID      int64
 	FeedID          int64
 	Statuses        []string
@@ -390,6 +565,160 @@ type UserTagModificationRequest struct {
 	Title *string `json:"title"`
 }
 
+// SavedSearch represents a search query saved as a smart feed.
+type SavedSearch struct {
+	ID          int64  `json:"id"`
+	UserID      int64  `json:"user_id"`
+	Title       string `json:"title"`
+	Query       string `json:"query"`
+	UnreadCount *int   `json:"unread_count,omitempty"`
+}
+
+func (s SavedSearch) String() string {
+	return fmt.Sprintf("#%d %s (%s)", s.ID, s.Title, s.Query)
+}
+
+// SavedSearches represents a list of saved searches.
+type SavedSearches []*SavedSearch
+
+// SavedSearchCreationRequest represents the request to create a saved search.
+type SavedSearchCreationRequest struct {
+	Title string `json:"title"`
+	Query string `json:"query"`
+}
+
+// SavedSearchModificationRequest represents the request to update a saved search.
+type SavedSearchModificationRequest struct {
+	Title *string `json:"title,omitempty"`
+	Query *string `json:"query,omitempty"`
+}
+
+// RulePreviewRequest represents the request to evaluate candidate rules against stored entries.
+type RulePreviewRequest struct {
+	FeedID     int64                        `json:"feed_id,omitempty"`
+	CategoryID int64                        `json:"category_id,omitempty"`
+	Limit      int                          `json:"limit,omitempty"`
+	User       *UserModificationRequest     `json:"user,omitempty"`
+	Category   *CategoryModificationRequest `json:"category,omitempty"`
+	Feed       *FeedModificationRequest     `json:"feed,omitempty"`
+}
+
+// RulePreviewRule identifies a rule by its source, its field and its line number.
+type RulePreviewRule struct {
+	Rule   string `json:"rule"`
+	Source string `json:"source"`
+	Field  string `json:"field"`
+	Line   int    `json:"line"`
+}
+
+// RulePreviewEntry represents the outcome of the rules evaluation for one entry.
+type RulePreviewEntry struct {
+	EntryID      int64              `json:"entry_id"`
+	FeedID       int64              `json:"feed_id"`
+	Title        string             `json:"title"`
+	URL          string             `json:"url"`
+	Blocked      bool               `json:"blocked"`
+	Rule         *RulePreviewRule   `json:"rule,omitempty"`
+	MatchedRules []*RulePreviewRule `json:"matched_rules"`
+}
+
+// RulePreview represents the result of a rule preview.
+type RulePreview struct {
+	Total   int                 `json:"total"`
+	Blocked int                 `json:"blocked"`
+	Entries []*RulePreviewEntry `json:"entries"`
+}
+
+// Actions executed on the stored entries blocked by the rules.
+const (
+	RuleJobActionMarkRead = "mark_read"
+	RuleJobActionRemove   = "remove"
+)
+
+// Rule job statuses.
+const (
+	RuleJobStatusPending   = "pending"
+	RuleJobStatusRunning   = "running"
+	RuleJobStatusCompleted = "completed"
+	RuleJobStatusFailed    = "failed"
+)
+
+// RuleJobRequest represents a request to apply the rules to the stored unread entries.
+type RuleJobRequest struct {
+	FeedID     int64  `json:"feed_id,omitempty"`
+	CategoryID int64  `json:"category_id,omitempty"`
+	Action     string `json:"action"`
+}
+
+// RuleJob represents the background application of the rules to the stored unread entries.
+type RuleJob struct {
+	ID         int64      `json:"id"`
+	UserID     int64      `json:"user_id"`
+	FeedID     int64      `json:"feed_id"`
+	CategoryID int64      `json:"category_id"`
+	Action     string     `json:"action"`
+	Status     string     `json:"status"`
+	Total      int        `json:"total"`
+	Processed  int        `json:"processed"`
+	Matched    int        `json:"matched"`
+	Error      string     `json:"error,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+// BlockedEntry represents an entry blocked by a rule while refreshing a feed.
+type BlockedEntry struct {
+	ID        int64            `json:"id"`
+	UserID    int64            `json:"user_id"`
+	FeedID    int64            `json:"feed_id"`
+	Hash      string           `json:"hash"`
+	Title     string           `json:"title"`
+	URL       string           `json:"url"`
+	Rule      *RulePreviewRule `json:"rule"`
+	Stage     string           `json:"stage"`
+	CreatedAt time.Time        `json:"created_at"`
+}
+
+// RuleHit represents the number of new entries matched by a rule of a feed.
+type RuleHit struct {
+	Rule      string     `json:"rule"`
+	Source    string     `json:"source"`
+	Field     string     `json:"field"`
+	Line      int        `json:"line"`
+	Hits      int        `json:"hits"`
+	LastHitAt *time.Time `json:"last_hit_at,omitempty"`
+}
+
+// RuleAudit represents the rules applied to a feed, with their hit counts and the recently blocked entries.
+type RuleAudit struct {
+	Hits           []*RuleHit      `json:"hits"`
+	BlockedEntries []*BlockedEntry `json:"blocked_entries"`
+}
+
+// ScraperPreviewRequest represents a request to scrape a web page with candidate scraper and rewrite rules.
+type ScraperPreviewRequest struct {
+	URL                         string `json:"url"`
+	FeedID                      int64  `json:"feed_id,omitempty"`
+	ScraperRules                string `json:"scraper_rules,omitempty"`
+	RewriteRules                string `json:"rewrite_rules,omitempty"`
+	UserAgent                   string `json:"user_agent,omitempty"`
+	Cookie                      string `json:"cookie,omitempty"`
+	AllowSelfSignedCertificates bool   `json:"allow_self_signed_certificates,omitempty"`
+	DisableHTTP2                bool   `json:"disable_http2,omitempty"`
+	FetchViaProxy               bool   `json:"fetch_via_proxy,omitempty"`
+	FetchViaProxyOverride       string `json:"fetch_via_proxy_override,omitempty"`
+	ProxyURL                    string `json:"proxy_url,omitempty"`
+}
+
+// ScraperPreview represents the content extracted from a web page by a scraper preview.
+type ScraperPreview struct {
+	EffectiveURL    string `json:"effective_url"`
+	Content         string `json:"content"`
+	ReadingTime     int    `json:"reading_time"`
+	Rules           string `json:"rules"`
+	PredefinedRules string `json:"predefined_rules"`
+}
+
 // EntryUserTagsRequest represents the request to set user tags on an entry.
 type EntryUserTagsRequest struct {
 	UserTagIDs []int64 `json:"user_tag_ids"`
@@ -28,6 +28,7 @@ func NewHandler(store *storage.Storage, pool *worker.Pool) http.Handler {
 	mux.HandleFunc("DELETE /v1/users/{userID}", handler.removeUserHandler)
 	mux.HandleFunc("PUT /v1/users/{userID}/mark-all-as-read", handler.markUserAsReadHandler)
 	mux.HandleFunc("GET /v1/me", handler.currentUserHandler)
+	mux.HandleFunc("GET /v1/me/stats", handler.currentUserStatsHandler)
 	mux.HandleFunc("POST /v1/categories", handler.createCategoryHandler)
 	mux.HandleFunc("GET /v1/categories", handler.getCategoriesHandler)
 	mux.HandleFunc("PUT /v1/categories/{categoryID}", handler.updateCategoryHandler)
@@ -47,7 +48,10 @@ func NewHandler(store *storage.Storage, pool *worker.Pool) http.Handler {
 	mux.HandleFunc("PUT /v1/feeds/{feedID}", handler.updateFeedHandler)
 	mux.HandleFunc("DELETE /v1/feeds/{feedID}", handler.removeFeedHandler)
 	mux.HandleFunc("GET /v1/feeds/{feedID}/icon", handler.getIconByFeedIDHandler)
+	mux.HandleFunc("PUT /v1/feeds/{feedID}/icon/refresh", handler.refreshFeedIconHandler)
 	mux.HandleFunc("PUT /v1/feeds/{feedID}/mark-all-as-read", handler.markFeedAsReadHandler)
+	mux.HandleFunc("GET /v1/feeds/{feedID}/rules/audit", handler.getFeedRuleAudit)
+	mux.HandleFunc("POST /v1/feeds/{feedID}/blocked-entries/{blockedEntryID}/rescue", handler.rescueBlockedEntry)
 	mux.HandleFunc("GET /v1/export", handler.exportFeedsHandler)
 	mux.HandleFunc("POST /v1/import", handler.importFeedsHandler)
 	mux.HandleFunc("GET /v1/feeds/{feedID}/entries", handler.getFeedEntriesHandler)
@@ -61,6 +65,11 @@ func NewHandler(store *storage.Storage, pool *worker.Pool) http.Handler {
 	mux.HandleFunc("PUT /v1/entries/{entryID}/star", handler.toggleStarredHandler)
 	mux.HandleFunc("POST /v1/entries/{entryID}/save", handler.saveEntryHandler)
 	mux.HandleFunc("GET /v1/entries/{entryID}/fetch-content", handler.fetchContentHandler)
+	mux.HandleFunc("POST /v1/entries/{entryID}/summary", handler.summarizeEntryHandler)
+	mux.HandleFunc("GET /v1/entries/{entryID}/revisions", handler.getEntryRevisionsHandler)
+	mux.HandleFunc("GET /v1/entries/{entryID}/snapshot", handler.getEntrySnapshot)
+	mux.HandleFunc("POST /v1/entries/{entryID}/snapshot", handler.createEntrySnapshot)
+	mux.HandleFunc("DELETE /v1/entries/{entryID}/snapshot", handler.removeEntrySnapshot)
 	mux.HandleFunc("PUT /v1/flush-history", handler.flushHistoryHandler)
 	mux.HandleFunc("DELETE /v1/flush-history", handler.flushHistoryHandler)
 	mux.HandleFunc("GET /v1/icons/{iconID}", handler.getIconByIconIDHandler)
@@ -78,6 +87,15 @@ func NewHandler(store *storage.Storage, pool *worker.Pool) http.Handler {
 	mux.HandleFunc("PUT /v1/user-tags/{userTagID}", handler.updateUserTag)
 	mux.HandleFunc("DELETE /v1/user-tags/{userTagID}", handler.removeUserTag)
 	mux.HandleFunc("GET /v1/user-tags/{userTagID}/entries", handler.getUserTagEntries)
+	mux.HandleFunc("GET /v1/saved-searches", handler.getSavedSearches)
+	mux.HandleFunc("POST /v1/saved-searches", handler.createSavedSearch)
+	mux.HandleFunc("PUT /v1/saved-searches/{savedSearchID}", handler.updateSavedSearch)
+	mux.HandleFunc("DELETE /v1/saved-searches/{savedSearchID}", handler.removeSavedSearch)
+	mux.HandleFunc("GET /v1/saved-searches/{savedSearchID}/entries", handler.getSavedSearchEntries)
+	mux.HandleFunc("POST /v1/rules/preview", handler.previewRules)
+	mux.HandleFunc("POST /v1/rules/jobs", handler.createRuleJob)
+	mux.HandleFunc("GET /v1/rules/jobs/{jobID}", handler.getRuleJob)
+	mux.HandleFunc("POST /v1/scraper/preview", handler.previewScraper)
 
 	return middleware.withCORSHeaders(middleware.validateAPIKeyAuth(middleware.validateBasicAuth(mux)))
 }
@@ -1949,6 +1949,51 @@ func TestGetFeedIcon(t *testing.T) {
 	}
 }
 
+func TestRefreshFeedIcon(t *testing.T) {
+	testConfig := newIntegrationTestConfig()
+	if !testConfig.isConfigured() {
+		t.Skip(skipIntegrationTestsMessage)
+	}
+
+	adminClient := miniflux.NewClient(testConfig.testBaseURL, testConfig.testAdminUsername, testConfig.testAdminPassword)
+
+	regularTestUser, err := adminClient.CreateUser(testConfig.genRandomUsername(), testConfig.testRegularPassword, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer adminClient.DeleteUser(regularTestUser.ID)
+
+	regularUserClient := miniflux.NewClient(testConfig.testBaseURL, regularTestUser.Username, testConfig.testRegularPassword)
+
+	feedID, err := regularUserClient.CreateFeed(&miniflux.FeedCreationRequest{
+		FeedURL: testConfig.testFeedURL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	icon, err := regularUserClient.RefreshFeedIcon(feedID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if icon == nil {
+		t.Fatalf(`Invalid icon, got nil`)
+	}
+
+	if icon.MimeType == "" {
+		t.Fatalf(`Invalid mime type, got %q`, icon.MimeType)
+	}
+
+	if len(icon.Data) == 0 {
+		t.Fatalf(`Invalid data, got empty`)
+	}
+
+	if _, err := regularUserClient.RefreshFeedIcon(123456789); err == nil {
+		t.Fatalf(`Refreshing the icon of an inexisting feed should raise an error`)
+	}
+}
+
 func TestGetFeedIconWithInexistingFeedID(t *testing.T) {
 	testConfig := newIntegrationTestConfig()
 	if !testConfig.isConfigured() {
@@ -2631,6 +2676,60 @@ func TestUpdateEntryStatusEndpoint(t *testing.T) {
 	}
 }
 
+func TestGetCurrentUserStatsEndpoint(t *testing.T) {
+	testConfig := newIntegrationTestConfig()
+	if !testConfig.isConfigured() {
+		t.Skip(skipIntegrationTestsMessage)
+	}
+
+	adminClient := miniflux.NewClient(testConfig.testBaseURL, testConfig.testAdminUsername, testConfig.testAdminPassword)
+
+	regularTestUser, err := adminClient.CreateUser(testConfig.genRandomUsername(), testConfig.testRegularPassword, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer adminClient.DeleteUser(regularTestUser.ID)
+
+	regularUserClient := miniflux.NewClient(testConfig.testBaseURL, regularTestUser.Username, testConfig.testRegularPassword)
+
+	feedID, err := regularUserClient.CreateFeed(&miniflux.FeedCreationRequest{
+		FeedURL: testConfig.testFeedURL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := regularUserClient.FeedEntries(feedID, nil)
+	if err != nil {
+		t.Fatalf(`Failed to get entries: %v`, err)
+	}
+
+	if err := regularUserClient.UpdateEntries([]int64{result.Entries[0].ID}, miniflux.EntryStatusRead); err != nil {
+		t.Fatal(err)
+	}
+
+	stats, err := regularUserClient.MeStats()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(stats.Days) != 30 || len(stats.Weeks) != 12 || len(stats.Months) != 12 {
+		t.Fatalf(`Invalid number of periods, got %d days, %d weeks and %d months`, len(stats.Days), len(stats.Weeks), len(stats.Months))
+	}
+
+	if today := stats.Days[len(stats.Days)-1]; today.Read != 1 || today.NewEntries != int(result.Total) {
+		t.Errorf(`Invalid activity of the day, got %+v`, today)
+	}
+
+	if len(stats.TopFeeds) != 1 || stats.TopFeeds[0].FeedID != feedID || stats.TopFeeds[0].Read != 1 {
+		t.Errorf(`Invalid top feeds, got %+v`, stats.TopFeeds)
+	}
+
+	if stats.UnreadAge.Count != int(result.Total)-1 {
+		t.Errorf(`Invalid number of unread entries, got %d`, stats.UnreadAge.Count)
+	}
+}
+
 func TestUpdateEntryEndpoint(t *testing.T) {
 	testConfig := newIntegrationTestConfig()
 	if !testConfig.isConfigured() {
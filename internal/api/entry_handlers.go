@@ -17,6 +17,8 @@ import (
 	"miniflux.app/v2/internal/integration"
 	"miniflux.app/v2/internal/mediaproxy"
 	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/language"
+	"miniflux.app/v2/internal/reader/mirror"
 	"miniflux.app/v2/internal/reader/processor"
 	"miniflux.app/v2/internal/reader/readingtime"
 	"miniflux.app/v2/internal/reader/sanitizer"
@@ -25,6 +27,7 @@ import (
 )
 
 func (h *handler) getEntryFromBuilder(w http.ResponseWriter, r *http.Request, b *storage.EntryQueryBuilder) {
+	b.WithDuplicates()
 	entry, err := b.GetEntry()
 	if err != nil {
 		response.JSONServerError(w, r, err)
@@ -37,6 +40,7 @@ func (h *handler) getEntryFromBuilder(w http.ResponseWriter, r *http.Request, b
 	}
 
 	entry.Content = mediaproxy.RewriteDocumentWithAbsoluteProxyURL(entry.Content)
+	entry.Enclosures.UseMirrors()
 	entry.Enclosures.ProxifyEnclosureURL(config.Opts.MediaProxyMode(), config.Opts.MediaProxyResourceTypes())
 
 	response.JSON(w, r, entry)
@@ -128,7 +132,7 @@ func (h *handler) findEntries(w http.ResponseWriter, r *http.Request, feedID int
 	}
 
 	order := request.QueryStringParam(r, "order", model.DefaultSortingOrder)
-	if err := validator.ValidateEntryOrder(order); err != nil {
+	if err := validator.ValidateEntryQueryOrder(order); err != nil {
 		response.JSONBadRequest(w, r, err)
 		return
 	}
@@ -189,6 +193,7 @@ func (h *handler) findEntries(w http.ResponseWriter, r *http.Request, feedID int
 
 	for i := range entries {
 		entries[i].Content = mediaproxy.RewriteDocumentWithAbsoluteProxyURL(entries[i].Content)
+		entries[i].Enclosures.UseMirrors()
 	}
 
 	response.JSON(w, r, &entriesResponse{Total: count, Entries: entries})
@@ -221,10 +226,14 @@ func (h *handler) toggleStarredHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.store.ToggleStarred(request.UserID(r), entryID); err != nil {
+	starred, err := h.store.ToggleStarred(request.UserID(r), entryID)
+	if err != nil {
 		response.JSONServerError(w, r, err)
 		return
 	}
+	if starred {
+		mirror.Request()
+	}
 
 	response.NoContent(w, r)
 }
@@ -426,6 +435,7 @@ func (h *handler) importFeedEntryHandler(w http.ResponseWriter, r *http.Request)
 			response.JSONServerError(w, r, err)
 			return
 		}
+		mirror.Request()
 		entry.Starred = true
 	}
 
@@ -546,6 +556,42 @@ func (h *handler) updateEntryVote(w http.ResponseWriter, r *http.Request) {
 	response.NoContent(w, r)
 }
 
+func (h *handler) getEntryRevisionsHandler(w http.ResponseWriter, r *http.Request) {
+	entryID := request.RouteInt64Param(r, "entryID")
+	if entryID == 0 {
+		response.JSONBadRequest(w, r, errors.New("invalid entry ID"))
+		return
+	}
+
+	userID := request.UserID(r)
+	builder := h.store.NewEntryQueryBuilder(userID)
+	builder.WithEntryID(entryID)
+	builder.WithoutContent()
+
+	entry, err := builder.GetEntry()
+	if err != nil {
+		response.JSONServerError(w, r, err)
+		return
+	}
+
+	if entry == nil {
+		response.JSONNotFound(w, r)
+		return
+	}
+
+	revisions, err := h.store.EntryRevisions(userID, entry.ID)
+	if err != nil {
+		response.JSONServerError(w, r, err)
+		return
+	}
+
+	for _, revision := range revisions {
+		revision.Content = mediaproxy.RewriteDocumentWithAbsoluteProxyURL(revision.Content)
+	}
+
+	response.JSON(w, r, revisions)
+}
+
 func configureFilters(builder *storage.EntryQueryBuilder, r *http.Request) {
 	if beforeEntryID := request.QueryInt64Param(r, "before_entry_id", 0); beforeEntryID > 0 {
 		builder.BeforeEntryID(beforeEntryID)
@@ -593,4 +639,8 @@ func configureFilters(builder *storage.EntryQueryBuilder, r *http.Request) {
 	if searchQuery := request.QueryStringParam(r, "search", ""); searchQuery != "" {
 		builder.WithSearchQuery(searchQuery)
 	}
+
+	if entryLanguage := language.Normalize(request.QueryStringParam(r, "language", "")); entryLanguage != "" {
+		builder.WithLanguage(entryLanguage)
+	}
 }
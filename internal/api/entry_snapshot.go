@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package api // import "miniflux.app/v2/internal/api"
+
+import (
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response"
+	"miniflux.app/v2/internal/reader/processor"
+)
+
+func (h *handler) getEntrySnapshot(w http.ResponseWriter, r *http.Request) {
+	userID := request.UserID(r)
+	entryID := request.RouteInt64Param(r, "entryID")
+
+	snapshot, err := h.store.EntrySnapshot(userID, entryID)
+	if err != nil {
+		response.JSONServerError(w, r, err)
+		return
+	}
+
+	if snapshot == nil {
+		response.JSONNotFound(w, r)
+		return
+	}
+
+	if snapshot.Content, err = h.store.EntrySnapshotContent(userID, entryID); err != nil {
+		response.JSONServerError(w, r, err)
+		return
+	}
+
+	response.JSON(w, r, snapshot)
+}
+
+func (h *handler) createEntrySnapshot(w http.ResponseWriter, r *http.Request) {
+	userID := request.UserID(r)
+	builder := h.store.NewEntryQueryBuilder(userID)
+	builder.WithEntryID(request.RouteInt64Param(r, "entryID"))
+
+	entry, err := builder.GetEntry()
+	if err != nil {
+		response.JSONServerError(w, r, err)
+		return
+	}
+
+	if entry == nil {
+		response.JSONNotFound(w, r)
+		return
+	}
+
+	feed, err := h.store.FeedByID(userID, entry.FeedID)
+	if err != nil {
+		response.JSONServerError(w, r, err)
+		return
+	}
+
+	if feed == nil {
+		response.JSONNotFound(w, r)
+		return
+	}
+
+	snapshot, err := processor.SnapshotEntry(h.store, feed, entry)
+	if err != nil {
+		response.JSONBadRequest(w, r, err)
+		return
+	}
+
+	// The content is returned by the GET endpoint.
+	snapshot.Content = ""
+	response.JSONCreated(w, r, snapshot)
+}
+
+func (h *handler) removeEntrySnapshot(w http.ResponseWriter, r *http.Request) {
+	if err := h.store.RemoveEntrySnapshot(request.UserID(r), request.RouteInt64Param(r, "entryID")); err != nil {
+		response.JSONServerError(w, r, err)
+		return
+	}
+
+	response.NoContent(w, r)
+}
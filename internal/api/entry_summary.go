@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package api // import "miniflux.app/v2/internal/api"
+
+import (
+	"errors"
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response"
+	"miniflux.app/v2/internal/reader/processor"
+	"miniflux.app/v2/internal/reader/summary"
+)
+
+type entrySummaryResponse struct {
+	Summary string `json:"summary"`
+}
+
+func (h *handler) summarizeEntryHandler(w http.ResponseWriter, r *http.Request) {
+	userID := request.UserID(r)
+	builder := h.store.NewEntryQueryBuilder(userID)
+	builder.WithEntryID(request.RouteInt64Param(r, "entryID"))
+
+	entry, err := builder.GetEntry()
+	if err != nil {
+		response.JSONServerError(w, r, err)
+		return
+	}
+
+	if entry == nil {
+		response.JSONNotFound(w, r)
+		return
+	}
+
+	feed, err := h.store.FeedByID(userID, entry.FeedID)
+	if err != nil {
+		response.JSONServerError(w, r, err)
+		return
+	}
+
+	if feed == nil {
+		response.JSONNotFound(w, r)
+		return
+	}
+
+	err = processor.SummarizeEntry(h.store, feed, entry)
+	switch {
+	case errors.Is(err, summary.ErrNotConfigured), errors.Is(err, summary.ErrTokenBudgetExceeded):
+		response.JSONBadRequest(w, r, err)
+		return
+	case err != nil:
+		response.JSONServerError(w, r, err)
+		return
+	}
+
+	response.JSON(w, r, &entrySummaryResponse{Summary: entry.Summary})
+}
@@ -9,6 +9,7 @@ import (
 
 	"miniflux.app/v2/internal/http/request"
 	"miniflux.app/v2/internal/http/response"
+	"miniflux.app/v2/internal/reader/icon"
 )
 
 func (h *handler) getIconByFeedIDHandler(w http.ResponseWriter, r *http.Request) {
@@ -36,6 +37,42 @@ func (h *handler) getIconByFeedIDHandler(w http.ResponseWriter, r *http.Request)
 	})
 }
 
+func (h *handler) refreshFeedIconHandler(w http.ResponseWriter, r *http.Request) {
+	feedID := request.RouteInt64Param(r, "feedID")
+	if feedID == 0 {
+		response.JSONBadRequest(w, r, errors.New("invalid feed ID"))
+		return
+	}
+
+	feed, err := h.store.FeedByID(request.UserID(r), feedID)
+	if err != nil {
+		response.JSONServerError(w, r, err)
+		return
+	}
+
+	if feed == nil {
+		response.JSONNotFound(w, r)
+		return
+	}
+
+	feedIcon, err := icon.NewIconChecker(h.store, feed).RefreshFeedIcon()
+	if err != nil {
+		response.JSONServerError(w, r, err)
+		return
+	}
+
+	if feedIcon == nil {
+		response.JSONNotFound(w, r)
+		return
+	}
+
+	response.JSON(w, r, &feedIconResponse{
+		ID:       feedIcon.ID,
+		MimeType: feedIcon.MimeType,
+		Data:     feedIcon.DataURL(),
+	})
+}
+
 func (h *handler) getIconByIconIDHandler(w http.ResponseWriter, r *http.Request) {
 	iconID := request.RouteInt64Param(r, "iconID")
 	if iconID == 0 {
@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package api // import "miniflux.app/v2/internal/api"
+
+import (
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response"
+	"miniflux.app/v2/internal/reader/processor"
+)
+
+func (h *handler) getFeedRuleAudit(w http.ResponseWriter, r *http.Request) {
+	userID := request.UserID(r)
+
+	user, err := h.store.UserByID(userID)
+	if err != nil {
+		response.JSONServerError(w, r, err)
+		return
+	}
+
+	feed, err := h.store.FeedByID(userID, request.RouteInt64Param(r, "feedID"))
+	if err != nil {
+		response.JSONServerError(w, r, err)
+		return
+	}
+
+	if user == nil || feed == nil {
+		response.JSONNotFound(w, r)
+		return
+	}
+
+	audit, err := processor.FeedRuleAudit(h.store, user, feed)
+	if err != nil {
+		response.JSONServerError(w, r, err)
+		return
+	}
+
+	response.JSON(w, r, audit)
+}
+
+func (h *handler) rescueBlockedEntry(w http.ResponseWriter, r *http.Request) {
+	userID := request.UserID(r)
+
+	user, err := h.store.UserByID(userID)
+	if err != nil {
+		response.JSONServerError(w, r, err)
+		return
+	}
+
+	feed, err := h.store.FeedByID(userID, request.RouteInt64Param(r, "feedID"))
+	if err != nil {
+		response.JSONServerError(w, r, err)
+		return
+	}
+
+	blockedEntry, err := h.store.BlockedEntryByID(userID, request.RouteInt64Param(r, "blockedEntryID"))
+	if err != nil {
+		response.JSONServerError(w, r, err)
+		return
+	}
+
+	if user == nil || feed == nil || blockedEntry == nil || blockedEntry.FeedID != feed.ID {
+		response.JSONNotFound(w, r)
+		return
+	}
+
+	if err := processor.RescueBlockedEntry(h.store, user, feed, blockedEntry); err != nil {
+		response.JSONServerError(w, r, err)
+		return
+	}
+
+	response.JSONCreated(w, r, entryIDResponse{ID: blockedEntry.Entry.ID})
+}
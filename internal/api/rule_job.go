@@ -0,0 +1,61 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package api // import "miniflux.app/v2/internal/api"
+
+import (
+	json_parser "encoding/json"
+	"errors"
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/processor"
+	"miniflux.app/v2/internal/storage"
+	"miniflux.app/v2/internal/validator"
+)
+
+func (h *handler) createRuleJob(w http.ResponseWriter, r *http.Request) {
+	userID := request.UserID(r)
+
+	var ruleJobRequest model.RuleJobRequest
+	if err := json_parser.NewDecoder(r.Body).Decode(&ruleJobRequest); err != nil {
+		response.JSONBadRequest(w, r, err)
+		return
+	}
+
+	if validationErr := validator.ValidateRuleJobRequest(h.store, userID, &ruleJobRequest); validationErr != nil {
+		response.JSONBadRequest(w, r, validationErr.Error())
+		return
+	}
+
+	job, err := h.store.CreateRuleJob(userID, &ruleJobRequest)
+	if errors.Is(err, storage.ErrRuleJobAlreadyRunning) {
+		response.JSONBadRequest(w, r, err)
+		return
+	}
+	if err != nil {
+		response.JSONServerError(w, r, err)
+		return
+	}
+
+	processor.RequestRuleJobs()
+
+	response.JSONCreated(w, r, job)
+}
+
+func (h *handler) getRuleJob(w http.ResponseWriter, r *http.Request) {
+	job, err := h.store.RuleJobByID(request.UserID(r), request.RouteInt64Param(r, "jobID"))
+	if err != nil {
+		response.JSONServerError(w, r, err)
+		return
+	}
+
+	if job == nil {
+		response.JSONNotFound(w, r)
+		return
+	}
+
+	response.JSON(w, r, job)
+}
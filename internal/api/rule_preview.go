@@ -0,0 +1,38 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package api // import "miniflux.app/v2/internal/api"
+
+import (
+	json_parser "encoding/json"
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/processor"
+	"miniflux.app/v2/internal/validator"
+)
+
+func (h *handler) previewRules(w http.ResponseWriter, r *http.Request) {
+	userID := request.UserID(r)
+
+	var rulePreviewRequest model.RulePreviewRequest
+	if err := json_parser.NewDecoder(r.Body).Decode(&rulePreviewRequest); err != nil {
+		response.JSONBadRequest(w, r, err)
+		return
+	}
+
+	if validationErr := validator.ValidateRulePreview(h.store, userID, &rulePreviewRequest); validationErr != nil {
+		response.JSONBadRequest(w, r, validationErr.Error())
+		return
+	}
+
+	preview, err := processor.PreviewRules(h.store, userID, &rulePreviewRequest)
+	if err != nil {
+		response.JSONServerError(w, r, err)
+		return
+	}
+
+	response.JSON(w, r, preview)
+}
@@ -0,0 +1,177 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package api // import "miniflux.app/v2/internal/api"
+
+import (
+	json_parser "encoding/json"
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/validator"
+)
+
+func (h *handler) getSavedSearches(w http.ResponseWriter, r *http.Request) {
+	userID := request.UserID(r)
+
+	var savedSearches model.SavedSearches
+	var err error
+	includeCounts := request.QueryStringParam(r, "counts", "false")
+
+	if includeCounts == "true" {
+		savedSearches, err = h.store.SavedSearchesWithUnreadCount(userID)
+	} else {
+		savedSearches, err = h.store.SavedSearches(userID)
+	}
+
+	if err != nil {
+		response.JSONServerError(w, r, err)
+		return
+	}
+	response.JSON(w, r, savedSearches)
+}
+
+func (h *handler) createSavedSearch(w http.ResponseWriter, r *http.Request) {
+	userID := request.UserID(r)
+
+	var savedSearchCreationRequest model.SavedSearchCreationRequest
+	if err := json_parser.NewDecoder(r.Body).Decode(&savedSearchCreationRequest); err != nil {
+		response.JSONBadRequest(w, r, err)
+		return
+	}
+
+	if validationErr := validator.ValidateSavedSearchCreation(h.store, userID, &savedSearchCreationRequest); validationErr != nil {
+		response.JSONBadRequest(w, r, validationErr.Error())
+		return
+	}
+
+	savedSearch, err := h.store.CreateSavedSearch(userID, &savedSearchCreationRequest)
+	if err != nil {
+		response.JSONServerError(w, r, err)
+		return
+	}
+
+	response.JSONCreated(w, r, savedSearch)
+}
+
+func (h *handler) updateSavedSearch(w http.ResponseWriter, r *http.Request) {
+	userID := request.UserID(r)
+	savedSearchID := request.RouteInt64Param(r, "savedSearchID")
+
+	savedSearch, err := h.store.SavedSearchByID(userID, savedSearchID)
+	if err != nil {
+		response.JSONServerError(w, r, err)
+		return
+	}
+
+	if savedSearch == nil {
+		response.JSONNotFound(w, r)
+		return
+	}
+
+	var savedSearchModificationRequest model.SavedSearchModificationRequest
+	if err := json_parser.NewDecoder(r.Body).Decode(&savedSearchModificationRequest); err != nil {
+		response.JSONBadRequest(w, r, err)
+		return
+	}
+
+	if validationErr := validator.ValidateSavedSearchModification(h.store, userID, savedSearch.ID, &savedSearchModificationRequest); validationErr != nil {
+		response.JSONBadRequest(w, r, validationErr.Error())
+		return
+	}
+
+	savedSearchModificationRequest.Patch(savedSearch)
+
+	if err := h.store.UpdateSavedSearch(savedSearch); err != nil {
+		response.JSONServerError(w, r, err)
+		return
+	}
+
+	response.JSONCreated(w, r, savedSearch)
+}
+
+func (h *handler) removeSavedSearch(w http.ResponseWriter, r *http.Request) {
+	userID := request.UserID(r)
+	savedSearchID := request.RouteInt64Param(r, "savedSearchID")
+
+	if !h.store.SavedSearchIDExists(userID, savedSearchID) {
+		response.JSONNotFound(w, r)
+		return
+	}
+
+	if err := h.store.RemoveSavedSearch(userID, savedSearchID); err != nil {
+		response.JSONServerError(w, r, err)
+		return
+	}
+
+	response.NoContent(w, r)
+}
+
+func (h *handler) getSavedSearchEntries(w http.ResponseWriter, r *http.Request) {
+	userID := request.UserID(r)
+	savedSearchID := request.RouteInt64Param(r, "savedSearchID")
+
+	savedSearch, err := h.store.SavedSearchByID(userID, savedSearchID)
+	if err != nil {
+		response.JSONServerError(w, r, err)
+		return
+	}
+
+	if savedSearch == nil {
+		response.JSONNotFound(w, r)
+		return
+	}
+
+	statuses := request.QueryStringParamList(r, "status")
+	for _, status := range statuses {
+		if err := validator.ValidateEntryStatus(status); err != nil {
+			response.JSONBadRequest(w, r, err)
+			return
+		}
+	}
+
+	order := request.QueryStringParam(r, "order", model.DefaultSortingOrder)
+	if err := validator.ValidateEntryQueryOrder(order); err != nil {
+		response.JSONBadRequest(w, r, err)
+		return
+	}
+
+	direction := request.QueryStringParam(r, "direction", model.DefaultSortingDirection)
+	if err := validator.ValidateDirection(direction); err != nil {
+		response.JSONBadRequest(w, r, err)
+		return
+	}
+
+	limit := request.QueryIntParam(r, "limit", 100)
+	offset := request.QueryIntParam(r, "offset", 0)
+	if err := validator.ValidateRange(offset, limit); err != nil {
+		response.JSONBadRequest(w, r, err)
+		return
+	}
+
+	builder := h.store.NewEntryQueryBuilder(userID)
+	builder.WithStatuses(statuses)
+	builder.WithSorting(order, direction)
+	builder.WithSearchQuery(savedSearch.Query)
+	builder.WithOffset(offset)
+	builder.WithLimit(limit)
+	builder.WithEnclosures()
+
+	configureFilters(builder, r)
+
+	entries, err := builder.GetEntries()
+	if err != nil {
+		response.JSONServerError(w, r, err)
+		return
+	}
+
+	count, err := builder.CountEntries()
+	if err != nil {
+		response.JSONServerError(w, r, err)
+		return
+	}
+
+	response.JSON(w, r, &entriesResponse{Total: count, Entries: entries})
+}
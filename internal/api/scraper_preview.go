@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package api // import "miniflux.app/v2/internal/api"
+
+import (
+	json_parser "encoding/json"
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/processor"
+	"miniflux.app/v2/internal/validator"
+)
+
+func (h *handler) previewScraper(w http.ResponseWriter, r *http.Request) {
+	userID := request.UserID(r)
+
+	var scraperPreviewRequest model.ScraperPreviewRequest
+	if err := json_parser.NewDecoder(r.Body).Decode(&scraperPreviewRequest); err != nil {
+		response.JSONBadRequest(w, r, err)
+		return
+	}
+
+	if validationErr := validator.ValidateScraperPreview(h.store, userID, &scraperPreviewRequest); validationErr != nil {
+		response.JSONBadRequest(w, r, validationErr.Error())
+		return
+	}
+
+	user, err := h.store.UserByID(userID)
+	if err != nil {
+		response.JSONServerError(w, r, err)
+		return
+	}
+
+	if user == nil {
+		response.JSONNotFound(w, r)
+		return
+	}
+
+	preview, err := processor.PreviewScraper(h.store, user, &scraperPreviewRequest)
+	if err != nil {
+		response.JSONServerError(w, r, err)
+		return
+	}
+
+	response.JSON(w, r, preview)
+}
@@ -25,6 +25,22 @@ func (h *handler) currentUserHandler(w http.ResponseWriter, r *http.Request) {
 	response.JSON(w, r, user)
 }
 
+func (h *handler) currentUserStatsHandler(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		response.JSONServerError(w, r, err)
+		return
+	}
+
+	stats, err := h.store.UserStats(user.ID, user.Timezone)
+	if err != nil {
+		response.JSONServerError(w, r, err)
+		return
+	}
+
+	response.JSON(w, r, stats)
+}
+
 func (h *handler) createUserHandler(w http.ResponseWriter, r *http.Request) {
 	if !request.IsAdminUser(r) {
 		response.JSONForbidden(w, r)
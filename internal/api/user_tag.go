@@ -122,7 +122,7 @@ func (h *handler) getUserTagEntries(w http.ResponseWriter, r *http.Request) {
 	}
 
 	order := request.QueryStringParam(r, "order", model.DefaultSortingOrder)
-	if err := validator.ValidateEntryOrder(order); err != nil {
+	if err := validator.ValidateEntryQueryOrder(order); err != nil {
 		response.JSONBadRequest(w, r, err)
 		return
 	}
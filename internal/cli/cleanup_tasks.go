@@ -22,6 +22,30 @@ func runCleanupTasks(store *storage.Storage) {
 		)
 	}
 
+	if nbRuleJobs, err := store.CleanOldRuleJobs(model.RuleJobRetentionInterval); err != nil {
+		slog.Error("Unable to clean old rule jobs", slog.Any("error", err))
+	} else {
+		slog.Info("Rule jobs cleanup completed",
+			slog.Int64("rule_jobs_removed", nbRuleJobs),
+		)
+	}
+
+	if nbEvents, err := store.CleanOldEntryStatusEvents(model.EntryStatusEventRetentionInterval); err != nil {
+		slog.Error("Unable to clean old entry status events", slog.Any("error", err))
+	} else {
+		slog.Info("Entry status events cleanup completed",
+			slog.Int64("entry_status_events_removed", nbEvents),
+		)
+	}
+
+	if nbIcons, err := store.RemoveOrphanIcons(); err != nil {
+		slog.Error("Unable to remove orphan icons", slog.Any("error", err))
+	} else {
+		slog.Info("Icons cleanup completed",
+			slog.Int64("icons_removed", nbIcons),
+		)
+	}
+
 	startTime := time.Now()
 	if rowsAffected, err := store.ArchiveEntries(model.EntryStatusRead, config.Opts.CleanupArchiveReadInterval(), config.Opts.CleanupArchiveBatchSize()); err != nil {
 		slog.Error("Unable to archive read entries", slog.Any("error", err))
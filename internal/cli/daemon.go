@@ -15,6 +15,7 @@ import (
 	"miniflux.app/v2/internal/config"
 	"miniflux.app/v2/internal/http/server"
 	"miniflux.app/v2/internal/metric"
+	"miniflux.app/v2/internal/reader/processor"
 	"miniflux.app/v2/internal/storage"
 	"miniflux.app/v2/internal/systemd"
 	"miniflux.app/v2/internal/worker"
@@ -29,6 +30,12 @@ func startDaemon(store *storage.Storage) {
 
 	pool := worker.NewPool(store, config.Opts.WorkerPoolSize())
 
+	if config.Opts.HasSummaryAPI() {
+		go processor.RunSummaryWorker(store)
+	}
+
+	go processor.RunRuleJobWorker(store)
+
 	if config.Opts.HasSchedulerService() && !config.Opts.HasMaintenanceMode() {
 		runScheduler(store, pool)
 	}
@@ -4,10 +4,19 @@
 package cli // import "miniflux.app/v2/internal/cli"
 
 import (
+	"errors"
 	"log/slog"
+	"maps"
+	"os"
+	"sync"
 	"time"
 
 	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/attachment"
+	"miniflux.app/v2/internal/reader/icon"
+	"miniflux.app/v2/internal/reader/mirror"
+	"miniflux.app/v2/internal/reader/processor"
 	"miniflux.app/v2/internal/storage"
 	"miniflux.app/v2/internal/worker"
 )
@@ -28,6 +37,38 @@ func runScheduler(store *storage.Storage, pool *worker.Pool) {
 		store,
 		config.Opts.CleanupFrequency(),
 	)
+
+	go snapshotScheduler(
+		store,
+		config.Opts.SnapshotFrequency(),
+		config.Opts.BatchSize(),
+	)
+
+	if refreshInterval := config.Opts.IconRefreshInterval(); refreshInterval > 0 {
+		go iconScheduler(
+			store,
+			config.Opts.IconRefreshFrequency(),
+			refreshInterval,
+			config.Opts.BatchSize(),
+		)
+	}
+
+	if mirrorDirectory := config.Opts.EnclosureMirrorDir(); mirrorDirectory != "" {
+		go enclosureMirrorScheduler(
+			store,
+			mirrorDirectory,
+			config.Opts.EnclosureMirrorFrequency(),
+			config.Opts.BatchSize(),
+		)
+	}
+
+	if config.Opts.AttachmentTextExtraction() {
+		go attachmentTextScheduler(
+			store,
+			config.Opts.AttachmentTextFrequency(),
+			config.Opts.BatchSize(),
+		)
+	}
 }
 
 func feedScheduler(store *storage.Storage, pool *worker.Pool, frequency time.Duration, batchSize, errorLimit, limitPerHost int) {
@@ -54,3 +95,269 @@ func cleanupScheduler(store *storage.Storage, frequency time.Duration) {
 		runCleanupTasks(store)
 	}
 }
+
+func snapshotScheduler(store *storage.Storage, frequency time.Duration, batchSize int) {
+	for range time.Tick(frequency) {
+		entries, err := store.EntriesToSnapshot(batchSize)
+		if err != nil {
+			slog.Error("Unable to fetch entries to snapshot", slog.Any("error", err))
+			continue
+		}
+
+		for _, entry := range entries {
+			feed, err := store.FeedByID(entry.UserID, entry.FeedID)
+			if err != nil || feed == nil {
+				continue
+			}
+
+			// The error is logged and stored with the snapshot.
+			processor.SnapshotEntry(store, feed, entry)
+		}
+
+		if len(entries) > 0 {
+			slog.Info("Entry snapshots completed", slog.Int("entries", len(entries)))
+		}
+	}
+}
+
+func iconScheduler(store *storage.Storage, frequency, refreshInterval time.Duration, batchSize int) {
+	for range time.Tick(frequency) {
+		jobs, err := store.FeedIconsToRefresh(refreshInterval, model.FeedIconRetryInterval, batchSize)
+		if err != nil {
+			slog.Error("Unable to fetch feed icons to refresh", slog.Any("error", err))
+			continue
+		}
+
+		for _, job := range jobs {
+			feed, err := store.FeedByID(job.UserID, job.FeedID)
+			if err != nil || feed == nil {
+				continue
+			}
+
+			// The errors are logged by the icon checker.
+			icon.NewIconChecker(store, feed).RefreshFeedIcon()
+		}
+
+		if len(jobs) > 0 {
+			slog.Info("Feed icons refresh completed", slog.Int("feeds", len(jobs)))
+		}
+	}
+}
+
+// enclosureMirrorWorkers is the number of enclosures downloaded at the same time.
+const enclosureMirrorWorkers = 4
+
+// enclosureMirrorJob is an enclosure waiting to be downloaded by a mirror worker.
+type enclosureMirrorJob struct {
+	feed      *model.Feed
+	enclosure *model.Enclosure
+}
+
+// enclosureMirrorScheduler queues the enclosures to mirror at each tick, or as soon as new enclosures are requested.
+// The downloads run in parallel, so a large file does not hold back the others.
+func enclosureMirrorScheduler(store *storage.Storage, directory string, frequency time.Duration, batchSize int) {
+	queue := make(chan enclosureMirrorJob, batchSize)
+	downloads := &enclosureMirrorDownloads{userIDs: make(map[int64]int64)}
+
+	for range enclosureMirrorWorkers {
+		go func() {
+			for job := range queue {
+				// The error is logged and stored with the mirror.
+				if err := processor.MirrorEnclosure(store, job.feed, job.enclosure); err == nil {
+					// The storage limit of the user is enforced as soon as the file is downloaded.
+					removeEnclosureMirrorsOverLimits(store, directory, job.enclosure.UserID)
+				}
+				downloads.done(job.enclosure.ID)
+			}
+		}()
+	}
+
+	ticker := time.NewTicker(frequency)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-mirror.Requests():
+		}
+
+		removeEnclosureMirrorsOverLimits(store, directory, 0)
+		removeOrphanEnclosureFiles(store, directory, downloads.pending())
+
+		entries, err := store.EntriesWithEnclosuresToMirror(batchSize)
+		if err != nil {
+			slog.Error("Unable to fetch enclosures to mirror", slog.Any("error", err))
+			continue
+		}
+
+		feeds := make(map[int64]*model.Feed)
+		enclosureCount := 0
+		for _, entry := range entries {
+			feed, found := feeds[entry.FeedID]
+			if !found {
+				feed, err = store.FeedByID(entry.UserID, entry.FeedID)
+				if err != nil || feed == nil {
+					continue
+				}
+				feeds[entry.FeedID] = feed
+			}
+
+			for _, enclosure := range entry.Enclosures {
+				if !downloads.start(enclosure) {
+					continue
+				}
+
+				select {
+				case queue <- enclosureMirrorJob{feed: feed, enclosure: enclosure}:
+					enclosureCount++
+				default:
+					// The queue is full, the enclosure is picked up again at the next run.
+					downloads.done(enclosure.ID)
+				}
+			}
+		}
+
+		if enclosureCount > 0 {
+			slog.Info("Enclosures queued for mirroring", slog.Int("enclosures", enclosureCount))
+		}
+	}
+}
+
+// enclosureMirrorDownloads tracks the enclosures queued or being downloaded,
+// so they are not queued twice and their files are not removed as orphans.
+type enclosureMirrorDownloads struct {
+	mu sync.Mutex
+
+	// userIDs maps the enclosure IDs to their user.
+	userIDs map[int64]int64
+}
+
+func (d *enclosureMirrorDownloads) start(enclosure *model.Enclosure) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if _, found := d.userIDs[enclosure.ID]; found {
+		return false
+	}
+	d.userIDs[enclosure.ID] = enclosure.UserID
+	return true
+}
+
+func (d *enclosureMirrorDownloads) done(enclosureID int64) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.userIDs, enclosureID)
+}
+
+func (d *enclosureMirrorDownloads) pending() map[int64]int64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return maps.Clone(d.userIDs)
+}
+
+func attachmentTextScheduler(store *storage.Storage, frequency time.Duration, batchSize int) {
+	for range time.Tick(frequency) {
+		entries, err := store.EntriesWithEnclosuresToExtract(attachment.MimeTypes, batchSize)
+		if err != nil {
+			slog.Error("Unable to fetch enclosures to extract", slog.Any("error", err))
+			continue
+		}
+
+		feeds := make(map[int64]*model.Feed)
+		enclosureCount := 0
+		for _, entry := range entries {
+			feed, found := feeds[entry.FeedID]
+			if !found {
+				feed, err = store.FeedByID(entry.UserID, entry.FeedID)
+				if err != nil || feed == nil {
+					continue
+				}
+				feeds[entry.FeedID] = feed
+			}
+
+			for _, enclosure := range entry.Enclosures {
+				// The error is logged and stored with the enclosure text.
+				processor.ExtractEnclosureText(store, feed, enclosure)
+				enclosureCount++
+			}
+		}
+
+		if enclosureCount > 0 {
+			slog.Info("Enclosure text extraction completed", slog.Int("enclosures", enclosureCount))
+		}
+	}
+}
+
+// removeEnclosureMirrorsOverLimits deletes the files exceeding the retention limits.
+// When userID is greater than zero, only the files of this user are considered.
+func removeEnclosureMirrorsOverLimits(store *storage.Storage, directory string, userID int64) {
+	mirrors, err := store.EnclosureMirrorsToRemove(userID, config.Opts.EnclosureMirrorMaxSizePerUser())
+	if err != nil {
+		slog.Error("Unable to fetch enclosure mirrors to remove", slog.Any("error", err))
+		return
+	}
+
+	var removedIDs, evictedIDs []int64
+	for _, enclosureMirror := range mirrors {
+		path := mirror.Path(directory, enclosureMirror.UserID, enclosureMirror.EnclosureID)
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			slog.Error("Unable to remove mirrored enclosure",
+				slog.Int64("enclosure_id", enclosureMirror.EnclosureID),
+				slog.Any("error", err),
+			)
+			continue
+		}
+
+		if enclosureMirror.Evicted {
+			evictedIDs = append(evictedIDs, enclosureMirror.EnclosureID)
+		} else {
+			removedIDs = append(removedIDs, enclosureMirror.EnclosureID)
+		}
+	}
+
+	if len(removedIDs) > 0 {
+		if err := store.RemoveEnclosureMirrors(removedIDs); err != nil {
+			slog.Error("Unable to remove enclosure mirrors", slog.Any("error", err))
+		}
+	}
+
+	if len(evictedIDs) > 0 {
+		if err := store.EvictEnclosureMirrors(evictedIDs); err != nil {
+			slog.Error("Unable to evict enclosure mirrors", slog.Any("error", err))
+		}
+	}
+
+	if len(mirrors) > 0 {
+		slog.Info("Enclosure mirrors removed",
+			slog.Int64("user_id", userID),
+			slog.Int("removed", len(removedIDs)),
+			slog.Int("evicted", len(evictedIDs)),
+		)
+	}
+}
+
+// removeOrphanEnclosureFiles deletes the files left without mirror, except the files of the pending downloads.
+func removeOrphanEnclosureFiles(store *storage.Storage, directory string, pendingUserIDs map[int64]int64) {
+	mirroredEnclosureIDs, err := store.MirroredEnclosureIDs()
+	if err != nil {
+		slog.Error("Unable to fetch mirrored enclosures", slog.Any("error", err))
+		return
+	}
+
+	// The downloaded file is moved in place before its mirror is saved.
+	for enclosureID, userID := range pendingUserIDs {
+		if mirroredEnclosureIDs[userID] == nil {
+			mirroredEnclosureIDs[userID] = make(map[int64]bool)
+		}
+		mirroredEnclosureIDs[userID][enclosureID] = true
+	}
+
+	removedFiles, err := mirror.RemoveOrphanFiles(directory, mirroredEnclosureIDs)
+	if err != nil {
+		slog.Error("Unable to remove orphan enclosure files", slog.Any("error", err))
+	}
+
+	if removedFiles > 0 {
+		slog.Info("Orphan enclosure files removed", slog.Int("files", removedFiles))
+	}
+}
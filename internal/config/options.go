@@ -8,6 +8,7 @@ import (
 	"net"
 	"net/url"
 	"slices"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -89,6 +90,27 @@ func NewConfigOptions() *configOptions {
 				valueType:         secretFileType,
 				targetKey:         "ADMIN_USERNAME",
 			},
+			"ATTACHMENT_TEXT_EXTRACTION": {
+				parsedBoolValue: false,
+				rawValue:        "0",
+				valueType:       boolType,
+			},
+			"ATTACHMENT_TEXT_FREQUENCY": {
+				parsedDuration: 10 * time.Minute,
+				rawValue:       "10",
+				valueType:      minuteType,
+				validator: func(rawValue string) error {
+					return validateGreaterOrEqualThan(rawValue, 1)
+				},
+			},
+			"ATTACHMENT_TEXT_MAX_FILE_SIZE": {
+				parsedInt64Value: 20,
+				rawValue:         "20",
+				valueType:        int64Type,
+				validator: func(rawValue string) error {
+					return validateGreaterOrEqualThan(rawValue, 1)
+				},
+			},
 			"AUTH_PROXY_HEADER": {
 				parsedStringValue: "",
 				rawValue:          "",
@@ -219,6 +241,75 @@ func NewConfigOptions() *configOptions {
 				rawValue:        "0",
 				valueType:       boolType,
 			},
+			"ENCLOSURE_MIRROR_DIR": {
+				parsedStringValue: "",
+				rawValue:          "",
+				valueType:         stringType,
+			},
+			"ENCLOSURE_MIRROR_FREQUENCY": {
+				parsedDuration: 10 * time.Minute,
+				rawValue:       "10",
+				valueType:      minuteType,
+				validator: func(rawValue string) error {
+					return validateGreaterOrEqualThan(rawValue, 1)
+				},
+			},
+			"ENCLOSURE_MIRROR_MAX_FILE_SIZE": {
+				parsedInt64Value: 500,
+				rawValue:         "500",
+				valueType:        int64Type,
+				validator: func(rawValue string) error {
+					return validateGreaterOrEqualThan(rawValue, 1)
+				},
+			},
+			"ENCLOSURE_MIRROR_MAX_SIZE_PER_USER": {
+				parsedInt64Value: 0,
+				rawValue:         "0",
+				valueType:        int64Type,
+				validator: func(rawValue string) error {
+					return validateGreaterOrEqualThan(rawValue, 0)
+				},
+			},
+			"ENRICHMENT_PROCESSOR_COOLDOWN": {
+				parsedDuration: 300 * time.Second,
+				rawValue:       "300",
+				valueType:      secondType,
+				validator: func(rawValue string) error {
+					return validateGreaterOrEqualThan(rawValue, 1)
+				},
+			},
+			"ENRICHMENT_PROCESSOR_FAILURE_THRESHOLD": {
+				parsedIntValue: 5,
+				rawValue:       "5",
+				valueType:      intType,
+				validator: func(rawValue string) error {
+					return validateGreaterOrEqualThan(rawValue, 1)
+				},
+			},
+			"ENRICHMENT_PROCESSOR_TIMEOUT": {
+				parsedDuration: 10 * time.Second,
+				rawValue:       "10",
+				valueType:      secondType,
+				validator: func(rawValue string) error {
+					return validateGreaterOrEqualThan(rawValue, 1)
+				},
+			},
+			"ENRICHMENT_PROCESSORS": {
+				parsedStringList: []string{},
+				rawValue:         "",
+				valueType:        stringListType,
+				validator: func(rawValue string) error {
+					return validateEnrichmentProcessors(strings.Split(rawValue, ","))
+				},
+			},
+			"ENTRY_REVISIONS_LIMIT": {
+				parsedIntValue: 10,
+				rawValue:       "10",
+				valueType:      intType,
+				validator: func(rawValue string) error {
+					return validateGreaterOrEqualThan(rawValue, 0)
+				},
+			},
 			"FETCHER_ALLOW_PRIVATE_NETWORKS": {
 				parsedBoolValue: false,
 				rawValue:        "0",
@@ -298,6 +389,22 @@ func NewConfigOptions() *configOptions {
 				rawValue:        "0",
 				valueType:       boolType,
 			},
+			"ICON_REFRESH_DAYS": {
+				parsedDuration: time.Hour * 24 * 30,
+				rawValue:       "30",
+				valueType:      dayType,
+				validator: func(rawValue string) error {
+					return validateGreaterOrEqualThan(rawValue, 0)
+				},
+			},
+			"ICON_REFRESH_FREQUENCY_HOURS": {
+				parsedDuration: time.Hour,
+				rawValue:       "1",
+				valueType:      hourType,
+				validator: func(rawValue string) error {
+					return validateGreaterOrEqualThan(rawValue, 1)
+				},
+			},
 			"INTEGRATION_ALLOW_PRIVATE_NETWORKS": {
 				parsedBoolValue: false,
 				rawValue:        "0",
@@ -354,6 +461,27 @@ func NewConfigOptions() *configOptions {
 				rawValue:        "0",
 				valueType:       boolType,
 			},
+			"MEDIA_PROXY_CACHE_DIR": {
+				parsedStringValue: "",
+				rawValue:          "",
+				valueType:         stringType,
+			},
+			"MEDIA_PROXY_CACHE_MAX_ITEM_SIZE": {
+				parsedInt64Value: 50,
+				rawValue:         "50",
+				valueType:        int64Type,
+				validator: func(rawValue string) error {
+					return validateGreaterOrEqualThan(rawValue, 1)
+				},
+			},
+			"MEDIA_PROXY_CACHE_MAX_SIZE": {
+				parsedInt64Value: 1024,
+				rawValue:         "1024",
+				valueType:        int64Type,
+				validator: func(rawValue string) error {
+					return validateGreaterOrEqualThan(rawValue, 1)
+				},
+			},
 			"MEDIA_PROXY_CUSTOM_URL": {
 				rawValue:  "",
 				valueType: urlType,
@@ -366,6 +494,22 @@ func NewConfigOptions() *configOptions {
 					return validateGreaterOrEqualThan(rawValue, 1)
 				},
 			},
+			"MEDIA_PROXY_IMAGE_QUALITY": {
+				parsedIntValue: 75,
+				rawValue:       "75",
+				valueType:      intType,
+				validator: func(rawValue string) error {
+					return validateRange(rawValue, 1, 100)
+				},
+			},
+			"MEDIA_PROXY_IMAGE_WIDTHS": {
+				parsedStringList: []string{},
+				rawValue:         "",
+				valueType:        stringListType,
+				validator: func(rawValue string) error {
+					return validateListRange(strings.Split(rawValue, ","), 16, 4096)
+				},
+			},
 			"MEDIA_PROXY_MODE": {
 				parsedStringValue: "http-only",
 				rawValue:          "http-only",
@@ -561,6 +705,81 @@ func NewConfigOptions() *configOptions {
 					return validateGreaterOrEqualThan(rawValue, 1)
 				},
 			},
+			"SCRAPER_MAX_PAGES": {
+				parsedIntValue: 1,
+				rawValue:       "1",
+				valueType:      intType,
+				validator: func(rawValue string) error {
+					return validateGreaterOrEqualThan(rawValue, 1)
+				},
+			},
+			"SNAPSHOT_FREQUENCY": {
+				parsedDuration: 10 * time.Minute,
+				rawValue:       "10",
+				valueType:      minuteType,
+				validator: func(rawValue string) error {
+					return validateGreaterOrEqualThan(rawValue, 1)
+				},
+			},
+			"SNAPSHOT_MAX_SIZE": {
+				parsedInt64Value: 25,
+				rawValue:         "25",
+				valueType:        int64Type,
+				validator: func(rawValue string) error {
+					return validateGreaterOrEqualThan(rawValue, 1)
+				},
+			},
+			"SUMMARY_API_KEY": {
+				parsedStringValue: "",
+				rawValue:          "",
+				valueType:         stringType,
+				secret:            true,
+			},
+			"SUMMARY_API_URL": {
+				parsedStringValue: "",
+				rawValue:          "",
+				valueType:         stringType,
+				validator: func(rawValue string) error {
+					return validateHTTPURL(rawValue)
+				},
+			},
+			"SUMMARY_DAILY_TOKEN_BUDGET": {
+				parsedIntValue: 0,
+				rawValue:       "0",
+				valueType:      intType,
+				validator: func(rawValue string) error {
+					return validateGreaterOrEqualThan(rawValue, 0)
+				},
+			},
+			"SUMMARY_MAX_INPUT_TOKENS": {
+				parsedIntValue: 3000,
+				rawValue:       "3000",
+				valueType:      intType,
+				validator: func(rawValue string) error {
+					return validateGreaterOrEqualThan(rawValue, 100)
+				},
+			},
+			"SUMMARY_MAX_OUTPUT_TOKENS": {
+				parsedIntValue: 300,
+				rawValue:       "300",
+				valueType:      intType,
+				validator: func(rawValue string) error {
+					return validateGreaterOrEqualThan(rawValue, 1)
+				},
+			},
+			"SUMMARY_MODEL": {
+				parsedStringValue: "",
+				rawValue:          "",
+				valueType:         stringType,
+			},
+			"SUMMARY_TIMEOUT": {
+				parsedDuration: 60 * time.Second,
+				rawValue:       "60",
+				valueType:      secondType,
+				validator: func(rawValue string) error {
+					return validateGreaterOrEqualThan(rawValue, 1)
+				},
+			},
 			"TRUSTED_REVERSE_PROXY_NETWORKS": {
 				parsedStringList: []string{},
 				rawValue:         "",
@@ -616,6 +835,18 @@ func (c *configOptions) AdminUsername() string {
 	return c.options["ADMIN_USERNAME"].parsedStringValue
 }
 
+func (c *configOptions) AttachmentTextExtraction() bool {
+	return c.options["ATTACHMENT_TEXT_EXTRACTION"].parsedBoolValue
+}
+
+func (c *configOptions) AttachmentTextFrequency() time.Duration {
+	return c.options["ATTACHMENT_TEXT_FREQUENCY"].parsedDuration
+}
+
+func (c *configOptions) AttachmentTextMaxFileSize() int64 {
+	return c.options["ATTACHMENT_TEXT_MAX_FILE_SIZE"].parsedInt64Value * 1024 * 1024
+}
+
 func (c *configOptions) AuthProxyHeader() string {
 	return c.options["AUTH_PROXY_HEADER"].parsedStringValue
 }
@@ -704,6 +935,59 @@ func (c *configOptions) DisableSchedulerService() bool {
 	return c.options["DISABLE_SCHEDULER_SERVICE"].parsedBoolValue
 }
 
+func (c *configOptions) EnclosureMirrorDir() string {
+	return c.options["ENCLOSURE_MIRROR_DIR"].parsedStringValue
+}
+
+func (c *configOptions) EnclosureMirrorFrequency() time.Duration {
+	return c.options["ENCLOSURE_MIRROR_FREQUENCY"].parsedDuration
+}
+
+func (c *configOptions) EnclosureMirrorMaxFileSize() int64 {
+	return c.options["ENCLOSURE_MIRROR_MAX_FILE_SIZE"].parsedInt64Value * 1024 * 1024
+}
+
+func (c *configOptions) EnclosureMirrorMaxSizePerUser() int64 {
+	return c.options["ENCLOSURE_MIRROR_MAX_SIZE_PER_USER"].parsedInt64Value * 1024 * 1024
+}
+
+func (c *configOptions) EnrichmentProcessorCooldown() time.Duration {
+	return c.options["ENRICHMENT_PROCESSOR_COOLDOWN"].parsedDuration
+}
+
+func (c *configOptions) EnrichmentProcessorFailureThreshold() int {
+	return c.options["ENRICHMENT_PROCESSOR_FAILURE_THRESHOLD"].parsedIntValue
+}
+
+func (c *configOptions) EnrichmentProcessorTimeout() time.Duration {
+	return c.options["ENRICHMENT_PROCESSOR_TIMEOUT"].parsedDuration
+}
+
+// EnrichmentProcessors returns the URL of the enrichment processors by name.
+func (c *configOptions) EnrichmentProcessors() map[string]string {
+	processors := make(map[string]string)
+	for _, value := range c.options["ENRICHMENT_PROCESSORS"].parsedStringList {
+		if name, endpoint, found := strings.Cut(value, "="); found {
+			processors[strings.TrimSpace(name)] = strings.TrimSpace(endpoint)
+		}
+	}
+	return processors
+}
+
+// EnrichmentProcessorNames returns the sorted names of the enrichment processors.
+func (c *configOptions) EnrichmentProcessorNames() []string {
+	return slices.Sorted(maps.Keys(c.EnrichmentProcessors()))
+}
+
+// HasEnrichmentProcessors returns true if at least one enrichment processor is defined.
+func (c *configOptions) HasEnrichmentProcessors() bool {
+	return len(c.options["ENRICHMENT_PROCESSORS"].parsedStringList) > 0
+}
+
+func (c *configOptions) EntryRevisionsLimit() int {
+	return c.options["ENTRY_REVISIONS_LIMIT"].parsedIntValue
+}
+
 func (c *configOptions) FetchBilibiliWatchTime() bool {
 	return c.options["FETCH_BILIBILI_WATCH_TIME"].parsedBoolValue
 }
@@ -795,6 +1079,14 @@ func (c *configOptions) FetcherAllowPrivateNetworks() bool {
 	return c.options["FETCHER_ALLOW_PRIVATE_NETWORKS"].parsedBoolValue
 }
 
+func (c *configOptions) IconRefreshInterval() time.Duration {
+	return c.options["ICON_REFRESH_DAYS"].parsedDuration
+}
+
+func (c *configOptions) IconRefreshFrequency() time.Duration {
+	return c.options["ICON_REFRESH_FREQUENCY_HOURS"].parsedDuration
+}
+
 func (c *configOptions) IntegrationAllowPrivateNetworks() bool {
 	if c == nil {
 		return false
@@ -850,6 +1142,18 @@ func (c *configOptions) MaintenanceMode() bool {
 	return c.options["MAINTENANCE_MODE"].parsedBoolValue
 }
 
+func (c *configOptions) MediaProxyCacheDir() string {
+	return c.options["MEDIA_PROXY_CACHE_DIR"].parsedStringValue
+}
+
+func (c *configOptions) MediaProxyCacheMaxItemSize() int64 {
+	return c.options["MEDIA_PROXY_CACHE_MAX_ITEM_SIZE"].parsedInt64Value * 1024 * 1024
+}
+
+func (c *configOptions) MediaProxyCacheMaxSize() int64 {
+	return c.options["MEDIA_PROXY_CACHE_MAX_SIZE"].parsedInt64Value * 1024 * 1024
+}
+
 func (c *configOptions) MediaCustomProxyURL() *url.URL {
 	return c.options["MEDIA_PROXY_CUSTOM_URL"].parsedURLValue
 }
@@ -858,6 +1162,22 @@ func (c *configOptions) MediaProxyHTTPClientTimeout() time.Duration {
 	return c.options["MEDIA_PROXY_HTTP_CLIENT_TIMEOUT"].parsedDuration
 }
 
+func (c *configOptions) MediaProxyImageQuality() int {
+	return c.options["MEDIA_PROXY_IMAGE_QUALITY"].parsedIntValue
+}
+
+// MediaProxyImageWidths returns the widths of the resized images, in ascending order.
+func (c *configOptions) MediaProxyImageWidths() []int {
+	widths := make([]int, 0, len(c.options["MEDIA_PROXY_IMAGE_WIDTHS"].parsedStringList))
+	for _, value := range c.options["MEDIA_PROXY_IMAGE_WIDTHS"].parsedStringList {
+		if width, err := strconv.Atoi(value); err == nil {
+			widths = append(widths, width)
+		}
+	}
+	slices.Sort(widths)
+	return widths
+}
+
 func (c *configOptions) MediaProxyMode() string {
 	return c.options["MEDIA_PROXY_MODE"].parsedStringValue
 }
@@ -976,6 +1296,53 @@ func (c *configOptions) SchedulerRoundRobinMinInterval() time.Duration {
 	return c.options["SCHEDULER_ROUND_ROBIN_MIN_INTERVAL"].parsedDuration
 }
 
+func (c *configOptions) ScraperMaxPages() int {
+	return c.options["SCRAPER_MAX_PAGES"].parsedIntValue
+}
+
+func (c *configOptions) SnapshotFrequency() time.Duration {
+	return c.options["SNAPSHOT_FREQUENCY"].parsedDuration
+}
+
+func (c *configOptions) SnapshotMaxSize() int64 {
+	return c.options["SNAPSHOT_MAX_SIZE"].parsedInt64Value * 1024 * 1024
+}
+
+func (c *configOptions) SummaryAPIKey() string {
+	return c.options["SUMMARY_API_KEY"].parsedStringValue
+}
+
+// SummaryAPIURL returns the base URL of the OpenAI-compatible API used to summarize the entries.
+func (c *configOptions) SummaryAPIURL() string {
+	return strings.TrimSuffix(c.options["SUMMARY_API_URL"].parsedStringValue, "/")
+}
+
+// SummaryDailyTokenBudget returns the maximum number of tokens used per day to summarize the entries, 0 means unlimited.
+func (c *configOptions) SummaryDailyTokenBudget() int {
+	return c.options["SUMMARY_DAILY_TOKEN_BUDGET"].parsedIntValue
+}
+
+func (c *configOptions) SummaryMaxInputTokens() int {
+	return c.options["SUMMARY_MAX_INPUT_TOKENS"].parsedIntValue
+}
+
+func (c *configOptions) SummaryMaxOutputTokens() int {
+	return c.options["SUMMARY_MAX_OUTPUT_TOKENS"].parsedIntValue
+}
+
+func (c *configOptions) SummaryModel() string {
+	return c.options["SUMMARY_MODEL"].parsedStringValue
+}
+
+func (c *configOptions) SummaryTimeout() time.Duration {
+	return c.options["SUMMARY_TIMEOUT"].parsedDuration
+}
+
+// HasSummaryAPI returns true if an API is configured to summarize the entries.
+func (c *configOptions) HasSummaryAPI() bool {
+	return c.SummaryAPIURL() != "" && c.SummaryModel() != ""
+}
+
 func (c *configOptions) TrustedReverseProxyNetworks() []string {
 	return c.options["TRUSTED_REVERSE_PROXY_NETWORKS"].parsedStringList
 }
@@ -6,6 +6,7 @@ package config // import "miniflux.app/v2/internal/config"
 import (
 	"slices"
 	"testing"
+	"time"
 )
 
 func TestBaseURLOptionParsing(t *testing.T) {
@@ -504,6 +505,26 @@ func TestDisableSchedulerServiceOptionParsing(t *testing.T) {
 	}
 }
 
+func TestEntryRevisionsLimitOptionParsing(t *testing.T) {
+	configParser := NewConfigParser()
+
+	if configParser.options.EntryRevisionsLimit() != 10 {
+		t.Fatalf("Expected ENTRY_REVISIONS_LIMIT to be 10 by default")
+	}
+
+	if err := configParser.parseLines([]string{"ENTRY_REVISIONS_LIMIT=0"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if configParser.options.EntryRevisionsLimit() != 0 {
+		t.Fatalf("Expected ENTRY_REVISIONS_LIMIT to be 0")
+	}
+
+	if err := configParser.parseLines([]string{"ENTRY_REVISIONS_LIMIT=-1"}); err == nil {
+		t.Fatalf("Expected error for negative ENTRY_REVISIONS_LIMIT")
+	}
+}
+
 func TestFetchBilibiliWatchTimeOptionParsing(t *testing.T) {
 	configParser := NewConfigParser()
 
@@ -2043,3 +2064,363 @@ func TestValidateSchedulerEntryFrequencyMinLessThanMax(t *testing.T) {
 		t.Fatalf("Unexpected error: %v", err)
 	}
 }
+
+func TestScraperMaxPagesOptionParsing(t *testing.T) {
+	configParser := NewConfigParser()
+
+	if configParser.options.ScraperMaxPages() != 1 {
+		t.Fatalf("Expected SCRAPER_MAX_PAGES to be 1 by default, got %d", configParser.options.ScraperMaxPages())
+	}
+
+	if err := configParser.parseLines([]string{"SCRAPER_MAX_PAGES=5"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if configParser.options.ScraperMaxPages() != 5 {
+		t.Fatalf("Expected SCRAPER_MAX_PAGES to be 5, got %d", configParser.options.ScraperMaxPages())
+	}
+
+	if err := configParser.parseLines([]string{"SCRAPER_MAX_PAGES=0"}); err == nil {
+		t.Fatal("Expected an error for SCRAPER_MAX_PAGES=0")
+	}
+}
+
+func TestSnapshotOptionsParsing(t *testing.T) {
+	configParser := NewConfigParser()
+
+	if configParser.options.SnapshotFrequency() != 10*time.Minute {
+		t.Fatalf("Expected SNAPSHOT_FREQUENCY to be 10 minutes by default, got %v", configParser.options.SnapshotFrequency())
+	}
+
+	if configParser.options.SnapshotMaxSize() != 25*1024*1024 {
+		t.Fatalf("Expected SNAPSHOT_MAX_SIZE to be 25 MiB by default, got %d", configParser.options.SnapshotMaxSize())
+	}
+
+	if err := configParser.parseLines([]string{"SNAPSHOT_FREQUENCY=30", "SNAPSHOT_MAX_SIZE=5"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if configParser.options.SnapshotFrequency() != 30*time.Minute {
+		t.Fatalf("Expected SNAPSHOT_FREQUENCY to be 30 minutes, got %v", configParser.options.SnapshotFrequency())
+	}
+
+	if configParser.options.SnapshotMaxSize() != 5*1024*1024 {
+		t.Fatalf("Expected SNAPSHOT_MAX_SIZE to be 5 MiB, got %d", configParser.options.SnapshotMaxSize())
+	}
+
+	if err := configParser.parseLines([]string{"SNAPSHOT_MAX_SIZE=0"}); err == nil {
+		t.Fatal("Expected an error for SNAPSHOT_MAX_SIZE=0")
+	}
+}
+
+func TestMediaProxyCacheOptionsParsing(t *testing.T) {
+	configParser := NewConfigParser()
+
+	if configParser.options.MediaProxyCacheDir() != "" {
+		t.Fatalf("Expected MEDIA_PROXY_CACHE_DIR to be empty by default, got %q", configParser.options.MediaProxyCacheDir())
+	}
+
+	if configParser.options.MediaProxyCacheMaxSize() != 1024*1024*1024 {
+		t.Fatalf("Expected MEDIA_PROXY_CACHE_MAX_SIZE to be 1024 MiB by default, got %d", configParser.options.MediaProxyCacheMaxSize())
+	}
+
+	if configParser.options.MediaProxyCacheMaxItemSize() != 50*1024*1024 {
+		t.Fatalf("Expected MEDIA_PROXY_CACHE_MAX_ITEM_SIZE to be 50 MiB by default, got %d", configParser.options.MediaProxyCacheMaxItemSize())
+	}
+
+	if err := configParser.parseLines([]string{"MEDIA_PROXY_CACHE_DIR=/var/cache/miniflux", "MEDIA_PROXY_CACHE_MAX_SIZE=256", "MEDIA_PROXY_CACHE_MAX_ITEM_SIZE=10"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if configParser.options.MediaProxyCacheDir() != "/var/cache/miniflux" {
+		t.Fatalf("Expected MEDIA_PROXY_CACHE_DIR to be /var/cache/miniflux, got %q", configParser.options.MediaProxyCacheDir())
+	}
+
+	if configParser.options.MediaProxyCacheMaxSize() != 256*1024*1024 {
+		t.Fatalf("Expected MEDIA_PROXY_CACHE_MAX_SIZE to be 256 MiB, got %d", configParser.options.MediaProxyCacheMaxSize())
+	}
+
+	if configParser.options.MediaProxyCacheMaxItemSize() != 10*1024*1024 {
+		t.Fatalf("Expected MEDIA_PROXY_CACHE_MAX_ITEM_SIZE to be 10 MiB, got %d", configParser.options.MediaProxyCacheMaxItemSize())
+	}
+
+	if err := configParser.parseLines([]string{"MEDIA_PROXY_CACHE_MAX_SIZE=0"}); err == nil {
+		t.Fatal("Expected an error for MEDIA_PROXY_CACHE_MAX_SIZE=0")
+	}
+}
+
+func TestMediaProxyImageOptionsParsing(t *testing.T) {
+	configParser := NewConfigParser()
+
+	if len(configParser.options.MediaProxyImageWidths()) != 0 {
+		t.Fatalf("Expected MEDIA_PROXY_IMAGE_WIDTHS to be empty by default, got %v", configParser.options.MediaProxyImageWidths())
+	}
+
+	if configParser.options.MediaProxyImageQuality() != 75 {
+		t.Fatalf("Expected MEDIA_PROXY_IMAGE_QUALITY to be 75 by default, got %d", configParser.options.MediaProxyImageQuality())
+	}
+
+	if err := configParser.parseLines([]string{"MEDIA_PROXY_IMAGE_WIDTHS=1280, 480,960", "MEDIA_PROXY_IMAGE_QUALITY=60"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if widths := configParser.options.MediaProxyImageWidths(); !slices.Equal(widths, []int{480, 960, 1280}) {
+		t.Fatalf("Expected MEDIA_PROXY_IMAGE_WIDTHS to be sorted, got %v", widths)
+	}
+
+	if configParser.options.MediaProxyImageQuality() != 60 {
+		t.Fatalf("Expected MEDIA_PROXY_IMAGE_QUALITY to be 60, got %d", configParser.options.MediaProxyImageQuality())
+	}
+
+	if err := configParser.parseLines([]string{"MEDIA_PROXY_IMAGE_WIDTHS=480,10000"}); err == nil {
+		t.Fatal("Expected an error for MEDIA_PROXY_IMAGE_WIDTHS=480,10000")
+	}
+
+	if err := configParser.parseLines([]string{"MEDIA_PROXY_IMAGE_QUALITY=0"}); err == nil {
+		t.Fatal("Expected an error for MEDIA_PROXY_IMAGE_QUALITY=0")
+	}
+}
+
+func TestEnclosureMirrorOptionsParsing(t *testing.T) {
+	configParser := NewConfigParser()
+
+	if configParser.options.EnclosureMirrorDir() != "" {
+		t.Fatalf("Expected ENCLOSURE_MIRROR_DIR to be empty by default, got %q", configParser.options.EnclosureMirrorDir())
+	}
+
+	if configParser.options.EnclosureMirrorFrequency() != 10*time.Minute {
+		t.Fatalf("Expected ENCLOSURE_MIRROR_FREQUENCY to be 10 minutes by default, got %v", configParser.options.EnclosureMirrorFrequency())
+	}
+
+	if configParser.options.EnclosureMirrorMaxFileSize() != 500*1024*1024 {
+		t.Fatalf("Expected ENCLOSURE_MIRROR_MAX_FILE_SIZE to be 500 MiB by default, got %d", configParser.options.EnclosureMirrorMaxFileSize())
+	}
+
+	if configParser.options.EnclosureMirrorMaxSizePerUser() != 0 {
+		t.Fatalf("Expected ENCLOSURE_MIRROR_MAX_SIZE_PER_USER to be 0 by default, got %d", configParser.options.EnclosureMirrorMaxSizePerUser())
+	}
+
+	lines := []string{
+		"ENCLOSURE_MIRROR_DIR=/var/lib/miniflux/enclosures",
+		"ENCLOSURE_MIRROR_FREQUENCY=60",
+		"ENCLOSURE_MIRROR_MAX_FILE_SIZE=100",
+		"ENCLOSURE_MIRROR_MAX_SIZE_PER_USER=2048",
+	}
+	if err := configParser.parseLines(lines); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if configParser.options.EnclosureMirrorDir() != "/var/lib/miniflux/enclosures" {
+		t.Fatalf("Unexpected ENCLOSURE_MIRROR_DIR, got %q", configParser.options.EnclosureMirrorDir())
+	}
+
+	if configParser.options.EnclosureMirrorFrequency() != time.Hour {
+		t.Fatalf("Expected ENCLOSURE_MIRROR_FREQUENCY to be 1 hour, got %v", configParser.options.EnclosureMirrorFrequency())
+	}
+
+	if configParser.options.EnclosureMirrorMaxFileSize() != 100*1024*1024 {
+		t.Fatalf("Expected ENCLOSURE_MIRROR_MAX_FILE_SIZE to be 100 MiB, got %d", configParser.options.EnclosureMirrorMaxFileSize())
+	}
+
+	if configParser.options.EnclosureMirrorMaxSizePerUser() != 2048*1024*1024 {
+		t.Fatalf("Expected ENCLOSURE_MIRROR_MAX_SIZE_PER_USER to be 2048 MiB, got %d", configParser.options.EnclosureMirrorMaxSizePerUser())
+	}
+
+	if err := configParser.parseLines([]string{"ENCLOSURE_MIRROR_MAX_FILE_SIZE=0"}); err == nil {
+		t.Fatal("Expected an error for ENCLOSURE_MIRROR_MAX_FILE_SIZE=0")
+	}
+}
+
+func TestAttachmentTextOptionsParsing(t *testing.T) {
+	configParser := NewConfigParser()
+
+	if configParser.options.AttachmentTextExtraction() {
+		t.Fatal("Expected ATTACHMENT_TEXT_EXTRACTION to be disabled by default")
+	}
+
+	if configParser.options.AttachmentTextFrequency() != 10*time.Minute {
+		t.Fatalf("Expected ATTACHMENT_TEXT_FREQUENCY to be 10 minutes by default, got %v", configParser.options.AttachmentTextFrequency())
+	}
+
+	if configParser.options.AttachmentTextMaxFileSize() != 20*1024*1024 {
+		t.Fatalf("Expected ATTACHMENT_TEXT_MAX_FILE_SIZE to be 20 MiB by default, got %d", configParser.options.AttachmentTextMaxFileSize())
+	}
+
+	lines := []string{
+		"ATTACHMENT_TEXT_EXTRACTION=1",
+		"ATTACHMENT_TEXT_FREQUENCY=30",
+		"ATTACHMENT_TEXT_MAX_FILE_SIZE=5",
+	}
+	if err := configParser.parseLines(lines); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !configParser.options.AttachmentTextExtraction() {
+		t.Fatal("Expected ATTACHMENT_TEXT_EXTRACTION to be enabled")
+	}
+
+	if configParser.options.AttachmentTextFrequency() != 30*time.Minute {
+		t.Fatalf("Expected ATTACHMENT_TEXT_FREQUENCY to be 30 minutes, got %v", configParser.options.AttachmentTextFrequency())
+	}
+
+	if configParser.options.AttachmentTextMaxFileSize() != 5*1024*1024 {
+		t.Fatalf("Expected ATTACHMENT_TEXT_MAX_FILE_SIZE to be 5 MiB, got %d", configParser.options.AttachmentTextMaxFileSize())
+	}
+
+	if err := configParser.parseLines([]string{"ATTACHMENT_TEXT_FREQUENCY=0"}); err == nil {
+		t.Fatal("Expected an error for ATTACHMENT_TEXT_FREQUENCY=0")
+	}
+}
+
+func TestIconRefreshOptionsParsing(t *testing.T) {
+	configParser := NewConfigParser()
+
+	if configParser.options.IconRefreshInterval() != 30*24*time.Hour {
+		t.Fatalf("Expected ICON_REFRESH_DAYS to be 30 days by default, got %v", configParser.options.IconRefreshInterval())
+	}
+
+	if configParser.options.IconRefreshFrequency() != time.Hour {
+		t.Fatalf("Expected ICON_REFRESH_FREQUENCY_HOURS to be 1 hour by default, got %v", configParser.options.IconRefreshFrequency())
+	}
+
+	if err := configParser.parseLines([]string{"ICON_REFRESH_DAYS=0", "ICON_REFRESH_FREQUENCY_HOURS=6"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if configParser.options.IconRefreshInterval() != 0 {
+		t.Fatalf("Expected ICON_REFRESH_DAYS to be disabled, got %v", configParser.options.IconRefreshInterval())
+	}
+
+	if configParser.options.IconRefreshFrequency() != 6*time.Hour {
+		t.Fatalf("Expected ICON_REFRESH_FREQUENCY_HOURS to be 6 hours, got %v", configParser.options.IconRefreshFrequency())
+	}
+
+	if err := configParser.parseLines([]string{"ICON_REFRESH_DAYS=-1"}); err == nil {
+		t.Fatal("Expected an error for ICON_REFRESH_DAYS=-1")
+	}
+}
+
+func TestEnrichmentProcessorOptionsParsing(t *testing.T) {
+	configParser := NewConfigParser()
+
+	if configParser.options.HasEnrichmentProcessors() {
+		t.Fatal("Expected no enrichment processor by default")
+	}
+
+	if configParser.options.EnrichmentProcessorTimeout() != 10*time.Second {
+		t.Fatalf("Expected ENRICHMENT_PROCESSOR_TIMEOUT to be 10 seconds by default, got %v", configParser.options.EnrichmentProcessorTimeout())
+	}
+
+	if configParser.options.EnrichmentProcessorFailureThreshold() != 5 {
+		t.Fatalf("Expected ENRICHMENT_PROCESSOR_FAILURE_THRESHOLD to be 5 by default, got %d", configParser.options.EnrichmentProcessorFailureThreshold())
+	}
+
+	if configParser.options.EnrichmentProcessorCooldown() != 5*time.Minute {
+		t.Fatalf("Expected ENRICHMENT_PROCESSOR_COOLDOWN to be 5 minutes by default, got %v", configParser.options.EnrichmentProcessorCooldown())
+	}
+
+	lines := []string{
+		"ENRICHMENT_PROCESSORS=summarizer=http://127.0.0.1:8000/summarize, classifier = https://example.org/classify",
+		"ENRICHMENT_PROCESSOR_TIMEOUT=30",
+		"ENRICHMENT_PROCESSOR_FAILURE_THRESHOLD=3",
+		"ENRICHMENT_PROCESSOR_COOLDOWN=60",
+	}
+	if err := configParser.parseLines(lines); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	processors := configParser.options.EnrichmentProcessors()
+	if len(processors) != 2 || processors["summarizer"] != "http://127.0.0.1:8000/summarize" || processors["classifier"] != "https://example.org/classify" {
+		t.Fatalf("Unexpected ENRICHMENT_PROCESSORS, got %v", processors)
+	}
+
+	if configParser.options.EnrichmentProcessorTimeout() != 30*time.Second {
+		t.Fatalf("Expected ENRICHMENT_PROCESSOR_TIMEOUT to be 30 seconds, got %v", configParser.options.EnrichmentProcessorTimeout())
+	}
+
+	if configParser.options.EnrichmentProcessorFailureThreshold() != 3 {
+		t.Fatalf("Expected ENRICHMENT_PROCESSOR_FAILURE_THRESHOLD to be 3, got %d", configParser.options.EnrichmentProcessorFailureThreshold())
+	}
+
+	if configParser.options.EnrichmentProcessorCooldown() != time.Minute {
+		t.Fatalf("Expected ENRICHMENT_PROCESSOR_COOLDOWN to be 1 minute, got %v", configParser.options.EnrichmentProcessorCooldown())
+	}
+
+	for _, line := range []string{
+		"ENRICHMENT_PROCESSORS=summarizer",
+		"ENRICHMENT_PROCESSORS=bad name=http://localhost/",
+		"ENRICHMENT_PROCESSORS=summarizer=ftp://localhost/",
+		"ENRICHMENT_PROCESSORS=summarizer=http://localhost/a,summarizer=http://localhost/b",
+	} {
+		if err := NewConfigParser().parseLines([]string{line}); err == nil {
+			t.Errorf("Expected an error for %q", line)
+		}
+	}
+}
+
+func TestSummaryOptionsParsing(t *testing.T) {
+	configParser := NewConfigParser()
+
+	if configParser.options.HasSummaryAPI() {
+		t.Fatal("Expected no summary API by default")
+	}
+
+	if configParser.options.SummaryMaxInputTokens() != 3000 || configParser.options.SummaryMaxOutputTokens() != 300 {
+		t.Fatalf("Unexpected default token limits, got %d and %d", configParser.options.SummaryMaxInputTokens(), configParser.options.SummaryMaxOutputTokens())
+	}
+
+	if configParser.options.SummaryDailyTokenBudget() != 0 {
+		t.Fatalf("Expected SUMMARY_DAILY_TOKEN_BUDGET to be 0 by default, got %d", configParser.options.SummaryDailyTokenBudget())
+	}
+
+	if configParser.options.SummaryTimeout() != time.Minute {
+		t.Fatalf("Expected SUMMARY_TIMEOUT to be 1 minute by default, got %v", configParser.options.SummaryTimeout())
+	}
+
+	lines := []string{
+		"SUMMARY_API_URL=http://127.0.0.1:11434/v1/",
+		"SUMMARY_MODEL=llama3.2",
+		"SUMMARY_API_KEY=secret",
+		"SUMMARY_DAILY_TOKEN_BUDGET=100000",
+		"SUMMARY_MAX_INPUT_TOKENS=2000",
+		"SUMMARY_MAX_OUTPUT_TOKENS=200",
+		"SUMMARY_TIMEOUT=120",
+	}
+	if err := configParser.parseLines(lines); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !configParser.options.HasSummaryAPI() {
+		t.Fatal("Expected a summary API")
+	}
+
+	if configParser.options.SummaryAPIURL() != "http://127.0.0.1:11434/v1" {
+		t.Fatalf("Unexpected SUMMARY_API_URL, got %q", configParser.options.SummaryAPIURL())
+	}
+
+	if configParser.options.SummaryModel() != "llama3.2" || configParser.options.SummaryAPIKey() != "secret" {
+		t.Fatalf("Unexpected SUMMARY_MODEL or SUMMARY_API_KEY")
+	}
+
+	if configParser.options.SummaryDailyTokenBudget() != 100000 {
+		t.Fatalf("Expected SUMMARY_DAILY_TOKEN_BUDGET to be 100000, got %d", configParser.options.SummaryDailyTokenBudget())
+	}
+
+	if configParser.options.SummaryMaxInputTokens() != 2000 || configParser.options.SummaryMaxOutputTokens() != 200 {
+		t.Fatalf("Unexpected token limits, got %d and %d", configParser.options.SummaryMaxInputTokens(), configParser.options.SummaryMaxOutputTokens())
+	}
+
+	if configParser.options.SummaryTimeout() != 2*time.Minute {
+		t.Fatalf("Expected SUMMARY_TIMEOUT to be 2 minutes, got %v", configParser.options.SummaryTimeout())
+	}
+
+	for _, line := range []string{
+		"SUMMARY_API_URL=localhost:11434",
+		"SUMMARY_DAILY_TOKEN_BUDGET=-1",
+		"SUMMARY_MAX_INPUT_TOKENS=10",
+	} {
+		if err := NewConfigParser().parseLines([]string{line}); err == nil {
+			t.Errorf("Expected an error for %q", line)
+		}
+	}
+}
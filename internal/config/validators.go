@@ -6,11 +6,15 @@ package config // import "miniflux.app/v2/internal/config"
 import (
 	"errors"
 	"fmt"
+	"net/url"
+	"regexp"
 	"slices"
 	"strconv"
 	"strings"
 )
 
+var enrichmentProcessorNameRegex = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
 func validateChoices(rawValue string, choices []string) error {
 	if !slices.Contains(choices, rawValue) {
 		return fmt.Errorf("value must be one of: %v", strings.Join(choices, ", "))
@@ -59,3 +63,59 @@ func validateRange(rawValue string, min, max int) error {
 	}
 	return nil
 }
+
+func validateListRange(inputValues []string, min, max int) error {
+	for _, value := range inputValues {
+		if value = strings.TrimSpace(value); value == "" {
+			continue
+		}
+		if err := validateRange(value, min, max); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateHTTPURL checks that an optional value is an absolute HTTP or HTTPS URL.
+func validateHTTPURL(rawValue string) error {
+	if rawValue == "" {
+		return nil
+	}
+
+	parsedURL, err := url.Parse(rawValue)
+	if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || parsedURL.Host == "" {
+		return fmt.Errorf("value must be an absolute HTTP URL")
+	}
+	return nil
+}
+
+// validateEnrichmentProcessors checks a list of "name=URL" pairs.
+func validateEnrichmentProcessors(inputValues []string) error {
+	names := make(map[string]bool)
+	for _, value := range inputValues {
+		if value = strings.TrimSpace(value); value == "" {
+			continue
+		}
+
+		name, endpoint, found := strings.Cut(value, "=")
+		if !found {
+			return fmt.Errorf("processor %q must be defined as name=URL", value)
+		}
+
+		name = strings.TrimSpace(name)
+		if !enrichmentProcessorNameRegex.MatchString(name) {
+			return fmt.Errorf("processor name %q must only contain letters, digits, dashes and underscores", name)
+		}
+
+		if names[name] {
+			return fmt.Errorf("processor %q is defined more than once", name)
+		}
+		names[name] = true
+
+		parsedURL, err := url.Parse(strings.TrimSpace(endpoint))
+		if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || parsedURL.Host == "" {
+			return fmt.Errorf("processor %q must have an absolute HTTP URL", name)
+		}
+	}
+	return nil
+}
@@ -370,3 +370,29 @@ func TestValidateRange(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateListRange(t *testing.T) {
+	tests := []struct {
+		name        string
+		rawValue    string
+		expectError bool
+	}{
+		{name: "empty list", rawValue: "", expectError: false},
+		{name: "valid values", rawValue: "480, 960,1440", expectError: false},
+		{name: "value below minimum", rawValue: "480,0", expectError: true},
+		{name: "value above maximum", rawValue: "480,5000", expectError: true},
+		{name: "non-integer value", rawValue: "480,large", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateListRange(strings.Split(tt.rawValue, ","), 1, 4096)
+			if tt.expectError && err == nil {
+				t.Errorf("expected error but got none")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("expected no error but got: %v", err)
+			}
+		})
+	}
+}
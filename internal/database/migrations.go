@@ -1545,4 +1545,266 @@ var migrations = [...]func(tx *sql.Tx) error{
 		`)
 		return err
 	},
+	func(tx *sql.Tx) (err error) {
+		_, err = tx.Exec(`
+			ALTER TABLE feeds ADD COLUMN mark_unread_on_entry_revision bool default 'f';
+			ALTER TABLE entries ADD COLUMN revised_at timestamp with time zone;
+			ALTER TABLE entries ADD COLUMN read_at timestamp with time zone;
+
+			CREATE TABLE entry_revisions (
+				id bigserial not null,
+				entry_id bigint not null,
+				title text not null,
+				content text not null,
+				created_at timestamp with time zone not null default now(),
+				primary key (id),
+				foreign key (entry_id) references entries(id) on delete cascade
+			);
+			CREATE INDEX entry_revisions_entry_id_created_at_idx ON entry_revisions(entry_id, created_at);
+		`)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		_, err = tx.Exec(`
+			ALTER TABLE users ADD COLUMN duplicate_entries_action text not null default 'none';
+			ALTER TABLE entries ADD COLUMN fingerprint bigint;
+			CREATE INDEX entries_fingerprint_band0_idx ON entries(user_id, (fingerprint & 65535)) WHERE fingerprint IS NOT NULL;
+			CREATE INDEX entries_fingerprint_band1_idx ON entries(user_id, ((fingerprint >> 16) & 65535)) WHERE fingerprint IS NOT NULL;
+			CREATE INDEX entries_fingerprint_band2_idx ON entries(user_id, ((fingerprint >> 32) & 65535)) WHERE fingerprint IS NOT NULL;
+			CREATE INDEX entries_fingerprint_band3_idx ON entries(user_id, ((fingerprint >> 48) & 65535)) WHERE fingerprint IS NOT NULL;
+
+			CREATE TABLE entry_duplicates (
+				entry_id bigint not null references entries(id) on delete cascade,
+				feed_id bigint not null references feeds(id) on delete cascade,
+				url text not null,
+				created_at timestamp with time zone not null default now(),
+				primary key (entry_id, feed_id)
+			);
+		`)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		_, err = tx.Exec(`
+			CREATE TABLE saved_searches (
+				id bigserial PRIMARY KEY,
+				user_id bigint NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+				title text NOT NULL,
+				query text NOT NULL,
+				created_at timestamp with time zone NOT NULL DEFAULT now()
+			);
+			CREATE UNIQUE INDEX saved_searches_user_id_lower_title_idx ON saved_searches(user_id, lower(title));
+		`)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		_, err = tx.Exec(`
+			ALTER TABLE users ADD COLUMN entry_rules text NOT NULL DEFAULT '';
+			ALTER TABLE categories ADD COLUMN entry_rules text NOT NULL DEFAULT '';
+			ALTER TABLE feeds ADD COLUMN entry_rules text NOT NULL DEFAULT '';
+		`)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		_, err = tx.Exec(`
+			CREATE TABLE rule_jobs (
+				id bigserial PRIMARY KEY,
+				user_id bigint NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+				feed_id bigint NOT NULL DEFAULT 0,
+				category_id bigint NOT NULL DEFAULT 0,
+				action text NOT NULL,
+				status text NOT NULL DEFAULT 'pending',
+				total int NOT NULL DEFAULT 0,
+				processed int NOT NULL DEFAULT 0,
+				matched int NOT NULL DEFAULT 0,
+				error_msg text NOT NULL DEFAULT '',
+				created_at timestamp with time zone NOT NULL DEFAULT now(),
+				updated_at timestamp with time zone NOT NULL DEFAULT now(),
+				finished_at timestamp with time zone
+			);
+			CREATE INDEX rule_jobs_user_id_idx ON rule_jobs(user_id);
+			CREATE UNIQUE INDEX rule_jobs_active_idx ON rule_jobs(user_id, feed_id, category_id) WHERE status IN ('pending', 'running');
+		`)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		_, err = tx.Exec(`
+			ALTER TABLE categories
+				ADD COLUMN scraper_rules text NOT NULL DEFAULT '',
+				ADD COLUMN rewrite_rules text NOT NULL DEFAULT '',
+				ADD COLUMN urlrewrite_rules text NOT NULL DEFAULT '',
+				ADD COLUMN blocklist_rules text NOT NULL DEFAULT '',
+				ADD COLUMN keeplist_rules text NOT NULL DEFAULT '',
+				ADD COLUMN block_filter_entry_rules text NOT NULL DEFAULT '',
+				ADD COLUMN keep_filter_entry_rules text NOT NULL DEFAULT '',
+				ADD COLUMN crawler boolean NOT NULL DEFAULT false,
+				ADD COLUMN user_agent text NOT NULL DEFAULT '',
+				ADD COLUMN fetch_via_proxy boolean NOT NULL DEFAULT false,
+				ADD COLUMN proxy_url text NOT NULL DEFAULT '';
+		`)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		_, err = tx.Exec(`
+			CREATE TABLE blocked_entries (
+				id bigserial PRIMARY KEY,
+				user_id bigint NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+				feed_id bigint NOT NULL REFERENCES feeds(id) ON DELETE CASCADE,
+				hash text NOT NULL,
+				title text NOT NULL DEFAULT '',
+				url text NOT NULL DEFAULT '',
+				entry jsonb NOT NULL,
+				rule text NOT NULL,
+				rule_source text NOT NULL,
+				rule_field text NOT NULL,
+				rule_line int NOT NULL DEFAULT 0,
+				stage text NOT NULL DEFAULT '',
+				created_at timestamp with time zone NOT NULL DEFAULT now(),
+				UNIQUE (feed_id, hash)
+			);
+			CREATE INDEX blocked_entries_user_id_idx ON blocked_entries(user_id);
+
+			CREATE TABLE rule_hits (
+				user_id bigint NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+				feed_id bigint NOT NULL REFERENCES feeds(id) ON DELETE CASCADE,
+				rule_source text NOT NULL,
+				rule_field text NOT NULL,
+				rule_line int NOT NULL DEFAULT 0,
+				rule text NOT NULL,
+				hits bigint NOT NULL DEFAULT 0,
+				last_hit_at timestamp with time zone NOT NULL DEFAULT now(),
+				PRIMARY KEY (feed_id, rule_source, rule_field, rule_line)
+			);
+		`)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		_, err = tx.Exec(`
+			ALTER TABLE feeds ADD COLUMN snapshot_entries bool default 'f';
+
+			CREATE TABLE entry_snapshots (
+				entry_id bigint PRIMARY KEY REFERENCES entries(id) ON DELETE CASCADE,
+				user_id bigint NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+				url text NOT NULL DEFAULT '',
+				content text NOT NULL DEFAULT '',
+				size bigint NOT NULL DEFAULT 0,
+				error_msg text NOT NULL DEFAULT '',
+				created_at timestamp with time zone NOT NULL DEFAULT now()
+			);
+			CREATE INDEX entry_snapshots_user_id_idx ON entry_snapshots(user_id);
+		`)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		_, err = tx.Exec(`
+			ALTER TABLE feeds ADD COLUMN mirror_enclosures bool default 'f';
+			ALTER TABLE feeds ADD COLUMN mirror_enclosures_limit int default 0;
+
+			CREATE TABLE enclosure_mirrors (
+				enclosure_id bigint PRIMARY KEY REFERENCES enclosures(id) ON DELETE CASCADE,
+				user_id bigint NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+				mime_type text NOT NULL DEFAULT '',
+				size bigint NOT NULL DEFAULT 0,
+				error_msg text NOT NULL DEFAULT '',
+				evicted bool NOT NULL DEFAULT 'f',
+				created_at timestamp with time zone NOT NULL DEFAULT now()
+			);
+			CREATE INDEX enclosure_mirrors_user_id_idx ON enclosure_mirrors(user_id);
+		`)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		_, err = tx.Exec(`ALTER TABLE feeds ADD COLUMN icon_checked_at timestamp with time zone NOT NULL DEFAULT now()`)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE enclosures ADD COLUMN thumbnail_url text NOT NULL DEFAULT '';
+			ALTER TABLE enclosures ADD COLUMN duration int NOT NULL DEFAULT 0;
+			ALTER TABLE enclosures ADD COLUMN channel_name text NOT NULL DEFAULT '';
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE feeds ADD COLUMN enrichment_processors text NOT NULL DEFAULT '';
+			ALTER TABLE categories ADD COLUMN enrichment_processors text NOT NULL DEFAULT '';
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE entries ADD COLUMN summary text NOT NULL DEFAULT '';
+			ALTER TABLE entries ADD COLUMN summary_pending bool NOT NULL DEFAULT 'f';
+			CREATE INDEX entries_summary_pending_idx ON entries(id) WHERE summary_pending;
+			ALTER TABLE categories ADD COLUMN summarize_entries bool NOT NULL DEFAULT 'f';
+			ALTER TABLE categories ADD COLUMN summary_prompt text NOT NULL DEFAULT '';
+
+			CREATE TABLE summary_token_usage (
+				user_id bigint NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+				day date NOT NULL,
+				tokens bigint NOT NULL DEFAULT 0,
+				PRIMARY KEY (user_id, day)
+			);
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		sql := `
+			ALTER TABLE entries ADD COLUMN language text NOT NULL DEFAULT '';
+			CREATE INDEX entries_user_id_language_idx ON entries(user_id, language);
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		// The text extracted from the PDF and transcript enclosures is indexed with the entry.
+		sql := `
+			ALTER TABLE entries ADD COLUMN attachment_text text NOT NULL DEFAULT '';
+			CREATE TABLE enclosure_texts (
+				enclosure_id bigint PRIMARY KEY REFERENCES enclosures(id) ON DELETE CASCADE,
+				user_id bigint NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+				text_content text NOT NULL DEFAULT '',
+				error_msg text NOT NULL DEFAULT '',
+				created_at timestamp with time zone NOT NULL DEFAULT now()
+			);
+			CREATE INDEX enclosure_texts_user_id_idx ON enclosure_texts(user_id);
+			ALTER TABLE enclosures ADD COLUMN transcript bool NOT NULL DEFAULT false;
+			CREATE INDEX enclosures_text_extraction_idx ON enclosures(entry_id)
+				WHERE transcript OR lower(trim(split_part(mime_type, ';', 1))) = 'application/pdf';
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		// The status changes of the entries are recorded for the reading statistics,
+		// the entries and their changed_at column being overwritten or archived.
+		sql := `
+			CREATE TABLE entry_status_events (
+				id bigserial PRIMARY KEY,
+				user_id bigint NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+				entry_id bigint NOT NULL,
+				feed_id bigint NOT NULL,
+				status entry_status NOT NULL,
+				reading_time int NOT NULL DEFAULT 0,
+				bulk bool NOT NULL DEFAULT 'f',
+				created_at timestamp with time zone NOT NULL DEFAULT now()
+			);
+			CREATE INDEX entry_status_events_user_id_created_at_idx ON entry_status_events(user_id, created_at);
+			CREATE INDEX entry_status_events_created_at_idx ON entry_status_events(created_at);
+		`
+		_, err = tx.Exec(sql)
+		return err
+	},
+	func(tx *sql.Tx) (err error) {
+		// A null value inherits the setting of the category, like a disabled setting did before.
+		_, err = tx.Exec(`
+			ALTER TABLE feeds ALTER COLUMN crawler DROP DEFAULT, ALTER COLUMN fetch_via_proxy DROP DEFAULT;
+			UPDATE feeds SET crawler=NULL WHERE crawler IS NOT true;
+			UPDATE feeds SET fetch_via_proxy=NULL WHERE fetch_via_proxy IS NOT true;
+		`)
+		return err
+	},
 }
@@ -15,6 +15,7 @@ import (
 	"miniflux.app/v2/internal/integration"
 	"miniflux.app/v2/internal/mediaproxy"
 	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/mirror"
 	"miniflux.app/v2/internal/storage"
 )
 
@@ -446,10 +447,14 @@ func (h *feverHandler) handleWriteItems(w http.ResponseWriter, r *http.Request)
 			slog.Int64("user_id", userID),
 			slog.Int64("entry_id", entryID),
 		)
-		if err := h.store.ToggleStarred(userID, entryID); err != nil {
+		starred, err := h.store.ToggleStarred(userID, entryID)
+		if err != nil {
 			response.JSONServerError(w, r, err)
 			return
 		}
+		if starred {
+			mirror.Request()
+		}
 
 		settings, err := h.store.Integration(userID)
 		if err != nil {
@@ -465,7 +470,7 @@ func (h *feverHandler) handleWriteItems(w http.ResponseWriter, r *http.Request)
 			slog.Int64("user_id", userID),
 			slog.Int64("entry_id", entryID),
 		)
-		if err := h.store.ToggleStarred(userID, entryID); err != nil {
+		if _, err := h.store.ToggleStarred(userID, entryID); err != nil {
 			response.JSONServerError(w, r, err)
 			return
 		}
@@ -20,6 +20,7 @@ import (
 	"miniflux.app/v2/internal/proxyrotator"
 	"miniflux.app/v2/internal/reader/fetcher"
 	mff "miniflux.app/v2/internal/reader/handler"
+	"miniflux.app/v2/internal/reader/mirror"
 	mfs "miniflux.app/v2/internal/reader/subscription"
 	"miniflux.app/v2/internal/storage"
 	"miniflux.app/v2/internal/urllib"
@@ -300,6 +301,7 @@ func (h *greaderHandler) editTagHandler(w http.ResponseWriter, r *http.Request)
 			response.JSONServerError(w, r, err)
 			return
 		}
+		mirror.Request()
 	}
 
 	if len(entries) > 0 {
@@ -692,6 +694,7 @@ func (h *greaderHandler) streamItemContentsHandler(w http.ResponseWriter, r *htt
 		}
 
 		entry.Content = mediaproxy.RewriteDocumentWithAbsoluteProxyURL(entry.Content)
+		entry.Enclosures.UseMirrors()
 		entry.Enclosures.ProxifyEnclosureURL(config.Opts.MediaProxyMode(), config.Opts.MediaProxyResourceTypes())
 
 		result.Items[i] = contentItem{
@@ -863,7 +866,12 @@ func (h *greaderHandler) tagListHandler(w http.ResponseWriter, r *http.Request)
 		response.JSONServerError(w, r, err)
 		return
 	}
-	result.Tags = make([]subscriptionCategoryResponse, 0, 1+len(categories))
+	savedSearches, err := h.store.SavedSearches(userID)
+	if err != nil {
+		response.JSONServerError(w, r, err)
+		return
+	}
+	result.Tags = make([]subscriptionCategoryResponse, 0, 1+len(categories)+len(savedSearches))
 	result.Tags = append(result.Tags, subscriptionCategoryResponse{
 		ID: fmt.Sprintf(userStreamPrefix, userID) + starredStreamSuffix,
 	})
@@ -875,6 +883,14 @@ func (h *greaderHandler) tagListHandler(w http.ResponseWriter, r *http.Request)
 			Type:  "folder",
 		})
 	}
+	savedSearchPrefix := fmt.Sprintf(userSavedSearchPrefix, userID)
+	for _, savedSearch := range savedSearches {
+		result.Tags = append(result.Tags, subscriptionCategoryResponse{
+			ID:    savedSearchPrefix + strconv.FormatInt(savedSearch.ID, 10),
+			Label: savedSearch.Title,
+			Type:  "tag",
+		})
+	}
 	response.JSON(w, r, result)
 }
 
@@ -992,6 +1008,8 @@ func (h *greaderHandler) streamItemIDsHandler(w http.ResponseWriter, r *http.Req
 		h.handleReadStreamHandler(w, r, rm)
 	case FeedStream:
 		h.handleFeedStreamHandler(w, r, rm)
+	case SavedSearchStream:
+		h.handleSavedSearchStreamHandler(w, r, rm)
 	default:
 		slog.Warn("[GoogleReader] Unknown Stream",
 			slog.String("handler", "streamItemIDsHandler"),
@@ -1144,6 +1162,52 @@ func (h *greaderHandler) handleFeedStreamHandler(w http.ResponseWriter, r *http.
 	response.JSON(w, r, streamIDResponse{itemRefs, continuation})
 }
 
+func (h *greaderHandler) handleSavedSearchStreamHandler(w http.ResponseWriter, r *http.Request, rm requestModifiers) {
+	savedSearchID, err := strconv.ParseInt(rm.Streams[0].ID, 10, 64)
+	if err != nil {
+		response.JSONServerError(w, r, err)
+		return
+	}
+
+	savedSearch, err := h.store.SavedSearchByID(rm.UserID, savedSearchID)
+	if err != nil {
+		response.JSONServerError(w, r, err)
+		return
+	}
+
+	if savedSearch == nil {
+		response.JSONNotFound(w, r)
+		return
+	}
+
+	builder := h.store.NewEntryQueryBuilder(rm.UserID)
+	builder.WithSorting(model.DefaultSortingOrder, rm.SortDirection)
+	builder.WithSearchQuery(savedSearch.Query)
+	builder.WithLimit(rm.Count)
+	builder.WithOffset(rm.Offset)
+
+	if rm.StartTime > 0 {
+		builder.AfterPublishedDate(time.Unix(rm.StartTime, 0))
+	}
+
+	if rm.StopTime > 0 {
+		builder.BeforePublishedDate(time.Unix(rm.StopTime, 0))
+	}
+
+	for _, s := range rm.ExcludeTargets {
+		if s.Type == ReadStream {
+			builder.WithoutStatus(model.EntryStatusRead)
+		}
+	}
+
+	itemRefs, continuation, err := getItemRefsAndContinuation(*builder, rm)
+	if err != nil {
+		response.JSONServerError(w, r, err)
+		return
+	}
+	response.JSON(w, r, streamIDResponse{itemRefs, continuation})
+}
+
 func (h *greaderHandler) markAllAsReadHandler(w http.ResponseWriter, r *http.Request) {
 	userID := request.UserID(r)
 	clientIP := request.ClientIP(r)
@@ -1218,6 +1282,25 @@ func (h *greaderHandler) markAllAsReadHandler(w http.ResponseWriter, r *http.Req
 			response.JSONServerError(w, r, err)
 			return
 		}
+	case SavedSearchStream:
+		savedSearchID, err := strconv.ParseInt(stream.ID, 10, 64)
+		if err != nil {
+			response.JSONBadRequest(w, r, err)
+			return
+		}
+		savedSearch, err := h.store.SavedSearchByID(userID, savedSearchID)
+		if err != nil {
+			response.JSONServerError(w, r, err)
+			return
+		}
+		if savedSearch == nil {
+			response.JSONNotFound(w, r)
+			return
+		}
+		if err := h.store.MarkSavedSearchAsRead(userID, savedSearch.Query, before); err != nil {
+			response.JSONServerError(w, r, err)
+			return
+		}
 	}
 
 	response.Text(w, r, "OK")
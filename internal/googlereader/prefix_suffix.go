@@ -12,6 +12,10 @@ const (
 	labelPrefix = "user/-/label/"
 	// userLabelPrefix is the user specific prefix prefix for a label stream
 	userLabelPrefix = "user/%d/label/"
+	// savedSearchPrefix is the prefix for a saved search stream
+	savedSearchPrefix = "user/-/saved-search/"
+	// userSavedSearchPrefix is the user specific prefix for a saved search stream
+	userSavedSearchPrefix = "user/%d/saved-search/"
 	// feedPrefix is the prefix for a feed stream
 	feedPrefix = "feed/"
 	// readStreamSuffix is the suffix for read stream
@@ -31,6 +31,8 @@ const (
 	FeedStream
 	// LikeStream - like stream type
 	LikeStream
+	// SavedSearchStream - saved search stream type
+	SavedSearchStream
 )
 
 // Stream defines a stream type and its ID.
@@ -65,6 +67,8 @@ func (st StreamType) String() string {
 		return "FeedStream"
 	case LikeStream:
 		return "LikeStream"
+	case SavedSearchStream:
+		return "SavedSearchStream"
 	default:
 		return st.String()
 	}
@@ -99,6 +103,10 @@ func getStream(streamID string, userID int64) (Stream, error) {
 		id := strings.TrimPrefix(streamID, fmt.Sprintf(userLabelPrefix, userID))
 		id = strings.TrimPrefix(id, labelPrefix)
 		return Stream{LabelStream, id}, nil
+	case strings.HasPrefix(streamID, fmt.Sprintf(userSavedSearchPrefix, userID)), strings.HasPrefix(streamID, savedSearchPrefix):
+		id := strings.TrimPrefix(streamID, fmt.Sprintf(userSavedSearchPrefix, userID))
+		id = strings.TrimPrefix(id, savedSearchPrefix)
+		return Stream{SavedSearchStream, id}, nil
 	case streamID == "":
 		return Stream{NoStream, ""}, nil
 	default:
@@ -0,0 +1,34 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package googlereader // import "miniflux.app/v2/internal/googlereader"
+
+import "testing"
+
+func TestGetStreamWithSavedSearch(t *testing.T) {
+	for _, streamID := range []string{"user/-/saved-search/42", "user/7/saved-search/42"} {
+		stream, err := getStream(streamID, 7)
+		if err != nil {
+			t.Fatalf(`Unexpected error for %q: %v`, streamID, err)
+		}
+
+		if stream.Type != SavedSearchStream {
+			t.Errorf(`Unexpected stream type for %q: %v`, streamID, stream.Type)
+		}
+
+		if stream.ID != "42" {
+			t.Errorf(`Unexpected stream ID for %q: %q`, streamID, stream.ID)
+		}
+	}
+}
+
+func TestGetStreamWithLabel(t *testing.T) {
+	stream, err := getStream("user/-/label/Tech", 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if stream.Type != LabelStream || stream.ID != "Tech" {
+		t.Errorf(`Unexpected stream: %v`, stream)
+	}
+}
@@ -119,7 +119,18 @@ func (b *Builder) Write() {
 	}
 }
 
+// WriteContent sends the content of a seekable reader and handles the range and conditional requests.
+func (b *Builder) WriteContent(modTime time.Time, content io.ReadSeeker) {
+	b.setHeaders()
+	http.ServeContent(b.w, b.r, "", modTime, content)
+}
+
 func (b *Builder) writeHeaders() {
+	b.setHeaders()
+	b.w.WriteHeader(b.statusCode)
+}
+
+func (b *Builder) setHeaders() {
 	b.headers["X-Content-Type-Options"] = "nosniff"
 	b.headers["X-Frame-Options"] = "DENY"
 	b.headers["Referrer-Policy"] = "no-referrer"
@@ -127,8 +138,6 @@ func (b *Builder) writeHeaders() {
 	for key, value := range b.headers {
 		b.w.Header().Set(key, value)
 	}
-
-	b.w.WriteHeader(b.statusCode)
 }
 
 func (b *Builder) compress(data []byte) {
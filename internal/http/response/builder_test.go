@@ -540,3 +540,40 @@ func TestBuildResponseWithReaderBody(t *testing.T) {
 		t.Fatalf(`Unexpected body, got %s instead of %s`, actualBody, "body")
 	}
 }
+
+func TestBuildResponseWithSeekableContentAndRange(t *testing.T) {
+	r, err := http.NewRequest("GET", "/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.Header.Set("Range", "bytes=2-5")
+
+	w := httptest.NewRecorder()
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		NewBuilder(w, r).WithHeader("Content-Type", "video/mp4").WriteContent(time.Time{}, strings.NewReader("0123456789"))
+	})
+
+	handler.ServeHTTP(w, r)
+	resp := w.Result()
+
+	if resp.StatusCode != http.StatusPartialContent {
+		t.Fatalf(`Unexpected status code, got %d instead of %d`, resp.StatusCode, http.StatusPartialContent)
+	}
+
+	if actualBody := w.Body.String(); actualBody != "2345" {
+		t.Fatalf(`Unexpected body, got %s instead of %s`, actualBody, "2345")
+	}
+
+	if contentRange := resp.Header.Get("Content-Range"); contentRange != "bytes 2-5/10" {
+		t.Fatalf(`Unexpected Content-Range header, got %q`, contentRange)
+	}
+
+	if contentType := resp.Header.Get("Content-Type"); contentType != "video/mp4" {
+		t.Fatalf(`Unexpected Content-Type header, got %q`, contentType)
+	}
+
+	if resp.Header.Get("X-Content-Type-Options") != "nosniff" {
+		t.Fatal(`The X-Content-Type-Options header should be set`)
+	}
+}
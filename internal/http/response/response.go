@@ -15,6 +15,10 @@ import "net/http"
 // https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Content-Security-Policy/default-src
 const ContentSecurityPolicyForUntrustedContent = `default-src 'none'; form-action 'none'; sandbox;`
 
+// ContentSecurityPolicyForSnapshots is the CSP for the offline snapshots of web pages.
+// Only inline stylesheets and inlined resources are allowed, images too large to be inlined are loaded from their website.
+const ContentSecurityPolicyForSnapshots = `default-src 'none'; img-src data: http: https:; style-src 'unsafe-inline' data:; font-src data:; media-src http: https:; form-action 'none'; sandbox allow-popups allow-popups-to-escape-sandbox;`
+
 // NoContent sends a no content response to the client.
 func NoContent(w http.ResponseWriter, r *http.Request) {
 	builder := NewBuilder(w, r)
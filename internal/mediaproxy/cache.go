@@ -0,0 +1,477 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package mediaproxy // import "miniflux.app/v2/internal/mediaproxy"
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultCacheDuration is the lifetime of the cached media when the origin does not send any caching header.
+const DefaultCacheDuration = 72 * time.Hour
+
+const (
+	cacheDataExtension     = ".data"
+	cacheMetadataExtension = ".json"
+	cacheTemporaryPrefix   = "tmp-"
+)
+
+// ErrMediaTooLarge is returned when a media exceeds the maximum size of a cached item.
+var ErrMediaTooLarge = errors.New("mediaproxy: media too large to be cached")
+
+// CachedMedia holds the metadata of a media stored in the cache.
+type CachedMedia struct {
+	URL          string    `json:"url"`
+	ContentType  string    `json:"content_type"`
+	Size         int64     `json:"size"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"last_modified,omitempty"`
+	ExpiresAt    time.Time `json:"expires_at"`
+	CreatedAt    time.Time `json:"created_at"`
+
+	key string
+}
+
+// IsExpired returns true when the media must be fetched again or revalidated.
+func (m *CachedMedia) IsExpired() bool {
+	return time.Now().After(m.ExpiresAt)
+}
+
+// CanBeRevalidated returns true when the origin can confirm that the media has not changed.
+func (m *CachedMedia) CanBeRevalidated() bool {
+	return m.ETag != "" || m.LastModified != ""
+}
+
+// Cache is a content-addressed cache of the proxied media stored on the local disk.
+//
+// Each media is stored in two files named after the SHA-256 of its URL: the content and its metadata.
+// The least recently used media are removed when the cache grows beyond its maximum size.
+type Cache struct {
+	directory   string
+	maxSize     int64
+	maxItemSize int64
+
+	mu    sync.Mutex
+	size  int64
+	items map[string]*list.Element
+	lru   *list.List
+
+	// tooLarge remembers the media rejected by Store until they expire, so they are proxied directly.
+	tooLarge map[string]time.Time
+}
+
+// NewCache opens the cache stored in the given directory and loads the index of the cached media.
+func NewCache(directory string, maxSize, maxItemSize int64) (*Cache, error) {
+	if err := os.MkdirAll(directory, 0o750); err != nil {
+		return nil, fmt.Errorf("mediaproxy: unable to create the cache directory: %w", err)
+	}
+
+	cache := &Cache{
+		directory:   directory,
+		maxSize:     maxSize,
+		maxItemSize: min(maxItemSize, maxSize),
+		items:       make(map[string]*list.Element),
+		lru:         list.New(),
+		tooLarge:    make(map[string]time.Time),
+	}
+
+	if err := cache.load(); err != nil {
+		return nil, err
+	}
+
+	return cache, nil
+}
+
+// MaxItemSize returns the maximum size of a cached media.
+func (c *Cache) MaxItemSize() int64 {
+	return c.maxItemSize
+}
+
+// IsTooLarge returns true when the media has recently been rejected because it exceeds the maximum size of a cached item.
+func (c *Cache) IsTooLarge(mediaURL string) bool {
+	key := cacheKey(mediaURL)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expiresAt, found := c.tooLarge[key]
+	if found && time.Now().After(expiresAt) {
+		delete(c.tooLarge, key)
+		return false
+	}
+	return found
+}
+
+// Stats returns the number of cached media and their total size.
+func (c *Cache) Stats() (int, int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len(), c.size
+}
+
+// Open returns the metadata and the content of a cached media, or nil when the media is not in the cache.
+// Expired media are returned as well, the caller is responsible for closing the file.
+func (c *Cache) Open(mediaURL string) (*CachedMedia, *os.File, error) {
+	key := cacheKey(mediaURL)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, found := c.items[key]
+	if !found {
+		return nil, nil, nil
+	}
+
+	file, err := os.Open(c.dataPath(key))
+	if err != nil {
+		c.removeElement(element)
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil, nil
+		}
+		return nil, nil, fmt.Errorf("mediaproxy: unable to open the cached media: %w", err)
+	}
+
+	c.lru.MoveToFront(element)
+
+	// The modification time of the metadata file keeps the order of the LRU list across restarts.
+	now := time.Now()
+	os.Chtimes(c.metadataPath(key), now, now)
+
+	media := *element.Value.(*CachedMedia)
+	return &media, file, nil
+}
+
+// Store saves the media fetched from the origin and evicts the least recently used media when the cache is full.
+// ErrMediaTooLarge is returned when the body exceeds the maximum size of a cached item,
+// the body is left untouched when the Content-Length header is already too large.
+// The rejected media is remembered until its expiration date, see IsTooLarge.
+func (c *Cache) Store(mediaURL string, header http.Header, expiresAt time.Time, body io.Reader) (*CachedMedia, error) {
+	key := cacheKey(mediaURL)
+
+	if contentLength, err := strconv.ParseInt(header.Get("Content-Length"), 10, 64); err == nil && contentLength > c.maxItemSize {
+		c.rejectTooLarge(key, expiresAt)
+		return nil, ErrMediaTooLarge
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.dataPath(key)), 0o750); err != nil {
+		return nil, fmt.Errorf("mediaproxy: unable to create the cache directory: %w", err)
+	}
+
+	temporaryFile, err := os.CreateTemp(c.directory, cacheTemporaryPrefix+"*")
+	if err != nil {
+		return nil, fmt.Errorf("mediaproxy: unable to create a temporary file: %w", err)
+	}
+	defer os.Remove(temporaryFile.Name())
+
+	size, err := io.Copy(temporaryFile, io.LimitReader(body, c.maxItemSize+1))
+	if closeErr := temporaryFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("mediaproxy: unable to write the cached media: %w", err)
+	}
+	if size > c.maxItemSize {
+		c.rejectTooLarge(key, expiresAt)
+		return nil, ErrMediaTooLarge
+	}
+
+	media := &CachedMedia{
+		URL:          mediaURL,
+		ContentType:  header.Get("Content-Type"),
+		Size:         size,
+		ETag:         header.Get("ETag"),
+		LastModified: header.Get("Last-Modified"),
+		ExpiresAt:    expiresAt,
+		CreatedAt:    time.Now(),
+		key:          key,
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := os.Rename(temporaryFile.Name(), c.dataPath(key)); err != nil {
+		return nil, fmt.Errorf("mediaproxy: unable to move the cached media: %w", err)
+	}
+
+	if err := c.writeMetadata(media); err != nil {
+		os.Remove(c.dataPath(key))
+		return nil, err
+	}
+
+	if element, found := c.items[key]; found {
+		c.size -= element.Value.(*CachedMedia).Size
+		element.Value = media
+		c.lru.MoveToFront(element)
+	} else {
+		c.items[key] = c.lru.PushFront(media)
+	}
+	c.size += size
+
+	c.evict()
+
+	return media, nil
+}
+
+// Refresh updates the expiration date of a media revalidated by the origin.
+func (c *Cache) Refresh(mediaURL string, expiresAt time.Time) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, found := c.items[cacheKey(mediaURL)]
+	if !found {
+		return nil
+	}
+
+	media := element.Value.(*CachedMedia)
+	media.ExpiresAt = expiresAt
+	return c.writeMetadata(media)
+}
+
+// Remove deletes a media from the cache.
+func (c *Cache) Remove(mediaURL string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, found := c.items[cacheKey(mediaURL)]; found {
+		c.removeElement(element)
+	}
+}
+
+func (c *Cache) rejectTooLarge(key string, expiresAt time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	for rejectedKey, rejectedUntil := range c.tooLarge {
+		if now.After(rejectedUntil) {
+			delete(c.tooLarge, rejectedKey)
+		}
+	}
+	c.tooLarge[key] = expiresAt
+}
+
+func (c *Cache) load() error {
+	var loadedMedia []*CachedMedia
+	modificationTimes := make(map[string]time.Time)
+
+	err := filepath.WalkDir(c.directory, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+
+		// Leftovers of an interrupted write.
+		if strings.HasPrefix(entry.Name(), cacheTemporaryPrefix) {
+			os.Remove(path)
+			return nil
+		}
+
+		key, isMetadata := strings.CutSuffix(entry.Name(), cacheMetadataExtension)
+		if !isMetadata {
+			return nil
+		}
+
+		media, info, err := c.readMetadata(key)
+		if err != nil {
+			slog.Debug("Removing invalid media proxy cache item",
+				slog.String("key", key),
+				slog.Any("error", err),
+			)
+			c.removeFiles(key)
+			return nil
+		}
+
+		loadedMedia = append(loadedMedia, media)
+		modificationTimes[key] = info.ModTime()
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("mediaproxy: unable to load the cache: %w", err)
+	}
+
+	// The most recently used media are at the front of the list.
+	slices.SortFunc(loadedMedia, func(a, b *CachedMedia) int {
+		return modificationTimes[b.key].Compare(modificationTimes[a.key])
+	})
+
+	for _, media := range loadedMedia {
+		c.items[media.key] = c.lru.PushBack(media)
+		c.size += media.Size
+	}
+
+	c.evict()
+
+	slog.Info("Media proxy cache loaded",
+		slog.String("directory", c.directory),
+		slog.Int("items", c.lru.Len()),
+		slog.Int64("size", c.size),
+	)
+
+	return nil
+}
+
+func (c *Cache) readMetadata(key string) (*CachedMedia, fs.FileInfo, error) {
+	info, err := os.Stat(c.metadataPath(key))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	data, err := os.ReadFile(c.metadataPath(key))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var media CachedMedia
+	if err := json.Unmarshal(data, &media); err != nil {
+		return nil, nil, err
+	}
+
+	if cacheKey(media.URL) != key {
+		return nil, nil, errors.New("the URL does not match the key")
+	}
+
+	dataInfo, err := os.Stat(c.dataPath(key))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if dataInfo.Size() != media.Size {
+		return nil, nil, errors.New("the content size does not match the metadata")
+	}
+
+	media.key = key
+	return &media, info, nil
+}
+
+func (c *Cache) writeMetadata(media *CachedMedia) error {
+	data, err := json.Marshal(media)
+	if err != nil {
+		return fmt.Errorf("mediaproxy: unable to encode the cached media metadata: %w", err)
+	}
+
+	temporaryFile, err := os.CreateTemp(c.directory, cacheTemporaryPrefix+"*")
+	if err != nil {
+		return fmt.Errorf("mediaproxy: unable to create a temporary file: %w", err)
+	}
+	defer os.Remove(temporaryFile.Name())
+
+	_, err = temporaryFile.Write(data)
+	if closeErr := temporaryFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("mediaproxy: unable to write the cached media metadata: %w", err)
+	}
+
+	if err := os.Rename(temporaryFile.Name(), c.metadataPath(media.key)); err != nil {
+		return fmt.Errorf("mediaproxy: unable to move the cached media metadata: %w", err)
+	}
+
+	return nil
+}
+
+// evict removes the least recently used media until the cache fits in its maximum size.
+// The lock must be held by the caller.
+func (c *Cache) evict() {
+	for c.size > c.maxSize {
+		element := c.lru.Back()
+		if element == nil {
+			return
+		}
+
+		slog.Debug("Evicting media from the media proxy cache",
+			slog.String("media_url", element.Value.(*CachedMedia).URL),
+		)
+		c.removeElement(element)
+	}
+}
+
+func (c *Cache) removeElement(element *list.Element) {
+	media := element.Value.(*CachedMedia)
+	c.lru.Remove(element)
+	delete(c.items, media.key)
+	c.size -= media.Size
+	c.removeFiles(media.key)
+}
+
+func (c *Cache) removeFiles(key string) {
+	os.Remove(c.metadataPath(key))
+	os.Remove(c.dataPath(key))
+}
+
+func (c *Cache) dataPath(key string) string {
+	return filepath.Join(c.directory, key[:2], key+cacheDataExtension)
+}
+
+func (c *Cache) metadataPath(key string) string {
+	return filepath.Join(c.directory, key[:2], key+cacheMetadataExtension)
+}
+
+func cacheKey(mediaURL string) string {
+	digest := sha256.Sum256([]byte(mediaURL))
+	return hex.EncodeToString(digest[:])
+}
+
+// CacheExpiration returns the expiration date of a media according to the caching headers sent by the origin.
+// The second value is false when the origin does not allow the media to be stored in a shared cache.
+func CacheExpiration(header http.Header, now time.Time) (time.Time, bool) {
+	var maxAge, sharedMaxAge = -1, -1
+
+	for directive := range strings.SplitSeq(strings.ToLower(header.Get("Cache-Control")), ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(directive), "=")
+		value = strings.Trim(value, `"`)
+
+		switch name {
+		case "no-store", "no-cache", "private":
+			return time.Time{}, false
+		case "max-age":
+			if seconds, err := strconv.Atoi(value); err == nil {
+				maxAge = seconds
+			}
+		case "s-maxage":
+			if seconds, err := strconv.Atoi(value); err == nil {
+				sharedMaxAge = seconds
+			}
+		}
+	}
+
+	if sharedMaxAge >= 0 {
+		maxAge = sharedMaxAge
+	}
+
+	if maxAge >= 0 {
+		// The Age header is the time already spent in the caches between the origin and us.
+		if age, err := strconv.Atoi(header.Get("Age")); err == nil && age > 0 {
+			maxAge -= age
+		}
+		if maxAge <= 0 {
+			return time.Time{}, false
+		}
+		return now.Add(time.Duration(maxAge) * time.Second), true
+	}
+
+	if expires := header.Get("Expires"); expires != "" {
+		expiresAt, err := http.ParseTime(expires)
+		if err != nil || !expiresAt.After(now) {
+			return time.Time{}, false
+		}
+		return expiresAt, true
+	}
+
+	return now.Add(DefaultCacheDuration), true
+}
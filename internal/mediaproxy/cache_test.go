@@ -0,0 +1,216 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package mediaproxy // import "miniflux.app/v2/internal/mediaproxy"
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func storeTestMedia(t *testing.T, cache *Cache, mediaURL, content string) {
+	t.Helper()
+
+	header := http.Header{}
+	header.Set("Content-Type", "image/png")
+	header.Set("ETag", `"v1"`)
+
+	if _, err := cache.Store(mediaURL, header, time.Now().Add(time.Hour), strings.NewReader(content)); err != nil {
+		t.Fatalf(`Unable to store %q: %v`, mediaURL, err)
+	}
+}
+
+func readTestMedia(t *testing.T, cache *Cache, mediaURL string) (*CachedMedia, string) {
+	t.Helper()
+
+	media, file, err := cache.Open(mediaURL)
+	if err != nil {
+		t.Fatalf(`Unable to open %q: %v`, mediaURL, err)
+	}
+	if media == nil {
+		return nil, ""
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return media, string(content)
+}
+
+func TestCacheStoreAndOpen(t *testing.T) {
+	cache, err := NewCache(t.TempDir(), 1024, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if media, _ := readTestMedia(t, cache, "https://example.org/missing.png"); media != nil {
+		t.Fatal(`A missing media should not be returned`)
+	}
+
+	storeTestMedia(t, cache, "https://example.org/image.png", "image data")
+
+	media, content := readTestMedia(t, cache, "https://example.org/image.png")
+	if media == nil {
+		t.Fatal(`The cached media should be returned`)
+	}
+
+	if content != "image data" {
+		t.Errorf(`Unexpected content, got %q`, content)
+	}
+
+	if media.ContentType != "image/png" || media.ETag != `"v1"` || media.Size != 10 || media.IsExpired() {
+		t.Errorf(`Unexpected metadata: %+v`, media)
+	}
+
+	if items, size := cache.Stats(); items != 1 || size != 10 {
+		t.Errorf(`Unexpected stats, got %d items and %d bytes`, items, size)
+	}
+}
+
+func TestCacheEvictsLeastRecentlyUsedMedia(t *testing.T) {
+	cache, err := NewCache(t.TempDir(), 30, 30)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	storeTestMedia(t, cache, "https://example.org/1.png", "0123456789")
+	storeTestMedia(t, cache, "https://example.org/2.png", "0123456789")
+	storeTestMedia(t, cache, "https://example.org/3.png", "0123456789")
+
+	// The first media becomes the most recently used.
+	readTestMedia(t, cache, "https://example.org/1.png")
+
+	storeTestMedia(t, cache, "https://example.org/4.png", "0123456789")
+
+	if media, _ := readTestMedia(t, cache, "https://example.org/2.png"); media != nil {
+		t.Error(`The least recently used media should be evicted`)
+	}
+
+	for _, mediaURL := range []string{"https://example.org/1.png", "https://example.org/3.png", "https://example.org/4.png"} {
+		if media, _ := readTestMedia(t, cache, mediaURL); media == nil {
+			t.Errorf(`The media %q should be kept`, mediaURL)
+		}
+	}
+
+	if items, size := cache.Stats(); items != 3 || size != 30 {
+		t.Errorf(`Unexpected stats, got %d items and %d bytes`, items, size)
+	}
+}
+
+func TestCacheRejectsLargeMedia(t *testing.T) {
+	cache, err := NewCache(t.TempDir(), 100, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cache.IsTooLarge("https://example.org/video.mp4") {
+		t.Error(`The media should not be rejected before being stored`)
+	}
+
+	if _, err := cache.Store("https://example.org/video.mp4", http.Header{}, time.Now().Add(time.Hour), strings.NewReader("0123456789")); !errors.Is(err, ErrMediaTooLarge) {
+		t.Fatalf(`Expected ErrMediaTooLarge, got %v`, err)
+	}
+
+	if !cache.IsTooLarge("https://example.org/video.mp4") {
+		t.Error(`The rejected media should be remembered`)
+	}
+
+	header := http.Header{}
+	header.Set("Content-Length", "10")
+	body := strings.NewReader("0123456789")
+	if _, err := cache.Store("https://example.org/audio.mp3", header, time.Now().Add(-time.Second), body); !errors.Is(err, ErrMediaTooLarge) {
+		t.Fatalf(`Expected ErrMediaTooLarge, got %v`, err)
+	}
+
+	if body.Len() != 10 {
+		t.Error(`The body should not be consumed when the Content-Length is too large`)
+	}
+
+	if cache.IsTooLarge("https://example.org/audio.mp3") {
+		t.Error(`The rejection should expire with the media`)
+	}
+
+	if items, size := cache.Stats(); items != 0 || size != 0 {
+		t.Errorf(`Unexpected stats, got %d items and %d bytes`, items, size)
+	}
+}
+
+func TestCacheIsLoadedFromDisk(t *testing.T) {
+	directory := t.TempDir()
+
+	cache, err := NewCache(directory, 1024, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	storeTestMedia(t, cache, "https://example.org/image.png", "image data")
+
+	expiresAt := time.Now().Add(-time.Minute)
+	if err := cache.Refresh("https://example.org/image.png", expiresAt); err != nil {
+		t.Fatal(err)
+	}
+
+	cache, err = NewCache(directory, 1024, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	media, content := readTestMedia(t, cache, "https://example.org/image.png")
+	if media == nil || content != "image data" {
+		t.Fatalf(`The cached media should be loaded from the disk, got %+v and %q`, media, content)
+	}
+
+	if !media.IsExpired() || !media.CanBeRevalidated() {
+		t.Errorf(`The refreshed metadata should be loaded from the disk: %+v`, media)
+	}
+
+	cache.Remove("https://example.org/image.png")
+
+	cache, err = NewCache(directory, 1024, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if items, _ := cache.Stats(); items != 0 {
+		t.Errorf(`The removed media should not be loaded, got %d items`, items)
+	}
+}
+
+func TestCacheExpiration(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	scenarios := []struct {
+		headers   map[string]string
+		expiresAt time.Time
+		cacheable bool
+	}{
+		{map[string]string{}, now.Add(DefaultCacheDuration), true},
+		{map[string]string{"Cache-Control": "public, max-age=3600"}, now.Add(time.Hour), true},
+		{map[string]string{"Cache-Control": "max-age=3600, s-maxage=60"}, now.Add(time.Minute), true},
+		{map[string]string{"Cache-Control": "max-age=3600", "Age": "600"}, now.Add(50 * time.Minute), true},
+		{map[string]string{"Cache-Control": "max-age=0"}, time.Time{}, false},
+		{map[string]string{"Cache-Control": "no-store"}, time.Time{}, false},
+		{map[string]string{"Cache-Control": "private, max-age=3600"}, time.Time{}, false},
+		{map[string]string{"Expires": "Mon, 01 Jan 2024 14:00:00 GMT"}, now.Add(2 * time.Hour), true},
+		{map[string]string{"Expires": "Mon, 01 Jan 2024 10:00:00 GMT"}, time.Time{}, false},
+		{map[string]string{"Expires": "0"}, time.Time{}, false},
+		{map[string]string{"Cache-Control": "max-age=60", "Expires": "Mon, 01 Jan 2024 14:00:00 GMT"}, now.Add(time.Minute), true},
+	}
+
+	for _, scenario := range scenarios {
+		header := http.Header{}
+		for name, value := range scenario.headers {
+			header.Set(name, value)
+		}
+
+		expiresAt, cacheable := CacheExpiration(header, now)
+		if cacheable != scenario.cacheable || !expiresAt.Equal(scenario.expiresAt) {
+			t.Errorf(`Unexpected expiration for %v, got %v (%v) instead of %v (%v)`, scenario.headers, expiresAt, cacheable, scenario.expiresAt, scenario.cacheable)
+		}
+	}
+}
@@ -0,0 +1,229 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package mediaproxy // import "miniflux.app/v2/internal/mediaproxy"
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"net/url"
+	"strconv"
+
+	"golang.org/x/image/draw"
+	"golang.org/x/image/webp"
+)
+
+const (
+	// MaxSourceImageSize is the maximum size of the images downloaded to be resized.
+	MaxSourceImageSize = 25 * 1024 * 1024
+
+	maxImageWidth       = 4096
+	maxSourceImagePixel = 50_000_000
+)
+
+// ImageOptions describes how the media proxy transforms an image.
+// The zero value keeps the image unchanged.
+type ImageOptions struct {
+	Width   int
+	Quality int
+}
+
+// IsZero returns true when the image must be proxied unchanged.
+func (o ImageOptions) IsZero() bool {
+	return o.Width == 0 && o.Quality == 0
+}
+
+// Encode returns the options as a query string, the same options always give the same string.
+func (o ImageOptions) Encode() string {
+	if o.IsZero() {
+		return ""
+	}
+
+	values := url.Values{}
+	if o.Width > 0 {
+		values.Set("w", strconv.Itoa(o.Width))
+	}
+	if o.Quality > 0 {
+		values.Set("q", strconv.Itoa(o.Quality))
+	}
+	return values.Encode()
+}
+
+// ParseImageOptions returns the image options of a proxified URL.
+func ParseImageOptions(query url.Values) (ImageOptions, error) {
+	var options ImageOptions
+
+	if value := query.Get("w"); value != "" {
+		width, err := strconv.Atoi(value)
+		if err != nil || width < 1 || width > maxImageWidth {
+			return options, fmt.Errorf("mediaproxy: invalid image width %q", value)
+		}
+		options.Width = width
+	}
+
+	if value := query.Get("q"); value != "" {
+		quality, err := strconv.Atoi(value)
+		if err != nil || quality < 1 || quality > 100 {
+			return options, fmt.Errorf("mediaproxy: invalid image quality %q", value)
+		}
+		options.Quality = quality
+	}
+
+	return options, nil
+}
+
+// TransformImage downscales the image to the width of the options and re-encodes it.
+// Opaque images are encoded in JPEG and the others in PNG.
+// The original image is returned when it cannot be decoded or when the result would be larger.
+func TransformImage(data []byte, contentType string, options ImageOptions) ([]byte, string, error) {
+	var decode func([]byte) (image.Image, error)
+	switch contentType {
+	case "image/jpeg":
+		// The orientation stored in the EXIF metadata would be lost.
+		if jpegOrientation(data) > 1 {
+			return data, contentType, nil
+		}
+		decode = func(data []byte) (image.Image, error) { return jpeg.Decode(bytes.NewReader(data)) }
+	case "image/png":
+		decode = func(data []byte) (image.Image, error) { return png.Decode(bytes.NewReader(data)) }
+	case "image/webp":
+		decode = func(data []byte) (image.Image, error) { return webp.Decode(bytes.NewReader(data)) }
+	default:
+		// SVG images don't need to be resized, and GIF images may be animated.
+		return data, contentType, nil
+	}
+
+	imageConfig, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return data, contentType, fmt.Errorf("mediaproxy: unable to decode the image metadata: %w", err)
+	}
+
+	if imageConfig.Width <= 0 || imageConfig.Height <= 0 {
+		return data, contentType, errors.New("mediaproxy: invalid image dimensions")
+	}
+
+	if int64(imageConfig.Width)*int64(imageConfig.Height) > maxSourceImagePixel {
+		return data, contentType, nil
+	}
+
+	src, err := decode(data)
+	if err != nil {
+		return data, contentType, fmt.Errorf("mediaproxy: unable to decode the image: %w", err)
+	}
+
+	bounds := src.Bounds()
+	if options.Width > 0 && bounds.Dx() > options.Width {
+		height := max(1, bounds.Dy()*options.Width/bounds.Dx())
+		dst := image.NewRGBA(image.Rect(0, 0, options.Width, height))
+		draw.BiLinear.Scale(dst, dst.Rect, src, bounds, draw.Src, nil)
+		src = dst
+	}
+
+	var buffer bytes.Buffer
+	outputContentType := "image/jpeg"
+	if isOpaque(src) {
+		quality := options.Quality
+		if quality == 0 {
+			quality = jpeg.DefaultQuality
+		}
+		err = jpeg.Encode(&buffer, src, &jpeg.Options{Quality: quality})
+	} else {
+		outputContentType = "image/png"
+		encoder := png.Encoder{CompressionLevel: png.BestCompression}
+		err = encoder.Encode(&buffer, src)
+	}
+	if err != nil {
+		return data, contentType, fmt.Errorf("mediaproxy: unable to encode the image: %w", err)
+	}
+
+	if buffer.Len() >= len(data) {
+		return data, contentType, nil
+	}
+
+	return buffer.Bytes(), outputContentType, nil
+}
+
+func isOpaque(img image.Image) bool {
+	if opaqueImage, ok := img.(interface{ Opaque() bool }); ok {
+		return opaqueImage.Opaque()
+	}
+	return false
+}
+
+// jpegOrientation returns the EXIF orientation of a JPEG image, or 0 when the image has no orientation.
+func jpegOrientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 0
+	}
+
+	position := 2
+	for position+4 <= len(data) {
+		if data[position] != 0xFF {
+			return 0
+		}
+
+		marker := data[position+1]
+		segmentLength := int(binary.BigEndian.Uint16(data[position+2:]))
+		if segmentLength < 2 || position+2+segmentLength > len(data) {
+			return 0
+		}
+
+		// The EXIF metadata are stored in the APP1 segment, before the image data.
+		if marker == 0xE1 {
+			if orientation := exifOrientation(data[position+4 : position+2+segmentLength]); orientation > 0 {
+				return orientation
+			}
+		}
+
+		// Start of scan: the image data follows.
+		if marker == 0xDA {
+			return 0
+		}
+
+		position += 2 + segmentLength
+	}
+
+	return 0
+}
+
+func exifOrientation(segment []byte) int {
+	const orientationTag = 0x0112
+
+	tiff, found := bytes.CutPrefix(segment, []byte("Exif\x00\x00"))
+	if !found || len(tiff) < 8 {
+		return 0
+	}
+
+	var byteOrder binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		byteOrder = binary.LittleEndian
+	case "MM":
+		byteOrder = binary.BigEndian
+	default:
+		return 0
+	}
+
+	offset := int(byteOrder.Uint32(tiff[4:]))
+	if offset < 8 || offset+2 > len(tiff) {
+		return 0
+	}
+
+	entries := int(byteOrder.Uint16(tiff[offset:]))
+	for i := range entries {
+		entry := offset + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 0
+		}
+		if byteOrder.Uint16(tiff[entry:]) == orientationTag {
+			return int(byteOrder.Uint16(tiff[entry+8:]))
+		}
+	}
+
+	return 0
+}
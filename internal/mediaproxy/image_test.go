@@ -0,0 +1,165 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package mediaproxy // import "miniflux.app/v2/internal/mediaproxy"
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"net/url"
+	"testing"
+)
+
+func encodeTestPNG(t *testing.T, width, height int, alpha uint8) []byte {
+	t.Helper()
+
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := range height {
+		for x := range width {
+			img.Set(x, y, color.NRGBA{R: uint8(x), G: uint8(y), B: uint8(x * y), A: alpha})
+		}
+	}
+
+	var buffer bytes.Buffer
+	if err := png.Encode(&buffer, img); err != nil {
+		t.Fatal(err)
+	}
+	return buffer.Bytes()
+}
+
+func TestImageOptionsEncoding(t *testing.T) {
+	if encoded := (ImageOptions{}).Encode(); encoded != "" {
+		t.Errorf(`The zero options should not be encoded, got %q`, encoded)
+	}
+
+	options := ImageOptions{Width: 480, Quality: 75}
+	if encoded := options.Encode(); encoded != "q=75&w=480" {
+		t.Errorf(`Unexpected encoded options, got %q`, encoded)
+	}
+
+	query, _ := url.ParseQuery(options.Encode())
+	parsedOptions, err := ParseImageOptions(query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if parsedOptions != options {
+		t.Errorf(`Unexpected parsed options, got %+v`, parsedOptions)
+	}
+
+	for _, queryString := range []string{"w=0", "w=100000", "w=large", "q=0", "q=101"} {
+		query, _ := url.ParseQuery(queryString)
+		if _, err := ParseImageOptions(query); err == nil {
+			t.Errorf(`The options %q should be rejected`, queryString)
+		}
+	}
+}
+
+func TestTransformImageDownscalesOpaqueImageToJPEG(t *testing.T) {
+	data := encodeTestPNG(t, 400, 200, 255)
+
+	resizedImage, contentType, err := TransformImage(data, "image/png", ImageOptions{Width: 100, Quality: 60})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if contentType != "image/jpeg" {
+		t.Fatalf(`Unexpected content type, got %q`, contentType)
+	}
+
+	imageConfig, err := jpeg.DecodeConfig(bytes.NewReader(resizedImage))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if imageConfig.Width != 100 || imageConfig.Height != 50 {
+		t.Errorf(`Unexpected dimensions, got %dx%d`, imageConfig.Width, imageConfig.Height)
+	}
+}
+
+func TestTransformImageKeepsTransparency(t *testing.T) {
+	data := encodeTestPNG(t, 400, 200, 128)
+
+	resizedImage, contentType, err := TransformImage(data, "image/png", ImageOptions{Width: 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if contentType != "image/png" {
+		t.Fatalf(`Unexpected content type, got %q`, contentType)
+	}
+
+	imageConfig, err := png.DecodeConfig(bytes.NewReader(resizedImage))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if imageConfig.Width != 100 || imageConfig.Height != 50 {
+		t.Errorf(`Unexpected dimensions, got %dx%d`, imageConfig.Width, imageConfig.Height)
+	}
+}
+
+func TestTransformImageKeepsUnsupportedImages(t *testing.T) {
+	scenarios := map[string][]byte{
+		"image/svg+xml": []byte(`<svg xmlns="http://www.w3.org/2000/svg"></svg>`),
+		"image/gif":     []byte("GIF89a"),
+	}
+
+	for contentType, data := range scenarios {
+		resizedImage, resizedContentType, err := TransformImage(data, contentType, ImageOptions{Width: 100})
+		if err != nil || resizedContentType != contentType || !bytes.Equal(resizedImage, data) {
+			t.Errorf(`The %s image should be kept unchanged`, contentType)
+		}
+	}
+
+	resizedImage, contentType, err := TransformImage([]byte("not an image"), "image/png", ImageOptions{Width: 100})
+	if err == nil {
+		t.Error(`An error should be returned for invalid images`)
+	}
+	if contentType != "image/png" || string(resizedImage) != "not an image" {
+		t.Error(`The original data should be returned for invalid images`)
+	}
+}
+
+func TestTransformImageKeepsRotatedJPEG(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 400, 200))
+	var buffer bytes.Buffer
+	if err := jpeg.Encode(&buffer, img, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// Insert an APP1 segment with the orientation 6 (rotated 90°) after the SOI marker.
+	tiff := []byte("Exif\x00\x00II*\x00\x08\x00\x00\x00\x01\x00")
+	entry := make([]byte, 12)
+	binary.LittleEndian.PutUint16(entry[0:], 0x0112)
+	binary.LittleEndian.PutUint16(entry[2:], 3)
+	binary.LittleEndian.PutUint32(entry[4:], 1)
+	binary.LittleEndian.PutUint16(entry[8:], 6)
+	tiff = append(tiff, entry...)
+
+	segment := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(segment[2:], uint16(len(tiff)+2))
+	segment = append(segment, tiff...)
+
+	data := append([]byte{0xFF, 0xD8}, append(segment, buffer.Bytes()[2:]...)...)
+
+	if orientation := jpegOrientation(data); orientation != 6 {
+		t.Fatalf(`Unexpected orientation, got %d`, orientation)
+	}
+
+	resizedImage, _, err := TransformImage(data, "image/jpeg", ImageOptions{Width: 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(resizedImage, data) {
+		t.Error(`A rotated JPEG image should be kept unchanged`)
+	}
+
+	if orientation := jpegOrientation(buffer.Bytes()); orientation != 0 {
+		t.Errorf(`Unexpected orientation for an image without EXIF metadata, got %d`, orientation)
+	}
+}
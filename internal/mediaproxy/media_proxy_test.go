@@ -4,7 +4,9 @@
 package mediaproxy // import "miniflux.app/v2/internal/mediaproxy"
 
 import (
+	"html"
 	"os"
+	"strings"
 	"testing"
 
 	"miniflux.app/v2/internal/config"
@@ -1124,3 +1126,74 @@ func TestShouldProxifyURLWithMimeType(t *testing.T) {
 		})
 	}
 }
+
+func TestMediaProxyWithResponsiveImages(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("MEDIA_PROXY_MODE", "all")
+	os.Setenv("MEDIA_PROXY_RESOURCE_TYPES", "image")
+	os.Setenv("MEDIA_PROXY_PRIVATE_KEY", "test")
+	os.Setenv("MEDIA_PROXY_IMAGE_WIDTHS", "960,480")
+	os.Setenv("MEDIA_PROXY_IMAGE_QUALITY", "60")
+
+	var err error
+	parser := config.NewConfigParser()
+	config.Opts, err = parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	resizedURL := func(width int) string {
+		return ProxifyRelativeImageURL("http://website/folder/image.png", ImageOptions{Width: width, Quality: 60})
+	}
+
+	if !strings.HasSuffix(resizedURL(480), "/aHR0cDovL3dlYnNpdGUvZm9sZGVyL2ltYWdlLnBuZw==?q=60&w=480") {
+		t.Fatalf(`Unexpected resized image URL: %s`, resizedURL(480))
+	}
+
+	input := `<p><img src="http://website/folder/image.png" width="600" alt="test"></p>`
+	expected := `<p><img src="/proxy/okK5PsdNY8F082UMQEAbLPeUFfbe2WnNfInNmR9T4WA=/aHR0cDovL3dlYnNpdGUvZm9sZGVyL2ltYWdlLnBuZw==" width="600" alt="test" ` +
+		`srcset="` + html.EscapeString(resizedURL(480)) + ` 480w, ` + html.EscapeString(resizedURL(960)) + ` 960w" sizes="(max-width: 600px) 100vw, 600px"/></p>`
+	output := RewriteDocumentWithRelativeProxyURL(input)
+
+	if expected != output {
+		t.Errorf(`Not expected output: got %s`, output)
+	}
+
+	// The images with a srcset attribute are kept as is.
+	input = `<p><img src="http://website/folder/image.png" srcset="http://website/folder/image2.png 2x" alt="test"></p>`
+	if output := RewriteDocumentWithRelativeProxyURL(input); strings.Contains(output, "w=480") || strings.Contains(output, "sizes=") {
+		t.Errorf(`The existing srcset should not be replaced: got %s`, output)
+	}
+}
+
+func TestVerifyMediaURL(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("MEDIA_PROXY_PRIVATE_KEY", "test")
+
+	var err error
+	parser := config.NewConfigParser()
+	config.Opts, err = parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Parsing failure: %v`, err)
+	}
+
+	options := ImageOptions{Width: 480, Quality: 75}
+	digest := signMediaURL("http://website/image.png", options)
+
+	if !VerifyMediaURL("http://website/image.png", options, digest) {
+		t.Error(`The digest should match the URL and the options`)
+	}
+
+	if VerifyMediaURL("http://website/image.png", ImageOptions{Width: 4096, Quality: 75}, digest) {
+		t.Error(`The digest should not match other options`)
+	}
+
+	if VerifyMediaURL("http://website/image.png", ImageOptions{}, digest) {
+		t.Error(`The digest should not match the URL without options`)
+	}
+
+	// The digest of the URLs without options is unchanged.
+	if !strings.Contains(ProxifyRelativeURL("http://website/folder/image.png"), "/proxy/okK5PsdNY8F082UMQEAbLPeUFfbe2WnNfInNmR9T4WA=/") {
+		t.Error(`The digest of the URLs without options should not change`)
+	}
+}
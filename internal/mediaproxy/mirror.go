@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package mediaproxy // import "miniflux.app/v2/internal/mediaproxy"
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"miniflux.app/v2/internal/config"
+)
+
+// MirrorRelativeURL returns the URL of the local copy of an enclosure.
+func MirrorRelativeURL(enclosureID int64) string {
+	return fmt.Sprintf("%s/mirror/%s/%d", config.Opts.BasePath(), base64.URLEncoding.EncodeToString(signEnclosureID(enclosureID)), enclosureID)
+}
+
+// MirrorAbsoluteURL returns the absolute URL of the local copy of an enclosure, for the API clients.
+func MirrorAbsoluteURL(enclosureID int64) string {
+	absoluteURL, err := url.JoinPath(config.Opts.RootURL(), MirrorRelativeURL(enclosureID))
+	if err != nil {
+		return MirrorRelativeURL(enclosureID)
+	}
+	return absoluteURL
+}
+
+// VerifyMirrorURL returns true when the digest of a mirror URL matches the enclosure ID.
+func VerifyMirrorURL(enclosureID int64, digest []byte) bool {
+	return hmac.Equal(digest, signEnclosureID(enclosureID))
+}
+
+// signEnclosureID signs the enclosure ID with the media proxy key.
+// The prefix prevents a mirror digest from being valid for a proxified URL.
+func signEnclosureID(enclosureID int64) []byte {
+	mac := hmac.New(sha256.New, config.Opts.MediaProxyPrivateKey())
+	mac.Write([]byte("enclosure:" + strconv.FormatInt(enclosureID, 10)))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,53 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package mediaproxy // import "miniflux.app/v2/internal/mediaproxy"
+
+import (
+	"encoding/base64"
+	"os"
+	"strings"
+	"testing"
+
+	"miniflux.app/v2/internal/config"
+)
+
+func TestMirrorURL(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("BASE_URL", "http://localhost/folder")
+	os.Setenv("MEDIA_PROXY_PRIVATE_KEY", "test")
+
+	var err error
+	parser := config.NewConfigParser()
+	config.Opts, err = parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Config parsing failure: %v`, err)
+	}
+
+	relativeURL := MirrorRelativeURL(42)
+	if !strings.HasPrefix(relativeURL, "/folder/mirror/") || !strings.HasSuffix(relativeURL, "/42") {
+		t.Fatalf(`Unexpected mirror URL, got %q`, relativeURL)
+	}
+
+	if absoluteURL := MirrorAbsoluteURL(42); absoluteURL != "http://localhost"+relativeURL {
+		t.Errorf(`Unexpected absolute mirror URL, got %q`, absoluteURL)
+	}
+
+	encodedDigest := strings.Split(relativeURL, "/")[3]
+	digest, err := base64.URLEncoding.DecodeString(encodedDigest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !VerifyMirrorURL(42, digest) {
+		t.Error(`The digest should be valid for the enclosure`)
+	}
+
+	if VerifyMirrorURL(43, digest) {
+		t.Error(`The digest should not be valid for another enclosure`)
+	}
+
+	if VerifyMediaURL("42", ImageOptions{}, digest) {
+		t.Error(`The digest should not be valid for a proxified URL`)
+	}
+}
@@ -4,8 +4,10 @@
 package mediaproxy // import "miniflux.app/v2/internal/mediaproxy"
 
 import (
+	"fmt"
 	"net/url"
 	"slices"
+	"strconv"
 	"strings"
 
 	"miniflux.app/v2/internal/config"
@@ -16,20 +18,35 @@ import (
 
 type urlProxyRewriter func(url string) string
 
+type imageProxyRewriter func(url string, options ImageOptions) string
+
+// maxContentWidth is the maximum width of the entry content in the user interface.
+const maxContentWidth = 900
+
 func RewriteDocumentWithRelativeProxyURL(htmlDocument string) string {
-	return genericProxyRewriter(ProxifyRelativeURL, htmlDocument)
+	return genericProxyRewriter(ProxifyRelativeImageURL, htmlDocument)
 }
 
 func RewriteDocumentWithAbsoluteProxyURL(htmlDocument string) string {
-	return genericProxyRewriter(ProxifyAbsoluteURL, htmlDocument)
+	return genericProxyRewriter(ProxifyAbsoluteImageURL, htmlDocument)
 }
 
-func genericProxyRewriter(proxifyFunction urlProxyRewriter, htmlDocument string) string {
+func genericProxyRewriter(proxifyImageFunction imageProxyRewriter, htmlDocument string) string {
 	proxyOption := config.Opts.MediaProxyMode()
 	if proxyOption == "none" {
 		return htmlDocument
 	}
 
+	proxifyFunction := func(mediaURL string) string {
+		return proxifyImageFunction(mediaURL, ImageOptions{})
+	}
+
+	// The images can only be resized by the internal media proxy.
+	var imageWidths []int
+	if config.Opts.MediaCustomProxyURL() == nil {
+		imageWidths = config.Opts.MediaProxyImageWidths()
+	}
+
 	doc, err := goquery.NewDocumentFromReader(strings.NewReader(htmlDocument))
 	if err != nil {
 		return htmlDocument
@@ -39,13 +56,19 @@ func genericProxyRewriter(proxifyFunction urlProxyRewriter, htmlDocument string)
 		switch mediaType {
 		case "image":
 			doc.Find("img, picture source").Each(func(i int, img *goquery.Selection) {
+				srcsetAttrValue, hasSrcset := img.Attr("srcset")
+
 				if srcAttrValue, ok := img.Attr("src"); ok {
 					if shouldProxifyURL(srcAttrValue, proxyOption) {
 						img.SetAttr("src", proxifyFunction(srcAttrValue))
+
+						if !hasSrcset && len(imageWidths) > 0 && goquery.NodeName(img) == "img" {
+							addResponsiveSourceSet(img, proxifyImageFunction, srcAttrValue, imageWidths)
+						}
 					}
 				}
 
-				if srcsetAttrValue, ok := img.Attr("srcset"); ok {
+				if hasSrcset {
 					proxifySourceSet(img, proxifyFunction, proxyOption, srcsetAttrValue)
 				}
 			})
@@ -106,6 +129,23 @@ func proxifySourceSet(element *goquery.Selection, proxifyFunction urlProxyRewrit
 	element.SetAttr("srcset", imageCandidates.String())
 }
 
+// addResponsiveSourceSet points the srcset attribute of an image to its variants resized by the media proxy.
+func addResponsiveSourceSet(img *goquery.Selection, proxifyImageFunction imageProxyRewriter, mediaURL string, imageWidths []int) {
+	quality := config.Opts.MediaProxyImageQuality()
+	imageCandidates := sanitizer.NewResponsiveImageCandidates(imageWidths, func(width int) string {
+		return proxifyImageFunction(mediaURL, ImageOptions{Width: width, Quality: quality})
+	})
+	img.SetAttr("srcset", imageCandidates.String())
+
+	if _, ok := img.Attr("sizes"); !ok {
+		displayWidth := maxContentWidth
+		if width, err := strconv.Atoi(img.AttrOr("width", "")); err == nil && width > 0 {
+			displayWidth = min(width, displayWidth)
+		}
+		img.SetAttr("sizes", fmt.Sprintf("(max-width: %dpx) 100vw, %dpx", displayWidth, displayWidth))
+	}
+}
+
 // shouldProxifyURL checks if the media URL should be proxified based on the media proxy option and URL scheme.
 func shouldProxifyURL(mediaURL, mediaProxyOption string) bool {
 	parsedURL, err := url.Parse(mediaURL)
@@ -14,6 +14,12 @@ import (
 )
 
 func ProxifyRelativeURL(mediaURL string) string {
+	return ProxifyRelativeImageURL(mediaURL, ImageOptions{})
+}
+
+// ProxifyRelativeImageURL returns the proxified URL of an image transformed with the given options.
+// The options are ignored when an external proxy is used.
+func ProxifyRelativeImageURL(mediaURL string, options ImageOptions) string {
 	if mediaURL == "" {
 		return ""
 	}
@@ -22,17 +28,15 @@ func ProxifyRelativeURL(mediaURL string) string {
 		return proxifyURLWithCustomProxy(mediaURL, customProxyURL)
 	}
 
-	mediaURLBytes := []byte(mediaURL)
-
-	mac := hmac.New(sha256.New, config.Opts.MediaProxyPrivateKey())
-	mac.Write(mediaURLBytes)
-	digest := mac.Sum(nil)
-
-	// Preserve the configured base path so proxied URLs still work when Miniflux is served from a subfolder.
-	return fmt.Sprintf("%s/proxy/%s/%s", config.Opts.BasePath(), base64.URLEncoding.EncodeToString(digest), base64.URLEncoding.EncodeToString(mediaURLBytes))
+	return appendImageOptions(proxyPath(mediaURL, options), options)
 }
 
 func ProxifyAbsoluteURL(mediaURL string) string {
+	return ProxifyAbsoluteImageURL(mediaURL, ImageOptions{})
+}
+
+// ProxifyAbsoluteImageURL returns the absolute proxified URL of an image transformed with the given options.
+func ProxifyAbsoluteImageURL(mediaURL string, options ImageOptions) string {
 	if mediaURL == "" {
 		return ""
 	}
@@ -42,13 +46,42 @@ func ProxifyAbsoluteURL(mediaURL string) string {
 	}
 
 	// Note that the proxyified URL is relative to the root URL.
-	proxifiedUrl := ProxifyRelativeURL(mediaURL)
-	absoluteURL, err := url.JoinPath(config.Opts.RootURL(), proxifiedUrl)
+	absoluteURL, err := url.JoinPath(config.Opts.RootURL(), proxyPath(mediaURL, options))
 	if err != nil {
 		return mediaURL
 	}
 
-	return absoluteURL
+	return appendImageOptions(absoluteURL, options)
+}
+
+func proxyPath(mediaURL string, options ImageOptions) string {
+	digest := signMediaURL(mediaURL, options)
+
+	// Preserve the configured base path so proxied URLs still work when Miniflux is served from a subfolder.
+	return fmt.Sprintf("%s/proxy/%s/%s", config.Opts.BasePath(), base64.URLEncoding.EncodeToString(digest), base64.URLEncoding.EncodeToString([]byte(mediaURL)))
+}
+
+func appendImageOptions(proxifiedURL string, options ImageOptions) string {
+	if queryString := options.Encode(); queryString != "" {
+		return proxifiedURL + "?" + queryString
+	}
+	return proxifiedURL
+}
+
+// VerifyMediaURL returns true when the digest of a proxified URL matches the media URL and the image options.
+func VerifyMediaURL(mediaURL string, options ImageOptions, digest []byte) bool {
+	return hmac.Equal(digest, signMediaURL(mediaURL, options))
+}
+
+// signMediaURL signs the media URL and the image options, so that the proxy only transforms the images it has rewritten.
+// The digest of an URL without options is kept unchanged for the URLs proxified by older versions.
+func signMediaURL(mediaURL string, options ImageOptions) []byte {
+	mac := hmac.New(sha256.New, config.Opts.MediaProxyPrivateKey())
+	mac.Write([]byte(mediaURL))
+	if queryString := options.Encode(); queryString != "" {
+		mac.Write([]byte("\n" + queryString))
+	}
+	return mac.Sum(nil)
 }
 
 func proxifyURLWithCustomProxy(mediaURL string, customProxyURL *url.URL) string {
@@ -19,6 +19,15 @@ const (
 	StatusError   = "error"
 )
 
+// Media proxy cache status label values.
+const (
+	CacheStatusHit         = "hit"
+	CacheStatusMiss        = "miss"
+	CacheStatusRevalidated = "revalidated"
+	CacheStatusStale       = "stale"
+	CacheStatusBypass      = "bypass"
+)
+
 // Prometheus Metrics.
 var (
 	BackgroundFeedRefreshDuration = prometheus.NewHistogramVec(
@@ -51,6 +60,50 @@ var (
 		[]string{"status"},
 	)
 
+	EnrichmentRequestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "miniflux",
+			Name:      "enrichment_request_duration",
+			Help:      "Enrichment processor request duration",
+			Buckets:   prometheus.LinearBuckets(0.5, 0.5, 20),
+		},
+		[]string{"processor", "status"},
+	)
+
+	EnrichmentSkippedRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "miniflux",
+			Name:      "enrichment_skipped_requests_total",
+			Help:      "Number of entries not sent to an enrichment processor because its circuit breaker is open",
+		},
+		[]string{"processor"},
+	)
+
+	MediaProxyCacheRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "miniflux",
+			Name:      "media_proxy_cache_requests_total",
+			Help:      "Number of media proxy requests by cache status",
+		},
+		[]string{"status"},
+	)
+
+	MediaProxyCacheItems = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "miniflux",
+			Name:      "media_proxy_cache_items",
+			Help:      "Number of media stored in the media proxy cache",
+		},
+	)
+
+	MediaProxyCacheSize = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "miniflux",
+			Name:      "media_proxy_cache_size_bytes",
+			Help:      "Total size of the media stored in the media proxy cache",
+		},
+	)
+
 	usersGauge = prometheus.NewGauge(
 		prometheus.GaugeOpts{
 			Namespace: "miniflux",
@@ -153,6 +206,11 @@ func NewCollector(store *storage.Storage, refreshInterval time.Duration) *collec
 	prometheus.MustRegister(BackgroundFeedRefreshDuration)
 	prometheus.MustRegister(ScraperRequestDuration)
 	prometheus.MustRegister(ArchiveEntriesDuration)
+	prometheus.MustRegister(EnrichmentRequestDuration)
+	prometheus.MustRegister(EnrichmentSkippedRequests)
+	prometheus.MustRegister(MediaProxyCacheRequests)
+	prometheus.MustRegister(MediaProxyCacheItems)
+	prometheus.MustRegister(MediaProxyCacheSize)
 	prometheus.MustRegister(usersGauge)
 	prometheus.MustRegister(feedsGauge)
 	prometheus.MustRegister(brokenFeedsGauge)
@@ -6,11 +6,30 @@ package model // import "miniflux.app/v2/internal/model"
 import "fmt"
 
 // Category represents a feed category.
+//
+// The rules and the fetch settings of a category are inherited by its feeds:
+// filter rules are applied in addition to the feed rules, other settings are used
+// when the feed does not override them.
 type Category struct {
-	ID           int64  `json:"id"`
-	Title        string `json:"title"`
-	UserID       int64  `json:"user_id"`
-	HideGlobally bool   `json:"hide_globally"`
+	ID                    int64  `json:"id"`
+	Title                 string `json:"title"`
+	UserID                int64  `json:"user_id"`
+	HideGlobally          bool   `json:"hide_globally"`
+	EntryRules            string `json:"entry_rules"`
+	ScraperRules          string `json:"scraper_rules"`
+	RewriteRules          string `json:"rewrite_rules"`
+	UrlRewriteRules       string `json:"urlrewrite_rules"`
+	BlocklistRules        string `json:"blocklist_rules"`
+	KeeplistRules         string `json:"keeplist_rules"`
+	BlockFilterEntryRules string `json:"block_filter_entry_rules"`
+	KeepFilterEntryRules  string `json:"keep_filter_entry_rules"`
+	Crawler               bool   `json:"crawler"`
+	UserAgent             string `json:"user_agent"`
+	FetchViaProxy         bool   `json:"fetch_via_proxy"`
+	ProxyURL              string `json:"proxy_url"`
+	EnrichmentProcessors  string `json:"enrichment_processors"`
+	SummarizeEntries      bool   `json:"summarize_entries"`
+	SummaryPrompt         string `json:"summary_prompt"`
 	// Pointers are needed to avoid breaking /v1/categories?counts=true
 	FeedCount   *int `json:"feed_count,omitempty"`
 	TotalUnread *int `json:"total_unread,omitempty"`
@@ -21,13 +40,43 @@ func (c *Category) String() string {
 }
 
 type CategoryCreationRequest struct {
-	Title        string `json:"title"`
-	HideGlobally bool   `json:"hide_globally"`
+	Title                 string `json:"title"`
+	HideGlobally          bool   `json:"hide_globally"`
+	EntryRules            string `json:"entry_rules"`
+	ScraperRules          string `json:"scraper_rules"`
+	RewriteRules          string `json:"rewrite_rules"`
+	UrlRewriteRules       string `json:"urlrewrite_rules"`
+	BlocklistRules        string `json:"blocklist_rules"`
+	KeeplistRules         string `json:"keeplist_rules"`
+	BlockFilterEntryRules string `json:"block_filter_entry_rules"`
+	KeepFilterEntryRules  string `json:"keep_filter_entry_rules"`
+	Crawler               bool   `json:"crawler"`
+	UserAgent             string `json:"user_agent"`
+	FetchViaProxy         bool   `json:"fetch_via_proxy"`
+	ProxyURL              string `json:"proxy_url"`
+	EnrichmentProcessors  string `json:"enrichment_processors"`
+	SummarizeEntries      bool   `json:"summarize_entries"`
+	SummaryPrompt         string `json:"summary_prompt"`
 }
 
 type CategoryModificationRequest struct {
-	Title        *string `json:"title"`
-	HideGlobally *bool   `json:"hide_globally"`
+	Title                 *string `json:"title"`
+	HideGlobally          *bool   `json:"hide_globally"`
+	EntryRules            *string `json:"entry_rules"`
+	ScraperRules          *string `json:"scraper_rules"`
+	RewriteRules          *string `json:"rewrite_rules"`
+	UrlRewriteRules       *string `json:"urlrewrite_rules"`
+	BlocklistRules        *string `json:"blocklist_rules"`
+	KeeplistRules         *string `json:"keeplist_rules"`
+	BlockFilterEntryRules *string `json:"block_filter_entry_rules"`
+	KeepFilterEntryRules  *string `json:"keep_filter_entry_rules"`
+	Crawler               *bool   `json:"crawler"`
+	UserAgent             *string `json:"user_agent"`
+	FetchViaProxy         *bool   `json:"fetch_via_proxy"`
+	ProxyURL              *string `json:"proxy_url"`
+	EnrichmentProcessors  *string `json:"enrichment_processors"`
+	SummarizeEntries      *bool   `json:"summarize_entries"`
+	SummaryPrompt         *string `json:"summary_prompt"`
 }
 
 func (c *CategoryModificationRequest) Patch(category *Category) {
@@ -38,6 +87,66 @@ func (c *CategoryModificationRequest) Patch(category *Category) {
 	if c.HideGlobally != nil {
 		category.HideGlobally = *c.HideGlobally
 	}
+
+	if c.EntryRules != nil {
+		category.EntryRules = *c.EntryRules
+	}
+
+	if c.ScraperRules != nil {
+		category.ScraperRules = *c.ScraperRules
+	}
+
+	if c.RewriteRules != nil {
+		category.RewriteRules = *c.RewriteRules
+	}
+
+	if c.UrlRewriteRules != nil {
+		category.UrlRewriteRules = *c.UrlRewriteRules
+	}
+
+	if c.BlocklistRules != nil {
+		category.BlocklistRules = *c.BlocklistRules
+	}
+
+	if c.KeeplistRules != nil {
+		category.KeeplistRules = *c.KeeplistRules
+	}
+
+	if c.BlockFilterEntryRules != nil {
+		category.BlockFilterEntryRules = *c.BlockFilterEntryRules
+	}
+
+	if c.KeepFilterEntryRules != nil {
+		category.KeepFilterEntryRules = *c.KeepFilterEntryRules
+	}
+
+	if c.Crawler != nil {
+		category.Crawler = *c.Crawler
+	}
+
+	if c.UserAgent != nil {
+		category.UserAgent = *c.UserAgent
+	}
+
+	if c.FetchViaProxy != nil {
+		category.FetchViaProxy = *c.FetchViaProxy
+	}
+
+	if c.ProxyURL != nil {
+		category.ProxyURL = *c.ProxyURL
+	}
+
+	if c.EnrichmentProcessors != nil {
+		category.EnrichmentProcessors = *c.EnrichmentProcessors
+	}
+
+	if c.SummarizeEntries != nil {
+		category.SummarizeEntries = *c.SummarizeEntries
+	}
+
+	if c.SummaryPrompt != nil {
+		category.SummaryPrompt = *c.SummaryPrompt
+	}
 }
 
 // Categories represents a list of categories.
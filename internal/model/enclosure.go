@@ -4,6 +4,7 @@
 package model // import "miniflux.app/v2/internal/model"
 
 import (
+	"fmt"
 	"strings"
 
 	"miniflux.app/v2/internal/mediaproxy"
@@ -18,6 +19,20 @@ type Enclosure struct {
 	MimeType         string `json:"mime_type"`
 	Size             int64  `json:"size"`
 	MediaProgression int64  `json:"media_progression"`
+
+	// Mirrored is true when a local copy of the enclosure is available.
+	Mirrored bool `json:"mirrored"`
+
+	// OriginalURL is the URL of the enclosure when URL points to the local copy.
+	OriginalURL string `json:"original_url,omitempty"`
+
+	// ThumbnailURL, Duration (in seconds) and ChannelName describe the video of the entry, when known.
+	ThumbnailURL string `json:"thumbnail_url,omitempty"`
+	Duration     int    `json:"duration,omitempty"`
+	ChannelName  string `json:"channel_name,omitempty"`
+
+	// Transcript is true when the enclosure is the transcript of a podcast episode.
+	Transcript bool `json:"transcript,omitempty"`
 }
 
 type EnclosureUpdateRequest struct {
@@ -49,11 +64,47 @@ func (e *Enclosure) IsImage() bool {
 	return strings.HasSuffix(mediaURL, ".jpg") || strings.HasSuffix(mediaURL, ".jpeg") || strings.HasSuffix(mediaURL, ".png") || strings.HasSuffix(mediaURL, ".gif")
 }
 
+// HasVideoMetadata returns true when the enclosure describes the video of the entry.
+func (e *Enclosure) HasVideoMetadata() bool {
+	return e.ThumbnailURL != "" || e.Duration > 0 || e.ChannelName != ""
+}
+
+// FormattedDuration returns the video duration as displayed by video players, for example "4:05" or "1:02:03".
+func (e *Enclosure) FormattedDuration() string {
+	if e.Duration <= 0 {
+		return ""
+	}
+
+	hours, minutes, seconds := e.Duration/3600, e.Duration%3600/60, e.Duration%60
+	if hours > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", hours, minutes, seconds)
+	}
+	return fmt.Sprintf("%d:%02d", minutes, seconds)
+}
+
+// IsMirrorable returns true when a local copy of the enclosure can be downloaded.
+func (e *Enclosure) IsMirrorable() bool {
+	return e.IsAudio() || e.IsVideo() || strings.EqualFold(e.MimeType, "application/pdf")
+}
+
+// UseMirror modifies the enclosure URL to point to the local copy, if any.
+func (e *Enclosure) UseMirror() {
+	if e.Mirrored && e.OriginalURL == "" {
+		e.OriginalURL = e.URL
+		e.URL = mediaproxy.MirrorAbsoluteURL(e.ID)
+	}
+}
+
 // ProxifyEnclosureURL modifies the enclosure URL to use the media proxy if necessary.
+// The URLs of the local copies are not proxified.
 func (e *Enclosure) ProxifyEnclosureURL(mediaProxyOption string, mediaProxyResourceTypes []string) {
-	if mediaproxy.ShouldProxifyURLWithMimeType(e.URL, e.MimeType, mediaProxyOption, mediaProxyResourceTypes) {
+	if e.OriginalURL == "" && mediaproxy.ShouldProxifyURLWithMimeType(e.URL, e.MimeType, mediaProxyOption, mediaProxyResourceTypes) {
 		e.URL = mediaproxy.ProxifyAbsoluteURL(e.URL)
 	}
+
+	if e.ThumbnailURL != "" && mediaproxy.ShouldProxifyURLWithMimeType(e.ThumbnailURL, "image/*", mediaProxyOption, mediaProxyResourceTypes) {
+		e.ThumbnailURL = mediaproxy.ProxifyAbsoluteURL(e.ThumbnailURL)
+	}
 }
 
 // EnclosureList represents a list of attachments.
@@ -72,6 +123,17 @@ func (el EnclosureList) FindMediaPlayerEnclosure() *Enclosure {
 	return nil
 }
 
+// FindVideoEnclosure returns the enclosure describing the video of the entry, if any.
+func (el EnclosureList) FindVideoEnclosure() *Enclosure {
+	for _, enclosure := range el {
+		if enclosure.HasVideoMetadata() {
+			return enclosure
+		}
+	}
+
+	return nil
+}
+
 func (el EnclosureList) ContainsAudioOrVideo() bool {
 	for _, enclosure := range el {
 		if enclosure.IsAudio() || enclosure.IsVideo() {
@@ -81,6 +143,13 @@ func (el EnclosureList) ContainsAudioOrVideo() bool {
 	return false
 }
 
+// UseMirrors modifies the URL of the mirrored enclosures to point to their local copy.
+func (el EnclosureList) UseMirrors() {
+	for _, enclosure := range el {
+		enclosure.UseMirror()
+	}
+}
+
 func (el EnclosureList) ProxifyEnclosureURL(mediaProxyOption string, mediaProxyResourceTypes []string) {
 	for _, enclosure := range el {
 		enclosure.ProxifyEnclosureURL(mediaProxyOption, mediaProxyResourceTypes)
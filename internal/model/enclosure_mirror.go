@@ -0,0 +1,23 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import "time"
+
+// EnclosureMirrorRetryInterval is the delay before retrying a download that failed.
+const EnclosureMirrorRetryInterval = 24 * time.Hour
+
+// EnclosureMirror is a local copy of an audio, video or PDF enclosure.
+type EnclosureMirror struct {
+	EnclosureID int64
+	UserID      int64
+	MimeType    string
+	Size        int64
+	Error       string
+
+	// Evicted is true when the file was removed to stay under the storage limit of the user.
+	// Evicted enclosures are not downloaded again, unless their entry is starred afterward.
+	Evicted   bool
+	CreatedAt time.Time
+}
@@ -578,3 +578,66 @@ func TestEnclosure_ProxifyEnclosureURL_EdgeCases(t *testing.T) {
 		}
 	})
 }
+
+func TestEnclosure_FormattedDuration(t *testing.T) {
+	testCases := []struct {
+		duration int
+		expected string
+	}{
+		{0, ""},
+		{-5, ""},
+		{7, "0:07"},
+		{245, "4:05"},
+		{3723, "1:02:03"},
+	}
+
+	for _, tc := range testCases {
+		enclosure := &Enclosure{Duration: tc.duration}
+		if result := enclosure.FormattedDuration(); result != tc.expected {
+			t.Errorf("Expected %q for %d seconds, got %q", tc.expected, tc.duration, result)
+		}
+	}
+}
+
+func TestEnclosureList_FindVideoEnclosure(t *testing.T) {
+	enclosures := EnclosureList{
+		&Enclosure{URL: "https://example.com/audio.mp3", MimeType: "audio/mpeg"},
+		&Enclosure{URL: "https://example.com/thumbnail.jpg", MimeType: "image/*", ChannelName: "Channel"},
+	}
+
+	if result := enclosures.FindVideoEnclosure(); result != enclosures[1] {
+		t.Errorf("Expected the enclosure with video metadata, got %v", result)
+	}
+
+	if result := enclosures[:1].FindVideoEnclosure(); result != nil {
+		t.Errorf("Expected no enclosure, got %v", result)
+	}
+}
+
+func TestEnclosure_ProxifyEnclosureURL_Thumbnail(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("BASE_URL", "http://localhost")
+	os.Setenv("MEDIA_PROXY_PRIVATE_KEY", "test-private-key")
+
+	var err error
+	parser := config.NewConfigParser()
+	config.Opts, err = parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Config parsing failure: %v`, err)
+	}
+
+	enclosure := &Enclosure{
+		URL:          "https://example.com/video.mp4",
+		MimeType:     "video/mp4",
+		ThumbnailURL: "https://example.com/thumbnail.jpg",
+	}
+	enclosure.ProxifyEnclosureURL("all", []string{"image"})
+
+	if enclosure.URL != "https://example.com/video.mp4" {
+		t.Errorf("The video URL should not be proxified, got %s", enclosure.URL)
+	}
+
+	if enclosure.ThumbnailURL == "https://example.com/thumbnail.jpg" {
+		t.Error("The thumbnail URL should be proxified")
+	}
+}
@@ -0,0 +1,18 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import "time"
+
+// EnclosureTextRetryInterval is the delay before retrying a text extraction that failed.
+const EnclosureTextRetryInterval = 24 * time.Hour
+
+// EnclosureText is the text extracted from a PDF or transcript enclosure to index it with the entry.
+type EnclosureText struct {
+	EnclosureID int64
+	UserID      int64
+	Text        string
+	Error       string
+	CreatedAt   time.Time
+}
@@ -0,0 +1,21 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import (
+	"slices"
+	"strings"
+)
+
+// ParseEnrichmentProcessorNames returns the names of a comma-separated list of enrichment processors, without duplicates.
+func ParseEnrichmentProcessorNames(value string) []string {
+	var names []string
+	for name := range strings.SplitSeq(value, ",") {
+		name = strings.TrimSpace(name)
+		if name != "" && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
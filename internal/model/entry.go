@@ -13,32 +13,48 @@ const (
 	EntryStatusRead         = "read"
 	DefaultSortingOrder     = "published_at"
 	DefaultSortingDirection = "asc"
+	RelevanceSortingOrder   = "relevance"
 )
 
 // Entry represents a feed item in the system.
 type Entry struct {
-	ID            int64         `json:"id"`
-	UserID        int64         `json:"user_id"`
-	FeedID        int64         `json:"feed_id"`
-	Status        string        `json:"status"`
-	Hash          string        `json:"hash"`
-	Title         string        `json:"title"`
-	URL           string        `json:"url"`
-	CommentsURL   string        `json:"comments_url"`
-	Date          time.Time     `json:"published_at"`
-	CreatedAt     time.Time     `json:"created_at"`
-	ChangedAt     time.Time     `json:"changed_at"`
-	Content       string        `json:"content"`
-	Author        string        `json:"author"`
-	ShareCode     string        `json:"share_code"`
-	Starred       bool          `json:"starred"`
-	SavedForLater bool          `json:"saved_for_later"`
-	ReadingTime   int           `json:"reading_time"`
-	Enclosures    EnclosureList `json:"enclosures"`
-	Feed          *Feed         `json:"feed,omitempty"`
-	Tags          []string      `json:"tags"`
-	Score         int64         `json:"score"`
-	Vote          int           `json:"vote"`
+	ID            int64             `json:"id"`
+	UserID        int64             `json:"user_id"`
+	FeedID        int64             `json:"feed_id"`
+	Status        string            `json:"status"`
+	Hash          string            `json:"hash"`
+	Title         string            `json:"title"`
+	URL           string            `json:"url"`
+	CommentsURL   string            `json:"comments_url"`
+	Date          time.Time         `json:"published_at"`
+	CreatedAt     time.Time         `json:"created_at"`
+	ChangedAt     time.Time         `json:"changed_at"`
+	RevisedAt     *time.Time        `json:"revised_at"`
+	ReadAt        *time.Time        `json:"read_at"`
+	SnapshotAt    *time.Time        `json:"snapshot_at"`
+	Content       string            `json:"content"`
+	Summary       string            `json:"summary"`
+	Language      string            `json:"language"`
+	Author        string            `json:"author"`
+	ShareCode     string            `json:"share_code"`
+	Starred       bool              `json:"starred"`
+	SavedForLater bool              `json:"saved_for_later"`
+	ReadingTime   int               `json:"reading_time"`
+	Enclosures    EnclosureList     `json:"enclosures"`
+	Feed          *Feed             `json:"feed,omitempty"`
+	Tags          []string          `json:"tags"`
+	Score         int64             `json:"score"`
+	Vote          int               `json:"vote"`
+	Fingerprint   uint64            `json:"-"`
+	AlsoIn        []*EntryDuplicate `json:"also_in,omitempty"`
+	Snippet       string            `json:"snippet,omitempty"`
+	SearchRank    float64           `json:"search_rank,omitempty"`
+
+	// SendToIntegrations are the integrations a rule sends the new entry to once stored.
+	SendToIntegrations []string `json:"-"`
+
+	// SummaryUpdated is true when the summary of an updated entry must replace the stored one, even when empty.
+	SummaryUpdated bool `json:"-"`
 }
 
 func NewEntry() *Entry {
@@ -68,6 +84,11 @@ func (e *Entry) ShouldMarkAsReadOnView(user *User) bool {
 	return user.MarkReadOnView
 }
 
+// IsUpdatedSinceRead returns true if the title or the content of a read entry changed after it was read.
+func (e *Entry) IsUpdatedSinceRead() bool {
+	return e.Status == EntryStatusRead && e.RevisedAt != nil && e.ReadAt != nil && e.RevisedAt.After(*e.ReadAt)
+}
+
 // Entries represents a list of entries.
 type Entries []*Entry
 
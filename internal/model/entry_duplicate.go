@@ -0,0 +1,29 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+// What to do with an entry that is a near-duplicate of an entry received earlier from another feed.
+const (
+	DuplicateEntriesActionNone       = "none"
+	DuplicateEntriesActionAnnotate   = "annotate"
+	DuplicateEntriesActionMarkAsRead = "mark_as_read"
+	DuplicateEntriesActionMerge      = "merge"
+)
+
+// DuplicateEntriesActions returns the list of available actions for near-duplicate entries.
+func DuplicateEntriesActions() map[string]string {
+	return map[string]string{
+		DuplicateEntriesActionNone:       "form.prefs.select.duplicate_entries_none",
+		DuplicateEntriesActionAnnotate:   "form.prefs.select.duplicate_entries_annotate",
+		DuplicateEntriesActionMarkAsRead: "form.prefs.select.duplicate_entries_mark_as_read",
+		DuplicateEntriesActionMerge:      "form.prefs.select.duplicate_entries_merge",
+	}
+}
+
+// EntryDuplicate represents another feed where the same story was published.
+type EntryDuplicate struct {
+	FeedID    int64  `json:"feed_id"`
+	FeedTitle string `json:"feed_title"`
+	URL       string `json:"url"`
+}
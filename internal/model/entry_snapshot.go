@@ -0,0 +1,20 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import "time"
+
+// EntrySnapshotRetryInterval is the delay before retrying a snapshot that failed.
+const EntrySnapshotRetryInterval = 24 * time.Hour
+
+// EntrySnapshot is an offline copy of the web page of an entry, with its images and stylesheets.
+type EntrySnapshot struct {
+	EntryID   int64     `json:"entry_id"`
+	UserID    int64     `json:"user_id"`
+	URL       string    `json:"url"`
+	Content   string    `json:"content,omitempty"`
+	Size      int64     `json:"size"`
+	Error     string    `json:"error,omitempty"` // Error of the last attempt, the content of a previous snapshot is kept.
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,36 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEntryIsUpdatedSinceRead(t *testing.T) {
+	readAt := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	before := readAt.Add(-time.Hour)
+	after := readAt.Add(time.Hour)
+
+	scenarios := []struct {
+		status    string
+		readAt    *time.Time
+		revisedAt *time.Time
+		expected  bool
+	}{
+		{EntryStatusRead, &readAt, nil, false},
+		{EntryStatusRead, &readAt, &before, false},
+		{EntryStatusRead, &readAt, &after, true},
+		{EntryStatusRead, nil, &after, false},
+		{EntryStatusUnread, &readAt, &after, false},
+	}
+
+	for _, scenario := range scenarios {
+		// The entry is starred after the revision, which must not hide it.
+		entry := &Entry{Status: scenario.status, ChangedAt: after.Add(time.Hour), ReadAt: scenario.readAt, RevisedAt: scenario.revisedAt}
+		if result := entry.IsUpdatedSinceRead(); result != scenario.expected {
+			t.Errorf(`Unexpected result for status %q, read date %v and revision date %v: got %v`, scenario.status, scenario.readAt, scenario.revisedAt, result)
+		}
+	}
+}
@@ -20,6 +20,13 @@ const (
 	DefaultFeedSortingDirection = "desc"
 )
 
+// Overrides of the feed settings inherited from the category.
+const (
+	FeedSettingInherit = "inherit"
+	FeedSettingOn      = "on"
+	FeedSettingOff     = "off"
+)
+
 // Feed represents a feed in the application.
 type Feed struct {
 	ID                          int64     `json:"id"`
@@ -56,6 +63,12 @@ type Feed struct {
 	NtfyEnabled                 bool      `json:"ntfy_enabled"`
 	Crawler                     bool      `json:"crawler"`
 	IgnoreEntryUpdates          bool      `json:"ignore_entry_updates"`
+	EntryRules                  string    `json:"entry_rules"`
+	MarkUnreadOnEntryRevision   bool      `json:"mark_unread_on_entry_revision"`
+	SnapshotEntries             bool      `json:"snapshot_entries"`
+	MirrorEnclosures            bool      `json:"mirror_enclosures"`
+	MirrorEnclosuresLimit       int       `json:"mirror_enclosures_limit"`
+	EnrichmentProcessors        string    `json:"enrichment_processors"`
 	AppriseServiceURLs          string    `json:"apprise_service_urls"`
 	WebhookURL                  string    `json:"webhook_url"`
 	NtfyPriority                int       `json:"ntfy_priority"`
@@ -63,6 +76,11 @@ type Feed struct {
 	PushoverPriority            int       `json:"pushover_priority"`
 	ProxyURL                    string    `json:"proxy_url"`
 
+	// CrawlerOverride and FetchViaProxyOverride tell whether the feed inherits the setting of its category,
+	// Crawler and FetchViaProxy being the effective settings.
+	CrawlerOverride       string `json:"crawler_override"`
+	FetchViaProxyOverride string `json:"fetch_via_proxy_override"`
+
 	// Non-persisted attributes
 	Category *Category `json:"category,omitempty"`
 	Icon     *FeedIcon `json:"icon"`
@@ -97,6 +115,135 @@ func (f *Feed) WithCategoryID(categoryID int64) {
 	f.Category = &Category{ID: categoryID}
 }
 
+// EffectiveScraperRules returns the scraper rules of the feed, or the ones of its category.
+func (f *Feed) EffectiveScraperRules() string {
+	if f.ScraperRules == "" && f.Category != nil {
+		return f.Category.ScraperRules
+	}
+	return f.ScraperRules
+}
+
+// EffectiveRewriteRules returns the content rewrite rules of the feed, or the ones of its category.
+func (f *Feed) EffectiveRewriteRules() string {
+	if f.RewriteRules == "" && f.Category != nil {
+		return f.Category.RewriteRules
+	}
+	return f.RewriteRules
+}
+
+// EffectiveUrlRewriteRules returns the URL rewrite rules of the feed, or the ones of its category.
+func (f *Feed) EffectiveUrlRewriteRules() string {
+	if f.UrlRewriteRules == "" && f.Category != nil {
+		return f.Category.UrlRewriteRules
+	}
+	return f.UrlRewriteRules
+}
+
+// EffectiveKeeplistRules returns the keeplist rules of the feed, or the ones of its category.
+func (f *Feed) EffectiveKeeplistRules() string {
+	if f.KeeplistRules == "" && f.Category != nil {
+		return f.Category.KeeplistRules
+	}
+	return f.KeeplistRules
+}
+
+// EffectiveUserAgent returns the user agent of the feed, or the one of its category.
+func (f *Feed) EffectiveUserAgent() string {
+	if f.UserAgent == "" && f.Category != nil {
+		return f.Category.UserAgent
+	}
+	return f.UserAgent
+}
+
+// EffectiveProxyURL returns the proxy URL of the feed, or the one of its category.
+func (f *Feed) EffectiveProxyURL() string {
+	if f.ProxyURL == "" && f.Category != nil {
+		return f.Category.ProxyURL
+	}
+	return f.ProxyURL
+}
+
+// EffectiveEnrichmentProcessors returns the names of the enrichment processors of the feed, or the ones of its category.
+func (f *Feed) EffectiveEnrichmentProcessors() []string {
+	processors := f.EnrichmentProcessors
+	if processors == "" && f.Category != nil {
+		processors = f.Category.EnrichmentProcessors
+	}
+	return ParseEnrichmentProcessorNames(processors)
+}
+
+// EffectiveCrawler returns true if the original content is fetched for the feed.
+// The setting of the category is used when the feed does not override it.
+func (f *Feed) EffectiveCrawler() bool {
+	return effectiveFeedSetting(f.CrawlerOverride, f.Category != nil && f.Category.Crawler)
+}
+
+// EffectiveFetchViaProxy returns true if the application proxy is used for the feed.
+// The setting of the category is used when the feed does not override it.
+func (f *Feed) EffectiveFetchViaProxy() bool {
+	return effectiveFeedSetting(f.FetchViaProxyOverride, f.Category != nil && f.Category.FetchViaProxy)
+}
+
+// ResolveInheritedSettings sets the effective values of the settings inherited from the category.
+func (f *Feed) ResolveInheritedSettings() {
+	f.Crawler = f.EffectiveCrawler()
+	f.FetchViaProxy = f.EffectiveFetchViaProxy()
+}
+
+func effectiveFeedSetting(override string, categorySetting bool) bool {
+	switch override {
+	case FeedSettingOn:
+		return true
+	case FeedSettingOff:
+		return false
+	default:
+		return categorySetting
+	}
+}
+
+// IsValidFeedSettingOverride returns true if the value is an override of a feed setting.
+func IsValidFeedSettingOverride(override string) bool {
+	switch override {
+	case FeedSettingInherit, FeedSettingOn, FeedSettingOff:
+		return true
+	default:
+		return false
+	}
+}
+
+// FeedSettingOverride returns the override of a stored feed setting, a null value inheriting the setting of the category.
+func FeedSettingOverride(value *bool) string {
+	switch {
+	case value == nil:
+		return FeedSettingInherit
+	case *value:
+		return FeedSettingOn
+	default:
+		return FeedSettingOff
+	}
+}
+
+// FeedSettingValue returns the stored value of a feed setting override, nil when the setting is inherited.
+func FeedSettingValue(override string) *bool {
+	switch override {
+	case FeedSettingOn:
+		return new(true)
+	case FeedSettingOff:
+		return new(false)
+	default:
+		return nil
+	}
+}
+
+// RequestedFeedSettingOverride returns the override of a feed setting sent with a request:
+// the override is used when given, otherwise the boolean value enables or disables the setting.
+func RequestedFeedSettingOverride(override string, enabled bool) string {
+	if override != "" {
+		return override
+	}
+	return FeedSettingOverride(&enabled)
+}
+
 // WithTranslatedErrorMessage adds a new error message and increment the error counter.
 func (f *Feed) WithTranslatedErrorMessage(message string) {
 	f.ParsingErrorCount++
@@ -173,6 +320,11 @@ type FeedCreationRequest struct {
 	KeepFilterEntryRules        string `json:"keep_filter_entry_rules"`
 	UrlRewriteRules             string `json:"urlrewrite_rules"`
 	ProxyURL                    string `json:"proxy_url"`
+
+	// CrawlerOverride and FetchViaProxyOverride replace Crawler and FetchViaProxy when given,
+	// to inherit the setting of the category.
+	CrawlerOverride       string `json:"crawler_override"`
+	FetchViaProxyOverride string `json:"fetch_via_proxy_override"`
 }
 
 type FeedCreationRequestFromSubscriptionDiscovery struct {
@@ -198,6 +350,12 @@ type FeedModificationRequest struct {
 	KeepFilterEntryRules        *string `json:"keep_filter_entry_rules"`
 	Crawler                     *bool   `json:"crawler"`
 	IgnoreEntryUpdates          *bool   `json:"ignore_entry_updates"`
+	EntryRules                  *string `json:"entry_rules"`
+	MarkUnreadOnEntryRevision   *bool   `json:"mark_unread_on_entry_revision"`
+	SnapshotEntries             *bool   `json:"snapshot_entries"`
+	MirrorEnclosures            *bool   `json:"mirror_enclosures"`
+	MirrorEnclosuresLimit       *int    `json:"mirror_enclosures_limit"`
+	EnrichmentProcessors        *string `json:"enrichment_processors"`
 	UserAgent                   *string `json:"user_agent"`
 	Cookie                      *string `json:"cookie"`
 	Username                    *string `json:"username"`
@@ -211,6 +369,11 @@ type FeedModificationRequest struct {
 	HideGlobally                *bool   `json:"hide_globally"`
 	DisableHTTP2                *bool   `json:"disable_http2"`
 	ProxyURL                    *string `json:"proxy_url"`
+
+	// CrawlerOverride and FetchViaProxyOverride replace Crawler and FetchViaProxy when given,
+	// to inherit the setting of the category again.
+	CrawlerOverride       *string `json:"crawler_override"`
+	FetchViaProxyOverride *string `json:"fetch_via_proxy_override"`
 }
 
 // Patch updates a feed with modified values.
@@ -260,13 +423,37 @@ func (f *FeedModificationRequest) Patch(feed *Feed) {
 	}
 
 	if f.Crawler != nil {
-		feed.Crawler = *f.Crawler
+		feed.CrawlerOverride = FeedSettingOverride(f.Crawler)
+	}
+
+	if f.CrawlerOverride != nil {
+		feed.CrawlerOverride = *f.CrawlerOverride
 	}
 
 	if f.IgnoreEntryUpdates != nil {
 		feed.IgnoreEntryUpdates = *f.IgnoreEntryUpdates
 	}
 
+	if f.EntryRules != nil {
+		feed.EntryRules = *f.EntryRules
+	}
+
+	if f.MarkUnreadOnEntryRevision != nil {
+		feed.MarkUnreadOnEntryRevision = *f.MarkUnreadOnEntryRevision
+	}
+
+	if f.SnapshotEntries != nil {
+		feed.SnapshotEntries = *f.SnapshotEntries
+	}
+
+	if f.MirrorEnclosures != nil {
+		feed.MirrorEnclosures = *f.MirrorEnclosures
+	}
+
+	if f.MirrorEnclosuresLimit != nil {
+		feed.MirrorEnclosuresLimit = *f.MirrorEnclosuresLimit
+	}
+
 	if f.UserAgent != nil {
 		feed.UserAgent = *f.UserAgent
 	}
@@ -304,7 +491,11 @@ func (f *FeedModificationRequest) Patch(feed *Feed) {
 	}
 
 	if f.FetchViaProxy != nil {
-		feed.FetchViaProxy = *f.FetchViaProxy
+		feed.FetchViaProxyOverride = FeedSettingOverride(f.FetchViaProxy)
+	}
+
+	if f.FetchViaProxyOverride != nil {
+		feed.FetchViaProxyOverride = *f.FetchViaProxyOverride
 	}
 
 	if f.HideGlobally != nil {
@@ -318,6 +509,10 @@ func (f *FeedModificationRequest) Patch(feed *Feed) {
 	if f.ProxyURL != nil {
 		feed.ProxyURL = *f.ProxyURL
 	}
+
+	if f.EnrichmentProcessors != nil {
+		feed.EnrichmentProcessors = *f.EnrichmentProcessors
+	}
 }
 
 // Feeds is a list of feed
@@ -5,6 +5,7 @@ package model // import "miniflux.app/v2/internal/model"
 
 import (
 	"os"
+	"slices"
 	"strconv"
 	"testing"
 	"time"
@@ -30,6 +31,18 @@ func TestFeedCategorySetter(t *testing.T) {
 	}
 }
 
+func TestFeedEffectiveEnrichmentProcessors(t *testing.T) {
+	feed := &Feed{Category: &Category{EnrichmentProcessors: "classifier"}}
+	if processors := feed.EffectiveEnrichmentProcessors(); !slices.Equal(processors, []string{"classifier"}) {
+		t.Errorf(`The category processors should be used, got %v`, processors)
+	}
+
+	feed.EnrichmentProcessors = " summarizer, classifier,,summarizer "
+	if processors := feed.EffectiveEnrichmentProcessors(); !slices.Equal(processors, []string{"summarizer", "classifier"}) {
+		t.Errorf(`The feed processors should be used, got %v`, processors)
+	}
+}
+
 func TestFeedErrorCounter(t *testing.T) {
 	feed := &Feed{}
 	feed.WithTranslatedErrorMessage("Some Error")
@@ -376,3 +389,79 @@ func TestFeedScheduleNextCheckEntryFrequencyLargeNewTTL(t *testing.T) {
 		t.Error(`The next_check_at should be after timeBefore + entry frequency min interval`)
 	}
 }
+
+func TestFeedInheritsCategorySettings(t *testing.T) {
+	feed := &Feed{
+		Category: &Category{
+			ScraperRules:    "article",
+			RewriteRules:    "add_image_title",
+			UrlRewriteRules: `rewrite("a"|"b")`,
+			KeeplistRules:   "(?i)go",
+			Crawler:         true,
+			UserAgent:       "CategoryAgent",
+			FetchViaProxy:   true,
+			ProxyURL:        "http://category-proxy:3128",
+		},
+	}
+
+	if feed.EffectiveScraperRules() != "article" || feed.EffectiveRewriteRules() != "add_image_title" ||
+		feed.EffectiveUrlRewriteRules() != `rewrite("a"|"b")` || feed.EffectiveKeeplistRules() != "(?i)go" {
+		t.Error(`The feed should inherit the category rules`)
+	}
+
+	if !feed.EffectiveCrawler() || !feed.EffectiveFetchViaProxy() ||
+		feed.EffectiveUserAgent() != "CategoryAgent" || feed.EffectiveProxyURL() != "http://category-proxy:3128" {
+		t.Error(`The feed should inherit the category fetch settings`)
+	}
+
+	feed.ScraperRules = "main"
+	feed.UserAgent = "FeedAgent"
+	feed.ProxyURL = "http://feed-proxy:3128"
+	feed.CrawlerOverride = FeedSettingOff
+	feed.FetchViaProxyOverride = FeedSettingOff
+	if feed.EffectiveScraperRules() != "main" || feed.EffectiveUserAgent() != "FeedAgent" || feed.EffectiveProxyURL() != "http://feed-proxy:3128" ||
+		feed.EffectiveCrawler() || feed.EffectiveFetchViaProxy() {
+		t.Error(`The feed settings should override the category settings`)
+	}
+
+	feed.CrawlerOverride = FeedSettingOn
+	feed.Category = nil
+	if feed.EffectiveRewriteRules() != "" || !feed.EffectiveCrawler() || feed.EffectiveFetchViaProxy() {
+		t.Error(`A feed without category should use its own settings`)
+	}
+}
+
+func TestFeedModificationRequestPatchSettingOverrides(t *testing.T) {
+	feed := &Feed{CrawlerOverride: FeedSettingOn, FetchViaProxyOverride: FeedSettingOff, Category: &Category{FetchViaProxy: true}}
+
+	(&FeedModificationRequest{Crawler: new(false)}).Patch(feed)
+	if feed.CrawlerOverride != FeedSettingOff {
+		t.Errorf(`An explicit false value should disable the setting, got %q`, feed.CrawlerOverride)
+	}
+
+	(&FeedModificationRequest{Crawler: new(true), CrawlerOverride: new(FeedSettingInherit), FetchViaProxyOverride: new(FeedSettingInherit)}).Patch(feed)
+	if feed.CrawlerOverride != FeedSettingInherit || feed.FetchViaProxyOverride != FeedSettingInherit {
+		t.Errorf(`The override should reset the settings to inherit, got %q and %q`, feed.CrawlerOverride, feed.FetchViaProxyOverride)
+	}
+
+	feed.ResolveInheritedSettings()
+	if feed.Crawler || !feed.FetchViaProxy {
+		t.Error(`The effective settings should be inherited from the category`)
+	}
+}
+
+func TestFeedSettingOverrideRoundTrip(t *testing.T) {
+	for _, override := range []string{FeedSettingInherit, FeedSettingOn, FeedSettingOff} {
+		if got := FeedSettingOverride(FeedSettingValue(override)); got != override {
+			t.Errorf(`Expected %q, got %q`, override, got)
+		}
+	}
+
+	if got := RequestedFeedSettingOverride("", false); got != FeedSettingOff {
+		t.Errorf(`An explicit false value should disable the setting, got %q`, got)
+	}
+
+	if got := RequestedFeedSettingOverride(FeedSettingInherit, true); got != FeedSettingInherit {
+		t.Errorf(`The override should take precedence over the value, got %q`, got)
+	}
+}
@@ -5,8 +5,12 @@ package model // import "miniflux.app/v2/internal/model"
 
 import (
 	"encoding/base64"
+	"time"
 )
 
+// FeedIconRetryInterval is the delay before looking again for the icon of a feed without icon.
+const FeedIconRetryInterval = 24 * time.Hour
+
 // Icon represents a website icon (favicon)
 type Icon struct {
 	ID         int64  `json:"id"`
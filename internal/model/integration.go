@@ -128,3 +128,52 @@ type Integration struct {
 	PushoverPrefix                   string
 	ArchiveorgEnabled                bool
 }
+
+// sendEntryFlags returns the enabled flags of the integrations that save entries, keyed by name.
+func (i *Integration) sendEntryFlags() map[string]*bool {
+	return map[string]*bool{
+		"archiveorg":   &i.ArchiveorgEnabled,
+		"betula":       &i.BetulaEnabled,
+		"cubox":        &i.CuboxEnabled,
+		"espial":       &i.EspialEnabled,
+		"instapaper":   &i.InstapaperEnabled,
+		"karakeep":     &i.KarakeepEnabled,
+		"linkace":      &i.LinkAceEnabled,
+		"linkding":     &i.LinkdingEnabled,
+		"linktaco":     &i.LinktacoEnabled,
+		"linkwarden":   &i.LinkwardenEnabled,
+		"notion":       &i.NotionEnabled,
+		"nunux_keeper": &i.NunuxKeeperEnabled,
+		"omnivore":     &i.OmnivoreEnabled,
+		"pinboard":     &i.PinboardEnabled,
+		"raindrop":     &i.RaindropEnabled,
+		"readeck":      &i.ReadeckEnabled,
+		"readwise":     &i.ReadwiseEnabled,
+		"shaarli":      &i.ShaarliEnabled,
+		"shiori":       &i.ShioriEnabled,
+		"wallabag":     &i.WallabagEnabled,
+		"webhook":      &i.WebhookEnabled,
+	}
+}
+
+// IsSendEntryIntegration returns true if the name is an integration that saves entries.
+func IsSendEntryIntegration(name string) bool {
+	_, found := (&Integration{}).sendEntryFlags()[name]
+	return found
+}
+
+// OnlySendEntryIntegration returns a copy of the settings where the named integration is the only one saving entries.
+// It returns nil if the integration is not enabled.
+func (i *Integration) OnlySendEntryIntegration(name string) *Integration {
+	only := *i
+	flags := only.sendEntryFlags()
+	enabled, found := flags[name]
+	if !found || !*enabled {
+		return nil
+	}
+
+	for flagName, flag := range flags {
+		*flag = flagName == name
+	}
+	return &only
+}
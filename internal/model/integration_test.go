@@ -0,0 +1,37 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import "testing"
+
+func TestOnlySendEntryIntegration(t *testing.T) {
+	integration := &Integration{UserID: 1, WallabagEnabled: true, PinboardEnabled: true, TelegramBotEnabled: true}
+
+	only := integration.OnlySendEntryIntegration("wallabag")
+	if only == nil {
+		t.Fatal(`Expected the enabled integration to be returned`)
+	}
+	if !only.WallabagEnabled || only.PinboardEnabled || !only.TelegramBotEnabled || only.UserID != 1 {
+		t.Errorf(`Only the named integration should save entries, got %+v`, only)
+	}
+	if !integration.PinboardEnabled {
+		t.Error(`The original settings must not be modified`)
+	}
+
+	if integration.OnlySendEntryIntegration("instapaper") != nil {
+		t.Error(`A disabled integration should return nil`)
+	}
+	if integration.OnlySendEntryIntegration("telegram_bot") != nil {
+		t.Error(`An integration that does not save entries should return nil`)
+	}
+}
+
+func TestIsSendEntryIntegration(t *testing.T) {
+	if !IsSendEntryIntegration("nunux_keeper") {
+		t.Error(`nunux_keeper saves entries`)
+	}
+	if IsSendEntryIntegration("matrix_bot") || IsSendEntryIntegration("Wallabag") {
+		t.Error(`Only the lowercase names of the integrations saving entries are valid`)
+	}
+}
@@ -0,0 +1,48 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import (
+	"fmt"
+	"time"
+)
+
+// MaxBlockedEntriesPerFeed is the number of recently blocked entries kept for each feed.
+const MaxBlockedEntriesPerFeed = 100
+
+// BlockedEntry represents an entry blocked by a rule while refreshing a feed.
+type BlockedEntry struct {
+	ID        int64            `json:"id"`
+	UserID    int64            `json:"user_id"`
+	FeedID    int64            `json:"feed_id"`
+	Hash      string           `json:"hash"`
+	Title     string           `json:"title"`
+	URL       string           `json:"url"`
+	Rule      *RulePreviewRule `json:"rule"`
+	Stage     string           `json:"stage"`
+	CreatedAt time.Time        `json:"created_at"`
+
+	// Entry is the blocked entry, as it was when the rule matched.
+	Entry *Entry `json:"-"`
+}
+
+func (b *BlockedEntry) String() string {
+	return fmt.Sprintf("ID=%d, UserID=%d, FeedID=%d, Hash=%s, Rule=%s", b.ID, b.UserID, b.FeedID, b.Hash, b.Rule.Rule)
+}
+
+// RuleHit represents the number of new entries matched by a rule of a feed.
+type RuleHit struct {
+	Rule      string     `json:"rule"`
+	Source    string     `json:"source"`
+	Field     string     `json:"field"`
+	Line      int        `json:"line"`
+	Hits      int        `json:"hits"`
+	LastHitAt *time.Time `json:"last_hit_at,omitempty"`
+}
+
+// RuleAudit represents the rules applied to a feed, with their hit counts and the recently blocked entries.
+type RuleAudit struct {
+	Hits           []*RuleHit      `json:"hits"`
+	BlockedEntries []*BlockedEntry `json:"blocked_entries"`
+}
@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import (
+	"fmt"
+	"time"
+)
+
+// Actions executed on the stored entries blocked by the rules.
+const (
+	RuleJobActionMarkRead = "mark_read"
+	RuleJobActionRemove   = "remove"
+)
+
+// Rule job statuses.
+const (
+	RuleJobStatusPending   = "pending"
+	RuleJobStatusRunning   = "running"
+	RuleJobStatusCompleted = "completed"
+	RuleJobStatusFailed    = "failed"
+)
+
+// RuleJobRetentionInterval is how long finished rule jobs are kept.
+const RuleJobRetentionInterval = 30 * 24 * time.Hour
+
+// RuleJob represents the background application of the rules to the stored unread entries.
+//
+// The entries are taken from the given feed, the given category, or the whole account.
+type RuleJob struct {
+	ID         int64      `json:"id"`
+	UserID     int64      `json:"user_id"`
+	FeedID     int64      `json:"feed_id"`
+	CategoryID int64      `json:"category_id"`
+	Action     string     `json:"action"`
+	Status     string     `json:"status"`
+	Total      int        `json:"total"`
+	Processed  int        `json:"processed"`
+	Matched    int        `json:"matched"`
+	Error      string     `json:"error,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+func (j *RuleJob) String() string {
+	return fmt.Sprintf("ID=%d, UserID=%d, FeedID=%d, CategoryID=%d, Action=%s, Status=%s", j.ID, j.UserID, j.FeedID, j.CategoryID, j.Action, j.Status)
+}
+
+// IsFinished returns true if the job is completed or failed.
+func (j *RuleJob) IsFinished() bool {
+	return j.Status == RuleJobStatusCompleted || j.Status == RuleJobStatusFailed
+}
+
+// Progress returns the percentage of processed entries.
+func (j *RuleJob) Progress() int {
+	if j.Total <= 0 {
+		if j.IsFinished() {
+			return 100
+		}
+		return 0
+	}
+	return min(j.Processed*100/j.Total, 100)
+}
+
+// RuleJobRequest represents a request to apply the rules to the stored unread entries.
+type RuleJobRequest struct {
+	FeedID     int64  `json:"feed_id"`
+	CategoryID int64  `json:"category_id"`
+	Action     string `json:"action"`
+}
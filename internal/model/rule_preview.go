@@ -0,0 +1,53 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+// Default and maximum number of stored entries evaluated by a rule preview.
+const (
+	DefaultRulePreviewLimit = 100
+	MaxRulePreviewLimit     = 1000
+)
+
+// RulePreviewRequest represents a request to evaluate candidate rules against stored entries.
+//
+// The entries are taken from the given feed, the given category, or the whole account.
+// The candidate rules are applied to the user settings, the category and the feed before the evaluation.
+type RulePreviewRequest struct {
+	FeedID     int64                        `json:"feed_id"`
+	CategoryID int64                        `json:"category_id"`
+	Limit      int                          `json:"limit"`
+	User       *UserModificationRequest     `json:"user"`
+	Category   *CategoryModificationRequest `json:"category"`
+	Feed       *FeedModificationRequest     `json:"feed"`
+}
+
+// RulePreviewEntry represents the outcome of the rules evaluation for one entry.
+type RulePreviewEntry struct {
+	EntryID int64  `json:"entry_id"`
+	FeedID  int64  `json:"feed_id"`
+	Title   string `json:"title"`
+	URL     string `json:"url"`
+	Blocked bool   `json:"blocked"`
+
+	// Rule is the rule that blocked the entry.
+	Rule *RulePreviewRule `json:"rule,omitempty"`
+
+	// MatchedRules are all the rules matching the entry.
+	MatchedRules []*RulePreviewRule `json:"matched_rules"`
+}
+
+// RulePreviewRule identifies a rule by its source, its field and its line number.
+type RulePreviewRule struct {
+	Rule   string `json:"rule"`
+	Source string `json:"source"`
+	Field  string `json:"field"`
+	Line   int    `json:"line"`
+}
+
+// RulePreview represents the result of a rule preview.
+type RulePreview struct {
+	Total   int                 `json:"total"`
+	Blocked int                 `json:"blocked"`
+	Entries []*RulePreviewEntry `json:"entries"`
+}
@@ -0,0 +1,45 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import "fmt"
+
+// SavedSearch represents a search query saved as a smart feed.
+type SavedSearch struct {
+	ID          int64  `json:"id"`
+	UserID      int64  `json:"user_id"`
+	Title       string `json:"title"`
+	Query       string `json:"query"`
+	UnreadCount *int   `json:"unread_count,omitempty"`
+}
+
+func (s *SavedSearch) String() string {
+	return fmt.Sprintf("ID=%d, UserID=%d, Title=%s, Query=%s", s.ID, s.UserID, s.Title, s.Query)
+}
+
+// SavedSearchCreationRequest represents a request to create a saved search.
+type SavedSearchCreationRequest struct {
+	Title string `json:"title"`
+	Query string `json:"query"`
+}
+
+// SavedSearchModificationRequest represents a request to modify a saved search.
+type SavedSearchModificationRequest struct {
+	Title *string `json:"title"`
+	Query *string `json:"query"`
+}
+
+// Patch applies the modification request to the given saved search.
+func (r *SavedSearchModificationRequest) Patch(savedSearch *SavedSearch) {
+	if r.Title != nil {
+		savedSearch.Title = *r.Title
+	}
+
+	if r.Query != nil {
+		savedSearch.Query = *r.Query
+	}
+}
+
+// SavedSearches represents a list of saved searches.
+type SavedSearches []*SavedSearch
@@ -0,0 +1,38 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+// ScraperPreviewRequest represents a request to scrape a web page with candidate scraper and rewrite rules.
+//
+// When a feed is given, the empty rules and fetch options fall back to the settings of its category,
+// like when the feed is refreshed. FetchViaProxyOverride replaces FetchViaProxy when given.
+type ScraperPreviewRequest struct {
+	URL                         string `json:"url"`
+	FeedID                      int64  `json:"feed_id"`
+	ScraperRules                string `json:"scraper_rules"`
+	RewriteRules                string `json:"rewrite_rules"`
+	UserAgent                   string `json:"user_agent"`
+	Cookie                      string `json:"cookie"`
+	AllowSelfSignedCertificates bool   `json:"allow_self_signed_certificates"`
+	DisableHTTP2                bool   `json:"disable_http2"`
+	FetchViaProxy               bool   `json:"fetch_via_proxy"`
+	FetchViaProxyOverride       string `json:"fetch_via_proxy_override"`
+	ProxyURL                    string `json:"proxy_url"`
+}
+
+// ScraperPreview represents the content extracted from a web page by a scraper preview.
+type ScraperPreview struct {
+	// EffectiveURL is the URL of the page after redirects.
+	EffectiveURL string `json:"effective_url"`
+
+	// Content is the extracted content, rewritten and sanitized.
+	Content     string `json:"content"`
+	ReadingTime int    `json:"reading_time"`
+
+	// Rules are the CSS selectors used to extract the content, empty when readability is used.
+	Rules string `json:"rules"`
+
+	// PredefinedRules are the CSS selectors predefined for the website, if any.
+	PredefinedRules string `json:"predefined_rules"`
+}
@@ -46,6 +46,8 @@ type User struct {
 	OpenExternalLinksInNewTab       bool       `json:"open_external_links_in_new_tab"`
 	ShowVotingButtons               bool       `json:"show_voting_buttons"`
 	ShowFeedTags                    bool       `json:"show_feed_tags"`
+	DuplicateEntriesAction          string     `json:"duplicate_entries_action"`
+	EntryRules                      string     `json:"entry_rules"`
 }
 
 // UserCreationRequest represents the request to create a user.
@@ -92,6 +94,8 @@ type UserModificationRequest struct {
 	OpenExternalLinksInNewTab       *bool    `json:"open_external_links_in_new_tab"`
 	ShowVotingButtons               *bool    `json:"show_voting_buttons"`
 	ShowFeedTags                    *bool    `json:"show_feed_tags"`
+	DuplicateEntriesAction          *string  `json:"duplicate_entries_action"`
+	EntryRules                      *string  `json:"entry_rules"`
 }
 
 // Patch updates the User object with the modification request.
@@ -227,6 +231,14 @@ func (u *UserModificationRequest) Patch(user *User) {
 	if u.ShowFeedTags != nil {
 		user.ShowFeedTags = *u.ShowFeedTags
 	}
+
+	if u.DuplicateEntriesAction != nil {
+		user.DuplicateEntriesAction = *u.DuplicateEntriesAction
+	}
+
+	if u.EntryRules != nil {
+		user.EntryRules = *u.EntryRules
+	}
 }
 
 // UseTimezone converts last login date to the given timezone.
@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package model // import "miniflux.app/v2/internal/model"
+
+import "time"
+
+// EntryStatusEventRetentionInterval is how long the status changes of the entries are kept for the reading statistics.
+// It covers the monthly statistics of the last year.
+const EntryStatusEventRetentionInterval = 400 * 24 * time.Hour
+
+// Number of periods of the reading statistics.
+const (
+	UserStatsDays   = 30
+	UserStatsWeeks  = 12
+	UserStatsMonths = 12
+)
+
+// UserStatsTopLimit is the number of feeds and categories in the rankings of the reading statistics.
+const UserStatsTopLimit = 10
+
+// UserStats contains the reading statistics of a user.
+type UserStats struct {
+	Days          []*UserStatsPeriod   `json:"days"`
+	Weeks         []*UserStatsPeriod   `json:"weeks"`
+	Months        []*UserStatsPeriod   `json:"months"`
+	TopFeeds      []*UserStatsFeed     `json:"top_feeds"`
+	TopCategories []*UserStatsCategory `json:"top_categories"`
+	Votes         UserStatsVotes       `json:"votes"`
+	UnreadAge     UserStatsUnreadAge   `json:"unread_age"`
+}
+
+// UserStatsPeriod contains the activity of a day, a week or a month.
+//
+// The reading time is estimated from the reading time of the entries marked as read one by one,
+// the entries marked as read in bulk (a whole feed, category or list) being skimmed rather than read.
+type UserStatsPeriod struct {
+	// Date is the first day of the period, in the timezone of the user.
+	Date string `json:"date"`
+
+	// Read is the number of entries marked as read, in bulk or not.
+	Read int `json:"read"`
+
+	// ReadingTime is the estimated time spent reading, in minutes.
+	ReadingTime int `json:"reading_time"`
+
+	// Unread is the number of entries marked as unread again.
+	Unread int `json:"unread"`
+
+	// NewEntries is the number of entries received during the period.
+	NewEntries int `json:"new_entries"`
+
+	// BacklogGrowth is the change of the number of unread entries during the period.
+	BacklogGrowth int `json:"backlog_growth"`
+}
+
+// UserStatsFeed contains the number of entries read one by one and starred in a feed.
+type UserStatsFeed struct {
+	FeedID        int64  `json:"feed_id"`
+	FeedTitle     string `json:"feed_title"`
+	CategoryID    int64  `json:"category_id"`
+	CategoryTitle string `json:"category_title"`
+	Read          int    `json:"read"`
+	Starred       int    `json:"starred"`
+}
+
+// UserStatsCategory contains the number of entries read one by one and starred in a category.
+type UserStatsCategory struct {
+	CategoryID    int64  `json:"category_id"`
+	CategoryTitle string `json:"category_title"`
+	Read          int    `json:"read"`
+	Starred       int    `json:"starred"`
+}
+
+// UserStatsVotes contains the number of entries by vote.
+type UserStatsVotes struct {
+	Upvoted   int `json:"upvoted"`
+	Downvoted int `json:"downvoted"`
+	NotVoted  int `json:"not_voted"`
+}
+
+// UserStatsUnreadAge contains the percentiles of the age of the unread entries, in seconds.
+type UserStatsUnreadAge struct {
+	Count int   `json:"count"`
+	P50   int64 `json:"p50"`
+	P90   int64 `json:"p90"`
+	P99   int64 `json:"p99"`
+}
@@ -22,6 +22,9 @@ import (
 type atom10Feed struct {
 	XMLName xml.Name `xml:"http://www.w3.org/2005/Atom feed"`
 
+	// The "xml:lang" attribute indicates the natural language of the element and its children.
+	Lang string `xml:"http://www.w3.org/XML/1998/namespace lang,attr"`
+
 	// The "atom:id" element conveys a permanent, universally unique
 	// identifier for an entry or feed.
 	//
@@ -85,6 +88,9 @@ type atom10Feed struct {
 }
 
 type atom10Entry struct {
+	// The "xml:lang" attribute indicates the natural language of the entry.
+	Lang string `xml:"http://www.w3.org/XML/1998/namespace lang,attr"`
+
 	// The "atom:id" element conveys a permanent, universally unique
 	// identifier for an entry or feed.
 	//
@@ -162,6 +168,7 @@ type atom10Entry struct {
 // XHTML: https://datatracker.ietf.org/doc/html/rfc4287#section-3.1.1.3
 type atom10Text struct {
 	Type             string               `xml:"type,attr"`
+	Lang             string               `xml:"http://www.w3.org/XML/1998/namespace lang,attr"`
 	CharData         string               `xml:",chardata"`
 	InnerXML         string               `xml:",innerxml"`
 	XHTMLRootElement atomXHTMLRootElement `xml:"http://www.w3.org/1999/xhtml div"`
@@ -142,6 +142,14 @@ func (a *atom10Adapter) populateEntries(siteURL string) model.Entries {
 		sort.Strings(categories)
 		entry.Tags = slices.Compact(categories)
 
+		// Populate the entry language from the most specific xml:lang attribute.
+		for _, value := range []string{atomEntry.Content.Lang, atomEntry.Summary.Lang, atomEntry.Lang, a.atomFeed.Lang} {
+			if value = strings.TrimSpace(value); value != "" {
+				entry.Language = value
+				break
+			}
+		}
+
 		// Populate the commentsURL if defined.
 		// See https://tools.ietf.org/html/rfc4685#section-4
 		// If the type attribute of the atom:link is omitted, its value is assumed to be "application/atom+xml".
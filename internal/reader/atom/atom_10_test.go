@@ -1837,3 +1837,37 @@ func TestParseFeedWithIconURL(t *testing.T) {
 		t.Errorf("Incorrect icon URL, got: %s", feed.IconURL)
 	}
 }
+
+func TestParseEntryLanguage(t *testing.T) {
+	data := `<?xml version="1.0" encoding="utf-8"?>
+	<feed xmlns="http://www.w3.org/2005/Atom" xml:lang="en-US">
+		<title>Example Feed</title>
+		<entry>
+			<title>Feed language</title>
+			<id>urn:uuid:1</id>
+			<link href="http://example.org/1"/>
+		</entry>
+		<entry xml:lang="de">
+			<title>Entry language</title>
+			<id>urn:uuid:2</id>
+			<link href="http://example.org/2"/>
+		</entry>
+		<entry xml:lang="de">
+			<title>Content language</title>
+			<id>urn:uuid:3</id>
+			<link href="http://example.org/3"/>
+			<content type="html" xml:lang="fr">Du contenu.</content>
+		</entry>
+	</feed>`
+
+	feed, err := Parse("https://example.org/", bytes.NewReader([]byte(data)), "10")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, expected := range []string{"en-US", "de", "fr"} {
+		if feed.Entries[i].Language != expected {
+			t.Errorf("Incorrect entry language, got %q instead of %q", feed.Entries[i].Language, expected)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package attachment extracts the text of the files attached to the entries, such as PDF documents and podcast transcripts.
+package attachment // import "miniflux.app/v2/internal/reader/attachment"
+
+import (
+	"errors"
+	"slices"
+	"strings"
+)
+
+// maxTextSize is the maximum size of the text extracted from a file, in bytes.
+const maxTextSize = 512 * 1024
+
+// MimeTypes is the list of the file types with a text extractor.
+var MimeTypes = []string{
+	"application/pdf",
+	"application/json",
+	"application/srt",
+	"application/x-subrip",
+	"text/html",
+	"text/plain",
+	"text/srt",
+	"text/vtt",
+}
+
+// ErrUnsupportedType is returned when the file type has no text extractor.
+var ErrUnsupportedType = errors.New("attachment: unsupported file type")
+
+// IsSupported returns true if the text of the files of this MIME type can be extracted.
+func IsSupported(mimeType string) bool {
+	return slices.Contains(MimeTypes, normalizeMimeType(mimeType))
+}
+
+// ExtractText returns the text of a file, with the whitespace collapsed.
+func ExtractText(data []byte, mimeType string) (string, error) {
+	var text string
+	var err error
+
+	switch normalizeMimeType(mimeType) {
+	case "application/pdf":
+		text, err = extractPDFText(data)
+	case "text/vtt", "text/srt", "application/srt", "application/x-subrip":
+		text = extractCaptionsText(string(data))
+	case "application/json":
+		text, err = extractJSONTranscriptText(data)
+	case "text/html":
+		text = extractHTMLText(string(data))
+	case "text/plain":
+		text = string(data)
+	default:
+		return "", ErrUnsupportedType
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	return normalizeText(text), nil
+}
+
+func normalizeMimeType(mimeType string) string {
+	mimeType, _, _ = strings.Cut(mimeType, ";")
+	return strings.ToLower(strings.TrimSpace(mimeType))
+}
+
+// normalizeText collapses the whitespace of each line, removes the empty lines and truncates the text.
+// The invalid UTF-8 sequences and the null characters are removed since PostgreSQL rejects them.
+func normalizeText(text string) string {
+	text = strings.ToValidUTF8(text, "")
+	text = strings.ReplaceAll(text, "\x00", "")
+
+	var normalized strings.Builder
+	for line := range strings.Lines(text) {
+		if line = strings.Join(strings.Fields(line), " "); line == "" {
+			continue
+		}
+
+		if normalized.Len()+len(line) >= maxTextSize {
+			break
+		}
+
+		if normalized.Len() > 0 {
+			normalized.WriteByte('\n')
+		}
+		normalized.WriteString(line)
+	}
+
+	return normalized.String()
+}
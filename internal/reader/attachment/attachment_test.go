@@ -0,0 +1,60 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package attachment // import "miniflux.app/v2/internal/reader/attachment"
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestIsSupported(t *testing.T) {
+	scenarios := map[string]bool{
+		"application/pdf":           true,
+		"Application/PDF":           true,
+		"text/vtt; charset=utf-8":   true,
+		"application/x-subrip":      true,
+		"application/json":          true,
+		"text/html":                 true,
+		"audio/mpeg":                false,
+		"image/jpeg":                false,
+		"":                          false,
+		"application/pdf-something": false,
+	}
+
+	for mimeType, expected := range scenarios {
+		if result := IsSupported(mimeType); result != expected {
+			t.Errorf(`Unexpected result for %q: got %v instead of %v`, mimeType, result, expected)
+		}
+	}
+}
+
+func TestExtractTextWithUnsupportedType(t *testing.T) {
+	if _, err := ExtractText([]byte("ID3"), "audio/mpeg"); !errors.Is(err, ErrUnsupportedType) {
+		t.Errorf(`Unexpected error: %v`, err)
+	}
+}
+
+func TestExtractTextNormalizesWhitespace(t *testing.T) {
+	text, err := ExtractText([]byte("  First   line \n\n\t\nSecond\x00 line\xff\n"), "text/plain; charset=utf-8")
+	if err != nil {
+		t.Fatalf(`Unexpected error: %v`, err)
+	}
+
+	if expected := "First line\nSecond line"; text != expected {
+		t.Errorf(`Unexpected text: got %q instead of %q`, text, expected)
+	}
+}
+
+func TestExtractTextIsTruncated(t *testing.T) {
+	line := strings.Repeat("word ", 1000)
+	text, err := ExtractText([]byte(strings.Repeat(line+"\n", 200)), "text/plain")
+	if err != nil {
+		t.Fatalf(`Unexpected error: %v`, err)
+	}
+
+	if len(text) >= maxTextSize || len(text) == 0 {
+		t.Errorf(`Unexpected text size: %d`, len(text))
+	}
+}
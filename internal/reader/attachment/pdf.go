@@ -0,0 +1,994 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package attachment // import "miniflux.app/v2/internal/reader/attachment"
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"io"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/unicode/norm"
+)
+
+// The text of a PDF document is extracted from the text operators of the page content streams.
+// The documents are not rendered: the text is decoded with the ToUnicode maps and the encodings of the fonts,
+// and the words are separated when the text position changes. It is good enough to index the documents.
+//
+// Specs: https://opensource.adobe.com/dc-acrobat-sdk-docs/pdfstandards/PDF32000_2008.pdf
+
+// maxDecodedStreamSize limits the size of a decompressed stream.
+const maxDecodedStreamSize = 64 * 1024 * 1024
+
+// maxNestingDepth limits the depth of the nested objects, page trees and form XObjects.
+const maxNestingDepth = 32
+
+var (
+	errNotPDF       = errors.New("attachment: the file is not a PDF document")
+	errEncryptedPDF = errors.New("attachment: the PDF document is encrypted")
+)
+
+var pdfObjectHeaderRegex = regexp.MustCompile(`(\d+)\s+\d+\s+obj\b`)
+
+type (
+	pdfName    string
+	pdfString  []byte
+	pdfKeyword string
+	pdfArray   []any
+	pdfDict    map[pdfName]any
+	pdfRef     struct{ number int }
+	pdfStream  struct {
+		dict pdfDict
+		data []byte
+	}
+)
+
+type pdfDocument struct {
+	objects  map[int]any
+	trailers []pdfDict
+}
+
+type pdfPage struct {
+	dict      pdfDict
+	resources pdfDict
+}
+
+// extractPDFText returns the text of the pages of a PDF document.
+func extractPDFText(data []byte) (string, error) {
+	document, err := parsePDF(data)
+	if err != nil {
+		return "", err
+	}
+
+	extractor := &pdfTextExtractor{document: document, fonts: make(map[int]*pdfFont)}
+	for _, page := range document.pages() {
+		if extractor.text.Len() >= maxTextSize {
+			break
+		}
+		extractor.extractPage(page)
+	}
+
+	// The compatibility decomposition replaces the ligatures such as "ﬁ" with the letters.
+	return norm.NFKC.String(extractor.text.String()), nil
+}
+
+func parsePDF(data []byte) (*pdfDocument, error) {
+	if !bytes.Contains(data[:min(len(data), 1024)], []byte("%PDF-")) {
+		return nil, errNotPDF
+	}
+
+	document := &pdfDocument{objects: make(map[int]any)}
+
+	// The objects are found by scanning the file instead of reading the cross-reference tables,
+	// which are often damaged. The last definition of an object wins, as with incremental updates.
+	position := 0
+	for {
+		location := pdfObjectHeaderRegex.FindSubmatchIndex(data[position:])
+		if location == nil {
+			break
+		}
+
+		number, _ := strconv.Atoi(string(data[position+location[2] : position+location[3]]))
+		lexer := &pdfLexer{data: data, position: position + location[1]}
+		value, ok := lexer.object(0)
+		if !ok {
+			break
+		}
+
+		if dict, isDict := value.(pdfDict); isDict {
+			if stream, end, found := readPDFStream(data, lexer.position, dict); found {
+				value = stream
+				lexer.position = end
+			}
+			if dict["Type"] == pdfName("XRef") {
+				document.trailers = append(document.trailers, dict)
+			}
+		}
+
+		document.objects[number] = value
+		position = min(lexer.position, len(data))
+	}
+
+	for offset := 0; ; {
+		index := bytes.Index(data[offset:], []byte("trailer"))
+		if index < 0 {
+			break
+		}
+		lexer := &pdfLexer{data: data, position: offset + index + len("trailer")}
+		if trailer, ok := lexer.object(0); ok {
+			if dict, isDict := trailer.(pdfDict); isDict {
+				document.trailers = append(document.trailers, dict)
+			}
+		}
+		offset += index + len("trailer")
+	}
+
+	for _, trailer := range document.trailers {
+		if _, found := trailer["Encrypt"]; found {
+			return nil, errEncryptedPDF
+		}
+	}
+
+	var objectStreams []*pdfStream
+	for _, object := range document.objects {
+		if stream, isStream := object.(*pdfStream); isStream && stream.dict["Type"] == pdfName("ObjStm") {
+			objectStreams = append(objectStreams, stream)
+		}
+	}
+	for _, stream := range objectStreams {
+		document.loadObjectStream(stream)
+	}
+
+	return document, nil
+}
+
+// readPDFStream returns the stream following a dictionary and the position after the stream.
+func readPDFStream(data []byte, position int, dict pdfDict) (*pdfStream, int, bool) {
+	position = min(position, len(data))
+	for position < len(data) && isPDFSpace(data[position]) {
+		position++
+	}
+	if !bytes.HasPrefix(data[position:], []byte("stream")) {
+		return nil, 0, false
+	}
+
+	start := position + len("stream")
+	if start < len(data) && data[start] == '\r' {
+		start++
+	}
+	if start < len(data) && data[start] == '\n' {
+		start++
+	}
+
+	endKeyword := []byte("endstream")
+	if length, isNumber := dict["Length"].(float64); isNumber && length >= 0 && start+int(length) <= len(data) {
+		end := start + int(length)
+		if bytes.HasPrefix(bytes.TrimLeft(data[end:], " \t\r\n"), endKeyword) {
+			return &pdfStream{dict: dict, data: data[start:end]}, end + bytes.Index(data[end:], endKeyword) + len(endKeyword), true
+		}
+	}
+
+	// The length is an indirect object or is wrong: the stream ends at the end keyword.
+	index := bytes.Index(data[start:], endKeyword)
+	if index < 0 {
+		return &pdfStream{dict: dict, data: data[start:]}, len(data), true
+	}
+
+	streamData := data[start : start+index]
+	streamData = bytes.TrimSuffix(streamData, []byte("\n"))
+	streamData = bytes.TrimSuffix(streamData, []byte("\r"))
+	return &pdfStream{dict: dict, data: streamData}, start + index + len(endKeyword), true
+}
+
+// loadObjectStream adds the objects compressed in an object stream.
+func (d *pdfDocument) loadObjectStream(stream *pdfStream) {
+	data, ok := d.decodeStream(stream)
+	if !ok {
+		return
+	}
+
+	count, _ := d.resolve(stream.dict["N"]).(float64)
+	first, _ := d.resolve(stream.dict["First"]).(float64)
+	if first < 0 || int(first) > len(data) {
+		return
+	}
+
+	header := &pdfLexer{data: data[:int(first)]}
+	for range int(count) {
+		numberToken, _ := header.next()
+		offsetToken, _ := header.next()
+		number, isNumber := numberToken.(float64)
+		offset, isOffset := offsetToken.(float64)
+		if !isNumber || !isOffset {
+			break
+		}
+
+		if _, found := d.objects[int(number)]; found {
+			continue
+		}
+
+		objectPosition := int(first) + int(offset)
+		if objectPosition < 0 || objectPosition >= len(data) {
+			continue
+		}
+
+		lexer := &pdfLexer{data: data, position: objectPosition}
+		if value, ok := lexer.object(0); ok {
+			d.objects[int(number)] = value
+		}
+	}
+}
+
+// decodeStream returns the decoded data of a stream. Only the Flate compression is supported,
+// the other filters are used for images.
+func (d *pdfDocument) decodeStream(stream *pdfStream) ([]byte, bool) {
+	var filters []any
+	switch filter := d.resolve(stream.dict["Filter"]).(type) {
+	case pdfName:
+		filters = []any{filter}
+	case pdfArray:
+		filters = filter
+	}
+
+	data := stream.data
+	for _, filter := range filters {
+		switch d.resolve(filter) {
+		case pdfName("FlateDecode"), pdfName("Fl"):
+			reader, err := zlib.NewReader(bytes.NewReader(data))
+			if err != nil {
+				return nil, false
+			}
+
+			// The data decoded before an error is kept, the streams of damaged files are often truncated.
+			decoded, err := io.ReadAll(io.LimitReader(reader, maxDecodedStreamSize))
+			if err != nil && len(decoded) == 0 {
+				return nil, false
+			}
+			data = decoded
+		default:
+			return nil, false
+		}
+	}
+
+	return data, true
+}
+
+func (d *pdfDocument) resolve(value any) any {
+	for range maxNestingDepth {
+		reference, isReference := value.(pdfRef)
+		if !isReference {
+			return value
+		}
+		value = d.objects[reference.number]
+	}
+	return nil
+}
+
+// dict returns the dictionary of an object, or the dictionary of a stream.
+func (d *pdfDocument) dict(value any) pdfDict {
+	switch object := d.resolve(value).(type) {
+	case pdfDict:
+		return object
+	case *pdfStream:
+		return object.dict
+	}
+	return nil
+}
+
+// pages returns the pages in the order of the page tree, with their inherited resources.
+func (d *pdfDocument) pages() []pdfPage {
+	var pages []pdfPage
+	for _, trailer := range slices.Backward(d.trailers) {
+		if catalog := d.dict(trailer["Root"]); catalog != nil {
+			d.walkPageTree(d.dict(catalog["Pages"]), nil, 0, &pages)
+			if len(pages) > 0 {
+				return pages
+			}
+		}
+	}
+
+	// Without a page tree, the pages are sorted by object number.
+	numbers := make([]int, 0, len(d.objects))
+	for number := range d.objects {
+		numbers = append(numbers, number)
+	}
+	slices.Sort(numbers)
+
+	for _, number := range numbers {
+		if page := d.dict(d.objects[number]); page != nil && page["Type"] == pdfName("Page") {
+			pages = append(pages, pdfPage{dict: page, resources: d.dict(page["Resources"])})
+		}
+	}
+	return pages
+}
+
+func (d *pdfDocument) walkPageTree(node, resources pdfDict, depth int, pages *[]pdfPage) {
+	if node == nil || depth > maxNestingDepth {
+		return
+	}
+
+	if nodeResources := d.dict(node["Resources"]); nodeResources != nil {
+		resources = nodeResources
+	}
+
+	kids, isTree := d.resolve(node["Kids"]).(pdfArray)
+	if !isTree {
+		*pages = append(*pages, pdfPage{dict: node, resources: resources})
+		return
+	}
+
+	for _, kid := range kids {
+		d.walkPageTree(d.dict(kid), resources, depth+1, pages)
+	}
+}
+
+type pdfTextExtractor struct {
+	document *pdfDocument
+	fonts    map[int]*pdfFont
+	text     strings.Builder
+}
+
+func (e *pdfTextExtractor) extractPage(page pdfPage) {
+	var streams []any
+	switch contents := e.document.resolve(page.dict["Contents"]).(type) {
+	case *pdfStream:
+		streams = []any{contents}
+	case pdfArray:
+		streams = contents
+	}
+
+	// The page content can be split anywhere between the streams.
+	var content []byte
+	for _, value := range streams {
+		if stream, isStream := e.document.resolve(value).(*pdfStream); isStream {
+			if data, ok := e.document.decodeStream(stream); ok {
+				content = append(content, data...)
+				content = append(content, '\n')
+			}
+		}
+	}
+
+	e.interpret(content, page.resources, 0)
+	e.separate('\n')
+}
+
+// interpret writes the text shown by the operators of a content stream.
+func (e *pdfTextExtractor) interpret(content []byte, resources pdfDict, depth int) {
+	lexer := &pdfLexer{data: content}
+	font := defaultPDFFont
+	var operands []any
+
+	for e.text.Len() < maxTextSize {
+		token, ok := lexer.object(0)
+		if !ok {
+			return
+		}
+
+		operator, isOperator := token.(pdfKeyword)
+		if !isOperator {
+			operands = append(operands, token)
+			continue
+		}
+
+		switch operator {
+		case "Tf":
+			if len(operands) == 2 {
+				if name, isName := operands[0].(pdfName); isName {
+					font = e.font(resources, name)
+				}
+			}
+		case "Tj":
+			e.show(font, operands)
+		case "'", "\"":
+			e.separate('\n')
+			e.show(font, operands)
+		case "TJ":
+			if len(operands) > 0 {
+				items, _ := operands[len(operands)-1].(pdfArray)
+				for _, item := range items {
+					switch value := item.(type) {
+					case pdfString:
+						e.text.WriteString(font.decode(value))
+					case float64:
+						// A large negative offset, in thousandths of a text space unit, is a word space.
+						if value < -250 {
+							e.separate(' ')
+						}
+					}
+				}
+			}
+		case "Td", "TD", "Tm", "T*":
+			e.separate(' ')
+		case "ET":
+			e.separate('\n')
+		case "Do":
+			if len(operands) == 1 && depth < maxNestingDepth {
+				if name, isName := operands[0].(pdfName); isName {
+					e.interpretForm(resources, name, depth)
+				}
+			}
+		case "BI":
+			lexer.skipInlineImage()
+		}
+
+		operands = operands[:0]
+	}
+}
+
+// interpretForm writes the text of a form XObject, a content stream drawn by the page.
+func (e *pdfTextExtractor) interpretForm(resources pdfDict, name pdfName, depth int) {
+	xobjects := e.document.dict(resources["XObject"])
+	stream, isStream := e.document.resolve(xobjects[name]).(*pdfStream)
+	if !isStream || stream.dict["Subtype"] != pdfName("Form") {
+		return
+	}
+
+	data, ok := e.document.decodeStream(stream)
+	if !ok {
+		return
+	}
+
+	formResources := e.document.dict(stream.dict["Resources"])
+	if formResources == nil {
+		formResources = resources
+	}
+	e.interpret(data, formResources, depth+1)
+}
+
+func (e *pdfTextExtractor) show(font *pdfFont, operands []any) {
+	if len(operands) > 0 {
+		if value, isString := operands[len(operands)-1].(pdfString); isString {
+			e.text.WriteString(font.decode(value))
+		}
+	}
+}
+
+// separate adds a separator unless the text already ends with a space.
+func (e *pdfTextExtractor) separate(separator byte) {
+	text := e.text.String()
+	if text != "" && !strings.HasSuffix(text, " ") && !strings.HasSuffix(text, "\n") {
+		e.text.WriteByte(separator)
+	}
+}
+
+func (e *pdfTextExtractor) font(resources pdfDict, name pdfName) *pdfFont {
+	reference := e.document.dict(resources["Font"])[name]
+	if ref, isRef := reference.(pdfRef); isRef {
+		if font, found := e.fonts[ref.number]; found {
+			return font
+		}
+		font := e.document.newFont(e.document.dict(reference))
+		e.fonts[ref.number] = font
+		return font
+	}
+	return e.document.newFont(e.document.dict(reference))
+}
+
+// pdfFont decodes the character codes of the strings shown with a font.
+type pdfFont struct {
+	// codeLength is the number of bytes of a character code: one for simple fonts,
+	// usually two for composite fonts.
+	codeLength  int
+	toUnicode   map[uint32]string
+	differences map[byte]rune
+	charmap     *charmap.Charmap
+}
+
+var defaultPDFFont = &pdfFont{codeLength: 1, charmap: charmap.Windows1252}
+
+func (d *pdfDocument) newFont(dict pdfDict) *pdfFont {
+	font := &pdfFont{codeLength: 1, charmap: charmap.Windows1252}
+	if dict == nil {
+		return font
+	}
+
+	// Composite fonts have no single-byte encoding, their text can be extracted with the ToUnicode map only.
+	if dict["Subtype"] == pdfName("Type0") {
+		font.codeLength = 2
+		font.charmap = nil
+	}
+
+	switch encoding := d.resolve(dict["Encoding"]).(type) {
+	case pdfName:
+		if encoding == "MacRomanEncoding" {
+			font.charmap = charmap.Macintosh
+		}
+	case pdfDict:
+		if encoding["BaseEncoding"] == pdfName("MacRomanEncoding") {
+			font.charmap = charmap.Macintosh
+		}
+
+		differences, _ := d.resolve(encoding["Differences"]).(pdfArray)
+		code := 0
+		for _, item := range differences {
+			switch value := item.(type) {
+			case float64:
+				code = int(value)
+			case pdfName:
+				if r := glyphRune(string(value)); r != 0 && code >= 0 && code <= 255 {
+					if font.differences == nil {
+						font.differences = make(map[byte]rune)
+					}
+					font.differences[byte(code)] = r
+				}
+				code++
+			}
+		}
+	}
+
+	if stream, isStream := d.resolve(dict["ToUnicode"]).(*pdfStream); isStream {
+		if data, ok := d.decodeStream(stream); ok {
+			var codeLength int
+			font.toUnicode, codeLength = parseCMap(data)
+			if codeLength > 0 {
+				font.codeLength = codeLength
+			}
+		}
+	}
+
+	return font
+}
+
+func (f *pdfFont) decode(value pdfString) string {
+	var text strings.Builder
+	for i := 0; i+f.codeLength <= len(value); i += f.codeLength {
+		var code uint32
+		for _, b := range value[i : i+f.codeLength] {
+			code = code<<8 | uint32(b)
+		}
+
+		if unicodeText, found := f.toUnicode[code]; found {
+			text.WriteString(unicodeText)
+			continue
+		}
+
+		if f.codeLength == 1 {
+			if r, found := f.differences[byte(code)]; found {
+				text.WriteRune(r)
+			} else if f.charmap != nil && code >= 0x20 {
+				text.WriteRune(f.charmap.DecodeByte(byte(code)))
+			}
+		}
+	}
+	return text.String()
+}
+
+// parseCMap returns the mapping of a ToUnicode CMap and the length of its character codes.
+func parseCMap(data []byte) (map[uint32]string, int) {
+	mapping := make(map[uint32]string)
+	codeLength := 0
+	lexer := &pdfLexer{data: data}
+	var operands []any
+
+	for {
+		token, ok := lexer.object(0)
+		if !ok {
+			return mapping, codeLength
+		}
+
+		keyword, isKeyword := token.(pdfKeyword)
+		if !isKeyword {
+			operands = append(operands, token)
+			continue
+		}
+
+		switch keyword {
+		case "endcodespacerange":
+			if low, isString := firstOperand(operands).(pdfString); isString && codeLength == 0 && len(low) <= 4 {
+				codeLength = len(low)
+			}
+		case "endbfchar":
+			for i := 0; i+1 < len(operands); i += 2 {
+				source, isSource := operands[i].(pdfString)
+				destination, isDestination := operands[i+1].(pdfString)
+				if isSource && isDestination {
+					mapping[characterCode(source)] = decodeUTF16(destination)
+				}
+			}
+		case "endbfrange":
+			for i := 0; i+2 < len(operands); i += 3 {
+				low, isLow := operands[i].(pdfString)
+				high, isHigh := operands[i+1].(pdfString)
+				if !isLow || !isHigh {
+					continue
+				}
+
+				first, last := characterCode(low), characterCode(high)
+				if last < first || last-first > 0xFFFF {
+					continue
+				}
+
+				switch destination := operands[i+2].(type) {
+				case pdfString:
+					// The last character of the destination is incremented for each code of the range.
+					runes := []rune(decodeUTF16(destination))
+					if len(runes) == 0 {
+						continue
+					}
+					for offset := range last - first + 1 {
+						runes[len(runes)-1] += rune(min(offset, 1))
+						mapping[first+offset] = string(runes)
+					}
+				case pdfArray:
+					for offset, item := range destination {
+						if value, isString := item.(pdfString); isString && uint32(offset) <= last-first {
+							mapping[first+uint32(offset)] = decodeUTF16(value)
+						}
+					}
+				}
+			}
+		}
+
+		operands = operands[:0]
+	}
+}
+
+func firstOperand(operands []any) any {
+	if len(operands) == 0 {
+		return nil
+	}
+	return operands[0]
+}
+
+func characterCode(value pdfString) uint32 {
+	var code uint32
+	for _, b := range value {
+		code = code<<8 | uint32(b)
+	}
+	return code
+}
+
+func decodeUTF16(value pdfString) string {
+	units := make([]uint16, 0, len(value)/2)
+	for i := 0; i+1 < len(value); i += 2 {
+		units = append(units, uint16(value[i])<<8|uint16(value[i+1]))
+	}
+	return string(utf16.Decode(units))
+}
+
+// glyphNames contains the glyph names of the Adobe Glyph List used by the font encodings, other than the letters.
+var glyphNames = map[string]rune{
+	"space": ' ', "exclam": '!', "quotedbl": '"', "numbersign": '#', "dollar": '$', "percent": '%',
+	"ampersand": '&', "quotesingle": '\'', "parenleft": '(', "parenright": ')', "asterisk": '*', "plus": '+',
+	"comma": ',', "hyphen": '-', "period": '.', "slash": '/', "colon": ':', "semicolon": ';', "less": '<',
+	"equal": '=', "greater": '>', "question": '?', "at": '@', "bracketleft": '[', "backslash": '\\',
+	"bracketright": ']', "underscore": '_', "braceleft": '{', "bar": '|', "braceright": '}',
+	"zero": '0', "one": '1', "two": '2', "three": '3', "four": '4', "five": '5', "six": '6', "seven": '7', "eight": '8', "nine": '9',
+	"quoteleft": '‘', "quoteright": '’', "quotedblleft": '“', "quotedblright": '”', "quotesinglbase": '‚', "quotedblbase": '„',
+	"guillemotleft": '«', "guillemotright": '»', "endash": '–', "emdash": '—', "bullet": '•', "ellipsis": '…',
+	"minus": '−', "degree": '°', "section": '§', "paragraph": '¶', "copyright": '©', "registered": '®', "trademark": '™',
+	"fi": 'ﬁ', "fl": 'ﬂ', "ff": 'ﬀ', "ffi": 'ﬃ', "ffl": 'ﬄ', "germandbls": 'ß', "dotlessi": 'ı',
+	"ae": 'æ', "AE": 'Æ', "oe": 'œ', "OE": 'Œ', "oslash": 'ø', "Oslash": 'Ø', "eth": 'ð', "thorn": 'þ',
+}
+
+// glyphAccents contains the suffixes of the accented letter names, such as "eacute", and their combining marks.
+var glyphAccents = map[string]rune{
+	"acute": '́', "grave": '̀', "circumflex": '̂', "dieresis": '̈',
+	"tilde": '̃', "cedilla": '̧', "ring": '̊', "caron": '̌',
+}
+
+// glyphRune returns the character of a glyph name, or 0 if the name is unknown.
+func glyphRune(name string) rune {
+	if r, found := glyphNames[name]; found {
+		return r
+	}
+
+	if len(name) == 1 && isASCIILetter(name[0]) {
+		return rune(name[0])
+	}
+
+	if hex, found := strings.CutPrefix(name, "uni"); found && len(hex) == 4 {
+		if code, err := strconv.ParseUint(hex, 16, 16); err == nil {
+			return rune(code)
+		}
+	}
+
+	for suffix, mark := range glyphAccents {
+		if base, found := strings.CutSuffix(name, suffix); found && len(base) == 1 && isASCIILetter(base[0]) {
+			composed := norm.NFC.String(base + string(mark))
+			if r, size := utf8.DecodeRuneInString(composed); size == len(composed) {
+				return r
+			}
+		}
+	}
+
+	return 0
+}
+
+func isASCIILetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// pdfLexer reads the objects of a PDF file or of a content stream.
+type pdfLexer struct {
+	data     []byte
+	position int
+}
+
+func isPDFSpace(c byte) bool {
+	return c == ' ' || c == '\n' || c == '\r' || c == '\t' || c == '\f' || c == 0
+}
+
+func isPDFDelimiter(c byte) bool {
+	return strings.IndexByte("()<>[]{}/%", c) >= 0
+}
+
+func (l *pdfLexer) skipSpaces() {
+	for l.position < len(l.data) {
+		switch c := l.data[l.position]; {
+		case c == '%':
+			for l.position < len(l.data) && l.data[l.position] != '\n' && l.data[l.position] != '\r' {
+				l.position++
+			}
+		case isPDFSpace(c):
+			l.position++
+		default:
+			return
+		}
+	}
+}
+
+// next returns the next token: a number, a name, a string, or a keyword for the operators and the delimiters.
+func (l *pdfLexer) next() (any, bool) {
+	l.skipSpaces()
+	if l.position >= len(l.data) {
+		return nil, false
+	}
+
+	switch c := l.data[l.position]; c {
+	case '/':
+		l.position++
+		return pdfName(decodePDFName(l.regularCharacters())), true
+	case '(':
+		return l.literalString(), true
+	case '<':
+		if bytes.HasPrefix(l.remaining(), []byte("<<")) {
+			l.position += 2
+			return pdfKeyword("<<"), true
+		}
+		return l.hexString(), true
+	case '>':
+		if bytes.HasPrefix(l.remaining(), []byte(">>")) {
+			l.position += 2
+			return pdfKeyword(">>"), true
+		}
+		l.position++
+		return pdfKeyword(">"), true
+	case '[', ']', '{', '}', ')':
+		l.position++
+		return pdfKeyword(c), true
+	}
+
+	word := l.regularCharacters()
+	if number, err := strconv.ParseFloat(string(word), 64); err == nil {
+		return number, true
+	}
+	return pdfKeyword(word), true
+}
+
+// remaining returns the data after the current position, the position may be past the end of truncated data.
+func (l *pdfLexer) remaining() []byte {
+	return l.data[min(l.position, len(l.data)):]
+}
+
+func (l *pdfLexer) regularCharacters() []byte {
+	start := l.position
+	for l.position < len(l.data) && !isPDFSpace(l.data[l.position]) && !isPDFDelimiter(l.data[l.position]) {
+		l.position++
+	}
+	return l.data[start:l.position]
+}
+
+// object returns the next object, arrays and dictionaries included, or the next keyword.
+func (l *pdfLexer) object(depth int) (any, bool) {
+	if depth > maxNestingDepth {
+		return nil, false
+	}
+
+	token, ok := l.next()
+	if !ok {
+		return nil, false
+	}
+
+	switch value := token.(type) {
+	case float64:
+		// An indirect reference such as "12 0 R".
+		position := l.position
+		if generation, ok := l.next(); ok {
+			if _, isNumber := generation.(float64); isNumber {
+				if keyword, ok := l.next(); ok && keyword == pdfKeyword("R") {
+					return pdfRef{number: int(value)}, true
+				}
+			}
+		}
+		l.position = position
+		return value, true
+	case pdfKeyword:
+		switch value {
+		case "[":
+			var array pdfArray
+			for {
+				l.skipSpaces()
+				if l.position < len(l.data) && l.data[l.position] == ']' {
+					l.position++
+					return array, true
+				}
+				item, ok := l.object(depth + 1)
+				if !ok {
+					return array, false
+				}
+				array = append(array, item)
+			}
+		case "<<":
+			dict := make(pdfDict)
+			for {
+				l.skipSpaces()
+				if bytes.HasPrefix(l.remaining(), []byte(">>")) {
+					l.position += 2
+					return dict, true
+				}
+				key, ok := l.object(depth + 1)
+				if !ok {
+					return dict, false
+				}
+				if name, isName := key.(pdfName); isName {
+					if dict[name], ok = l.object(depth + 1); !ok {
+						return dict, false
+					}
+				}
+			}
+		case "true":
+			return true, true
+		case "false":
+			return false, true
+		case "null":
+			return nil, true
+		}
+	}
+
+	return token, true
+}
+
+func (l *pdfLexer) literalString() pdfString {
+	l.position++
+	var value []byte
+	depth := 1
+
+	for l.position < len(l.data) {
+		c := l.data[l.position]
+		l.position++
+
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return value
+			}
+		case '\\':
+			if l.position >= len(l.data) {
+				return value
+			}
+			c = l.data[l.position]
+			l.position++
+
+			switch c {
+			case 'n':
+				c = '\n'
+			case 'r':
+				c = '\r'
+			case 't':
+				c = '\t'
+			case 'b':
+				c = '\b'
+			case 'f':
+				c = '\f'
+			case '\r', '\n':
+				// A backslash at the end of a line continues the string on the next line.
+				if c == '\r' && l.position < len(l.data) && l.data[l.position] == '\n' {
+					l.position++
+				}
+				continue
+			case '0', '1', '2', '3', '4', '5', '6', '7':
+				code := int(c - '0')
+				for range 2 {
+					if l.position < len(l.data) && l.data[l.position] >= '0' && l.data[l.position] <= '7' {
+						code = code*8 + int(l.data[l.position]-'0')
+						l.position++
+					}
+				}
+				c = byte(code)
+			}
+		}
+
+		value = append(value, c)
+	}
+
+	return value
+}
+
+func (l *pdfLexer) hexString() pdfString {
+	l.position++
+	var digits []byte
+	for l.position < len(l.data) && l.data[l.position] != '>' {
+		if c := l.data[l.position]; isHexDigit(c) {
+			digits = append(digits, c)
+		}
+		l.position++
+	}
+
+	// The closing delimiter is missing when the data is truncated.
+	if l.position < len(l.data) {
+		l.position++
+	}
+
+	if len(digits)%2 == 1 {
+		digits = append(digits, '0')
+	}
+
+	value := make(pdfString, len(digits)/2)
+	for i := range value {
+		code, _ := strconv.ParseUint(string(digits[2*i:2*i+2]), 16, 8)
+		value[i] = byte(code)
+	}
+	return value
+}
+
+// skipInlineImage skips the data of an inline image, which ends with the EI operator.
+func (l *pdfLexer) skipInlineImage() {
+	for {
+		token, ok := l.next()
+		if !ok {
+			return
+		}
+		if token == pdfKeyword("ID") {
+			break
+		}
+	}
+
+	for l.position < len(l.data) {
+		index := bytes.Index(l.data[l.position:], []byte("EI"))
+		if index < 0 {
+			l.position = len(l.data)
+			return
+		}
+
+		start := l.position + index
+		end := start + len("EI")
+		l.position = end
+		if start > 0 && isPDFSpace(l.data[start-1]) && (end == len(l.data) || isPDFSpace(l.data[end])) {
+			return
+		}
+	}
+}
+
+func isHexDigit(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
+
+func decodePDFName(name []byte) string {
+	if !bytes.Contains(name, []byte("#")) {
+		return string(name)
+	}
+
+	var decoded []byte
+	for i := 0; i < len(name); i++ {
+		if name[i] == '#' && i+2 < len(name) && isHexDigit(name[i+1]) && isHexDigit(name[i+2]) {
+			code, _ := strconv.ParseUint(string(name[i+1:i+3]), 16, 8)
+			decoded = append(decoded, byte(code))
+			i += 2
+			continue
+		}
+		decoded = append(decoded, name[i])
+	}
+	return string(decoded)
+}
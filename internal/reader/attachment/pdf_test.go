@@ -0,0 +1,235 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package attachment // import "miniflux.app/v2/internal/reader/attachment"
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// buildPDF returns a PDF document with the objects, numbered from 1, and a trailer referencing the catalog.
+// The streams are given as a dictionary and a content separated by "stream\n" and are compressed.
+func buildPDF(objects ...string) []byte {
+	var document bytes.Buffer
+	document.WriteString("%PDF-1.7\n%\xe2\xe3\xcf\xd3\n")
+
+	for i, object := range objects {
+		fmt.Fprintf(&document, "%d 0 obj\n", i+1)
+		if dict, content, isStream := strings.Cut(object, "stream\n"); isStream {
+			var compressed bytes.Buffer
+			writer := zlib.NewWriter(&compressed)
+			writer.Write([]byte(content))
+			writer.Close()
+
+			dict = strings.TrimSuffix(strings.TrimSpace(dict), ">>")
+			fmt.Fprintf(&document, "%s /Filter /FlateDecode /Length %d >>\nstream\n", dict, compressed.Len())
+			document.Write(compressed.Bytes())
+			document.WriteString("\nendstream")
+		} else {
+			document.WriteString(object)
+		}
+		document.WriteString("\nendobj\n")
+	}
+
+	fmt.Fprintf(&document, "trailer\n<< /Size %d /Root 1 0 R >>\n%%%%EOF\n", len(objects)+1)
+	return document.Bytes()
+}
+
+func TestExtractPDFText(t *testing.T) {
+	document := buildPDF(
+		`<< /Type /Catalog /Pages 2 0 R >>`,
+		`<< /Type /Pages /Kids [3 0 R 4 0 R] /Count 2 /Resources << /Font << /F1 5 0 R >> >> >>`,
+		`<< /Type /Page /Parent 2 0 R /Contents 6 0 R >>`,
+		`<< /Type /Page /Parent 2 0 R /Contents [7 0 R 8 0 R] >>`,
+		`<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>`,
+		"<< >>stream\nBT /F1 12 Tf 72 720 Td (Attention Is All You Need) Tj ET\nBT 72 700 Td [(The domi) 20 (nant) -300 (models) ] TJ T* (caf\\351 \\(draft\\)) ' ET",
+		"<< >>stream\nBT /F1 12 Tf 72 720 Td (Second ",
+		"<< >>stream\npage) Tj ET",
+	)
+
+	text, err := ExtractText(document, "application/pdf")
+	if err != nil {
+		t.Fatalf(`Unexpected error: %v`, err)
+	}
+
+	expected := "Attention Is All You Need\nThe dominant models café (draft)\nSecond page"
+	if text != expected {
+		t.Errorf(`Unexpected text: got %q instead of %q`, text, expected)
+	}
+}
+
+func TestExtractPDFTextWithToUnicodeMap(t *testing.T) {
+	cmap := `/CIDInit /ProcSet findresource begin
+12 dict begin
+begincmap
+1 begincodespacerange
+<0000> <FFFF>
+endcodespacerange
+2 beginbfchar
+<0001> <0048>
+<0002> <00E9>
+endbfchar
+1 beginbfrange
+<0003> <0005> <006C>
+endbfrange
+endcmap`
+
+	document := buildPDF(
+		`<< /Type /Catalog /Pages 2 0 R >>`,
+		`<< /Type /Pages /Kids [3 0 R] /Count 1 >>`,
+		`<< /Type /Page /Parent 2 0 R /Contents 4 0 R /Resources << /Font << /F1 5 0 R >> >> >>`,
+		"<< >>stream\nBT /F1 10 Tf <000100020003000300050001> Tj ET",
+		`<< /Type /Font /Subtype /Type0 /BaseFont /ABCDEF+Font /Encoding /Identity-H /ToUnicode 6 0 R >>`,
+		"<< >>stream\n"+cmap,
+	)
+
+	text, err := ExtractText(document, "application/pdf")
+	if err != nil {
+		t.Fatalf(`Unexpected error: %v`, err)
+	}
+
+	if expected := "HéllnH"; text != expected {
+		t.Errorf(`Unexpected text: got %q instead of %q`, text, expected)
+	}
+}
+
+func TestExtractPDFTextWithDifferences(t *testing.T) {
+	document := buildPDF(
+		`<< /Type /Catalog /Pages 2 0 R >>`,
+		`<< /Type /Pages /Kids [3 0 R] /Count 1 >>`,
+		`<< /Type /Page /Parent 2 0 R /Contents 4 0 R /Resources << /Font << /F1 5 0 R >> /XObject << /X1 6 0 R >> >> >>`,
+		"<< >>stream\nq /X1 Do Q BI /W 2 /H 2 /BPC 8 ID \x00\x01EI\xff EI Q BT /F1 10 Tf (\x01\x02\x03) Tj ET",
+		`<< /Type /Font /Subtype /Type1 /Encoding << /Differences [1 /eacute /fi /uni00E0] >> >>`,
+		"<< /Type /XObject /Subtype /Form /Resources << /Font << /F2 7 0 R >> >> >>stream\nBT /F2 10 Tf (Form text) Tj ET",
+		`<< /Type /Font /Subtype /Type1 /Encoding /MacRomanEncoding >>`,
+	)
+
+	text, err := ExtractText(document, "application/pdf")
+	if err != nil {
+		t.Fatalf(`Unexpected error: %v`, err)
+	}
+
+	if expected := "Form text\néfià"; text != expected {
+		t.Errorf(`Unexpected text: got %q instead of %q`, text, expected)
+	}
+}
+
+func TestExtractPDFTextFromObjectStream(t *testing.T) {
+	page := `<< /Type /Page /Parent 2 0 R /Contents 4 0 R /Resources 6 0 R >>`
+	resources := `<< /Font << /F1 << /Type /Font /Subtype /Type1 >> >> >>`
+	header := fmt.Sprintf("5 0 6 %d ", len(page)+1)
+
+	document := buildPDF(
+		`<< /Type /Catalog /Pages 2 0 R >>`,
+		`<< /Type /Pages /Kids [5 0 R] /Count 1 >>`,
+		fmt.Sprintf("<< /Type /ObjStm /N 2 /First %d >>stream\n%s%s %s", len(header), header, page, resources),
+		"<< >>stream\nBT /F1 10 Tf (Compressed objects) Tj ET",
+	)
+
+	text, err := ExtractText(document, "application/pdf")
+	if err != nil {
+		t.Fatalf(`Unexpected error: %v`, err)
+	}
+
+	if expected := "Compressed objects"; text != expected {
+		t.Errorf(`Unexpected text: got %q instead of %q`, text, expected)
+	}
+}
+
+func TestExtractPDFTextWithoutPageTree(t *testing.T) {
+	document := []byte("%PDF-1.4\n" +
+		"1 0 obj << /Type /Page /Contents 2 0 R >> endobj\n" +
+		"2 0 obj << /Length 99 >> stream\nBT (Damaged file) Tj ET\nendstream endobj\n")
+
+	text, err := ExtractText(document, "application/pdf")
+	if err != nil {
+		t.Fatalf(`Unexpected error: %v`, err)
+	}
+
+	if expected := "Damaged file"; text != expected {
+		t.Errorf(`Unexpected text: got %q instead of %q`, text, expected)
+	}
+}
+
+func TestExtractPDFTextWithInvalidDocuments(t *testing.T) {
+	if _, err := ExtractText([]byte("<html>not a PDF</html>"), "application/pdf"); !errors.Is(err, errNotPDF) {
+		t.Errorf(`Unexpected error: %v`, err)
+	}
+
+	encrypted := []byte("%PDF-1.4\n1 0 obj << /Type /Catalog >> endobj\ntrailer << /Root 1 0 R /Encrypt 2 0 R >>\n")
+	if _, err := ExtractText(encrypted, "application/pdf"); !errors.Is(err, errEncryptedPDF) {
+		t.Errorf(`Unexpected error: %v`, err)
+	}
+}
+
+func TestExtractPDFTextWithTruncatedDocuments(t *testing.T) {
+	inputs := []string{
+		"%PDF-1.4\n1 0 obj <4142",
+		"%PDF-1.4\n1 0 obj << /A <41",
+		"%PDF-1.4\n1 0 obj << /A [<41",
+		"%PDF-1.4\ntrailer << /Root <41",
+		"%PDF-1.4\n1 0 obj << /Length 10 >> stream",
+	}
+
+	document := buildPDF(
+		`<< /Type /Catalog /Pages 2 0 R >>`,
+		`<< /Type /Pages /Kids [3 0 R] /Count 1 /Resources << /Font << /F1 4 0 R >> >> >>`,
+		`<< /Type /Page /Parent 2 0 R /Contents 5 0 R /Annots [<< /Contents <48656C6C6F> >>] >>`,
+		`<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>`,
+		"<< >>stream\nBT /F1 12 Tf 72 720 Td <48656C6C6F> Tj ET",
+	)
+	for length := range len(document) {
+		inputs = append(inputs, string(document[:length]))
+	}
+
+	for _, input := range inputs {
+		// The truncated documents must not panic, the text is not relevant.
+		ExtractText([]byte(input), "application/pdf")
+	}
+}
+
+func FuzzExtractPDFText(f *testing.F) {
+	f.Add([]byte("%PDF-1.4\n1 0 obj << /Type /Catalog /Pages 2 0 R >> endobj\ntrailer << /Root 1 0 R >>\n"))
+	f.Add([]byte("%PDF-1.4\n1 0 obj <4142"))
+	f.Add([]byte("%PDF-1.4\n1 0 obj << /A <41"))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		ExtractText(data, "application/pdf")
+	})
+}
+
+func TestPDFLexer(t *testing.T) {
+	lexer := &pdfLexer{data: []byte(`<< /Name#20With#20Spaces (nested (parens) \101\n) /Array [1 -2.5 3 0 R <48656C6C6F>] /Bool true >> % comment`)}
+
+	value, ok := lexer.object(0)
+	if !ok {
+		t.Fatal(`Unable to read the object`)
+	}
+
+	dict, isDict := value.(pdfDict)
+	if !isDict {
+		t.Fatalf(`Unexpected object: %#v`, value)
+	}
+
+	if name := dict["Name With Spaces"]; !bytes.Equal(name.(pdfString), []byte("nested (parens) A\n")) {
+		t.Errorf(`Unexpected string: %q`, name)
+	}
+
+	array := dict["Array"].(pdfArray)
+	if len(array) != 4 || array[0] != 1.0 || array[1] != -2.5 || array[2] != (pdfRef{number: 3}) || string(array[3].(pdfString)) != "Hello" {
+		t.Errorf(`Unexpected array: %#v`, array)
+	}
+
+	if dict["Bool"] != true {
+		t.Errorf(`Unexpected boolean: %#v`, dict["Bool"])
+	}
+
+	if _, ok := lexer.object(0); ok {
+		t.Error(`The comment should be skipped`)
+	}
+}
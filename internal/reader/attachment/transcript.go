@@ -0,0 +1,122 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package attachment // import "miniflux.app/v2/internal/reader/attachment"
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"miniflux.app/v2/internal/reader/sanitizer"
+
+	"golang.org/x/net/html"
+)
+
+// The podcast transcripts are published as WebVTT or SubRip captions, as JSON or as HTML.
+//
+// Specs:
+// - https://github.com/Podcastindex-org/podcast-namespace/blob/main/docs/examples/transcripts/transcripts.md
+// - https://www.w3.org/TR/webvtt1/
+
+type jsonTranscript struct {
+	Segments []struct {
+		Body string `json:"body"`
+	} `json:"segments"`
+}
+
+// extractCaptionsText returns the text of the cues of WebVTT and SubRip captions, without the timings.
+func extractCaptionsText(captions string) string {
+	captions = strings.ReplaceAll(captions, "\r\n", "\n")
+	captions = strings.TrimPrefix(captions, "\uFEFF")
+
+	var lines []string
+	for block := range strings.SplitSeq(captions, "\n\n") {
+		blockLines := strings.Split(strings.TrimSpace(block), "\n")
+
+		// The header and the comment, style and region blocks are not cues.
+		if firstLine := blockLines[0]; strings.HasPrefix(firstLine, "WEBVTT") || strings.HasPrefix(firstLine, "NOTE") ||
+			strings.HasPrefix(firstLine, "STYLE") || strings.HasPrefix(firstLine, "REGION") {
+			continue
+		}
+
+		// The lines before the timing line are the cue identifier.
+		for i, line := range blockLines {
+			if strings.Contains(line, "-->") {
+				blockLines = blockLines[i+1:]
+				break
+			}
+		}
+
+		for _, line := range blockLines {
+			line = strings.TrimSpace(sanitizer.StripTags(line))
+
+			// The captions often repeat the previous line while the next one appears.
+			if line != "" && (len(lines) == 0 || lines[len(lines)-1] != line) {
+				lines = append(lines, line)
+			}
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// extractJSONTranscriptText returns the text of the segments of a JSON transcript.
+func extractJSONTranscriptText(data []byte) (string, error) {
+	var transcript jsonTranscript
+	if err := json.Unmarshal(data, &transcript); err != nil {
+		return "", fmt.Errorf("attachment: unable to parse the JSON transcript: %w", err)
+	}
+
+	if len(transcript.Segments) == 0 {
+		return "", errors.New("attachment: the JSON transcript has no segments")
+	}
+
+	bodies := make([]string, 0, len(transcript.Segments))
+	for _, segment := range transcript.Segments {
+		bodies = append(bodies, segment.Body)
+	}
+
+	// The segments are usually a few words each, they are joined as sentences.
+	return strings.Join(bodies, " "), nil
+}
+
+// extractHTMLText returns the text of an HTML document, without the scripts and the styles.
+func extractHTMLText(document string) string {
+	tokenizer := html.NewTokenizer(strings.NewReader(document))
+	var text strings.Builder
+	skippedDepth := 0
+
+	for {
+		switch tokenType := tokenizer.Next(); tokenType {
+		case html.ErrorToken:
+			return text.String()
+		case html.TextToken:
+			if skippedDepth == 0 {
+				text.Write(tokenizer.Text())
+			}
+		case html.StartTagToken, html.EndTagToken, html.SelfClosingTagToken:
+			name, _ := tokenizer.TagName()
+			switch {
+			case isSkippedHTMLElement(string(name)) && tokenType == html.StartTagToken:
+				skippedDepth++
+			case isSkippedHTMLElement(string(name)) && tokenType == html.EndTagToken && skippedDepth > 0:
+				skippedDepth--
+			case blockHTMLElements[string(name)]:
+				// The lines are kept apart at the boundaries of the blocks.
+				text.WriteByte('\n')
+			}
+		}
+	}
+}
+
+var blockHTMLElements = map[string]bool{
+	"article": true, "blockquote": true, "br": true, "dd": true, "div": true, "dt": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"li": true, "p": true, "section": true, "td": true, "tr": true,
+}
+
+func isSkippedHTMLElement(name string) bool {
+	return name == "script" || name == "style" || name == "head"
+}
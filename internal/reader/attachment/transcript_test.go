@@ -0,0 +1,60 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package attachment // import "miniflux.app/v2/internal/reader/attachment"
+
+import "testing"
+
+func TestExtractWebVTTText(t *testing.T) {
+	captions := "\uFEFFWEBVTT - Episode 42\r\n\r\n" +
+		"NOTE This comment is ignored\r\n\r\n" +
+		"STYLE\r\n::cue { color: yellow }\r\n\r\n" +
+		"intro\r\n00:00:00.000 --> 00:00:02.500\r\n<v Alice>Welcome to the show.</v>\r\n\r\n" +
+		"00:00:02.500 --> 00:00:04.000 align:start\r\nWelcome to the show.\r\nToday we talk about <i>compilers</i> &amp; linkers.\r\n"
+
+	expected := "Welcome to the show.\nToday we talk about compilers & linkers."
+	if result := extractCaptionsText(captions); result != expected {
+		t.Errorf(`Unexpected text: got %q instead of %q`, result, expected)
+	}
+}
+
+func TestExtractSubRipText(t *testing.T) {
+	captions := "1\n00:00:01,000 --> 00:00:02,000\nFirst line\n\n2\n00:00:02,000 --> 00:00:03,000\nSecond line\non two lines\n"
+
+	expected := "First line\nSecond line\non two lines"
+	if result := extractCaptionsText(captions); result != expected {
+		t.Errorf(`Unexpected text: got %q instead of %q`, result, expected)
+	}
+}
+
+func TestExtractJSONTranscriptText(t *testing.T) {
+	data := []byte(`{"version": "1.0.0", "segments": [{"speaker": "Alice", "startTime": 0.5, "body": "Hello"}, {"startTime": 1, "body": "world."}]}`)
+
+	result, err := extractJSONTranscriptText(data)
+	if err != nil {
+		t.Fatalf(`Unexpected error: %v`, err)
+	}
+
+	if expected := "Hello world."; result != expected {
+		t.Errorf(`Unexpected text: got %q instead of %q`, result, expected)
+	}
+}
+
+func TestExtractJSONTranscriptTextWithoutSegments(t *testing.T) {
+	for _, data := range []string{`{}`, `{"segments": []}`, `not json`} {
+		if _, err := extractJSONTranscriptText([]byte(data)); err == nil {
+			t.Errorf(`An error was expected for %q`, data)
+		}
+	}
+}
+
+func TestExtractHTMLText(t *testing.T) {
+	document := `<html><head><title>Transcript</title><style>p { color: red }</style></head>
+<body><script>alert(1)</script><p>Alice: the <b>first</b> answer.</p><p>Bob: the second&nbsp;one.<br>Thanks</p></body></html>`
+
+	result := normalizeText(extractHTMLText(document))
+	expected := "Alice: the first answer.\nBob: the second one.\nThanks"
+	if result != expected {
+		t.Errorf(`Unexpected text: got %q instead of %q`, result, expected)
+	}
+}
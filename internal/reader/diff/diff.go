@@ -0,0 +1,157 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package diff compares two revisions of an entry at the paragraph level.
+package diff // import "miniflux.app/v2/internal/reader/diff"
+
+import (
+	"io"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// maxParagraphs bounds the size of the LCS table for pathological documents.
+const maxParagraphs = 2000
+
+// Operation describes how a paragraph changed between two revisions.
+type Operation int
+
+const (
+	Equal Operation = iota
+	Insert
+	Delete
+)
+
+// String returns the name of the operation, suitable for CSS class names.
+func (o Operation) String() string {
+	switch o {
+	case Insert:
+		return "insert"
+	case Delete:
+		return "delete"
+	default:
+		return "equal"
+	}
+}
+
+// Line is a paragraph of text with the operation that produced it.
+type Line struct {
+	Operation Operation
+	Text      string
+}
+
+// Paragraphs converts an HTML fragment to a list of plain text paragraphs.
+// Block-level elements and line breaks start a new paragraph, whitespace is collapsed and empty paragraphs are dropped.
+func Paragraphs(input string) []string {
+	var paragraphs []string
+	var buffer strings.Builder
+
+	flush := func() {
+		if text := strings.Join(strings.Fields(buffer.String()), " "); text != "" {
+			paragraphs = append(paragraphs, text)
+		}
+		buffer.Reset()
+	}
+
+	tokenizer := html.NewTokenizer(strings.NewReader(input))
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			if tokenizer.Err() != io.EOF {
+				return nil
+			}
+			flush()
+			if len(paragraphs) > maxParagraphs {
+				paragraphs = paragraphs[:maxParagraphs]
+			}
+			return paragraphs
+		case html.TextToken:
+			buffer.Write(tokenizer.Text())
+		case html.StartTagToken, html.EndTagToken, html.SelfClosingTagToken:
+			name, _ := tokenizer.TagName()
+			if isBlockElement(atom.Lookup(name)) {
+				flush()
+			}
+		}
+	}
+}
+
+// Compute returns the paragraph-level differences between two HTML fragments.
+func Compute(oldHTML, newHTML string) []Line {
+	return computeLines(Paragraphs(oldHTML), Paragraphs(newHTML))
+}
+
+// ChangeRatio returns the proportion of text that differs between two HTML fragments, from 0 (identical) to 1 (completely rewritten).
+// The ratio is weighted by paragraph length so that fixing a typo in a long article is not considered a substantial change.
+func ChangeRatio(oldHTML, newHTML string) float64 {
+	var changed, total int
+	for _, line := range Compute(oldHTML, newHTML) {
+		size := len(line.Text)
+		if line.Operation == Equal {
+			total += 2 * size
+		} else {
+			total += size
+			changed += size
+		}
+	}
+
+	if total == 0 {
+		return 0
+	}
+	return float64(changed) / float64(total)
+}
+
+func computeLines(a, b []string) []Line {
+	// lengths[i][j] is the length of the longest common subsequence of a[i:] and b[j:].
+	lengths := make([][]int, len(a)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else {
+				lengths[i][j] = max(lengths[i+1][j], lengths[i][j+1])
+			}
+		}
+	}
+
+	lines := make([]Line, 0, max(len(a), len(b)))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			lines = append(lines, Line{Operation: Equal, Text: a[i]})
+			i++
+			j++
+		case lengths[i+1][j] >= lengths[i][j+1]:
+			lines = append(lines, Line{Operation: Delete, Text: a[i]})
+			i++
+		default:
+			lines = append(lines, Line{Operation: Insert, Text: b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		lines = append(lines, Line{Operation: Delete, Text: a[i]})
+	}
+	for ; j < len(b); j++ {
+		lines = append(lines, Line{Operation: Insert, Text: b[j]})
+	}
+
+	return lines
+}
+
+func isBlockElement(tag atom.Atom) bool {
+	switch tag {
+	case atom.Address, atom.Article, atom.Aside, atom.Blockquote, atom.Br, atom.Dd, atom.Div,
+		atom.Dl, atom.Dt, atom.Figcaption, atom.Figure, atom.Footer, atom.H1, atom.H2, atom.H3,
+		atom.H4, atom.H5, atom.H6, atom.Header, atom.Hr, atom.Li, atom.Main, atom.Nav, atom.Ol,
+		atom.P, atom.Pre, atom.Section, atom.Table, atom.Td, atom.Th, atom.Tr, atom.Ul:
+		return true
+	}
+	return false
+}
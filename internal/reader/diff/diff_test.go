@@ -0,0 +1,77 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package diff // import "miniflux.app/v2/internal/reader/diff"
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParagraphs(t *testing.T) {
+	input := `<p>First   paragraph</p><div>Second <b>bold</b> &amp; text<br>Third</div><ul><li>Item</li></ul><p>  </p>`
+	expected := []string{"First paragraph", "Second bold & text", "Third", "Item"}
+
+	if result := Paragraphs(input); !slices.Equal(result, expected) {
+		t.Errorf(`Unexpected paragraphs, got %q instead of %q`, result, expected)
+	}
+}
+
+func TestParagraphsWithPlainText(t *testing.T) {
+	if result := Paragraphs("Some plain text"); !slices.Equal(result, []string{"Some plain text"}) {
+		t.Errorf(`Unexpected paragraphs, got %q`, result)
+	}
+
+	if result := Paragraphs(""); len(result) != 0 {
+		t.Errorf(`Expected no paragraphs, got %q`, result)
+	}
+}
+
+func TestCompute(t *testing.T) {
+	oldHTML := `<p>A</p><p>B</p><p>C</p>`
+	newHTML := `<p>A</p><p>X</p><p>C</p><p>D</p>`
+
+	expected := []Line{
+		{Operation: Equal, Text: "A"},
+		{Operation: Delete, Text: "B"},
+		{Operation: Insert, Text: "X"},
+		{Operation: Equal, Text: "C"},
+		{Operation: Insert, Text: "D"},
+	}
+
+	if result := Compute(oldHTML, newHTML); !slices.Equal(result, expected) {
+		t.Errorf(`Unexpected diff, got %v instead of %v`, result, expected)
+	}
+}
+
+func TestComputeIgnoresMarkupChanges(t *testing.T) {
+	for _, line := range Compute(`<p>Some <em>text</em></p>`, `<div>Some text</div>`) {
+		if line.Operation != Equal {
+			t.Fatalf(`Expected only equal lines, got %v`, line)
+		}
+	}
+}
+
+func TestChangeRatio(t *testing.T) {
+	scenarios := []struct {
+		oldHTML, newHTML string
+		expected         float64
+	}{
+		{`<p>Same</p>`, `<p>Same</p>`, 0},
+		{``, ``, 0},
+		{`<p>Old</p>`, `<p>New</p>`, 1},
+		{`<p>Kept</p><p>Gone</p>`, `<p>Kept</p>`, 1.0 / 3},
+	}
+
+	for _, scenario := range scenarios {
+		if result := ChangeRatio(scenario.oldHTML, scenario.newHTML); result != scenario.expected {
+			t.Errorf(`Unexpected ratio for %q -> %q: got %v instead of %v`, scenario.oldHTML, scenario.newHTML, result, scenario.expected)
+		}
+	}
+}
+
+func TestOperationString(t *testing.T) {
+	if Equal.String() != "equal" || Insert.String() != "insert" || Delete.String() != "delete" {
+		t.Error(`Unexpected operation names`)
+	}
+}
@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package enrichment // import "miniflux.app/v2/internal/reader/enrichment"
+
+import (
+	"sync"
+	"time"
+)
+
+var breakers = &breakerRegistry{breakers: make(map[string]*circuitBreaker)}
+
+type breakerRegistry struct {
+	mu       sync.Mutex
+	breakers map[string]*circuitBreaker
+}
+
+func (r *breakerRegistry) get(name string) *circuitBreaker {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	breaker, found := r.breakers[name]
+	if !found {
+		breaker = &circuitBreaker{}
+		r.breakers[name] = breaker
+	}
+	return breaker
+}
+
+// circuitBreaker stops calling a processor after consecutive failures.
+// Once the cooldown period is over, a single trial request is allowed, the others are skipped until it ends:
+// the breaker opens again immediately if it fails, and closes if it succeeds.
+type circuitBreaker struct {
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+	probing   bool
+}
+
+func (b *circuitBreaker) allow(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.openUntil.IsZero() {
+		return true
+	}
+
+	if now.Before(b.openUntil) || b.probing {
+		return false
+	}
+
+	b.probing = true
+	return true
+}
+
+func (b *circuitBreaker) recordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures = 0
+	b.openUntil = time.Time{}
+	b.probing = false
+}
+
+// recordFailure returns true when the failure opens the breaker.
+func (b *circuitBreaker) recordFailure(now time.Time, threshold int, cooldown time.Duration) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+	b.failures++
+	if b.failures < threshold {
+		return false
+	}
+
+	b.openUntil = now.Add(cooldown)
+	return true
+}
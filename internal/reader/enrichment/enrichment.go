@@ -0,0 +1,228 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package enrichment // import "miniflux.app/v2/internal/reader/enrichment"
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/http/client"
+	"miniflux.app/v2/internal/metric"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/version"
+)
+
+// Request is the document sent to the enrichment processors.
+type Request struct {
+	Processor string       `json:"processor"`
+	Feed      RequestFeed  `json:"feed"`
+	Entry     RequestEntry `json:"entry"`
+}
+
+type RequestFeed struct {
+	ID       int64  `json:"id"`
+	Title    string `json:"title"`
+	FeedURL  string `json:"feed_url"`
+	SiteURL  string `json:"site_url"`
+	Category string `json:"category"`
+}
+
+type RequestEntry struct {
+	Hash        string    `json:"hash"`
+	URL         string    `json:"url"`
+	CommentsURL string    `json:"comments_url"`
+	Title       string    `json:"title"`
+	Author      string    `json:"author"`
+	Content     string    `json:"content"`
+	Summary     string    `json:"summary"`
+	Language    string    `json:"language"`
+	Tags        []string  `json:"tags"`
+	Score       int64     `json:"score"`
+	PublishedAt time.Time `json:"published_at"`
+}
+
+// Response contains the fields modified by an enrichment processor.
+// The fields omitted from the response are left unchanged.
+type Response struct {
+	Content  *string   `json:"content"`
+	Summary  *string   `json:"summary"`
+	Language *string   `json:"language"`
+	Tags     *[]string `json:"tags"`
+	Score    *int64    `json:"score"`
+}
+
+// EnrichEntry sends the entry to the given processors, in order, and applies their modifications.
+// The processors that fail are skipped. It returns true when the content of the entry has been modified.
+func EnrichEntry(processorNames []string, feed *model.Feed, entry *model.Entry) bool {
+	processors := config.Opts.EnrichmentProcessors()
+	contentModified := false
+
+	for _, name := range processorNames {
+		endpoint, found := processors[name]
+		if !found {
+			slog.Debug("Enrichment processor not defined",
+				slog.String("processor", name),
+				slog.Int64("feed_id", feed.ID),
+			)
+			continue
+		}
+
+		breaker := breakers.get(name)
+		if !breaker.allow(time.Now()) {
+			if config.Opts.HasMetricsCollector() {
+				metric.EnrichmentSkippedRequests.WithLabelValues(name).Inc()
+			}
+			continue
+		}
+
+		startTime := time.Now()
+		response, err := sendRequest(name, endpoint, feed, entry)
+
+		if config.Opts.HasMetricsCollector() {
+			status := metric.StatusSuccess
+			if err != nil {
+				status = metric.StatusError
+			}
+			metric.EnrichmentRequestDuration.WithLabelValues(name, status).Observe(time.Since(startTime).Seconds())
+		}
+
+		if err != nil {
+			slog.Warn("Unable to enrich entry",
+				slog.String("processor", name),
+				slog.Int64("user_id", feed.UserID),
+				slog.String("entry_url", entry.URL),
+				slog.Int64("feed_id", feed.ID),
+				slog.String("feed_url", feed.FeedURL),
+				slog.Any("error", err),
+			)
+
+			if breaker.recordFailure(time.Now(), config.Opts.EnrichmentProcessorFailureThreshold(), config.Opts.EnrichmentProcessorCooldown()) {
+				slog.Warn("Enrichment processor disabled temporarily after consecutive failures",
+					slog.String("processor", name),
+					slog.Duration("cooldown", config.Opts.EnrichmentProcessorCooldown()),
+				)
+			}
+			continue
+		}
+
+		breaker.recordSuccess()
+
+		if response != nil && applyResponse(entry, response) {
+			contentModified = true
+		}
+	}
+
+	return contentModified
+}
+
+func sendRequest(name, endpoint string, feed *model.Feed, entry *model.Entry) (*Response, error) {
+	payload := &Request{
+		Processor: name,
+		Feed: RequestFeed{
+			ID:      feed.ID,
+			Title:   feed.Title,
+			FeedURL: feed.FeedURL,
+			SiteURL: feed.SiteURL,
+		},
+		Entry: RequestEntry{
+			Hash:        entry.Hash,
+			URL:         entry.URL,
+			CommentsURL: entry.CommentsURL,
+			Title:       entry.Title,
+			Author:      entry.Author,
+			Content:     entry.Content,
+			Summary:     entry.Summary,
+			Language:    entry.Language,
+			Tags:        entry.Tags,
+			Score:       entry.Score,
+			PublishedAt: entry.Date,
+		},
+	}
+
+	if feed.Category != nil {
+		payload.Feed.Category = feed.Category.Title
+	}
+
+	requestBody, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("enrichment: unable to encode request body: %v", err)
+	}
+
+	request, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(requestBody))
+	if err != nil {
+		return nil, fmt.Errorf("enrichment: unable to create request: %v", err)
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Accept", "application/json")
+	request.Header.Set("User-Agent", "Miniflux/"+version.Version)
+
+	// The processors are defined by the administrator and usually run on the same host or network.
+	httpClient := client.NewClientWithOptions(client.Options{Timeout: config.Opts.EnrichmentProcessorTimeout()})
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("enrichment: unable to send request: %v", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode == http.StatusNoContent {
+		return nil, nil
+	}
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return nil, fmt.Errorf("enrichment: incorrect response status code %d", response.StatusCode)
+	}
+
+	var enrichmentResponse Response
+	body := io.LimitReader(response.Body, config.Opts.HTTPClientMaxBodySize())
+	if err := json.NewDecoder(body).Decode(&enrichmentResponse); err != nil {
+		return nil, fmt.Errorf("enrichment: unable to decode response: %v", err)
+	}
+
+	return &enrichmentResponse, nil
+}
+
+// applyResponse modifies the entry with the fields of the response.
+// It returns true when the content has been modified.
+func applyResponse(entry *model.Entry, response *Response) bool {
+	contentModified := false
+
+	if response.Content != nil && *response.Content != entry.Content {
+		entry.Content = *response.Content
+		contentModified = true
+	}
+
+	if response.Summary != nil {
+		entry.Summary = strings.TrimSpace(*response.Summary)
+		entry.SummaryUpdated = true
+	}
+
+	if response.Language != nil {
+		entry.Language = strings.ToLower(strings.TrimSpace(*response.Language))
+	}
+
+	if response.Tags != nil {
+		tags := make([]string, 0, len(*response.Tags))
+		for _, tag := range *response.Tags {
+			if tag = strings.TrimSpace(tag); tag != "" && !slices.Contains(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+		entry.Tags = tags
+	}
+
+	if response.Score != nil {
+		entry.Score = *response.Score
+	}
+
+	return contentModified
+}
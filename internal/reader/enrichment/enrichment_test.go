@@ -0,0 +1,135 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package enrichment // import "miniflux.app/v2/internal/reader/enrichment"
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/model"
+)
+
+func configureProcessors(t *testing.T, processors string) {
+	t.Helper()
+
+	os.Clearenv()
+	os.Setenv("ENRICHMENT_PROCESSORS", processors)
+	os.Setenv("ENRICHMENT_PROCESSOR_FAILURE_THRESHOLD", "2")
+
+	var err error
+	config.Opts, err = config.NewConfigParser().ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Config parsing failure: %v`, err)
+	}
+
+	breakers = &breakerRegistry{breakers: make(map[string]*circuitBreaker)}
+}
+
+func TestEnrichEntry(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request Request
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Errorf(`Unable to decode request: %v`, err)
+		}
+
+		switch r.URL.Path {
+		case "/summarize":
+			if request.Processor != "summarizer" || request.Feed.Category != "News" || request.Entry.Title != "Title" {
+				t.Errorf(`Unexpected request: %+v`, request)
+			}
+			w.Write([]byte(`{"summary": " A summary. ", "content": "<p>New content</p>"}`))
+		case "/classify":
+			// The modifications of the previous processors are visible.
+			if request.Entry.Summary != "A summary." {
+				t.Errorf(`Unexpected summary: %q`, request.Entry.Summary)
+			}
+			w.Write([]byte(`{"tags": ["go", " ", "go", "news"], "score": 42, "language": "EN"}`))
+		case "/nothing":
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	configureProcessors(t, "summarizer="+server.URL+"/summarize,classifier="+server.URL+"/classify,nothing="+server.URL+"/nothing")
+
+	feed := &model.Feed{ID: 1, Category: &model.Category{Title: "News"}}
+	entry := &model.Entry{Title: "Title", Content: "<p>Content</p>", Tags: []string{"original"}}
+
+	if !EnrichEntry([]string{"summarizer", "classifier", "nothing", "undefined"}, feed, entry) {
+		t.Error(`The content should be modified`)
+	}
+
+	if entry.Content != "<p>New content</p>" || entry.Summary != "A summary." || entry.Language != "en" || entry.Score != 42 {
+		t.Errorf(`Unexpected entry: %+v`, entry)
+	}
+
+	if !slices.Equal(entry.Tags, []string{"go", "news"}) {
+		t.Errorf(`Unexpected tags: %v`, entry.Tags)
+	}
+
+	if EnrichEntry([]string{"nothing"}, feed, entry) {
+		t.Error(`The content should not be modified`)
+	}
+}
+
+func TestEnrichEntryCircuitBreaker(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	configureProcessors(t, "broken="+server.URL)
+
+	feed := &model.Feed{ID: 1}
+	for range 4 {
+		entry := &model.Entry{Content: "content"}
+		if EnrichEntry([]string{"broken"}, feed, entry) || entry.Content != "content" {
+			t.Fatal(`The entry should not be modified`)
+		}
+	}
+
+	if count := requests.Load(); count != 2 {
+		t.Errorf(`The breaker should open after 2 failures, got %d requests`, count)
+	}
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	breaker := &circuitBreaker{}
+	now := time.Now()
+
+	if breaker.recordFailure(now, 2, time.Minute) || !breaker.allow(now) {
+		t.Fatal(`The breaker should stay closed after the first failure`)
+	}
+
+	if !breaker.recordFailure(now, 2, time.Minute) || breaker.allow(now) {
+		t.Fatal(`The breaker should open after the second failure`)
+	}
+
+	later := now.Add(time.Minute)
+	if !breaker.allow(later) {
+		t.Fatal(`The breaker should allow a request after the cooldown`)
+	}
+
+	if breaker.allow(later) {
+		t.Fatal(`The breaker should allow a single request until the trial request ends`)
+	}
+
+	if !breaker.recordFailure(later, 2, time.Minute) || breaker.allow(later) {
+		t.Fatal(`The breaker should open again when the trial request fails`)
+	}
+
+	breaker.recordSuccess()
+	if !breaker.allow(later) || breaker.recordFailure(later, 2, time.Minute) {
+		t.Fatal(`The breaker should be closed after a success`)
+	}
+}
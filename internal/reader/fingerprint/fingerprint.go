@@ -0,0 +1,86 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package fingerprint computes SimHash fingerprints of entries to detect near-duplicates.
+//
+// Two entries are near-duplicates when the Hamming distance between their fingerprints is at most MaxDistance.
+// The 64-bit fingerprint is split into four 16-bit bands: two near-duplicates always share at least one band,
+// which allows the database to narrow down the candidates before the exact distance is computed.
+package fingerprint // import "miniflux.app/v2/internal/reader/fingerprint"
+
+import (
+	"hash/fnv"
+	"math/bits"
+	"strings"
+	"unicode"
+
+	"miniflux.app/v2/internal/reader/sanitizer"
+)
+
+const (
+	// MaxDistance is the maximum number of differing bits between two near-duplicate fingerprints.
+	MaxDistance = 3
+
+	// BandCount is the number of 16-bit bands in a fingerprint.
+	BandCount = 4
+
+	shingleSize = 3
+)
+
+// Compute returns the SimHash fingerprint of an entry title and HTML content.
+// It returns 0 when there is no text to fingerprint.
+func Compute(title, content string) uint64 {
+	words := normalize(title + " " + sanitizer.StripTags(content))
+	if len(words) == 0 {
+		return 0
+	}
+
+	size := min(shingleSize, len(words))
+	var weights [64]int
+	for i := 0; i+size <= len(words); i++ {
+		hasher := fnv.New64a()
+		hasher.Write([]byte(strings.Join(words[i:i+size], " ")))
+		sum := hasher.Sum64()
+
+		for bit := range weights {
+			if sum&(1<<bit) != 0 {
+				weights[bit]++
+			} else {
+				weights[bit]--
+			}
+		}
+	}
+
+	var fingerprint uint64
+	for bit, weight := range weights {
+		if weight > 0 {
+			fingerprint |= 1 << bit
+		}
+	}
+	return fingerprint
+}
+
+// Distance returns the number of differing bits between two fingerprints.
+func Distance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+// IsNearDuplicate returns true if both fingerprints are set and close enough to be considered the same story.
+func IsNearDuplicate(a, b uint64) bool {
+	return a != 0 && b != 0 && Distance(a, b) <= MaxDistance
+}
+
+// Bands splits a fingerprint into 16-bit bands, the lowest bits first.
+func Bands(fingerprint uint64) [BandCount]int64 {
+	var bands [BandCount]int64
+	for i := range bands {
+		bands[i] = int64((fingerprint >> (16 * i)) & 0xFFFF)
+	}
+	return bands
+}
+
+func normalize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
@@ -0,0 +1,85 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package fingerprint // import "miniflux.app/v2/internal/reader/fingerprint"
+
+import (
+	"testing"
+)
+
+const article = `<p>The city council approved on Tuesday a new plan to expand the network of protected bike lanes
+across downtown, adding more than forty kilometers of separated paths over the next three years.</p>
+<p>Officials said the project will be funded by a mix of federal grants and local taxes, and that construction
+should start in the spring once the final designs are published for public comment.</p>`
+
+func TestComputeIsStable(t *testing.T) {
+	if Compute("Title", article) != Compute("Title", article) {
+		t.Fatal(`Expected the same fingerprint for the same input`)
+	}
+}
+
+func TestComputeWithoutText(t *testing.T) {
+	if result := Compute("", "<p> </p>"); result != 0 {
+		t.Errorf(`Expected an empty fingerprint, got %d`, result)
+	}
+}
+
+func TestComputeIgnoresMarkupAndCase(t *testing.T) {
+	a := Compute("Bike lanes", article)
+	b := Compute("BIKE LANES!", `<div class="syndicated">`+article+`</div>`)
+
+	if !IsNearDuplicate(a, b) {
+		t.Errorf(`Expected near-duplicates, got a distance of %d`, Distance(a, b))
+	}
+}
+
+func TestComputeDetectsSmallEdits(t *testing.T) {
+	a := Compute("City expands bike lanes", article)
+	b := Compute("City expands bike lanes", article+`<p>Reporting by Jane Doe.</p>`)
+
+	if Distance(a, b) > 10 {
+		t.Errorf(`Expected close fingerprints, got a distance of %d`, Distance(a, b))
+	}
+}
+
+func TestComputeDistinguishesDifferentStories(t *testing.T) {
+	a := Compute("City expands bike lanes", article)
+	b := Compute("Local team wins championship", `<p>The home team won the final game of the season on Sunday night
+after a dramatic overtime goal, securing the first title for the club in more than two decades.</p>`)
+
+	if IsNearDuplicate(a, b) {
+		t.Errorf(`Expected different fingerprints, got a distance of %d`, Distance(a, b))
+	}
+}
+
+func TestIsNearDuplicate(t *testing.T) {
+	scenarios := []struct {
+		a, b     uint64
+		expected bool
+	}{
+		{0, 0, false},
+		{0b1010, 0, false},
+		{0b1010, 0b1010, true},
+		{0b1010, 0b0101, false},
+		{0b1111, 0b1000, true},
+	}
+
+	for _, scenario := range scenarios {
+		if result := IsNearDuplicate(scenario.a, scenario.b); result != scenario.expected {
+			t.Errorf(`Unexpected result for %b and %b: got %v`, scenario.a, scenario.b, result)
+		}
+	}
+}
+
+func TestBands(t *testing.T) {
+	bands := Bands(0x0004000300020001)
+	expected := [BandCount]int64{1, 2, 3, 4}
+	if bands != expected {
+		t.Errorf(`Unexpected bands: got %v instead of %v`, bands, expected)
+	}
+
+	bands = Bands(0xFFFF000000000000)
+	if bands[3] != 0xFFFF {
+		t.Errorf(`Expected the highest band to be unsigned, got %d`, bands[3])
+	}
+}
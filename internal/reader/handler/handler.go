@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"errors"
 	"log/slog"
+	"slices"
 	"time"
 
 	"miniflux.app/v2/internal/config"
@@ -16,6 +17,7 @@ import (
 	"miniflux.app/v2/internal/proxyrotator"
 	"miniflux.app/v2/internal/reader/fetcher"
 	"miniflux.app/v2/internal/reader/icon"
+	"miniflux.app/v2/internal/reader/mirror"
 	"miniflux.app/v2/internal/reader/parser"
 	"miniflux.app/v2/internal/reader/processor"
 	"miniflux.app/v2/internal/storage"
@@ -44,15 +46,40 @@ func CreateFeedFromSubscriptionDiscovery(store *storage.Storage, userID int64, f
 		slog.String("proxy_url", feedCreationRequest.ProxyURL),
 	)
 
-	if !store.CategoryIDExists(userID, feedCreationRequest.CategoryID) {
+	category, storeErr := store.Category(userID, feedCreationRequest.CategoryID)
+	if storeErr != nil {
+		return nil, locale.NewLocalizedErrorWrapper(storeErr, "error.database_error", storeErr)
+	}
+
+	if category == nil {
 		return nil, locale.NewLocalizedErrorWrapper(ErrCategoryNotFound, "error.category_not_found")
 	}
 
+	// The fetch settings of the category are used when the request does not override them.
+	fetchSettings := &model.Feed{
+		UserAgent:             feedCreationRequest.UserAgent,
+		FetchViaProxyOverride: model.RequestedFeedSettingOverride(feedCreationRequest.FetchViaProxyOverride, feedCreationRequest.FetchViaProxy),
+		ProxyURL:              feedCreationRequest.ProxyURL,
+		Category:              category,
+	}
+
 	if store.FeedURLExists(userID, feedCreationRequest.FeedURL) {
 		return nil, locale.NewLocalizedErrorWrapper(ErrDuplicatedFeed, "error.duplicated_feed")
 	}
 
-	subscription, parseErr := parser.ParseFeed(feedCreationRequest.FeedURL, feedCreationRequest.Content)
+	requestBuilder := fetcher.NewRequestBuilder()
+	requestBuilder.WithUsernameAndPassword(feedCreationRequest.Username, feedCreationRequest.Password)
+	requestBuilder.WithUserAgent(fetchSettings.EffectiveUserAgent(), config.Opts.HTTPClientUserAgent())
+	requestBuilder.WithCookie(feedCreationRequest.Cookie)
+	requestBuilder.WithTimeout(config.Opts.HTTPClientTimeout())
+	requestBuilder.WithProxyRotator(proxyrotator.ProxyRotatorInstance)
+	requestBuilder.WithCustomFeedProxyURL(fetchSettings.EffectiveProxyURL())
+	requestBuilder.WithCustomApplicationProxyURL(config.Opts.HTTPClientProxyURL())
+	requestBuilder.UseCustomApplicationProxyURL(fetchSettings.EffectiveFetchViaProxy())
+	requestBuilder.IgnoreTLSErrors(feedCreationRequest.AllowSelfSignedCertificates)
+	requestBuilder.DisableHTTP2(feedCreationRequest.DisableHTTP2)
+
+	subscription, parseErr := parser.ParseFeedWithRequestBuilder(requestBuilder, feedCreationRequest.FeedURL, feedCreationRequest.Content)
 	if parseErr != nil {
 		return nil, locale.NewLocalizedErrorWrapper(parseErr, "error.unable_to_parse_feed", parseErr)
 	}
@@ -62,12 +89,12 @@ func CreateFeedFromSubscriptionDiscovery(store *storage.Storage, userID int64, f
 	subscription.Cookie = feedCreationRequest.Cookie
 	subscription.Username = feedCreationRequest.Username
 	subscription.Password = feedCreationRequest.Password
-	subscription.Crawler = feedCreationRequest.Crawler
+	subscription.CrawlerOverride = model.RequestedFeedSettingOverride(feedCreationRequest.CrawlerOverride, feedCreationRequest.Crawler)
 	subscription.IgnoreEntryUpdates = feedCreationRequest.IgnoreEntryUpdates
 	subscription.Disabled = feedCreationRequest.Disabled
 	subscription.IgnoreHTTPCache = feedCreationRequest.IgnoreHTTPCache
 	subscription.AllowSelfSignedCertificates = feedCreationRequest.AllowSelfSignedCertificates
-	subscription.FetchViaProxy = feedCreationRequest.FetchViaProxy
+	subscription.FetchViaProxyOverride = fetchSettings.FetchViaProxyOverride
 	subscription.ScraperRules = feedCreationRequest.ScraperRules
 	subscription.RewriteRules = feedCreationRequest.RewriteRules
 	subscription.BlocklistRules = feedCreationRequest.BlocklistRules
@@ -79,7 +106,8 @@ func CreateFeedFromSubscriptionDiscovery(store *storage.Storage, userID int64, f
 	subscription.LastModifiedHeader = feedCreationRequest.LastModified
 	subscription.FeedURL = feedCreationRequest.FeedURL
 	subscription.DisableHTTP2 = feedCreationRequest.DisableHTTP2
-	subscription.WithCategoryID(feedCreationRequest.CategoryID)
+	subscription.Category = category
+	subscription.ResolveInheritedSettings()
 	subscription.ProxyURL = feedCreationRequest.ProxyURL
 	subscription.CheckedNow()
 
@@ -89,24 +117,15 @@ func CreateFeedFromSubscriptionDiscovery(store *storage.Storage, userID int64, f
 		return nil, locale.NewLocalizedErrorWrapper(storeErr, "error.database_error", storeErr)
 	}
 
+	sendNewEntriesToIntegrations(store, userID, subscription.Entries)
+	processor.QueueEntrySummaries(store, subscription, subscription.Entries)
+
 	slog.Debug("Created feed",
 		slog.Int64("user_id", userID),
 		slog.Int64("feed_id", subscription.ID),
 		slog.String("feed_url", subscription.FeedURL),
 	)
 
-	requestBuilder := fetcher.NewRequestBuilder()
-	requestBuilder.WithUsernameAndPassword(feedCreationRequest.Username, feedCreationRequest.Password)
-	requestBuilder.WithUserAgent(feedCreationRequest.UserAgent, config.Opts.HTTPClientUserAgent())
-	requestBuilder.WithCookie(feedCreationRequest.Cookie)
-	requestBuilder.WithTimeout(config.Opts.HTTPClientTimeout())
-	requestBuilder.WithProxyRotator(proxyrotator.ProxyRotatorInstance)
-	requestBuilder.WithCustomFeedProxyURL(feedCreationRequest.ProxyURL)
-	requestBuilder.WithCustomApplicationProxyURL(config.Opts.HTTPClientProxyURL())
-	requestBuilder.UseCustomApplicationProxyURL(feedCreationRequest.FetchViaProxy)
-	requestBuilder.IgnoreTLSErrors(feedCreationRequest.AllowSelfSignedCertificates)
-	requestBuilder.DisableHTTP2(feedCreationRequest.DisableHTTP2)
-
 	icon.NewIconChecker(store, subscription).UpdateOrCreateFeedIcon()
 
 	return subscription, nil
@@ -120,19 +139,32 @@ func CreateFeed(store *storage.Storage, userID int64, feedCreationRequest *model
 		slog.String("proxy_url", feedCreationRequest.ProxyURL),
 	)
 
-	if !store.CategoryIDExists(userID, feedCreationRequest.CategoryID) {
+	category, storeErr := store.Category(userID, feedCreationRequest.CategoryID)
+	if storeErr != nil {
+		return nil, locale.NewLocalizedErrorWrapper(storeErr, "error.database_error", storeErr)
+	}
+
+	if category == nil {
 		return nil, locale.NewLocalizedErrorWrapper(ErrCategoryNotFound, "error.category_not_found")
 	}
 
+	// The fetch settings of the category are used when the request does not override them.
+	fetchSettings := &model.Feed{
+		UserAgent:             feedCreationRequest.UserAgent,
+		FetchViaProxyOverride: model.RequestedFeedSettingOverride(feedCreationRequest.FetchViaProxyOverride, feedCreationRequest.FetchViaProxy),
+		ProxyURL:              feedCreationRequest.ProxyURL,
+		Category:              category,
+	}
+
 	requestBuilder := fetcher.NewRequestBuilder()
 	requestBuilder.WithUsernameAndPassword(feedCreationRequest.Username, feedCreationRequest.Password)
-	requestBuilder.WithUserAgent(feedCreationRequest.UserAgent, config.Opts.HTTPClientUserAgent())
+	requestBuilder.WithUserAgent(fetchSettings.EffectiveUserAgent(), config.Opts.HTTPClientUserAgent())
 	requestBuilder.WithCookie(feedCreationRequest.Cookie)
 	requestBuilder.WithTimeout(config.Opts.HTTPClientTimeout())
 	requestBuilder.WithProxyRotator(proxyrotator.ProxyRotatorInstance)
-	requestBuilder.WithCustomFeedProxyURL(feedCreationRequest.ProxyURL)
+	requestBuilder.WithCustomFeedProxyURL(fetchSettings.EffectiveProxyURL())
 	requestBuilder.WithCustomApplicationProxyURL(config.Opts.HTTPClientProxyURL())
-	requestBuilder.UseCustomApplicationProxyURL(feedCreationRequest.FetchViaProxy)
+	requestBuilder.UseCustomApplicationProxyURL(fetchSettings.EffectiveFetchViaProxy())
 	requestBuilder.IgnoreTLSErrors(feedCreationRequest.AllowSelfSignedCertificates)
 	requestBuilder.DisableHTTP2(feedCreationRequest.DisableHTTP2)
 
@@ -154,7 +186,7 @@ func CreateFeed(store *storage.Storage, userID int64, feedCreationRequest *model
 		return nil, locale.NewLocalizedErrorWrapper(ErrDuplicatedFeed, "error.duplicated_feed")
 	}
 
-	subscription, parseErr := parser.ParseFeed(responseHandler.EffectiveURL(), bytes.NewReader(responseBody))
+	subscription, parseErr := parser.ParseFeedWithRequestBuilder(requestBuilder, responseHandler.EffectiveURL(), bytes.NewReader(responseBody))
 	if parseErr != nil {
 		return nil, locale.NewLocalizedErrorWrapper(parseErr, "error.unable_to_parse_feed", parseErr)
 	}
@@ -164,13 +196,13 @@ func CreateFeed(store *storage.Storage, userID int64, feedCreationRequest *model
 	subscription.Cookie = feedCreationRequest.Cookie
 	subscription.Username = feedCreationRequest.Username
 	subscription.Password = feedCreationRequest.Password
-	subscription.Crawler = feedCreationRequest.Crawler
+	subscription.CrawlerOverride = model.RequestedFeedSettingOverride(feedCreationRequest.CrawlerOverride, feedCreationRequest.Crawler)
 	subscription.IgnoreEntryUpdates = feedCreationRequest.IgnoreEntryUpdates
 	subscription.Disabled = feedCreationRequest.Disabled
 	subscription.IgnoreHTTPCache = feedCreationRequest.IgnoreHTTPCache
 	subscription.AllowSelfSignedCertificates = feedCreationRequest.AllowSelfSignedCertificates
 	subscription.DisableHTTP2 = feedCreationRequest.DisableHTTP2
-	subscription.FetchViaProxy = feedCreationRequest.FetchViaProxy
+	subscription.FetchViaProxyOverride = fetchSettings.FetchViaProxyOverride
 	subscription.ScraperRules = feedCreationRequest.ScraperRules
 	subscription.RewriteRules = feedCreationRequest.RewriteRules
 	subscription.UrlRewriteRules = feedCreationRequest.UrlRewriteRules
@@ -183,7 +215,8 @@ func CreateFeed(store *storage.Storage, userID int64, feedCreationRequest *model
 	subscription.LastModifiedHeader = responseHandler.LastModified()
 	subscription.FeedURL = responseHandler.EffectiveURL()
 	subscription.ProxyURL = feedCreationRequest.ProxyURL
-	subscription.WithCategoryID(feedCreationRequest.CategoryID)
+	subscription.Category = category
+	subscription.ResolveInheritedSettings()
 	subscription.CheckedNow()
 
 	processor.ProcessFeedEntries(store, subscription, userID, true)
@@ -192,6 +225,9 @@ func CreateFeed(store *storage.Storage, userID int64, feedCreationRequest *model
 		return nil, locale.NewLocalizedErrorWrapper(storeErr, "error.database_error", storeErr)
 	}
 
+	sendNewEntriesToIntegrations(store, userID, subscription.Entries)
+	processor.QueueEntrySummaries(store, subscription, subscription.Entries)
+
 	slog.Debug("Created feed",
 		slog.Int64("user_id", userID),
 		slog.Int64("feed_id", subscription.ID),
@@ -234,13 +270,13 @@ func RefreshFeed(store *storage.Storage, userID, feedID int64, forceRefresh bool
 
 	requestBuilder := fetcher.NewRequestBuilder()
 	requestBuilder.WithUsernameAndPassword(originalFeed.Username, originalFeed.Password)
-	requestBuilder.WithUserAgent(originalFeed.UserAgent, config.Opts.HTTPClientUserAgent())
+	requestBuilder.WithUserAgent(originalFeed.EffectiveUserAgent(), config.Opts.HTTPClientUserAgent())
 	requestBuilder.WithCookie(originalFeed.Cookie)
 	requestBuilder.WithTimeout(config.Opts.HTTPClientTimeout())
 	requestBuilder.WithProxyRotator(proxyrotator.ProxyRotatorInstance)
-	requestBuilder.WithCustomFeedProxyURL(originalFeed.ProxyURL)
+	requestBuilder.WithCustomFeedProxyURL(originalFeed.EffectiveProxyURL())
 	requestBuilder.WithCustomApplicationProxyURL(config.Opts.HTTPClientProxyURL())
-	requestBuilder.UseCustomApplicationProxyURL(originalFeed.FetchViaProxy)
+	requestBuilder.UseCustomApplicationProxyURL(originalFeed.EffectiveFetchViaProxy())
 	requestBuilder.IgnoreTLSErrors(originalFeed.AllowSelfSignedCertificates)
 	requestBuilder.DisableHTTP2(originalFeed.DisableHTTP2)
 
@@ -294,7 +330,7 @@ func RefreshFeed(store *storage.Storage, userID, feedID int64, forceRefresh bool
 			return localizedError
 		}
 
-		updatedFeed, parseErr := parser.ParseFeed(responseHandler.EffectiveURL(), bytes.NewReader(responseBody))
+		updatedFeed, parseErr := parser.ParseFeedWithRequestBuilder(requestBuilder, responseHandler.EffectiveURL(), bytes.NewReader(responseBody))
 		if parseErr != nil {
 			localizedError := locale.NewLocalizedErrorWrapper(parseErr, "error.unable_to_parse_feed", parseErr)
 			if errors.Is(parseErr, parser.ErrFeedFormatNotDetected) {
@@ -331,13 +367,18 @@ func RefreshFeed(store *storage.Storage, userID, feedID int64, forceRefresh bool
 		// We don't update existing entries when the crawler is enabled (we crawl only inexisting entries).
 		// We also skip updating existing entries if the feed has ignore_entry_updates enabled.
 		// Unless it is forced to refresh.
-		updateExistingEntries := forceRefresh || (!originalFeed.Crawler && !originalFeed.IgnoreEntryUpdates)
-		newEntries, storeErr := store.RefreshFeedEntries(originalFeed.UserID, originalFeed.ID, originalFeed.Entries, updateExistingEntries)
+		updateExistingEntries := forceRefresh || (!originalFeed.EffectiveCrawler() && !originalFeed.IgnoreEntryUpdates)
+		newEntries, storeErr := store.RefreshFeedEntries(originalFeed.UserID, originalFeed.ID, originalFeed.Entries, updateExistingEntries, originalFeed.MarkUnreadOnEntryRevision)
 		if storeErr != nil {
 			localizedError := locale.NewLocalizedErrorWrapper(storeErr, "error.database_error", storeErr)
 			return getTranslatedLocalizedError(store, userID, originalFeed, localizedError)
 		}
 
+		if originalFeed.MirrorEnclosures && len(newEntries) > 0 {
+			mirror.Request()
+		}
+		processor.QueueEntrySummaries(store, originalFeed, newEntries)
+
 		userIntegrations, intErr := store.Integration(userID)
 		if intErr != nil {
 			slog.Error("Fetching integrations failed; the refresh process will go on, but no integrations will run this time",
@@ -347,6 +388,7 @@ func RefreshFeed(store *storage.Storage, userID, feedID int64, forceRefresh bool
 			)
 		} else if userIntegrations != nil && len(newEntries) > 0 {
 			go integration.PushEntries(originalFeed, newEntries, userIntegrations)
+			sendEntriesToIntegrations(newEntries, userIntegrations)
 		}
 
 		originalFeed.EtagHeader = responseHandler.ETag()
@@ -381,3 +423,36 @@ func RefreshFeed(store *storage.Storage, userID, feedID int64, forceRefresh bool
 
 	return nil
 }
+
+// sendNewEntriesToIntegrations sends the entries of a new feed to the integrations named by the rules.
+func sendNewEntriesToIntegrations(store *storage.Storage, userID int64, entries model.Entries) {
+	if !slices.ContainsFunc(entries, func(entry *model.Entry) bool { return len(entry.SendToIntegrations) > 0 }) {
+		return
+	}
+
+	userIntegrations, err := store.Integration(userID)
+	if err != nil {
+		slog.Error("Unable to fetch integrations",
+			slog.Int64("user_id", userID),
+			slog.Any("error", err),
+		)
+		return
+	}
+	sendEntriesToIntegrations(entries, userIntegrations)
+}
+
+// sendEntriesToIntegrations sends the stored entries to the integrations named by the rules.
+func sendEntriesToIntegrations(entries model.Entries, userIntegrations *model.Integration) {
+	for _, entry := range entries {
+		// Entries that already existed were not stored again.
+		if entry.ID == 0 {
+			continue
+		}
+
+		for _, name := range entry.SendToIntegrations {
+			if only := userIntegrations.OnlySendEntryIntegration(name); only != nil {
+				go integration.SendEntry(entry, only)
+			}
+		}
+	}
+}
@@ -26,49 +26,72 @@ func NewIconChecker(store *storage.Storage, feed *model.Feed) *iconChecker {
 }
 
 func (c *iconChecker) UpdateOrCreateFeedIcon() {
+	c.RefreshFeedIcon()
+}
+
+// RefreshFeedIcon downloads the icon of the feed and stores it.
+// It returns nil when the website has no icon.
+func (c *iconChecker) RefreshFeedIcon() (*model.Icon, error) {
 	requestBuilder := fetcher.NewRequestBuilder()
-	requestBuilder.WithUserAgent(c.feed.UserAgent, config.Opts.HTTPClientUserAgent())
+	requestBuilder.WithUserAgent(c.feed.EffectiveUserAgent(), config.Opts.HTTPClientUserAgent())
 	requestBuilder.WithCookie(c.feed.Cookie)
 	requestBuilder.WithTimeout(config.Opts.HTTPClientTimeout())
 	requestBuilder.WithProxyRotator(proxyrotator.ProxyRotatorInstance)
-	requestBuilder.WithCustomFeedProxyURL(c.feed.ProxyURL)
+	requestBuilder.WithCustomFeedProxyURL(c.feed.EffectiveProxyURL())
 	requestBuilder.WithCustomApplicationProxyURL(config.Opts.HTTPClientProxyURL())
-	requestBuilder.UseCustomApplicationProxyURL(c.feed.FetchViaProxy)
+	requestBuilder.UseCustomApplicationProxyURL(c.feed.EffectiveFetchViaProxy())
 	requestBuilder.IgnoreTLSErrors(c.feed.AllowSelfSignedCertificates)
 	requestBuilder.DisableHTTP2(c.feed.DisableHTTP2)
 
 	iconFinder := newIconFinder(requestBuilder, c.feed.SiteURL, c.feed.IconURL)
-	if icon, err := iconFinder.findIcon(); err != nil {
+	icon, err := iconFinder.findIcon()
+
+	// The failures are recorded as well, so the scheduler retries them later.
+	if markErr := c.store.MarkFeedIconChecked(c.feed.ID); markErr != nil {
+		slog.Error("Unable to update feed icon check date",
+			slog.Int64("feed_id", c.feed.ID),
+			slog.Any("error", markErr),
+		)
+	}
+
+	if err != nil {
 		slog.Debug("Unable to find feed icon",
 			slog.Int64("feed_id", c.feed.ID),
 			slog.String("website_url", c.feed.SiteURL),
 			slog.String("feed_icon_url", c.feed.IconURL),
 			slog.Any("error", err),
 		)
-	} else if icon == nil {
+		return nil, err
+	}
+
+	if icon == nil {
 		slog.Debug("No icon found",
 			slog.Int64("feed_id", c.feed.ID),
 			slog.String("website_url", c.feed.SiteURL),
 			slog.String("feed_icon_url", c.feed.IconURL),
 		)
-	} else {
-		if err := c.store.StoreFeedIcon(c.feed.ID, icon); err != nil {
-			slog.Error("Unable to store feed icon",
-				slog.Int64("feed_id", c.feed.ID),
-				slog.String("website_url", c.feed.SiteURL),
-				slog.String("feed_icon_url", c.feed.IconURL),
-				slog.Any("error", err),
-			)
-		} else {
-			slog.Debug("Feed icon stored",
-				slog.Int64("feed_id", c.feed.ID),
-				slog.String("website_url", c.feed.SiteURL),
-				slog.String("feed_icon_url", c.feed.IconURL),
-				slog.Int64("icon_id", icon.ID),
-				slog.String("icon_hash", icon.Hash),
-			)
-		}
+		return nil, nil
 	}
+
+	if err := c.store.StoreFeedIcon(c.feed.ID, icon); err != nil {
+		slog.Error("Unable to store feed icon",
+			slog.Int64("feed_id", c.feed.ID),
+			slog.String("website_url", c.feed.SiteURL),
+			slog.String("feed_icon_url", c.feed.IconURL),
+			slog.Any("error", err),
+		)
+		return nil, err
+	}
+
+	slog.Debug("Feed icon stored",
+		slog.Int64("feed_id", c.feed.ID),
+		slog.String("website_url", c.feed.SiteURL),
+		slog.String("feed_icon_url", c.feed.IconURL),
+		slog.Int64("icon_id", icon.ID),
+		slog.String("icon_hash", icon.Hash),
+	)
+
+	return icon, nil
 }
 
 func (c *iconChecker) CreateFeedIconIfMissing() {
@@ -168,6 +168,14 @@ func (j *JSONAdapter) BuildFeed(baseURL string) *model.Feed {
 		slices.Sort(entry.Tags)
 		entry.Tags = slices.Compact(entry.Tags)
 
+		// Populate the entry language.
+		for _, value := range []string{item.Language, j.jsonFeed.Language} {
+			if value = strings.TrimSpace(value); value != "" {
+				entry.Language = value
+				break
+			}
+		}
+
 		// Generate a hash for the entry.
 		for _, value := range []string{item.ID, item.URL, item.ExternalURL, item.ContentText + item.ContentHTML + item.Summary} {
 			value = strings.TrimSpace(value)
@@ -1103,3 +1103,26 @@ func TestParseNullJSONFeed(t *testing.T) {
 		t.Fatalf("Feed should not be nil")
 	}
 }
+
+func TestParseEntryLanguage(t *testing.T) {
+	data := `{
+		"version": "https://jsonfeed.org/version/1.1",
+		"title": "My Example Feed",
+		"language": "en-US",
+		"items": [
+			{"id": "1", "url": "https://example.org/1", "content_text": "Some text."},
+			{"id": "2", "url": "https://example.org/2", "content_text": "Du texte.", "language": "fr"}
+		]
+	}`
+
+	feed, err := Parse("https://example.org/feed.json", bytes.NewBufferString(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, expected := range []string{"en-US", "fr"} {
+		if feed.Entries[i].Language != expected {
+			t.Errorf("Incorrect entry language, got %q instead of %q", feed.Entries[i].Language, expected)
+		}
+	}
+}
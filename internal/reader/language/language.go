@@ -0,0 +1,168 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package language detects and normalizes the language of the entries.
+package language // import "miniflux.app/v2/internal/reader/language"
+
+import (
+	"strings"
+	"unicode"
+)
+
+// maxWords is the number of words of the text used to detect the language.
+const maxWords = 300
+
+// minStopwordHits is the number of stopwords required to detect a language written in the Latin script.
+const minStopwordHits = 3
+
+// stopwords contains the most frequent words of the languages written in the Latin script.
+// The words shared by several languages are listed for each of them.
+var stopwords = map[string][]string{
+	"da": {"og", "i", "at", "det", "er", "en", "til", "på", "de", "med", "af", "for", "ikke", "der", "som", "den", "har", "et", "jeg", "var", "fra", "men", "om", "også", "kan"},
+	"de": {"der", "die", "und", "in", "den", "von", "zu", "das", "mit", "sich", "des", "auf", "für", "ist", "im", "dem", "nicht", "ein", "eine", "als", "auch", "es", "an", "wird", "aus", "er", "hat", "dass", "sie", "nach", "bei", "wie", "oder", "noch", "über"},
+	"en": {"the", "of", "and", "to", "in", "a", "is", "that", "for", "it", "as", "was", "with", "be", "by", "on", "not", "he", "this", "are", "or", "his", "from", "at", "which", "but", "have", "an", "they", "you", "were", "their", "has", "been", "will", "would", "what", "there", "can", "we"},
+	"es": {"de", "la", "que", "el", "en", "y", "a", "los", "del", "se", "las", "por", "un", "para", "con", "no", "una", "su", "al", "es", "lo", "como", "más", "pero", "sus", "le", "ya", "o", "fue", "este", "ha", "sí", "porque", "esta", "entre", "cuando", "muy", "sin", "sobre", "también"},
+	"fi": {"ja", "on", "ei", "että", "se", "oli", "hän", "ovat", "mutta", "kun", "myös", "tai", "kuin", "niin", "jos", "vain", "sen", "ole", "nyt", "jo", "mukaan", "voi", "tämä", "ovat", "sitä"},
+	"fr": {"de", "la", "le", "et", "les", "des", "en", "un", "du", "une", "que", "est", "pour", "qui", "dans", "a", "par", "plus", "pas", "au", "sur", "ne", "se", "ce", "il", "sont", "avec", "mais", "nous", "vous", "aux", "cette", "ont", "ou", "leur", "été", "comme", "sa", "son", "elle"},
+	"hu": {"a", "az", "és", "hogy", "nem", "is", "egy", "meg", "van", "de", "volt", "már", "csak", "ez", "el", "még", "mint", "ki", "vagy", "be", "fel", "kell", "után", "pedig", "lesz"},
+	"it": {"di", "e", "il", "la", "che", "in", "a", "per", "un", "del", "non", "è", "una", "della", "le", "si", "con", "i", "da", "al", "dei", "alla", "sono", "gli", "anche", "più", "nel", "ha", "come", "ma", "delle", "questo", "nella", "lo", "se"},
+	"nl": {"de", "en", "van", "het", "een", "in", "is", "dat", "op", "te", "zijn", "met", "voor", "niet", "die", "aan", "er", "ook", "als", "bij", "door", "maar", "om", "dan", "wordt", "nog", "naar", "heeft", "over", "uit", "worden", "wel", "hij", "of"},
+	"no": {"og", "i", "er", "det", "som", "på", "en", "til", "av", "for", "med", "at", "har", "ikke", "den", "de", "et", "om", "var", "fra", "men", "seg", "kan", "også", "eller"},
+	"pt": {"de", "a", "o", "que", "e", "do", "da", "em", "um", "para", "é", "com", "não", "uma", "os", "no", "se", "na", "por", "mais", "as", "dos", "como", "mas", "foi", "ao", "ele", "das", "tem", "à", "seu", "sua", "ou", "ser", "quando", "muito", "nos", "já", "está", "também"},
+	"ro": {"de", "și", "în", "a", "la", "cu", "o", "pe", "care", "din", "nu", "un", "să", "pentru", "este", "mai", "sunt", "ca", "sau", "dar", "fost", "au", "ce", "acest", "fi"},
+	"sv": {"och", "i", "att", "det", "som", "en", "på", "är", "av", "för", "med", "till", "den", "har", "de", "inte", "om", "ett", "var", "men", "jag", "från", "kan", "också", "eller"},
+	"tr": {"ve", "bir", "bu", "da", "de", "için", "ile", "olarak", "daha", "çok", "gibi", "olan", "en", "ne", "kadar", "sonra", "ama", "her", "ise", "mi", "değil", "var", "diye", "şey", "ben"},
+}
+
+// stopwordLanguages maps each stopword to the languages using it.
+var stopwordLanguages = func() map[string][]string {
+	languages := make(map[string][]string)
+	for language, words := range stopwords {
+		for _, word := range words {
+			languages[word] = append(languages[word], language)
+		}
+	}
+	return languages
+}()
+
+// Normalize returns the lowercase primary subtag of a language tag, for example "en" for "en-US".
+// An empty string is returned when the tag is not a valid language code.
+func Normalize(tag string) string {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if primary, _, found := strings.Cut(strings.ReplaceAll(tag, "_", "-"), "-"); found {
+		tag = primary
+	}
+
+	if len(tag) < 2 || len(tag) > 3 {
+		return ""
+	}
+
+	for _, r := range tag {
+		if r < 'a' || r > 'z' {
+			return ""
+		}
+	}
+
+	return tag
+}
+
+// Detect returns the language code of the text, or an empty string if the language cannot be detected.
+// The writing system identifies most languages, the stopwords are used for the languages written in the Latin script.
+func Detect(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '\''
+	})
+	if len(words) > maxWords {
+		words = words[:maxWords]
+	}
+
+	if language := detectScript(words); language != "" {
+		return language
+	}
+
+	return detectStopwords(words)
+}
+
+func detectScript(words []string) string {
+	var latin, other int
+	scripts := make(map[string]int)
+
+	for _, word := range words {
+		for _, r := range word {
+			switch {
+			case unicode.Is(unicode.Latin, r):
+				latin++
+				continue
+			case unicode.Is(unicode.Hiragana, r), unicode.Is(unicode.Katakana, r):
+				scripts["ja"]++
+			case unicode.Is(unicode.Han, r):
+				scripts["zh"]++
+			case unicode.Is(unicode.Hangul, r):
+				scripts["ko"]++
+			case strings.ContainsRune("іїєґ", r):
+				scripts["uk"]++
+			case unicode.Is(unicode.Cyrillic, r):
+				scripts["ru"]++
+			case unicode.Is(unicode.Greek, r):
+				scripts["el"]++
+			case unicode.Is(unicode.Arabic, r):
+				scripts["ar"]++
+			case unicode.Is(unicode.Hebrew, r):
+				scripts["he"]++
+			case unicode.Is(unicode.Devanagari, r):
+				scripts["hi"]++
+			case unicode.Is(unicode.Thai, r):
+				scripts["th"]++
+			default:
+				continue
+			}
+			other++
+		}
+	}
+
+	if other == 0 || other < latin {
+		return ""
+	}
+
+	switch {
+	// Japanese mixes kana and Han characters.
+	case scripts["ja"] > 0:
+		return "ja"
+	// Ukrainian is written with the Cyrillic alphabet and a few specific letters.
+	case scripts["uk"] > 0:
+		return "uk"
+	}
+
+	var language string
+	for script, count := range scripts {
+		if count > scripts[language] || (count == scripts[language] && script < language) {
+			language = script
+		}
+	}
+	return language
+}
+
+func detectStopwords(words []string) string {
+	scores := make(map[string]int)
+	for _, word := range words {
+		for _, language := range stopwordLanguages[word] {
+			scores[language]++
+		}
+	}
+
+	var best, second string
+	for language, score := range scores {
+		switch {
+		case best == "" || score > scores[best] || (score == scores[best] && language < best):
+			best, second = language, best
+		case second == "" || score > scores[second] || (score == scores[second] && language < second):
+			second = language
+		}
+	}
+
+	if best == "" || scores[best] < minStopwordHits || scores[best] == scores[second] {
+		return ""
+	}
+
+	return best
+}
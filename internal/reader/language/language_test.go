@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package language // import "miniflux.app/v2/internal/reader/language"
+
+import "testing"
+
+func TestNormalize(t *testing.T) {
+	scenarios := map[string]string{
+		"en":      "en",
+		"en-US":   "en",
+		" fr_CA ": "fr",
+		"DE":      "de",
+		"fil":     "fil",
+		"":        "",
+		"english": "",
+		"x":       "",
+		"*":       "",
+		"12-34":   "",
+	}
+
+	for input, expected := range scenarios {
+		if result := Normalize(input); result != expected {
+			t.Errorf(`Unexpected language for %q: got %q instead of %q`, input, result, expected)
+		}
+	}
+}
+
+func TestDetect(t *testing.T) {
+	scenarios := map[string]string{
+		"The new version of the library was released and it is faster than the previous one.":              "en",
+		"La nouvelle version de la bibliothèque est disponible et elle est plus rapide que la précédente.": "fr",
+		"Die neue Version der Bibliothek ist verfügbar und sie ist schneller als die vorherige.":           "de",
+		"La nueva versión de la biblioteca ya está disponible y es más rápida que la anterior.":            "es",
+		"De nieuwe versie van de bibliotheek is beschikbaar en het is sneller dan de vorige.":              "nl",
+		"Новая версия библиотеки уже доступна и работает быстрее предыдущей.":                              "ru",
+		"Нова версія бібліотеки вже доступна і працює швидше за попередню.":                                "uk",
+		"Η νέα έκδοση της βιβλιοθήκης είναι διαθέσιμη.":                                                    "el",
+		"ライブラリの新しいバージョンが公開されました。":                                                                          "ja",
+		"新版本的库已经发布了。":              "zh",
+		"라이브러리의 새 버전이 출시되었습니다.":    "ko",
+		"Golang Kubernetes Docker": "",
+		"":                         "",
+		"<p>The release of <b>Go 1.22</b> is available, and the changelog is on the website of the project.</p>": "en",
+	}
+
+	for input, expected := range scenarios {
+		if result := Detect(input); result != expected {
+			t.Errorf(`Unexpected language for %q: got %q instead of %q`, input, result, expected)
+		}
+	}
+}
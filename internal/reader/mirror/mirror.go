@@ -0,0 +1,142 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package mirror // import "miniflux.app/v2/internal/reader/mirror"
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"miniflux.app/v2/internal/reader/fetcher"
+)
+
+// DownloadTimeout is the maximum duration of a download, audio and video files can be large.
+const DownloadTimeout = 30 * time.Minute
+
+// temporaryFilePrefix is the prefix of the files being downloaded.
+const temporaryFilePrefix = ".download-"
+
+// ErrFileTooLarge is returned when the file exceeds the maximum size.
+var ErrFileTooLarge = errors.New("mirror: the file exceeds the maximum size")
+
+// requests wakes up the scheduler when enclosures are waiting to be mirrored.
+var requests = make(chan struct{}, 1)
+
+// Request asks the scheduler to download the pending enclosures without waiting for its next run.
+// It never blocks: the requests made while the scheduler is busy are merged.
+func Request() {
+	select {
+	case requests <- struct{}{}:
+	default:
+	}
+}
+
+// Requests returns the channel notified by Request.
+func Requests() <-chan struct{} {
+	return requests
+}
+
+// Path returns the location of the local copy of an enclosure.
+func Path(directory string, userID, enclosureID int64) string {
+	return filepath.Join(directory, strconv.FormatInt(userID, 10), strconv.FormatInt(enclosureID, 10))
+}
+
+// Download saves the file at fileURL to destination and returns its size.
+//
+// The file is written to a temporary file first, so an incomplete download never replaces the destination.
+func Download(requestBuilder *fetcher.RequestBuilder, fileURL, destination string, maxSize int64) (int64, error) {
+	responseHandler := fetcher.NewResponseHandler(requestBuilder.ExecuteRequest(fileURL))
+	defer responseHandler.Close()
+
+	if localizedError := responseHandler.LocalizedError(); localizedError != nil {
+		return 0, localizedError.Error()
+	}
+
+	directory := filepath.Dir(destination)
+	if err := os.MkdirAll(directory, 0o700); err != nil {
+		return 0, fmt.Errorf("mirror: unable to create the directory: %w", err)
+	}
+
+	file, err := os.CreateTemp(directory, temporaryFilePrefix+"*")
+	if err != nil {
+		return 0, fmt.Errorf("mirror: unable to create the file: %w", err)
+	}
+	defer os.Remove(file.Name())
+
+	size, err := io.Copy(file, responseHandler.Body(maxSize))
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+
+	var maxBytesError *http.MaxBytesError
+	switch {
+	case errors.As(err, &maxBytesError):
+		return 0, ErrFileTooLarge
+	case err != nil:
+		return 0, fmt.Errorf("mirror: unable to download the file: %w", err)
+	case size == 0:
+		return 0, errors.New("mirror: empty file")
+	}
+
+	if err := os.Rename(file.Name(), destination); err != nil {
+		return 0, fmt.Errorf("mirror: unable to save the file: %w", err)
+	}
+
+	return size, nil
+}
+
+// RemoveOrphanFiles deletes the files of the enclosures that are no longer mirrored,
+// and the temporary files of the downloads interrupted for more than a day.
+// It returns the number of deleted files.
+func RemoveOrphanFiles(directory string, mirroredEnclosureIDs map[int64]map[int64]bool) (int, error) {
+	userDirectories, err := os.ReadDir(directory)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("mirror: unable to read the directory: %w", err)
+	}
+
+	removedFiles := 0
+	for _, userDirectory := range userDirectories {
+		userID, err := strconv.ParseInt(userDirectory.Name(), 10, 64)
+		if err != nil || !userDirectory.IsDir() {
+			continue
+		}
+
+		files, err := os.ReadDir(filepath.Join(directory, userDirectory.Name()))
+		if err != nil {
+			return removedFiles, fmt.Errorf("mirror: unable to read the directory: %w", err)
+		}
+
+		for _, file := range files {
+			if file.IsDir() || !isOrphanFile(file, mirroredEnclosureIDs[userID]) {
+				continue
+			}
+
+			if err := os.Remove(filepath.Join(directory, userDirectory.Name(), file.Name())); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return removedFiles, fmt.Errorf("mirror: unable to remove the file: %w", err)
+			}
+			removedFiles++
+		}
+	}
+
+	return removedFiles, nil
+}
+
+func isOrphanFile(file os.DirEntry, mirroredEnclosureIDs map[int64]bool) bool {
+	if strings.HasPrefix(file.Name(), temporaryFilePrefix) {
+		fileInfo, err := file.Info()
+		return err == nil && time.Since(fileInfo.ModTime()) > 24*time.Hour
+	}
+
+	enclosureID, err := strconv.ParseInt(file.Name(), 10, 64)
+	return err == nil && !mirroredEnclosureIDs[enclosureID]
+}
@@ -0,0 +1,155 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package mirror // import "miniflux.app/v2/internal/reader/mirror"
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/reader/fetcher"
+)
+
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	os.Clearenv()
+	os.Setenv("FETCHER_ALLOW_PRIVATE_NETWORKS", "1")
+
+	var err error
+	config.Opts, err = config.NewConfigParser().ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Config parsing failure: %v`, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/episode.mp3", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "audio/mpeg")
+		w.Write([]byte("0123456789"))
+	})
+	mux.HandleFunc("/missing.mp3", func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestDownload(t *testing.T) {
+	server := newTestServer(t)
+	destination := Path(t.TempDir(), 1, 42)
+
+	size, err := Download(fetcher.NewRequestBuilder(), server.URL+"/episode.mp3", destination, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if size != 10 {
+		t.Errorf(`Unexpected size, got %d`, size)
+	}
+
+	content, err := os.ReadFile(destination)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(content) != "0123456789" {
+		t.Errorf(`Unexpected content, got %q`, content)
+	}
+
+	if files, _ := os.ReadDir(filepath.Dir(destination)); len(files) != 1 {
+		t.Errorf(`The temporary file should be removed, got %d files`, len(files))
+	}
+}
+
+func TestDownloadErrors(t *testing.T) {
+	server := newTestServer(t)
+	destination := Path(t.TempDir(), 1, 42)
+
+	if _, err := Download(fetcher.NewRequestBuilder(), server.URL+"/episode.mp3", destination, 5); !errors.Is(err, ErrFileTooLarge) {
+		t.Errorf(`Expected ErrFileTooLarge, got %v`, err)
+	}
+
+	if _, err := Download(fetcher.NewRequestBuilder(), server.URL+"/missing.mp3", destination, 1024); err == nil {
+		t.Error(`An error should be returned for a missing file`)
+	}
+
+	if files, _ := os.ReadDir(filepath.Dir(destination)); len(files) != 0 {
+		t.Errorf(`The failed downloads should not leave any file, got %d files`, len(files))
+	}
+}
+
+func TestRemoveOrphanFiles(t *testing.T) {
+	directory := t.TempDir()
+
+	for _, path := range []string{Path(directory, 1, 10), Path(directory, 1, 11), Path(directory, 2, 20)} {
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("data"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	recentDownload := filepath.Join(directory, "1", temporaryFilePrefix+"recent")
+	interruptedDownload := filepath.Join(directory, "1", temporaryFilePrefix+"interrupted")
+	for _, path := range []string{recentDownload, interruptedDownload} {
+		if err := os.WriteFile(path, []byte("data"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	twoDaysAgo := time.Now().Add(-48 * time.Hour)
+	if err := os.Chtimes(interruptedDownload, twoDaysAgo, twoDaysAgo); err != nil {
+		t.Fatal(err)
+	}
+
+	removedFiles, err := RemoveOrphanFiles(directory, map[int64]map[int64]bool{1: {10: true}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if removedFiles != 3 {
+		t.Errorf(`Unexpected number of removed files, got %d`, removedFiles)
+	}
+
+	for path, exists := range map[string]bool{
+		Path(directory, 1, 10): true,
+		Path(directory, 1, 11): false,
+		Path(directory, 2, 20): false,
+		recentDownload:         true,
+		interruptedDownload:    false,
+	} {
+		if _, err := os.Stat(path); (err == nil) != exists {
+			t.Errorf(`Unexpected state for %q: exists=%v`, path, err == nil)
+		}
+	}
+
+	if removedFiles, err := RemoveOrphanFiles(filepath.Join(directory, "missing"), nil); err != nil || removedFiles != 0 {
+		t.Errorf(`A missing directory should be ignored, got %d and %v`, removedFiles, err)
+	}
+}
+
+func TestRequestsAreMerged(t *testing.T) {
+	Request()
+	Request()
+
+	select {
+	case <-Requests():
+	default:
+		t.Fatal(`Expected a pending request`)
+	}
+
+	select {
+	case <-Requests():
+		t.Fatal(`The requests should have been merged`)
+	default:
+	}
+}
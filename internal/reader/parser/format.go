@@ -17,7 +17,11 @@ const (
 	FormatRSS     = "rss"
 	FormatAtom    = "atom"
 	FormatJSON    = "json"
+	FormatSitemap = "sitemap"
 	FormatUnknown = "unknown"
+
+	// FormatSitemapIndex is a sitemap index file, which references other sitemaps.
+	FormatSitemapIndex = "sitemap_index"
 )
 
 const maxTokensToConsider = uint(50)
@@ -55,6 +59,10 @@ func DetectFeedFormat(r io.ReadSeeker) (string, string) {
 				return FormatAtom, "1.0"
 			case "RDF":
 				return FormatRDF, ""
+			case "urlset":
+				return FormatSitemap, ""
+			case "sitemapindex":
+				return FormatSitemapIndex, ""
 			}
 		}
 	}
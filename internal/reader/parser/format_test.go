@@ -53,6 +53,24 @@ func TestDetectAtomWithISOCharset(t *testing.T) {
 	}
 }
 
+func TestDetectSitemap(t *testing.T) {
+	data := `<?xml version="1.0" encoding="UTF-8"?><urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9"></urlset>`
+	format, _ := DetectFeedFormat(strings.NewReader(data))
+
+	if format != FormatSitemap {
+		t.Errorf(`Wrong format detected: %q instead of %q`, format, FormatSitemap)
+	}
+}
+
+func TestDetectSitemapIndex(t *testing.T) {
+	data := `<?xml version="1.0" encoding="UTF-8"?><sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9"></sitemapindex>`
+	format, _ := DetectFeedFormat(strings.NewReader(data))
+
+	if format != FormatSitemapIndex {
+		t.Errorf(`Wrong format detected: %q instead of %q`, format, FormatSitemapIndex)
+	}
+}
+
 func TestDetectJSON(t *testing.T) {
 	data := `
 	{
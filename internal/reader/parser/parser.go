@@ -9,15 +9,26 @@ import (
 
 	"miniflux.app/v2/internal/model"
 	"miniflux.app/v2/internal/reader/atom"
+	"miniflux.app/v2/internal/reader/fetcher"
 	"miniflux.app/v2/internal/reader/json"
 	"miniflux.app/v2/internal/reader/rdf"
 	"miniflux.app/v2/internal/reader/rss"
+	"miniflux.app/v2/internal/reader/sitemap"
 )
 
-var ErrFeedFormatNotDetected = errors.New("parser: unable to detect feed format")
+var (
+	ErrFeedFormatNotDetected  = errors.New("parser: unable to detect feed format")
+	ErrSitemapIndexNotFetched = errors.New("parser: sitemap index files require a request builder")
+)
 
 // ParseFeed analyzes the input data and returns a normalized feed object.
 func ParseFeed(baseURL string, r io.ReadSeeker) (*model.Feed, error) {
+	return ParseFeedWithRequestBuilder(nil, baseURL, r)
+}
+
+// ParseFeedWithRequestBuilder analyzes the input data and returns a normalized feed object.
+// The request builder is used to download the sitemaps referenced by a sitemap index.
+func ParseFeedWithRequestBuilder(requestBuilder *fetcher.RequestBuilder, baseURL string, r io.ReadSeeker) (*model.Feed, error) {
 	format, version := DetectFeedFormat(r)
 	switch format {
 	case FormatAtom:
@@ -28,6 +39,13 @@ func ParseFeed(baseURL string, r io.ReadSeeker) (*model.Feed, error) {
 		return json.Parse(baseURL, r)
 	case FormatRDF:
 		return rdf.Parse(baseURL, r)
+	case FormatSitemap:
+		return sitemap.Parse(baseURL, r)
+	case FormatSitemapIndex:
+		if requestBuilder == nil {
+			return nil, ErrSitemapIndexNotFetched
+		}
+		return sitemap.ParseIndex(requestBuilder, baseURL, r)
 	default:
 		return nil, ErrFeedFormatNotDetected
 	}
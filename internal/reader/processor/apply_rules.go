@@ -0,0 +1,203 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package processor // import "miniflux.app/v2/internal/reader/processor"
+
+import (
+	"fmt"
+	"log/slog"
+
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/rules"
+	"miniflux.app/v2/internal/storage"
+)
+
+const ruleJobBatchSize = 100
+
+// ruleJobRequests wakes up the rule job worker when a job is created.
+var ruleJobRequests = make(chan struct{}, 1)
+
+// RequestRuleJobs asks the rule job worker to apply the pending jobs.
+// It never blocks: the requests made while the worker is busy are merged.
+func RequestRuleJobs() {
+	select {
+	case ruleJobRequests <- struct{}{}:
+	default:
+	}
+}
+
+// RunRuleJobWorker applies the pending rule jobs one at a time, at startup and each time new jobs are requested.
+// The jobs are loaded from the database, so the jobs created by other instances or before a restart are also applied.
+func RunRuleJobWorker(store *storage.Storage) {
+	for {
+		jobs, err := store.PendingRuleJobs()
+		if err != nil {
+			slog.Error("Unable to fetch pending rule jobs", slog.Any("error", err))
+		}
+
+		for _, job := range jobs {
+			ApplyRules(store, job)
+		}
+
+		<-ruleJobRequests
+	}
+}
+
+// ApplyRules evaluates the rules against the stored unread entries of the job scope,
+// and marks as read or removes the blocked entries. The progress is saved after each batch.
+// The job is skipped if it is no longer pending.
+func ApplyRules(store *storage.Storage, job *model.RuleJob) {
+	started, err := store.StartRuleJob(job)
+	if err != nil {
+		slog.Error("Unable to start rule job",
+			slog.Int64("user_id", job.UserID),
+			slog.Int64("rule_job_id", job.ID),
+			slog.Any("error", err),
+		)
+		return
+	}
+	if !started {
+		slog.Debug("Skip rule job, it is no longer pending",
+			slog.Int64("user_id", job.UserID),
+			slog.Int64("rule_job_id", job.ID),
+		)
+		return
+	}
+
+	slog.Debug("Applying rules to stored entries",
+		slog.Int64("user_id", job.UserID),
+		slog.Int64("rule_job_id", job.ID),
+		slog.Int64("feed_id", job.FeedID),
+		slog.Int64("category_id", job.CategoryID),
+		slog.String("action", job.Action),
+	)
+
+	if err := applyRules(store, job); err != nil {
+		slog.Error("Unable to apply rules to stored entries",
+			slog.Int64("user_id", job.UserID),
+			slog.Int64("rule_job_id", job.ID),
+			slog.Any("error", err),
+		)
+
+		job.Status = model.RuleJobStatusFailed
+		job.Error = err.Error()
+		if err := store.UpdateRuleJob(job); err != nil {
+			slog.Error("Unable to update rule job",
+				slog.Int64("user_id", job.UserID),
+				slog.Int64("rule_job_id", job.ID),
+				slog.Any("error", err),
+			)
+		}
+		return
+	}
+
+	slog.Info("Rules applied to stored entries",
+		slog.Int64("user_id", job.UserID),
+		slog.Int64("rule_job_id", job.ID),
+		slog.String("action", job.Action),
+		slog.Int("nb_entries", job.Processed),
+		slog.Int("nb_matched_entries", job.Matched),
+	)
+}
+
+func applyRules(store *storage.Storage, job *model.RuleJob) error {
+	user, err := store.UserByID(job.UserID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return fmt.Errorf("user #%d not found", job.UserID)
+	}
+
+	feeds, err := store.Feeds(job.UserID)
+	if err != nil {
+		return err
+	}
+
+	feedsByID := make(map[int64]*model.Feed, len(feeds))
+	for _, feed := range feeds {
+		feedsByID[feed.ID] = feed
+	}
+
+	builder := store.NewEntryQueryBuilder(job.UserID)
+	builder.WithFeedID(job.FeedID)
+	builder.WithCategoryID(job.CategoryID)
+	builder.WithStatus(model.EntryStatusUnread)
+
+	job.Total, err = builder.CountEntries()
+	if err != nil {
+		return err
+	}
+
+	if err := store.UpdateRuleJob(job); err != nil {
+		return err
+	}
+
+	ruleSets := make(map[int64]rules.Rules)
+	var lastEntryID int64
+	for {
+		builder := store.NewEntryQueryBuilder(job.UserID)
+		builder.WithFeedID(job.FeedID)
+		builder.WithCategoryID(job.CategoryID)
+		builder.WithStatus(model.EntryStatusUnread)
+		builder.WithEnclosures()
+		builder.AfterEntryID(lastEntryID)
+		builder.WithSorting("e.id", "ASC")
+		builder.WithLimit(ruleJobBatchSize)
+
+		entries, err := builder.GetEntries()
+		if err != nil {
+			return err
+		}
+
+		if len(entries) == 0 {
+			break
+		}
+
+		var blockedEntryIDs []int64
+		for _, entry := range entries {
+			lastEntryID = entry.ID
+
+			feed, found := feedsByID[entry.FeedID]
+			if !found {
+				continue
+			}
+
+			ruleSet, found := ruleSets[feed.ID]
+			if !found {
+				ruleSet = rules.ForFeed(user, feed)
+				ruleSets[feed.ID] = ruleSet
+			}
+
+			if blocked, _ := ruleSet.Blocks(entry); blocked {
+				blockedEntryIDs = append(blockedEntryIDs, entry.ID)
+			}
+		}
+
+		if len(blockedEntryIDs) > 0 {
+			switch job.Action {
+			case model.RuleJobActionRemove:
+				count, err := store.RemoveEntriesWithTombstones(job.UserID, blockedEntryIDs)
+				if err != nil {
+					return err
+				}
+				job.Matched += count
+			default:
+				if err := store.MarkBlockedEntriesAsRead(job.UserID, blockedEntryIDs); err != nil {
+					return err
+				}
+				job.Matched += len(blockedEntryIDs)
+			}
+		}
+
+		// New entries may be stored while the job is running.
+		job.Processed += len(entries)
+		job.Total = max(job.Total, job.Processed)
+		if err := store.UpdateRuleJob(job); err != nil {
+			return err
+		}
+	}
+
+	job.Status = model.RuleJobStatusCompleted
+	return store.UpdateRuleJob(job)
+}
@@ -42,14 +42,14 @@ func extractBilibiliVideoID(websiteURL string) (string, string, error) {
 	return "", "", fmt.Errorf("unexpected regex match result for URL: %s", websiteURL)
 }
 
-func fetchBilibiliWatchTime(websiteURL string) (int, error) {
+func fetchBilibiliVideoMetadata(websiteURL string) (*videoMetadata, error) {
 	requestBuilder := fetcher.NewRequestBuilder()
 	requestBuilder.WithTimeout(config.Opts.HTTPClientTimeout())
 	requestBuilder.WithProxyRotator(proxyrotator.ProxyRotatorInstance)
 
 	idType, videoID, extractErr := extractBilibiliVideoID(websiteURL)
 	if extractErr != nil {
-		return 0, extractErr
+		return nil, extractErr
 	}
 	bilibiliApiURL := "https://api.bilibili.com/x/web-interface/view?" + idType + "=" + videoID
 
@@ -61,32 +61,37 @@ func fetchBilibiliWatchTime(websiteURL string) (int, error) {
 			slog.String("website_url", websiteURL),
 			slog.String("api_url", bilibiliApiURL),
 			slog.Any("error", localizedError.Error()))
-		return 0, localizedError.Error()
+		return nil, localizedError.Error()
 	}
 
 	var result map[string]any
 	doc := json.NewDecoder(responseHandler.Body(config.Opts.HTTPClientMaxBodySize()))
 	if docErr := doc.Decode(&result); docErr != nil {
-		return 0, fmt.Errorf("failed to decode API response: %v", docErr)
+		return nil, fmt.Errorf("failed to decode API response: %v", docErr)
 	}
 
 	if code, ok := result["code"].(float64); !ok || code != 0 {
-		return 0, fmt.Errorf("API returned error code: %v", result["code"])
+		return nil, fmt.Errorf("API returned error code: %v", result["code"])
 	}
 
 	data, ok := result["data"].(map[string]any)
 	if !ok {
-		return 0, errors.New("data field not found or not an object")
+		return nil, errors.New("data field not found or not an object")
 	}
 
 	duration, ok := data["duration"].(float64)
 	if !ok {
-		return 0, errors.New("duration not found or not a number")
+		return nil, errors.New("duration not found or not a number")
 	}
-	intDuration := int(duration)
-	durationMin := intDuration / 60
-	if intDuration%60 != 0 {
-		durationMin++
+
+	metadata := &videoMetadata{Duration: int(duration), roundUpReadingTime: true}
+	if pic, ok := data["pic"].(string); ok {
+		metadata.ThumbnailURL = pic
+	}
+	if owner, ok := data["owner"].(map[string]any); ok {
+		if name, ok := owner["name"].(string); ok {
+			metadata.ChannelName = name
+		}
 	}
-	return durationMin, nil
+	return metadata, nil
 }
@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package processor // import "miniflux.app/v2/internal/reader/processor"
+
+import (
+	"log/slog"
+
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/storage"
+)
+
+// processDuplicateEntry applies the user preference to a new entry that is a near-duplicate of an entry from another feed.
+// It returns false when the entry is merged into the original one and must not be stored.
+func processDuplicateEntry(store *storage.Storage, user *model.User, feed *model.Feed, entry *model.Entry) bool {
+	switch user.DuplicateEntriesAction {
+	case model.DuplicateEntriesActionAnnotate, model.DuplicateEntriesActionMarkAsRead, model.DuplicateEntriesActionMerge:
+	default:
+		return true
+	}
+
+	originalEntryID, err := store.FindDuplicateEntry(user.ID, feed.ID, entry.Fingerprint)
+	if err != nil {
+		slog.Error("Unable to find duplicate entries",
+			slog.Int64("user_id", user.ID),
+			slog.Int64("feed_id", feed.ID),
+			slog.Any("error", err),
+		)
+		return true
+	}
+
+	if originalEntryID == 0 {
+		return true
+	}
+
+	slog.Debug("Entry is a near-duplicate",
+		slog.Int64("user_id", user.ID),
+		slog.String("entry_url", entry.URL),
+		slog.String("entry_hash", entry.Hash),
+		slog.Int64("feed_id", feed.ID),
+		slog.Int64("original_entry_id", originalEntryID),
+		slog.String("duplicate_entries_action", user.DuplicateEntriesAction),
+	)
+
+	if user.DuplicateEntriesAction == model.DuplicateEntriesActionMerge {
+		if err := store.MergeDuplicateEntry(originalEntryID, feed.ID, entry.Hash, entry.URL); err != nil {
+			slog.Error("Unable to merge duplicate entry",
+				slog.Int64("user_id", user.ID),
+				slog.Int64("feed_id", feed.ID),
+				slog.Any("error", err),
+			)
+			return true
+		}
+		return false
+	}
+
+	if user.DuplicateEntriesAction == model.DuplicateEntriesActionMarkAsRead {
+		entry.Status = model.EntryStatusRead
+	}
+
+	if err := store.CreateEntryDuplicate(originalEntryID, feed.ID, entry.URL); err != nil {
+		slog.Error("Unable to record duplicate entry",
+			slog.Int64("user_id", user.ID),
+			slog.Int64("feed_id", feed.ID),
+			slog.Any("error", err),
+		)
+	}
+
+	return true
+}
@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package processor // import "miniflux.app/v2/internal/reader/processor"
+
+import (
+	"errors"
+	"log/slog"
+	"os"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/proxyrotator"
+	"miniflux.app/v2/internal/reader/attachment"
+	"miniflux.app/v2/internal/reader/fetcher"
+	"miniflux.app/v2/internal/reader/mirror"
+	"miniflux.app/v2/internal/storage"
+)
+
+// ExtractEnclosureText extracts the text of a PDF or transcript enclosure and adds it to the search index of the entry.
+// The local copy of the enclosure is used when it is mirrored. A failed attempt is stored as well,
+// so the scheduler does not retry it before the retry interval.
+func ExtractEnclosureText(store *storage.Storage, feed *model.Feed, enclosure *model.Enclosure) error {
+	enclosureText := &model.EnclosureText{
+		EnclosureID: enclosure.ID,
+		UserID:      enclosure.UserID,
+	}
+
+	data, extractionErr := readEnclosure(store, feed, enclosure)
+	if extractionErr == nil {
+		enclosureText.Text, extractionErr = attachment.ExtractText(data, enclosure.MimeType)
+	}
+	if extractionErr == nil && enclosureText.Text == "" {
+		extractionErr = errors.New("processor: the enclosure does not contain any text")
+	}
+
+	if extractionErr != nil {
+		slog.Warn("Unable to extract enclosure text",
+			slog.Int64("user_id", enclosure.UserID),
+			slog.Int64("enclosure_id", enclosure.ID),
+			slog.String("enclosure_url", enclosure.URL),
+			slog.Any("error", extractionErr),
+		)
+		enclosureText.Error = extractionErr.Error()
+	}
+
+	if err := store.SaveEnclosureText(enclosureText); err != nil {
+		return err
+	}
+
+	if extractionErr != nil {
+		return extractionErr
+	}
+
+	slog.Debug("Enclosure text extracted",
+		slog.Int64("user_id", enclosure.UserID),
+		slog.Int64("enclosure_id", enclosure.ID),
+		slog.String("enclosure_url", enclosure.URL),
+		slog.Int("text_length", len(enclosureText.Text)),
+	)
+
+	return nil
+}
+
+func readEnclosure(store *storage.Storage, feed *model.Feed, enclosure *model.Enclosure) ([]byte, error) {
+	maxSize := config.Opts.AttachmentTextMaxFileSize()
+
+	if directory := config.Opts.EnclosureMirrorDir(); directory != "" {
+		enclosureMirror, err := store.EnclosureMirror(enclosure.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		if enclosureMirror != nil {
+			if enclosureMirror.Size > maxSize {
+				return nil, mirror.ErrFileTooLarge
+			}
+			return os.ReadFile(mirror.Path(directory, enclosure.UserID, enclosure.ID))
+		}
+	}
+
+	requestBuilder := fetcher.NewRequestBuilder()
+	requestBuilder.WithUserAgent(feed.EffectiveUserAgent(), config.Opts.HTTPClientUserAgent())
+	requestBuilder.WithCookie(feed.Cookie)
+	requestBuilder.WithTimeout(config.Opts.HTTPClientTimeout())
+	requestBuilder.WithProxyRotator(proxyrotator.ProxyRotatorInstance)
+	requestBuilder.WithCustomFeedProxyURL(feed.EffectiveProxyURL())
+	requestBuilder.WithCustomApplicationProxyURL(config.Opts.HTTPClientProxyURL())
+	requestBuilder.UseCustomApplicationProxyURL(feed.EffectiveFetchViaProxy())
+	requestBuilder.IgnoreTLSErrors(feed.AllowSelfSignedCertificates)
+	requestBuilder.DisableHTTP2(feed.DisableHTTP2)
+
+	responseHandler := fetcher.NewResponseHandler(requestBuilder.ExecuteRequest(enclosure.URL))
+	defer responseHandler.Close()
+
+	if localizedError := responseHandler.LocalizedError(); localizedError != nil {
+		return nil, localizedError.Error()
+	}
+
+	data, localizedError := responseHandler.ReadBody(maxSize)
+	if localizedError != nil {
+		return nil, localizedError.Error()
+	}
+
+	return data, nil
+}
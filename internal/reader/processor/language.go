@@ -0,0 +1,18 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package processor // import "miniflux.app/v2/internal/reader/processor"
+
+import (
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/language"
+	"miniflux.app/v2/internal/reader/sanitizer"
+)
+
+// updateEntryLanguage normalizes the language declared by the feed,
+// or detects the language from the title and the content when the feed does not declare one.
+func updateEntryLanguage(entry *model.Entry) {
+	if entry.Language = language.Normalize(entry.Language); entry.Language == "" {
+		entry.Language = language.Detect(entry.Title + " " + sanitizer.StripTags(entry.Content))
+	}
+}
@@ -0,0 +1,29 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package processor // import "miniflux.app/v2/internal/reader/processor"
+
+import (
+	"testing"
+
+	"miniflux.app/v2/internal/model"
+)
+
+func TestUpdateEntryLanguage(t *testing.T) {
+	scenarios := []struct {
+		entry    *model.Entry
+		expected string
+	}{
+		{&model.Entry{Language: "fr-CA", Title: "The title"}, "fr"},
+		{&model.Entry{Title: "Die neue Version", Content: "<p>Die Bibliothek ist verfügbar und sie ist schneller als die vorherige.</p>"}, "de"},
+		{&model.Entry{Language: "invalid language", Title: "La nouvelle version", Content: "<p>Elle est plus rapide que la précédente et les tests sont plus courts.</p>"}, "fr"},
+		{&model.Entry{Title: "Go 1.22"}, ""},
+	}
+
+	for _, scenario := range scenarios {
+		updateEntryLanguage(scenario.entry)
+		if scenario.entry.Language != scenario.expected {
+			t.Errorf(`Unexpected language for %q: got %q instead of %q`, scenario.entry.Title, scenario.entry.Language, scenario.expected)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package processor // import "miniflux.app/v2/internal/reader/processor"
+
+import (
+	"log/slog"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/proxyrotator"
+	"miniflux.app/v2/internal/reader/fetcher"
+	"miniflux.app/v2/internal/reader/mirror"
+	"miniflux.app/v2/internal/storage"
+)
+
+// MirrorEnclosure downloads the enclosure to the mirror directory and stores it.
+// A failed attempt is stored as well, so the scheduler does not retry it before the retry interval.
+func MirrorEnclosure(store *storage.Storage, feed *model.Feed, enclosure *model.Enclosure) error {
+	requestBuilder := fetcher.NewRequestBuilder()
+	requestBuilder.WithUserAgent(feed.EffectiveUserAgent(), config.Opts.HTTPClientUserAgent())
+	requestBuilder.WithCookie(feed.Cookie)
+	requestBuilder.WithTimeout(mirror.DownloadTimeout)
+	requestBuilder.WithProxyRotator(proxyrotator.ProxyRotatorInstance)
+	requestBuilder.WithCustomFeedProxyURL(feed.EffectiveProxyURL())
+	requestBuilder.WithCustomApplicationProxyURL(config.Opts.HTTPClientProxyURL())
+	requestBuilder.UseCustomApplicationProxyURL(feed.EffectiveFetchViaProxy())
+	requestBuilder.IgnoreTLSErrors(feed.AllowSelfSignedCertificates)
+	requestBuilder.DisableHTTP2(feed.DisableHTTP2)
+
+	enclosureMirror := &model.EnclosureMirror{
+		EnclosureID: enclosure.ID,
+		UserID:      enclosure.UserID,
+		MimeType:    enclosure.Html5MimeType(),
+	}
+
+	destination := mirror.Path(config.Opts.EnclosureMirrorDir(), enclosure.UserID, enclosure.ID)
+	size, downloadErr := mirror.Download(requestBuilder, enclosure.URL, destination, config.Opts.EnclosureMirrorMaxFileSize())
+	if downloadErr != nil {
+		slog.Warn("Unable to mirror enclosure",
+			slog.Int64("user_id", enclosure.UserID),
+			slog.Int64("enclosure_id", enclosure.ID),
+			slog.String("enclosure_url", enclosure.URL),
+			slog.Any("error", downloadErr),
+		)
+		enclosureMirror.Error = downloadErr.Error()
+	} else {
+		enclosureMirror.Size = size
+	}
+
+	if err := store.SaveEnclosureMirror(enclosureMirror); err != nil {
+		return err
+	}
+
+	if downloadErr != nil {
+		return downloadErr
+	}
+
+	slog.Debug("Enclosure mirrored",
+		slog.Int64("user_id", enclosure.UserID),
+		slog.Int64("enclosure_id", enclosure.ID),
+		slog.String("enclosure_url", enclosure.URL),
+		slog.Int64("size", size),
+	)
+
+	return nil
+}
@@ -17,6 +17,6 @@ func shouldFetchNebulaWatchTime(entry *model.Entry) bool {
 	return urllib.DomainWithoutWWW(entry.URL) == "nebula.tv"
 }
 
-func fetchNebulaWatchTime(websiteURL string) (int, error) {
-	return fetchWatchTime(websiteURL, `meta[property="video:duration"]`, false)
+func fetchNebulaVideoMetadata(websiteURL string) (*videoMetadata, error) {
+	return fetchVideoMetadata(websiteURL, videoPageQueries{duration: `meta[property="video:duration"]`})
 }
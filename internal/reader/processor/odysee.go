@@ -17,6 +17,6 @@ func shouldFetchOdyseeWatchTime(entry *model.Entry) bool {
 	return urllib.DomainWithoutWWW(entry.URL) == "odysee.com"
 }
 
-func fetchOdyseeWatchTime(websiteURL string) (int, error) {
-	return fetchWatchTime(websiteURL, `meta[property="og:video:duration"]`, false)
+func fetchOdyseeVideoMetadata(websiteURL string) (*videoMetadata, error) {
+	return fetchVideoMetadata(websiteURL, videoPageQueries{duration: `meta[property="og:video:duration"]`})
 }
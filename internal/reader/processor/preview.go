@@ -0,0 +1,103 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package processor // import "miniflux.app/v2/internal/reader/processor"
+
+import (
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/rules"
+	"miniflux.app/v2/internal/storage"
+)
+
+// PreviewRules evaluates candidate rules against the most recent stored entries, without modifying them.
+// The rules are built like in ProcessFeedEntries, so the preview matches what the next refresh would do.
+func PreviewRules(store *storage.Storage, userID int64, request *model.RulePreviewRequest) (*model.RulePreview, error) {
+	user, err := store.UserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.User != nil {
+		request.User.Patch(user)
+	}
+
+	feeds, err := store.Feeds(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	feedsByID := make(map[int64]*model.Feed, len(feeds))
+	for _, feed := range feeds {
+		if request.Category != nil && feed.Category.ID == request.CategoryID {
+			request.Category.Patch(feed.Category)
+		}
+		if request.Feed != nil && feed.ID == request.FeedID {
+			request.Feed.Patch(feed)
+		}
+		feedsByID[feed.ID] = feed
+	}
+
+	limit := request.Limit
+	if limit <= 0 {
+		limit = model.DefaultRulePreviewLimit
+	}
+	limit = min(limit, model.MaxRulePreviewLimit)
+
+	builder := store.NewEntryQueryBuilder(userID)
+	builder.WithFeedID(request.FeedID)
+	builder.WithCategoryID(request.CategoryID)
+	builder.WithEnclosures()
+	builder.WithSorting("published_at", "DESC")
+	builder.WithLimit(limit)
+
+	entries, err := builder.GetEntries()
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &model.RulePreview{Entries: make([]*model.RulePreviewEntry, 0, len(entries))}
+	ruleSets := make(map[int64]rules.Rules)
+	for _, entry := range entries {
+		feed, found := feedsByID[entry.FeedID]
+		if !found {
+			continue
+		}
+
+		ruleSet, found := ruleSets[feed.ID]
+		if !found {
+			ruleSet = rules.ForFeed(user, feed)
+			ruleSets[feed.ID] = ruleSet
+		}
+
+		result := ruleSet.DryRun(entry)
+		previewEntry := &model.RulePreviewEntry{
+			EntryID:      entry.ID,
+			FeedID:       entry.FeedID,
+			Title:        entry.Title,
+			URL:          entry.URL,
+			Blocked:      result.Blocked,
+			MatchedRules: make([]*model.RulePreviewRule, 0, len(result.Matched)),
+		}
+		for _, rule := range result.Matched {
+			previewEntry.MatchedRules = append(previewEntry.MatchedRules, newRulePreviewRule(rule))
+		}
+		if result.Blocked {
+			previewEntry.Rule = newRulePreviewRule(result.BlockedBy)
+			preview.Blocked++
+		}
+
+		preview.Entries = append(preview.Entries, previewEntry)
+	}
+	preview.Total = len(preview.Entries)
+
+	return preview, nil
+}
+
+func newRulePreviewRule(rule *rules.Rule) *model.RulePreviewRule {
+	return &model.RulePreviewRule{
+		Rule:   rule.String(),
+		Source: rule.Source,
+		Field:  rule.Field(),
+		Line:   rule.Line,
+	}
+}
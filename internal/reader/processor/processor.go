@@ -13,10 +13,12 @@ import (
 	"miniflux.app/v2/internal/metric"
 	"miniflux.app/v2/internal/model"
 	"miniflux.app/v2/internal/proxyrotator"
+	"miniflux.app/v2/internal/reader/enrichment"
 	"miniflux.app/v2/internal/reader/fetcher"
-	"miniflux.app/v2/internal/reader/filter"
+	"miniflux.app/v2/internal/reader/fingerprint"
 	"miniflux.app/v2/internal/reader/readingtime"
 	"miniflux.app/v2/internal/reader/rewrite"
+	"miniflux.app/v2/internal/reader/rules"
 	"miniflux.app/v2/internal/reader/sanitizer"
 	"miniflux.app/v2/internal/reader/scraper"
 	"miniflux.app/v2/internal/reader/urlcleaner"
@@ -37,27 +39,24 @@ func ProcessFeedEntries(store *storage.Storage, feed *model.Feed, userID int64,
 	parsedFeedURL, _ := url.Parse(feed.FeedURL)
 	parsedSiteURL, _ := url.Parse(feed.SiteURL)
 
-	blockRules := filter.ParseRules(user.BlockFilterEntryRules, feed.BlockFilterEntryRules)
-	allowRules := filter.ParseRules(user.KeepFilterEntryRules, feed.KeepFilterEntryRules)
-	slog.Debug("Filter rules",
-		slog.String("user_block_filter_rules", user.BlockFilterEntryRules),
-		slog.String("feed_block_filter_rules", feed.BlockFilterEntryRules),
-		slog.String("user_keep_filter_rules", user.KeepFilterEntryRules),
-		slog.String("feed_keep_filter_rules", feed.KeepFilterEntryRules),
-		slog.Any("block_rules", blockRules),
-		slog.Any("allow_rules", allowRules),
+	ruleSet := rules.ForFeed(user, feed)
+	slog.Debug("Entry rules",
+		slog.String("rules", ruleSet.String()),
 		slog.Int64("user_id", user.ID),
 		slog.Int64("feed_id", feed.ID),
 	)
 
+	ruleHits := make(map[*rules.Rule]int)
+	enrichmentProcessors := feed.EffectiveEnrichmentProcessors()
+
 	requestBuilder := fetcher.NewRequestBuilder()
-	requestBuilder.WithUserAgent(feed.UserAgent, config.Opts.HTTPClientUserAgent())
+	requestBuilder.WithUserAgent(feed.EffectiveUserAgent(), config.Opts.HTTPClientUserAgent())
 	requestBuilder.WithCookie(feed.Cookie)
 	requestBuilder.WithTimeout(config.Opts.HTTPClientTimeout())
 	requestBuilder.WithProxyRotator(proxyrotator.ProxyRotatorInstance)
-	requestBuilder.WithCustomFeedProxyURL(feed.ProxyURL)
+	requestBuilder.WithCustomFeedProxyURL(feed.EffectiveProxyURL())
 	requestBuilder.WithCustomApplicationProxyURL(config.Opts.HTTPClientProxyURL())
-	requestBuilder.UseCustomApplicationProxyURL(feed.FetchViaProxy)
+	requestBuilder.UseCustomApplicationProxyURL(feed.EffectiveFetchViaProxy())
 	requestBuilder.IgnoreTLSErrors(feed.AllowSelfSignedCertificates)
 	requestBuilder.DisableHTTP2(feed.DisableHTTP2)
 
@@ -72,29 +71,25 @@ func ProcessFeedEntries(store *storage.Storage, feed *model.Feed, userID int64,
 			slog.String("feed_url", feed.FeedURL),
 		)
 
-		if filter.IsBlockedEntry(blockRules, allowRules, feed, entry) {
-			slog.Debug("Entry is blocked by filter rules",
-				slog.Int64("user_id", user.ID),
-				slog.String("entry_url", entry.URL),
-				slog.String("entry_hash", entry.Hash),
-				slog.String("entry_title", entry.Title),
-				slog.Int64("feed_id", feed.ID),
-				slog.String("feed_url", feed.FeedURL),
-				slog.String("filter_stage", "before_scrape"),
-			)
-			continue
-		}
-
 		parsedInputUrl, _ := url.Parse(entry.URL)
 		if cleanedURL, err := urlcleaner.RemoveTrackingParameters(parsedFeedURL, parsedSiteURL, parsedInputUrl); err == nil {
 			entry.URL = cleanedURL
 		}
 
+		updateEntryLanguage(entry)
+
+		result := ruleSet.Apply(entry)
+		if result.Blocked {
+			if recordBlockedEntry(store, user, feed, entry, result.BlockedBy, "before_scrape") {
+				countRuleHits(ruleHits, result.Matched, result.BlockedBy)
+			}
+			continue
+		}
+
 		webpageBaseURL := ""
-		entry.URL = rewrite.RewriteEntryURL(feed, entry)
 		entryIsNew := store.IsNewEntry(feed.ID, entry.Hash)
 		contentExtractedSuccessfully := false
-		if feed.Crawler && (entryIsNew || forceRefresh) {
+		if feed.EffectiveCrawler() && (entryIsNew || forceRefresh) {
 			slog.Debug("Scraping entry",
 				slog.Int64("user_id", user.ID),
 				slog.String("entry_url", entry.URL),
@@ -111,7 +106,7 @@ func ProcessFeedEntries(store *storage.Storage, feed *model.Feed, userID int64,
 			scrapedPageBaseURL, extractedContent, scraperErr := scraper.ScrapeWebsite(
 				requestBuilder,
 				entry.URL,
-				feed.ScraperRules,
+				feed.EffectiveScraperRules(),
 			)
 
 			if scrapedPageBaseURL != "" {
@@ -141,20 +136,26 @@ func ProcessFeedEntries(store *storage.Storage, feed *model.Feed, userID int64,
 			}
 		}
 
-		rewrite.ApplyContentRewriteRules(entry, feed.RewriteRules)
+		rewrite.ApplyContentRewriteRules(entry, feed.EffectiveRewriteRules())
+		for _, contentRewriteRules := range result.ContentRewriteRules {
+			rewrite.ApplyCustomContentRewriteRules(entry, contentRewriteRules)
+		}
 
-		// Re-run filters only when extracted content replaced entry.Content.
-		if contentExtractedSuccessfully && filter.IsBlockedEntry(blockRules, allowRules, feed, entry) {
-			slog.Debug("Entry is blocked by filter rules",
-				slog.Int64("user_id", user.ID),
-				slog.String("entry_url", entry.URL),
-				slog.String("entry_hash", entry.Hash),
-				slog.String("entry_title", entry.Title),
-				slog.Int64("feed_id", feed.ID),
-				slog.String("feed_url", feed.FeedURL),
-				slog.String("filter_stage", "after_scrape"),
-			)
-			continue
+		contentEnriched := false
+		if len(enrichmentProcessors) > 0 && (entryIsNew || forceRefresh) {
+			contentEnriched = enrichment.EnrichEntry(enrichmentProcessors, feed, entry)
+			// The enrichment processors may return another language or remove it.
+			updateEntryLanguage(entry)
+		}
+
+		// Re-run the rules only when extracted or enriched content replaced entry.Content.
+		if contentExtractedSuccessfully || contentEnriched {
+			if blocked, rule := ruleSet.Blocks(entry); blocked {
+				if recordBlockedEntry(store, user, feed, entry, rule, "after_scrape") {
+					countRuleHits(ruleHits, result.Matched, rule)
+				}
+				continue
+			}
 		}
 
 		if webpageBaseURL == "" {
@@ -164,13 +165,30 @@ func ProcessFeedEntries(store *storage.Storage, feed *model.Feed, userID int64,
 		// The sanitizer should always run at the end of the process to make sure unsafe HTML is filtered out.
 		entry.Content = sanitizer.SanitizeHTML(webpageBaseURL, entry.Content, &sanitizer.SanitizerOptions{OpenLinksInNewTab: user.OpenExternalLinksInNewTab})
 
-		updateEntryReadingTime(store, feed, entry, entryIsNew, user)
+		if entryIsNew {
+			countRuleHits(ruleHits, result.Matched, nil)
+		}
+
+		updateEntryVideoMetadata(store, feed, entry, entryIsNew, user)
+		updateEntryReadingTime(entry, user)
+
+		entry.Fingerprint = fingerprint.Compute(entry.Title, entry.Content)
+		if entryIsNew && !processDuplicateEntry(store, user, feed, entry) {
+			continue
+		}
+
+		// The entries are sent once they are stored and have an ID.
+		if entryIsNew {
+			entry.SendToIntegrations = result.SendToIntegrations
+		}
 
 		filteredEntries = append(filteredEntries, entry)
 	}
 
-	if user.ShowReadingTime && shouldFetchYouTubeWatchTimeInBulk() {
-		fetchYouTubeWatchTimeInBulk(filteredEntries)
+	saveRuleHits(store, user, feed, ruleHits)
+
+	if shouldFetchYouTubeWatchTimeInBulk() {
+		fetchYouTubeVideoMetadataInBulk(filteredEntries, user)
 	}
 
 	feed.Entries = filteredEntries
@@ -182,20 +200,20 @@ func ProcessEntryWebPage(feed *model.Feed, entry *model.Entry, user *model.User)
 	entry.URL = rewrite.RewriteEntryURL(feed, entry)
 
 	requestBuilder := fetcher.NewRequestBuilder()
-	requestBuilder.WithUserAgent(feed.UserAgent, config.Opts.HTTPClientUserAgent())
+	requestBuilder.WithUserAgent(feed.EffectiveUserAgent(), config.Opts.HTTPClientUserAgent())
 	requestBuilder.WithCookie(feed.Cookie)
 	requestBuilder.WithTimeout(config.Opts.HTTPClientTimeout())
 	requestBuilder.WithProxyRotator(proxyrotator.ProxyRotatorInstance)
-	requestBuilder.WithCustomFeedProxyURL(feed.ProxyURL)
+	requestBuilder.WithCustomFeedProxyURL(feed.EffectiveProxyURL())
 	requestBuilder.WithCustomApplicationProxyURL(config.Opts.HTTPClientProxyURL())
-	requestBuilder.UseCustomApplicationProxyURL(feed.FetchViaProxy)
+	requestBuilder.UseCustomApplicationProxyURL(feed.EffectiveFetchViaProxy())
 	requestBuilder.IgnoreTLSErrors(feed.AllowSelfSignedCertificates)
 	requestBuilder.DisableHTTP2(feed.DisableHTTP2)
 
 	webpageBaseURL, extractedContent, scraperErr := scraper.ScrapeWebsite(
 		requestBuilder,
 		entry.URL,
-		feed.ScraperRules,
+		feed.EffectiveScraperRules(),
 	)
 
 	if config.Opts.HasMetricsCollector() {
@@ -217,8 +235,78 @@ func ProcessEntryWebPage(feed *model.Feed, entry *model.Entry, user *model.User)
 		}
 	}
 
-	rewrite.ApplyContentRewriteRules(entry, entry.Feed.RewriteRules)
+	rewrite.ApplyContentRewriteRules(entry, entry.Feed.EffectiveRewriteRules())
 	entry.Content = sanitizer.SanitizeHTML(webpageBaseURL, entry.Content, &sanitizer.SanitizerOptions{OpenLinksInNewTab: user.OpenExternalLinksInNewTab})
 
 	return nil
 }
+
+// recordBlockedEntry adds a new entry blocked by a rule to the blocked log of the feed.
+// It returns false if the entry is already stored or already in the log.
+func recordBlockedEntry(store *storage.Storage, user *model.User, feed *model.Feed, entry *model.Entry, rule *rules.Rule, stage string) bool {
+	slog.Debug("Entry is blocked by rules",
+		slog.Int64("user_id", user.ID),
+		slog.String("entry_url", entry.URL),
+		slog.String("entry_hash", entry.Hash),
+		slog.String("entry_title", entry.Title),
+		slog.Int64("feed_id", feed.ID),
+		slog.String("feed_url", feed.FeedURL),
+		slog.String("rule", rule.String()),
+		slog.String("rule_source", rule.Source),
+		slog.Int("rule_line", rule.Line),
+		slog.String("filter_stage", stage),
+	)
+
+	recorded, err := store.RecordBlockedEntry(&model.BlockedEntry{
+		UserID: user.ID,
+		FeedID: feed.ID,
+		Hash:   entry.Hash,
+		Title:  entry.Title,
+		URL:    entry.URL,
+		Rule:   newRulePreviewRule(rule),
+		Stage:  stage,
+		Entry:  entry,
+	})
+	if err != nil {
+		slog.Error("Unable to record blocked entry",
+			slog.Int64("user_id", user.ID),
+			slog.Int64("feed_id", feed.ID),
+			slog.String("entry_hash", entry.Hash),
+			slog.Any("error", err),
+		)
+		return false
+	}
+
+	return recorded
+}
+
+// countRuleHits adds one hit to each rule matching a new entry, including the rule blocking the entry.
+func countRuleHits(hits map[*rules.Rule]int, matched rules.Rules, blockedBy *rules.Rule) {
+	for _, rule := range matched {
+		hits[rule]++
+	}
+	if blockedBy != nil && !slices.Contains(matched, blockedBy) {
+		hits[blockedBy]++
+	}
+}
+
+func saveRuleHits(store *storage.Storage, user *model.User, feed *model.Feed, hits map[*rules.Rule]int) {
+	ruleHits := make([]*model.RuleHit, 0, len(hits))
+	for rule, count := range hits {
+		ruleHits = append(ruleHits, &model.RuleHit{
+			Rule:   rule.String(),
+			Source: rule.Source,
+			Field:  rule.Field(),
+			Line:   rule.Line,
+			Hits:   count,
+		})
+	}
+
+	if err := store.RecordRuleHits(user.ID, feed.ID, ruleHits); err != nil {
+		slog.Error("Unable to record rule hits",
+			slog.Int64("user_id", user.ID),
+			slog.Int64("feed_id", feed.ID),
+			slog.Any("error", err),
+		)
+	}
+}
@@ -0,0 +1,79 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package processor // import "miniflux.app/v2/internal/reader/processor"
+
+import (
+	"fmt"
+
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/fingerprint"
+	"miniflux.app/v2/internal/reader/rewrite"
+	"miniflux.app/v2/internal/reader/rules"
+	"miniflux.app/v2/internal/reader/sanitizer"
+	"miniflux.app/v2/internal/storage"
+)
+
+// FeedRuleAudit returns the rules currently applied to the feed with their hit counts, and the recently blocked entries.
+// Counters recorded for rules that were removed or modified since are not returned.
+func FeedRuleAudit(store *storage.Storage, user *model.User, feed *model.Feed) (*model.RuleAudit, error) {
+	storedHits, err := store.RuleHits(user.ID, feed.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	blockedEntries, err := store.BlockedEntries(user.ID, feed.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	ruleSet := rules.ForFeed(user, feed)
+	audit := &model.RuleAudit{
+		Hits:           make([]*model.RuleHit, 0, len(ruleSet)),
+		BlockedEntries: blockedEntries,
+	}
+	for _, rule := range ruleSet {
+		audit.Hits = append(audit.Hits, findRuleHit(storedHits, rule))
+	}
+
+	return audit, nil
+}
+
+func findRuleHit(storedHits []*model.RuleHit, rule *rules.Rule) *model.RuleHit {
+	hit := &model.RuleHit{
+		Rule:   rule.String(),
+		Source: rule.Source,
+		Field:  rule.Field(),
+		Line:   rule.Line,
+	}
+	for _, storedHit := range storedHits {
+		if storedHit.Source == hit.Source && storedHit.Field == hit.Field && storedHit.Line == hit.Line && storedHit.Rule == hit.Rule {
+			hit.Hits = storedHit.Hits
+			hit.LastHitAt = storedHit.LastHitAt
+			break
+		}
+	}
+	return hit
+}
+
+// RescueBlockedEntry stores an entry of the blocked log as unread.
+// The entry is sanitized like the entries kept by ProcessFeedEntries, but the website is not scraped again.
+func RescueBlockedEntry(store *storage.Storage, user *model.User, feed *model.Feed, blockedEntry *model.BlockedEntry) error {
+	if blockedEntry.FeedID != feed.ID {
+		return fmt.Errorf("blocked entry #%d does not belong to feed #%d", blockedEntry.ID, feed.ID)
+	}
+
+	entry := blockedEntry.Entry
+
+	// The content rewrite rules are applied before the rules are evaluated again after scraping.
+	if blockedEntry.Stage != "after_scrape" {
+		rewrite.ApplyContentRewriteRules(entry, feed.EffectiveRewriteRules())
+	}
+
+	entry.Content = sanitizer.SanitizeHTML(entry.URL, entry.Content, &sanitizer.SanitizerOptions{OpenLinksInNewTab: user.OpenExternalLinksInNewTab})
+	updateEntryVideoMetadata(store, feed, entry, true, user)
+	updateEntryReadingTime(entry, user)
+	entry.Fingerprint = fingerprint.Compute(entry.Title, entry.Content)
+
+	return store.RescueBlockedEntry(blockedEntry)
+}
@@ -0,0 +1,55 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package processor // import "miniflux.app/v2/internal/reader/processor"
+
+import (
+	"testing"
+
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/rules"
+)
+
+func TestCountRuleHits(t *testing.T) {
+	ruleSet, err := rules.Parse("title ~ \"a\" then star\ntitle ~ \"b\" then block")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hits := make(map[*rules.Rule]int)
+	countRuleHits(hits, ruleSet, ruleSet[1])
+	countRuleHits(hits, ruleSet[:1], ruleSet[1])
+	countRuleHits(hits, ruleSet[:1], nil)
+
+	if hits[ruleSet[0]] != 3 {
+		t.Errorf(`Unexpected hits for the first rule, got %d instead of 3`, hits[ruleSet[0]])
+	}
+
+	if hits[ruleSet[1]] != 2 {
+		t.Errorf(`Unexpected hits for the blocking rule, got %d instead of 2`, hits[ruleSet[1]])
+	}
+}
+
+func TestFindRuleHit(t *testing.T) {
+	ruleSet, err := rules.Parse("title ~ \"a\" then block")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rule := ruleSet[0]
+	rule.Source = rules.SourceFeed
+
+	storedHits := []*model.RuleHit{
+		{Rule: rule.String(), Source: rules.SourceUser, Field: rule.Field(), Line: rule.Line, Hits: 1},
+		{Rule: rule.String(), Source: rules.SourceFeed, Field: rule.Field(), Line: rule.Line, Hits: 5},
+	}
+
+	if hit := findRuleHit(storedHits, rule); hit.Hits != 5 {
+		t.Errorf(`Unexpected hits, got %d instead of 5`, hit.Hits)
+	}
+
+	// The counter of a modified rule is not returned.
+	storedHits[1].Rule = `if title ~ "b" then block`
+	if hit := findRuleHit(storedHits, rule); hit.Hits != 0 {
+		t.Errorf(`Unexpected hits for a modified rule, got %d instead of 0`, hit.Hits)
+	}
+}
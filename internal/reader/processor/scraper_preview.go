@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package processor // import "miniflux.app/v2/internal/reader/processor"
+
+import (
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/proxyrotator"
+	"miniflux.app/v2/internal/reader/fetcher"
+	"miniflux.app/v2/internal/reader/readingtime"
+	"miniflux.app/v2/internal/reader/rewrite"
+	"miniflux.app/v2/internal/reader/sanitizer"
+	"miniflux.app/v2/internal/reader/scraper"
+	"miniflux.app/v2/internal/storage"
+)
+
+// PreviewScraper scrapes a web page with candidate scraper and rewrite rules, like the crawler does when a feed is refreshed.
+// Nothing is stored.
+func PreviewScraper(store *storage.Storage, user *model.User, request *model.ScraperPreviewRequest) (*model.ScraperPreview, error) {
+	feed := &model.Feed{
+		ScraperRules:                request.ScraperRules,
+		RewriteRules:                request.RewriteRules,
+		UserAgent:                   request.UserAgent,
+		Cookie:                      request.Cookie,
+		AllowSelfSignedCertificates: request.AllowSelfSignedCertificates,
+		DisableHTTP2:                request.DisableHTTP2,
+		FetchViaProxyOverride:       model.RequestedFeedSettingOverride(request.FetchViaProxyOverride, request.FetchViaProxy),
+		ProxyURL:                    request.ProxyURL,
+		Category:                    &model.Category{},
+	}
+
+	if request.FeedID != 0 {
+		storedFeed, err := store.FeedByID(user.ID, request.FeedID)
+		if err != nil {
+			return nil, err
+		}
+		if storedFeed != nil {
+			feed.Category = storedFeed.Category
+		}
+	}
+
+	requestBuilder := fetcher.NewRequestBuilder()
+	requestBuilder.WithUserAgent(feed.EffectiveUserAgent(), config.Opts.HTTPClientUserAgent())
+	requestBuilder.WithCookie(feed.Cookie)
+	requestBuilder.WithTimeout(config.Opts.HTTPClientTimeout())
+	requestBuilder.WithProxyRotator(proxyrotator.ProxyRotatorInstance)
+	requestBuilder.WithCustomFeedProxyURL(feed.EffectiveProxyURL())
+	requestBuilder.WithCustomApplicationProxyURL(config.Opts.HTTPClientProxyURL())
+	requestBuilder.UseCustomApplicationProxyURL(feed.EffectiveFetchViaProxy())
+	requestBuilder.IgnoreTLSErrors(feed.AllowSelfSignedCertificates)
+	requestBuilder.DisableHTTP2(feed.DisableHTTP2)
+
+	result, err := scraper.Scrape(requestBuilder, request.URL, feed.EffectiveScraperRules())
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &model.Entry{
+		URL:     result.EffectiveURL,
+		Content: minifyContent(result.Content),
+	}
+	rewrite.ApplyContentRewriteRules(entry, feed.EffectiveRewriteRules())
+	entry.Content = sanitizer.SanitizeHTML(result.BaseURL, entry.Content, &sanitizer.SanitizerOptions{OpenLinksInNewTab: user.OpenExternalLinksInNewTab})
+
+	return &model.ScraperPreview{
+		EffectiveURL:    result.EffectiveURL,
+		Content:         entry.Content,
+		ReadingTime:     readingtime.EstimateReadingTime(entry.Content, user.DefaultReadingSpeed, user.CJKReadingSpeed),
+		Rules:           result.Rules,
+		PredefinedRules: result.PredefinedRules,
+	}, nil
+}
@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package processor // import "miniflux.app/v2/internal/reader/processor"
+
+import (
+	"log/slog"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/proxyrotator"
+	"miniflux.app/v2/internal/reader/fetcher"
+	"miniflux.app/v2/internal/reader/snapshot"
+	"miniflux.app/v2/internal/storage"
+)
+
+// SnapshotEntry downloads the web page of the entry with its images and stylesheets, and stores it.
+// A failed attempt is stored as well, so the scheduler does not retry it before the retry interval.
+func SnapshotEntry(store *storage.Storage, feed *model.Feed, entry *model.Entry) (*model.EntrySnapshot, error) {
+	requestBuilder := fetcher.NewRequestBuilder()
+	requestBuilder.WithUserAgent(feed.EffectiveUserAgent(), config.Opts.HTTPClientUserAgent())
+	requestBuilder.WithCookie(feed.Cookie)
+	requestBuilder.WithTimeout(config.Opts.HTTPClientTimeout())
+	requestBuilder.WithProxyRotator(proxyrotator.ProxyRotatorInstance)
+	requestBuilder.WithCustomFeedProxyURL(feed.EffectiveProxyURL())
+	requestBuilder.WithCustomApplicationProxyURL(config.Opts.HTTPClientProxyURL())
+	requestBuilder.UseCustomApplicationProxyURL(feed.EffectiveFetchViaProxy())
+	requestBuilder.IgnoreTLSErrors(feed.AllowSelfSignedCertificates)
+	requestBuilder.DisableHTTP2(feed.DisableHTTP2)
+
+	entrySnapshot := &model.EntrySnapshot{
+		EntryID: entry.ID,
+		UserID:  entry.UserID,
+		URL:     entry.URL,
+	}
+
+	pageSnapshot, snapshotErr := snapshot.Create(requestBuilder, entrySnapshot.URL, config.Opts.SnapshotMaxSize())
+	if snapshotErr != nil {
+		slog.Warn("Unable to create entry snapshot",
+			slog.Int64("user_id", entry.UserID),
+			slog.Int64("entry_id", entry.ID),
+			slog.String("entry_url", entrySnapshot.URL),
+			slog.Any("error", snapshotErr),
+		)
+		entrySnapshot.Error = snapshotErr.Error()
+	} else {
+		entrySnapshot.URL = pageSnapshot.URL
+		entrySnapshot.Content = pageSnapshot.Content
+	}
+
+	if err := store.SaveEntrySnapshot(entrySnapshot); err != nil {
+		return nil, err
+	}
+
+	if snapshotErr != nil {
+		return nil, snapshotErr
+	}
+
+	slog.Debug("Entry snapshot created",
+		slog.Int64("user_id", entry.UserID),
+		slog.Int64("entry_id", entry.ID),
+		slog.String("entry_url", entrySnapshot.URL),
+		slog.Int64("size", entrySnapshot.Size),
+	)
+
+	return entrySnapshot, nil
+}
@@ -0,0 +1,147 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package processor // import "miniflux.app/v2/internal/reader/processor"
+
+import (
+	"errors"
+	"log/slog"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/summary"
+	"miniflux.app/v2/internal/storage"
+)
+
+// summaryBatchSize is the number of entries waiting for a summary loaded at once by the summary worker.
+const summaryBatchSize = 100
+
+// summaryRequests wakes up the summary worker when new entries are waiting for a summary.
+var summaryRequests = make(chan struct{}, 1)
+
+// SummarizeEntry summarizes an existing entry on demand and saves the summary.
+func SummarizeEntry(store *storage.Storage, feed *model.Feed, entry *model.Entry) error {
+	if !config.Opts.HasSummaryAPI() {
+		return summary.ErrNotConfigured
+	}
+
+	if err := summary.SummarizeEntry(store, feed, entry); err != nil {
+		return err
+	}
+
+	return store.UpdateEntrySummary(entry)
+}
+
+// QueueEntrySummaries marks the stored new entries of a category with summaries enabled as waiting for a summary,
+// RunSummaryWorker summarizes them in the background.
+func QueueEntrySummaries(store *storage.Storage, feed *model.Feed, entries model.Entries) {
+	if !config.Opts.HasSummaryAPI() || feed.Category == nil || !feed.Category.SummarizeEntries {
+		return
+	}
+
+	entryIDs := make([]int64, 0, len(entries))
+	for _, entry := range entries {
+		if entry.ID != 0 && entry.Summary == "" {
+			entryIDs = append(entryIDs, entry.ID)
+		}
+	}
+
+	if len(entryIDs) == 0 {
+		return
+	}
+
+	if err := store.MarkEntrySummariesPending(feed.UserID, entryIDs); err != nil {
+		slog.Error("Unable to queue entry summaries",
+			slog.Int64("user_id", feed.UserID),
+			slog.Int64("feed_id", feed.ID),
+			slog.Any("error", err),
+		)
+		return
+	}
+
+	select {
+	case summaryRequests <- struct{}{}:
+	default:
+	}
+}
+
+// RunSummaryWorker summarizes the entries waiting for a summary one at a time, the summary API being usually a local model.
+// The pending entries are loaded from the database at startup and each time new entries are queued,
+// so the entries queued before a restart are also summarized.
+func RunSummaryWorker(store *storage.Storage) {
+	for {
+		for {
+			entries, err := store.EntriesWithPendingSummary(summaryBatchSize)
+			if err != nil {
+				slog.Error("Unable to fetch entries waiting for a summary", slog.Any("error", err))
+				break
+			}
+
+			if len(entries) == 0 {
+				break
+			}
+
+			if err := summarizePendingEntries(store, entries); err != nil {
+				slog.Error("Unable to summarize entries", slog.Any("error", err))
+				break
+			}
+		}
+
+		<-summaryRequests
+	}
+}
+
+// summarizePendingEntries reloads and summarizes the given entries.
+// The entries which cannot be summarized are no longer waiting for a summary.
+func summarizePendingEntries(store *storage.Storage, pendingEntries model.Entries) error {
+	feeds := make(map[int64]*model.Feed)
+	for _, pendingEntry := range pendingEntries {
+		builder := store.NewEntryQueryBuilder(pendingEntry.UserID)
+		builder.WithEntryID(pendingEntry.ID)
+		entry, err := builder.GetEntry()
+		if err != nil {
+			return err
+		}
+
+		feed, found := feeds[pendingEntry.FeedID]
+		if !found {
+			if feed, err = store.FeedByID(pendingEntry.UserID, pendingEntry.FeedID); err != nil {
+				return err
+			}
+			feeds[pendingEntry.FeedID] = feed
+		}
+
+		if entry == nil || feed == nil {
+			if err := store.ClearEntrySummaryPending(pendingEntry.UserID, pendingEntry.ID); err != nil {
+				return err
+			}
+			continue
+		}
+
+		err = SummarizeEntry(store, feed, entry)
+		switch {
+		case err == nil:
+			continue
+		case errors.Is(err, summary.ErrTokenBudgetExceeded):
+			slog.Debug("Skip entry summary, the daily token budget is exceeded",
+				slog.Int64("user_id", feed.UserID),
+				slog.Int64("entry_id", entry.ID),
+			)
+		default:
+			slog.Warn("Unable to summarize entry",
+				slog.Int64("user_id", feed.UserID),
+				slog.Int64("entry_id", entry.ID),
+				slog.String("entry_url", entry.URL),
+				slog.Int64("feed_id", feed.ID),
+				slog.String("feed_url", feed.FeedURL),
+				slog.Any("error", err),
+			)
+		}
+
+		if err := store.ClearEntrySummaryPending(entry.UserID, entry.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,285 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package processor // import "miniflux.app/v2/internal/reader/processor"
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/proxyrotator"
+	"miniflux.app/v2/internal/reader/fetcher"
+	"miniflux.app/v2/internal/storage"
+	"miniflux.app/v2/internal/urllib"
+)
+
+// videoMetadata describes the video of an entry.
+type videoMetadata struct {
+	ThumbnailURL string
+	Duration     int // in seconds
+	ChannelName  string
+
+	// roundUpReadingTime counts the last started minute in the reading time.
+	roundUpReadingTime bool
+}
+
+func (m *videoMetadata) isEmpty() bool {
+	return m.ThumbnailURL == "" && m.Duration <= 0 && m.ChannelName == ""
+}
+
+// merge copies the non-empty fields of other into m.
+func (m *videoMetadata) merge(other *videoMetadata) {
+	if other.ThumbnailURL != "" {
+		m.ThumbnailURL = other.ThumbnailURL
+	}
+	if other.Duration > 0 {
+		m.Duration = other.Duration
+	}
+	if other.ChannelName != "" {
+		m.ChannelName = other.ChannelName
+	}
+	if other.roundUpReadingTime {
+		m.roundUpReadingTime = true
+	}
+}
+
+// readingTime returns the video duration in minutes.
+func (m *videoMetadata) readingTime() int {
+	if m.roundUpReadingTime {
+		return (m.Duration + 59) / 60
+	}
+	return m.Duration / 60
+}
+
+// videoPageQueries describes where the video metadata are located in a web page.
+type videoPageQueries struct {
+	duration    string
+	isoDuration bool
+	channelName string
+}
+
+var videoMetadataScenarios = [...]struct {
+	shouldFetch func(*model.Entry) bool
+	fetchFunc   func(string) (*videoMetadata, error)
+	platform    string
+}{
+	{shouldFetchYouTubeWatchTimeForSingleEntry, fetchYouTubeVideoMetadataForSingleEntry, "YouTube"},
+	{shouldFetchNebulaWatchTime, fetchNebulaVideoMetadata, "Nebula"},
+	{shouldFetchOdyseeWatchTime, fetchOdyseeVideoMetadata, "Odysee"},
+	{shouldFetchBilibiliWatchTime, fetchBilibiliVideoMetadata, "Bilibili"},
+}
+
+func fetchVideoMetadata(websiteURL string, queries videoPageQueries) (*videoMetadata, error) {
+	requestBuilder := fetcher.NewRequestBuilder()
+	requestBuilder.WithTimeout(config.Opts.HTTPClientTimeout())
+	requestBuilder.WithProxyRotator(proxyrotator.ProxyRotatorInstance)
+
+	responseHandler := fetcher.NewResponseHandler(requestBuilder.ExecuteRequest(websiteURL))
+	defer responseHandler.Close()
+
+	if localizedError := responseHandler.LocalizedError(); localizedError != nil {
+		slog.Warn("Unable to fetch video metadata", slog.String("website_url", websiteURL), slog.Any("error", localizedError.Error()))
+		return nil, localizedError.Error()
+	}
+
+	doc, docErr := goquery.NewDocumentFromReader(responseHandler.Body(config.Opts.HTTPClientMaxBodySize()))
+	if docErr != nil {
+		return nil, docErr
+	}
+
+	metadata := &videoMetadata{}
+
+	if duration, exists := doc.FindMatcher(goquery.Single(queries.duration)).Attr("content"); exists {
+		if queries.isoDuration {
+			parsedDuration, err := parseISO8601Duration(duration)
+			if err != nil {
+				return nil, fmt.Errorf("unable to parse iso duration %s: %v", duration, err)
+			}
+			metadata.Duration = int(parsedDuration.Seconds())
+		} else {
+			parsedDuration, err := strconv.Atoi(duration)
+			if err != nil {
+				return nil, fmt.Errorf("unable to parse duration %s: %v", duration, err)
+			}
+			metadata.Duration = parsedDuration
+		}
+	}
+
+	if thumbnailURL, exists := doc.FindMatcher(goquery.Single(`meta[property="og:image"]`)).Attr("content"); exists {
+		if absoluteURL, err := urllib.ResolveToAbsoluteURL(websiteURL, strings.TrimSpace(thumbnailURL)); err == nil {
+			metadata.ThumbnailURL = absoluteURL
+		}
+	}
+
+	if queries.channelName != "" {
+		if channelName, exists := doc.FindMatcher(goquery.Single(queries.channelName)).Attr("content"); exists {
+			metadata.ChannelName = strings.TrimSpace(channelName)
+		}
+	}
+
+	if metadata.isEmpty() {
+		return nil, errors.New("video metadata not found")
+	}
+
+	return metadata, nil
+}
+
+// findVideoMetadataEnclosure returns the enclosure that holds the video metadata of the entry.
+// The enclosure already holding the metadata has priority, then the thumbnail, the first video and the first image.
+func findVideoMetadataEnclosure(enclosures model.EnclosureList, thumbnailURL string) *model.Enclosure {
+	if enclosure := enclosures.FindVideoEnclosure(); enclosure != nil {
+		return enclosure
+	}
+
+	if thumbnailURL != "" {
+		for _, enclosure := range enclosures {
+			if enclosure.URL == thumbnailURL {
+				return enclosure
+			}
+		}
+	}
+
+	for _, enclosure := range enclosures {
+		if enclosure.IsVideo() || strings.EqualFold(enclosure.MimeType, "application/x-shockwave-flash") {
+			return enclosure
+		}
+	}
+
+	// The thumbnail of the video is not necessarily the first image of the entry.
+	if thumbnailURL != "" {
+		return nil
+	}
+
+	for _, enclosure := range enclosures {
+		if enclosure.IsImage() {
+			return enclosure
+		}
+	}
+
+	return nil
+}
+
+// attachVideoMetadata stores the video metadata in the enclosures of the entry.
+// A thumbnail enclosure is added when the entry has no suitable enclosure.
+func attachVideoMetadata(entry *model.Entry, metadata *videoMetadata) {
+	if metadata.isEmpty() {
+		return
+	}
+
+	enclosure := findVideoMetadataEnclosure(entry.Enclosures, metadata.ThumbnailURL)
+	if enclosure == nil {
+		if metadata.ThumbnailURL == "" {
+			return
+		}
+		enclosure = &model.Enclosure{URL: metadata.ThumbnailURL, MimeType: urllib.ImageMimeType(metadata.ThumbnailURL)}
+		entry.Enclosures = append(entry.Enclosures, enclosure)
+	}
+
+	if metadata.ThumbnailURL == "" && enclosure.ThumbnailURL == "" && enclosure.IsImage() {
+		metadata.ThumbnailURL = enclosure.URL
+	}
+
+	current := &videoMetadata{
+		ThumbnailURL: enclosure.ThumbnailURL,
+		Duration:     enclosure.Duration,
+		ChannelName:  enclosure.ChannelName,
+	}
+	current.merge(metadata)
+
+	enclosure.ThumbnailURL = current.ThumbnailURL
+	enclosure.Duration = current.Duration
+	enclosure.ChannelName = current.ChannelName
+}
+
+// attachStoredVideoMetadata copies the video metadata of the stored entry, to keep them when the entry is updated.
+func attachStoredVideoMetadata(entry *model.Entry, storedEnclosure *model.Enclosure) {
+	for _, enclosure := range entry.Enclosures {
+		if enclosure.URL == storedEnclosure.URL {
+			enclosure.ThumbnailURL = storedEnclosure.ThumbnailURL
+			enclosure.Duration = storedEnclosure.Duration
+			enclosure.ChannelName = storedEnclosure.ChannelName
+			return
+		}
+	}
+
+	entry.Enclosures = append(entry.Enclosures, &model.Enclosure{
+		URL:          storedEnclosure.URL,
+		Size:         storedEnclosure.Size,
+		MimeType:     storedEnclosure.MimeType,
+		ThumbnailURL: storedEnclosure.ThumbnailURL,
+		Duration:     storedEnclosure.Duration,
+		ChannelName:  storedEnclosure.ChannelName,
+	})
+}
+
+// updateEntryVideoMetadata collects the thumbnail, the duration and the channel of video entries.
+// The web pages are fetched only for new entries, the metadata of the existing entries are loaded from the database.
+func updateEntryVideoMetadata(store *storage.Storage, feed *model.Feed, entry *model.Entry, entryIsNew bool, user *model.User) {
+	if !entryIsNew {
+		if !isVideoEntry(entry) {
+			return
+		}
+
+		storedEnclosure, err := store.VideoEnclosure(feed.ID, entry.Hash)
+		if err != nil {
+			slog.Warn("Unable to load video metadata",
+				slog.Int64("user_id", user.ID),
+				slog.String("entry_url", entry.URL),
+				slog.Int64("feed_id", feed.ID),
+				slog.Any("error", err),
+			)
+		} else if storedEnclosure != nil {
+			attachStoredVideoMetadata(entry, storedEnclosure)
+		}
+
+		if user.ShowReadingTime {
+			entry.ReadingTime = store.GetReadTime(feed.ID, entry.Hash)
+		}
+		return
+	}
+
+	metadata := &videoMetadata{}
+	if isYouTubeVideoURL(entry.URL) {
+		metadata = youTubeFeedVideoMetadata(entry)
+	}
+
+	for _, scenario := range videoMetadataScenarios {
+		if scenario.shouldFetch(entry) {
+			if fetchedMetadata, err := scenario.fetchFunc(entry.URL); err != nil {
+				slog.Warn("Unable to fetch video metadata",
+					slog.String("platform", scenario.platform),
+					slog.Int64("user_id", user.ID),
+					slog.Int64("entry_id", entry.ID),
+					slog.String("entry_url", entry.URL),
+					slog.Int64("feed_id", feed.ID),
+					slog.String("feed_url", feed.FeedURL),
+					slog.Any("error", err),
+				)
+			} else {
+				metadata.merge(fetchedMetadata)
+			}
+			break
+		}
+	}
+
+	attachVideoMetadata(entry, metadata)
+
+	if user.ShowReadingTime && metadata.Duration > 0 {
+		entry.ReadingTime = metadata.readingTime()
+	}
+}
+
+func isVideoEntry(entry *model.Entry) bool {
+	for _, scenario := range videoMetadataScenarios {
+		if scenario.shouldFetch(entry) {
+			return true
+		}
+	}
+	return false
+}
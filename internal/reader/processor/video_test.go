@@ -0,0 +1,148 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package processor // import "miniflux.app/v2/internal/reader/processor"
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/model"
+)
+
+func TestFetchVideoMetadata(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("FETCHER_ALLOW_PRIVATE_NETWORKS", "1")
+
+	var err error
+	config.Opts, err = config.NewConfigParser().ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Config parsing failure: %v`, err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(`<html><head>
+			<meta property="og:image" content="/thumbnail.jpg">
+			<meta itemprop="duration" content="PT4M5S">
+		</head><body>
+			<span itemprop="author"><link itemprop="name" content="Some Channel"></span>
+		</body></html>`))
+	}))
+	defer server.Close()
+
+	metadata, err := fetchVideoMetadata(server.URL+"/watch", videoPageQueries{
+		duration:    `meta[itemprop="duration"]`,
+		isoDuration: true,
+		channelName: `span[itemprop="author"] link[itemprop="name"]`,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := videoMetadata{ThumbnailURL: server.URL + "/thumbnail.jpg", Duration: 245, ChannelName: "Some Channel"}
+	if *metadata != expected {
+		t.Errorf(`Unexpected metadata, got %+v`, *metadata)
+	}
+
+	if metadata.readingTime() != 4 {
+		t.Errorf(`Unexpected reading time, got %d`, metadata.readingTime())
+	}
+}
+
+func TestAttachVideoMetadataToThumbnailEnclosure(t *testing.T) {
+	entry := &model.Entry{
+		Author: "Some Channel",
+		Enclosures: model.EnclosureList{
+			{URL: "https://i.ytimg.com/vi/abc/hqdefault.jpg", MimeType: "image/*"},
+			{URL: "https://www.youtube.com/v/abc", MimeType: "application/x-shockwave-flash"},
+		},
+	}
+
+	metadata := youTubeFeedVideoMetadata(entry)
+	metadata.Duration = 120
+	attachVideoMetadata(entry, metadata)
+
+	if len(entry.Enclosures) != 2 {
+		t.Fatalf(`No enclosure should be added, got %d enclosures`, len(entry.Enclosures))
+	}
+
+	enclosure := entry.Enclosures.FindVideoEnclosure()
+	if enclosure != entry.Enclosures[0] {
+		t.Fatalf(`The metadata should be attached to the thumbnail enclosure`)
+	}
+
+	if enclosure.ThumbnailURL != "https://i.ytimg.com/vi/abc/hqdefault.jpg" || enclosure.Duration != 120 || enclosure.ChannelName != "Some Channel" {
+		t.Errorf(`Unexpected metadata, got %+v`, enclosure)
+	}
+
+	// The metadata fetched later are merged into the same enclosure.
+	attachVideoMetadata(entry, &videoMetadata{ThumbnailURL: "https://i.ytimg.com/vi/abc/maxresdefault.jpg", ChannelName: "Other Channel"})
+
+	if len(entry.Enclosures) != 2 || enclosure.ThumbnailURL != "https://i.ytimg.com/vi/abc/maxresdefault.jpg" || enclosure.Duration != 120 || enclosure.ChannelName != "Other Channel" {
+		t.Errorf(`Unexpected metadata after the merge, got %+v`, enclosure)
+	}
+}
+
+func TestAttachVideoMetadataAddsThumbnailEnclosure(t *testing.T) {
+	entry := &model.Entry{}
+
+	attachVideoMetadata(entry, &videoMetadata{})
+	if len(entry.Enclosures) != 0 {
+		t.Fatalf(`No enclosure should be added for empty metadata`)
+	}
+
+	attachVideoMetadata(entry, &videoMetadata{Duration: 60})
+	if len(entry.Enclosures) != 0 {
+		t.Fatalf(`No enclosure should be added without thumbnail`)
+	}
+
+	attachVideoMetadata(entry, &videoMetadata{ThumbnailURL: "https://example.org/thumbnail.jpg", Duration: 60})
+	if len(entry.Enclosures) != 1 {
+		t.Fatalf(`A thumbnail enclosure should be added`)
+	}
+
+	enclosure := entry.Enclosures[0]
+	if enclosure.URL != "https://example.org/thumbnail.jpg" || enclosure.MimeType != "image/jpeg" || enclosure.Duration != 60 {
+		t.Errorf(`Unexpected enclosure, got %+v`, enclosure)
+	}
+}
+
+func TestVideoMetadataReadingTime(t *testing.T) {
+	scenarios := []struct {
+		metadata videoMetadata
+		expected int
+	}{
+		{videoMetadata{Duration: 59}, 0},
+		{videoMetadata{Duration: 245}, 4},
+		{videoMetadata{Duration: 240, roundUpReadingTime: true}, 4},
+		{videoMetadata{Duration: 245, roundUpReadingTime: true}, 5},
+	}
+
+	for _, scenario := range scenarios {
+		if result := scenario.metadata.readingTime(); result != scenario.expected {
+			t.Errorf(`Unexpected reading time for %+v, got %d instead of %d`, scenario.metadata, result, scenario.expected)
+		}
+	}
+}
+
+func TestAttachStoredVideoMetadata(t *testing.T) {
+	storedEnclosure := &model.Enclosure{URL: "https://example.org/thumbnail.jpg", MimeType: "image/*", Duration: 90, ChannelName: "Some Channel"}
+
+	entry := &model.Entry{Enclosures: model.EnclosureList{{URL: "https://example.org/video.mp4", MimeType: "video/mp4"}}}
+	attachStoredVideoMetadata(entry, storedEnclosure)
+
+	if len(entry.Enclosures) != 2 || entry.Enclosures[1].URL != storedEnclosure.URL || entry.Enclosures[1].Duration != 90 {
+		t.Errorf(`The stored enclosure should be kept, got %+v`, entry.Enclosures)
+	}
+
+	entry = &model.Entry{Enclosures: model.EnclosureList{{URL: "https://example.org/thumbnail.jpg", MimeType: "image/jpeg"}}}
+	attachStoredVideoMetadata(entry, storedEnclosure)
+
+	if len(entry.Enclosures) != 1 || entry.Enclosures[0].ChannelName != "Some Channel" {
+		t.Errorf(`The metadata should be copied to the existing enclosure, got %+v`, entry.Enclosures)
+	}
+}
@@ -9,7 +9,6 @@ import (
 	"log/slog"
 	"net/url"
 	"strings"
-	"time"
 
 	"miniflux.app/v2/internal/config"
 	"miniflux.app/v2/internal/model"
@@ -38,11 +37,27 @@ func shouldFetchYouTubeWatchTimeInBulk() bool {
 	return config.Opts.FetchYouTubeWatchTime() && config.Opts.YouTubeAPIKey() != ""
 }
 
-func fetchYouTubeWatchTimeForSingleEntry(websiteURL string) (int, error) {
-	return fetchWatchTime(websiteURL, `meta[itemprop="duration"]`, true)
+// youTubeFeedVideoMetadata returns the video metadata available in the YouTube feeds: the thumbnail and the channel name.
+func youTubeFeedVideoMetadata(entry *model.Entry) *videoMetadata {
+	metadata := &videoMetadata{ChannelName: entry.Author}
+	for _, enclosure := range entry.Enclosures {
+		if enclosure.IsImage() {
+			metadata.ThumbnailURL = enclosure.URL
+			break
+		}
+	}
+	return metadata
+}
+
+func fetchYouTubeVideoMetadataForSingleEntry(websiteURL string) (*videoMetadata, error) {
+	return fetchVideoMetadata(websiteURL, videoPageQueries{
+		duration:    `meta[itemprop="duration"]`,
+		isoDuration: true,
+		channelName: `span[itemprop="author"] link[itemprop="name"]`,
+	})
 }
 
-func fetchYouTubeWatchTimeInBulk(entries []*model.Entry) {
+func fetchYouTubeVideoMetadataInBulk(entries []*model.Entry, user *model.User) {
 	videosEntriesMapping := make(map[string]*model.Entry, len(entries))
 	videoIDs := make([]string, 0, len(entries))
 
@@ -64,26 +79,29 @@ func fetchYouTubeWatchTimeInBulk(entries []*model.Entry) {
 		return
 	}
 
-	watchTimeMap, err := fetchYouTubeWatchTimeFromApiInBulk(videoIDs)
+	metadataMap, err := fetchYouTubeVideoMetadataFromApiInBulk(videoIDs)
 	if err != nil {
-		slog.Warn("Unable to fetch YouTube watch time in bulk", slog.Any("error", err))
+		slog.Warn("Unable to fetch YouTube video metadata in bulk", slog.Any("error", err))
 		return
 	}
 
-	for videoID, watchTime := range watchTimeMap {
+	for videoID, metadata := range metadataMap {
 		if entry, ok := videosEntriesMapping[videoID]; ok {
-			entry.ReadingTime = int(watchTime.Minutes())
+			attachVideoMetadata(entry, metadata)
+			if user.ShowReadingTime {
+				entry.ReadingTime = metadata.readingTime()
+			}
 		}
 	}
 }
 
-func fetchYouTubeWatchTimeFromApiInBulk(videoIDs []string) (map[string]time.Duration, error) {
-	slog.Debug("Fetching YouTube watch time in bulk", slog.Any("video_ids", videoIDs))
+func fetchYouTubeVideoMetadataFromApiInBulk(videoIDs []string) (map[string]*videoMetadata, error) {
+	slog.Debug("Fetching YouTube video metadata in bulk", slog.Any("video_ids", videoIDs))
 
 	apiQuery := url.Values{}
 	apiQuery.Set("id", strings.Join(videoIDs, ","))
 	apiQuery.Set("key", config.Opts.YouTubeAPIKey())
-	apiQuery.Set("part", "contentDetails")
+	apiQuery.Set("part", "snippet,contentDetails")
 
 	apiURL := url.URL{
 		Scheme:   "https",
@@ -100,13 +118,24 @@ func fetchYouTubeWatchTimeFromApiInBulk(videoIDs []string) (map[string]time.Dura
 	defer responseHandler.Close()
 
 	if localizedError := responseHandler.LocalizedError(); localizedError != nil {
-		slog.Warn("Unable to fetch contentDetails from YouTube API", slog.Any("error", localizedError.Error()))
+		slog.Warn("Unable to fetch snippet and contentDetails from YouTube API", slog.Any("error", localizedError.Error()))
 		return nil, localizedError.Error()
 	}
 
+	type youTubeThumbnail struct {
+		URL string `json:"url"`
+	}
+
 	videos := struct {
 		Items []struct {
-			ID             string `json:"id"`
+			ID      string `json:"id"`
+			Snippet struct {
+				ChannelTitle string `json:"channelTitle"`
+				Thumbnails   struct {
+					Default youTubeThumbnail `json:"default"`
+					High    youTubeThumbnail `json:"high"`
+				} `json:"thumbnails"`
+			} `json:"snippet"`
 			ContentDetails struct {
 				Duration string `json:"duration"`
 			} `json:"contentDetails"`
@@ -116,14 +145,24 @@ func fetchYouTubeWatchTimeFromApiInBulk(videoIDs []string) (map[string]time.Dura
 		return nil, fmt.Errorf("youtube: unable to decode JSON: %v", err)
 	}
 
-	watchTimeMap := make(map[string]time.Duration, len(videos.Items))
+	metadataMap := make(map[string]*videoMetadata, len(videos.Items))
 	for _, video := range videos.Items {
+		metadata := &videoMetadata{
+			ThumbnailURL: video.Snippet.Thumbnails.High.URL,
+			ChannelName:  video.Snippet.ChannelTitle,
+		}
+		if metadata.ThumbnailURL == "" {
+			metadata.ThumbnailURL = video.Snippet.Thumbnails.Default.URL
+		}
+
 		duration, err := parseISO8601Duration(video.ContentDetails.Duration)
 		if err != nil {
 			slog.Warn("Unable to parse ISO8601 duration", slog.Any("error", err))
-			continue
+		} else {
+			metadata.Duration = int(duration.Seconds())
 		}
-		watchTimeMap[video.ID] = duration
+
+		metadataMap[video.ID] = metadata
 	}
-	return watchTimeMap, nil
+	return metadataMap, nil
 }
@@ -100,6 +100,14 @@ func (r *rdfAdapter) buildFeed(baseURL string) *model.Feed {
 			entry.Author = stripTags(r.rdf.Channel.DublinCoreCreator)
 		}
 
+		// Populate the entry language.
+		for _, value := range []string{item.DublinCoreLanguage, r.rdf.Channel.DublinCoreLanguage} {
+			if value = strings.TrimSpace(value); value != "" {
+				entry.Language = value
+				break
+			}
+		}
+
 		feed.Entries = append(feed.Entries, entry)
 	}
 
@@ -848,3 +848,34 @@ func TestParseFeedWithInvalidCharacterEntity(t *testing.T) {
 		t.Errorf(`Incorrect URL, got: %q`, feed.SiteURL)
 	}
 }
+
+func TestParseEntryLanguage(t *testing.T) {
+	data := `<?xml version="1.0" encoding="utf-8"?>
+	<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#" xmlns="http://purl.org/rss/1.0/" xmlns:dc="http://purl.org/dc/elements/1.1/">
+	  <channel>
+			<title>Example Feed</title>
+			<link>http://example.org</link>
+			<dc:language>de-DE</dc:language>
+	  </channel>
+	  <item>
+			<title>Feed language</title>
+			<link>http://example.org/1</link>
+	  </item>
+	  <item>
+			<title>Item language</title>
+			<link>http://example.org/2</link>
+			<dc:language>fr</dc:language>
+	  </item>
+	</rdf:RDF>`
+
+	feed, err := Parse("http://example.org", bytes.NewReader([]byte(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, expected := range []string{"de-DE", "fr"} {
+		if feed.Entries[i].Language != expected {
+			t.Errorf("Incorrect entry language, got %q instead of %q", feed.Entries[i].Language, expected)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package readability // import "miniflux.app/v2/internal/reader/readability"
+
+import (
+	"net/url"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// pageSuffixRegex matches the page number at the end of a path: /2, -2, _2, /page/2, /page-2, /p2...
+var pageSuffixRegex = regexp.MustCompile(`(?i)[/_-](?:page|p)?[/_-]?(\d+)$`)
+
+var pageExtensions = [...]string{".htm", ".html", ".php", ".asp", ".aspx"}
+
+// NextPageURL returns the absolute URL of the next page of a paginated article, or an empty string.
+//
+// The next page is taken from a rel="next" link, or from a link to the following page number
+// in a pagination block. The page number of the given page starts at 1.
+// Links to other articles or to other websites are ignored.
+func NextPageURL(document *goquery.Document, pageURL string, pageNumber int) string {
+	currentURL, err := url.Parse(pageURL)
+	if err != nil {
+		return ""
+	}
+
+	baseURL := currentURL
+	if documentBaseURL := documentBaseURL(document); documentBaseURL != "" {
+		if parsedBaseURL, err := url.Parse(documentBaseURL); err == nil {
+			baseURL = parsedBaseURL
+		}
+	}
+
+	var links []string
+	document.Find(`link[rel~="next"], a[rel~="next"]`).Each(func(_ int, s *goquery.Selection) {
+		if href, exists := s.Attr("href"); exists {
+			links = append(links, href)
+		}
+	})
+
+	nextPageNumber := strconv.Itoa(pageNumber + 1)
+	document.Find(`[class*="pag"] a, [id*="pag"] a`).Each(func(_ int, s *goquery.Selection) {
+		if strings.TrimSpace(s.Text()) != nextPageNumber {
+			return
+		}
+		if href, exists := s.Attr("href"); exists {
+			links = append(links, href)
+		}
+	})
+
+	for _, link := range links {
+		nextURL, err := baseURL.Parse(strings.TrimSpace(link))
+		if err != nil {
+			continue
+		}
+		nextURL.Fragment = ""
+
+		if isNextPage(currentURL, nextURL, pageNumber+1) {
+			return nextURL.String()
+		}
+	}
+
+	return ""
+}
+
+// isNextPage reports whether nextURL is the given page of the article at currentURL.
+func isNextPage(currentURL, nextURL *url.URL, nextPageNumber int) bool {
+	if nextURL.Scheme != currentURL.Scheme || !strings.EqualFold(nextURL.Host, currentURL.Host) {
+		return false
+	}
+
+	// Page number in the query string: ?page=2, ?p=2...
+	if nextURL.Path == currentURL.Path {
+		if nextURL.RawQuery == currentURL.RawQuery {
+			return false
+		}
+		for _, values := range nextURL.Query() {
+			for _, value := range values {
+				if value == strconv.Itoa(nextPageNumber) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	// Page number in the path: /article/2, /article-2.html, /article/page/2...
+	nextBase, nextExtension, number := splitPagePath(nextURL.Path)
+	if number != nextPageNumber {
+		return false
+	}
+
+	currentPath, currentExtension := splitPageExtension(currentURL.Path)
+	if nextExtension != currentExtension {
+		return false
+	}
+
+	if nextBase == currentPath {
+		return true
+	}
+
+	currentBase, _, currentNumber := splitPagePath(currentURL.Path)
+	return currentNumber == nextPageNumber-1 && nextBase == currentBase
+}
+
+func splitPagePath(pagePath string) (base, extension string, number int) {
+	base, extension = splitPageExtension(pagePath)
+
+	matches := pageSuffixRegex.FindStringSubmatchIndex(base)
+	if matches == nil {
+		return base, extension, 0
+	}
+
+	number, err := strconv.Atoi(base[matches[2]:matches[3]])
+	if err != nil {
+		return base, extension, 0
+	}
+
+	return base[:matches[0]], extension, number
+}
+
+func splitPageExtension(pagePath string) (base, extension string) {
+	pagePath = strings.TrimSuffix(pagePath, "/")
+
+	extension = strings.ToLower(path.Ext(pagePath))
+	for _, pageExtension := range pageExtensions {
+		if extension == pageExtension {
+			return strings.TrimSuffix(pagePath, path.Ext(pagePath)), extension
+		}
+	}
+
+	return pagePath, ""
+}
@@ -0,0 +1,96 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package readability // import "miniflux.app/v2/internal/reader/readability"
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+func TestNextPageURL(t *testing.T) {
+	scenarios := []struct {
+		name       string
+		pageURL    string
+		pageNumber int
+		html       string
+		expected   string
+	}{
+		{
+			name:       "rel next link in head",
+			pageURL:    "https://example.org/article",
+			pageNumber: 1,
+			html:       `<html><head><link rel="next" href="/article/2"></head><body></body></html>`,
+			expected:   "https://example.org/article/2",
+		},
+		{
+			name:       "rel next anchor with page query",
+			pageURL:    "https://example.org/article?id=5",
+			pageNumber: 1,
+			html:       `<a rel="next" href="?id=5&page=2">Next</a>`,
+			expected:   "https://example.org/article?id=5&page=2",
+		},
+		{
+			name:       "numbered pagination",
+			pageURL:    "https://example.org/news/article-title.html",
+			pageNumber: 1,
+			html:       `<div class="pagination"><span>1</span> <a href="article-title-2.html">2</a> <a href="article-title-3.html">3</a></div>`,
+			expected:   "https://example.org/news/article-title-2.html",
+		},
+		{
+			name:       "numbered pagination from the second page",
+			pageURL:    "https://example.org/article/page/2/",
+			pageNumber: 2,
+			html:       `<nav id="pager"><a href="/article/">1</a> <span>2</span> <a href="/article/page/3/">3</a></nav>`,
+			expected:   "https://example.org/article/page/3/",
+		},
+		{
+			name:       "next article",
+			pageURL:    "https://example.org/story/123",
+			pageNumber: 1,
+			html:       `<link rel="next" href="/story/124">`,
+			expected:   "",
+		},
+		{
+			name:       "other article with a slug",
+			pageURL:    "https://example.org/2024/05/first-post/",
+			pageNumber: 1,
+			html:       `<link rel="next" href="/2024/05/second-post/">`,
+			expected:   "",
+		},
+		{
+			name:       "other website",
+			pageURL:    "https://example.org/article",
+			pageNumber: 1,
+			html:       `<link rel="next" href="https://example.com/article/2">`,
+			expected:   "",
+		},
+		{
+			name:       "same page",
+			pageURL:    "https://example.org/article?page=2",
+			pageNumber: 1,
+			html:       `<a rel="next" href="#comments">Next</a>`,
+			expected:   "",
+		},
+		{
+			name:       "no pagination",
+			pageURL:    "https://example.org/article",
+			pageNumber: 1,
+			html:       `<p>Content</p>`,
+			expected:   "",
+		},
+	}
+
+	for _, scenario := range scenarios {
+		document, err := goquery.NewDocumentFromReader(strings.NewReader(scenario.html))
+		if err != nil {
+			t.Fatalf(`Unable to parse HTML for %q: %v`, scenario.name, err)
+		}
+
+		if result := NextPageURL(document, scenario.pageURL, scenario.pageNumber); result != scenario.expected {
+			t.Errorf(`Unexpected next page URL for %q, got %q instead of %q`, scenario.name, result, scenario.expected)
+		}
+	}
+}
@@ -76,12 +76,14 @@ func ExtractContent(page io.Reader) (baseURL string, extractedContent string, er
 		return "", "", err
 	}
 
-	if hrefValue, exists := document.FindMatcher(goquery.Single("head base")).Attr("href"); exists {
-		hrefValue = strings.TrimSpace(hrefValue)
-		if urllib.IsAbsoluteURL(hrefValue) {
-			baseURL = hrefValue
-		}
-	}
+	baseURL, extractedContent = ExtractContentFromDocument(document)
+	return baseURL, extractedContent, nil
+}
+
+// ExtractContentFromDocument extracts the main content of a parsed HTML document.
+// The document is modified during the extraction.
+func ExtractContentFromDocument(document *goquery.Document) (baseURL string, extractedContent string) {
+	baseURL = documentBaseURL(document)
 
 	document.Find("script,style").Remove()
 
@@ -97,8 +99,17 @@ func ExtractContent(page io.Reader) (baseURL string, extractedContent string, er
 		slog.String("topCandidate", topCandidate.String()),
 	)
 
-	extractedContent = getArticle(topCandidate, candidates)
-	return baseURL, extractedContent, nil
+	return baseURL, getArticle(topCandidate, candidates)
+}
+
+func documentBaseURL(document *goquery.Document) string {
+	if hrefValue, exists := document.FindMatcher(goquery.Single("head base")).Attr("href"); exists {
+		hrefValue = strings.TrimSpace(hrefValue)
+		if urllib.IsAbsoluteURL(hrefValue) {
+			return hrefValue
+		}
+	}
+	return ""
 }
 
 func getSelectionLength(s *goquery.Selection) int {
@@ -118,6 +118,13 @@ func ApplyContentRewriteRules(entry *model.Entry, customRewriteRules string) {
 	}
 }
 
+// ApplyCustomContentRewriteRules applies only the given rewrite rules, without the predefined rules of the website.
+func ApplyCustomContentRewriteRules(entry *model.Entry, customRewriteRules string) {
+	for _, rule := range parseRules(customRewriteRules) {
+		rule.applyRule(entry.URL, entry)
+	}
+}
+
 func parseRules(rulesText string) (rules []rule) {
 	scan := scanner.Scanner{Mode: scanner.ScanIdents | scanner.ScanStrings}
 	scan.Init(strings.NewReader(rulesText))
@@ -12,24 +12,34 @@ import (
 
 var customReplaceRuleRegex = regexp.MustCompile(`^rewrite\("([^"]+)"\|"([^"]+)"\)$`)
 
+// ParseURLRewriteRule returns the search pattern and the replacement of a rule written as rewrite("pattern"|"replacement").
+func ParseURLRewriteRule(rule string) (pattern, replacement string, ok bool) {
+	parts := customReplaceRuleRegex.FindStringSubmatch(rule)
+	if len(parts) != 3 {
+		return "", "", false
+	}
+	return parts[1], parts[2], true
+}
+
 func RewriteEntryURL(feed *model.Feed, entry *model.Entry) string {
-	if feed.UrlRewriteRules == "" {
+	urlRewriteRules := feed.EffectiveUrlRewriteRules()
+	if urlRewriteRules == "" {
 		return entry.URL
 	}
 
 	var rewrittenURL = entry.URL
-	parts := customReplaceRuleRegex.FindStringSubmatch(feed.UrlRewriteRules)
+	pattern, replacement, ok := ParseURLRewriteRule(urlRewriteRules)
 
-	if len(parts) == 3 {
-		re, err := regexp.Compile(parts[1])
+	if ok {
+		re, err := regexp.Compile(pattern)
 		if err != nil {
 			slog.Error("Failed on regexp compilation",
-				slog.String("url_rewrite_rules", feed.UrlRewriteRules),
+				slog.String("url_rewrite_rules", urlRewriteRules),
 				slog.Any("error", err),
 			)
 			return rewrittenURL
 		}
-		rewrittenURL = re.ReplaceAllString(entry.URL, parts[2])
+		rewrittenURL = re.ReplaceAllString(entry.URL, replacement)
 		slog.Debug("Rewriting entry URL",
 			slog.String("original_entry_url", entry.URL),
 			slog.String("rewritten_entry_url", rewrittenURL),
@@ -42,7 +52,7 @@ func RewriteEntryURL(feed *model.Feed, entry *model.Entry) string {
 			slog.String("rewritten_entry_url", rewrittenURL),
 			slog.Int64("feed_id", feed.ID),
 			slog.String("feed_url", feed.FeedURL),
-			slog.String("url_rewrite_rules", feed.UrlRewriteRules),
+			slog.String("url_rewrite_rules", urlRewriteRules),
 		)
 	}
 
@@ -154,6 +154,14 @@ func (r *rssAdapter) buildFeed(baseURL string) *model.Feed {
 		slices.Sort(entry.Tags)
 		entry.Tags = slices.Compact(entry.Tags)
 
+		// Populate the entry language.
+		for _, value := range []string{item.DublinCoreLanguage, r.rss.Channel.Language} {
+			if value = strings.TrimSpace(value); value != "" {
+				entry.Language = value
+				break
+			}
+		}
+
 		feed.Entries = append(feed.Entries, entry)
 	}
 
@@ -375,6 +383,27 @@ func findEntryEnclosures(rssItem *rssItem, siteURL string) model.EnclosureList {
 		}
 	}
 
+	// The transcripts are stored as enclosures, their text can be indexed with the entry.
+	for _, transcript := range rssItem.Transcripts {
+		transcriptURL := strings.TrimSpace(transcript.URL)
+		if transcriptURL == "" {
+			continue
+		}
+
+		if absoluteTranscriptURL, err := urllib.ResolveToAbsoluteURL(siteURL, transcriptURL); err == nil {
+			transcriptURL = absoluteTranscriptURL
+		}
+
+		if _, found := duplicates[transcriptURL]; !found {
+			duplicates[transcriptURL] = true
+			enclosures = append(enclosures, &model.Enclosure{
+				URL:        transcriptURL,
+				MimeType:   strings.TrimSpace(transcript.Type),
+				Transcript: true,
+			})
+		}
+	}
+
 	for _, mediaContent := range rssItem.AllMediaContents() {
 		mediaURL := strings.TrimSpace(mediaContent.URL)
 		if mediaURL == "" {
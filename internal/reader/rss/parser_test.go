@@ -1411,6 +1411,62 @@ func TestParseEntryWithRelativeEnclosureURL(t *testing.T) {
 	}
 }
 
+func TestParseEntryWithPodcastTranscripts(t *testing.T) {
+	data := `<?xml version="1.0" encoding="utf-8"?>
+		<rss version="2.0" xmlns:podcast="https://podcastindex.org/namespace/1.0">
+		<channel>
+		<title>My Podcast Feed</title>
+		<link>http://example.org</link>
+		<item>
+			<title>Episode 1</title>
+			<link>http://www.example.org/entries/1</link>
+			<enclosure url="http://www.example.org/myaudiofile.mp3" length="12345" type="audio/mpeg" />
+			<podcast:transcript url="/transcripts/1.vtt" type="text/vtt" />
+			<podcast:transcript url="http://www.example.org/transcripts/1.json" type="application/json" language="en" />
+			<podcast:transcript url="http://www.example.org/myaudiofile.mp3" type="audio/mpeg" />
+			<podcast:transcript url=" " type="text/html" />
+		</item>
+		</channel>
+		</rss>`
+
+	feed, err := Parse("https://example.org/", bytes.NewReader([]byte(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(feed.Entries) != 1 {
+		t.Fatalf("Incorrect number of entries, got: %d", len(feed.Entries))
+	}
+
+	expectedResults := []struct {
+		url        string
+		mimeType   string
+		transcript bool
+	}{
+		{"http://www.example.org/myaudiofile.mp3", "audio/mpeg", false},
+		{"http://example.org/transcripts/1.vtt", "text/vtt", true},
+		{"http://www.example.org/transcripts/1.json", "application/json", true},
+	}
+
+	if len(feed.Entries[0].Enclosures) != len(expectedResults) {
+		t.Fatalf("Incorrect number of enclosures, got: %d", len(feed.Entries[0].Enclosures))
+	}
+
+	for index, enclosure := range feed.Entries[0].Enclosures {
+		if expectedResults[index].url != enclosure.URL {
+			t.Errorf(`Unexpected enclosure URL, got %q instead of %q`, enclosure.URL, expectedResults[index].url)
+		}
+
+		if expectedResults[index].mimeType != enclosure.MimeType {
+			t.Errorf(`Unexpected enclosure type, got %q instead of %q`, enclosure.MimeType, expectedResults[index].mimeType)
+		}
+
+		if expectedResults[index].transcript != enclosure.Transcript {
+			t.Errorf(`Unexpected transcript flag for %q, got %v`, enclosure.URL, enclosure.Transcript)
+		}
+	}
+}
+
 func TestParseEntryWithFeedBurnerEnclosures(t *testing.T) {
 	data := `<?xml version="1.0" encoding="utf-8"?>
 		<rss version="2.0" xmlns:feedburner="http://rssnamespace.org/feedburner/ext/1.0">
@@ -2315,3 +2371,34 @@ func TestParseEntriesWithUniqueGUIDsAreUnchanged(t *testing.T) {
 		t.Errorf("Entry 1: incorrect hash, got: %s", feed.Entries[1].Hash)
 	}
 }
+
+func TestParseEntryLanguage(t *testing.T) {
+	data := `<?xml version="1.0" encoding="utf-8"?>
+	<rss version="2.0" xmlns:dc="http://purl.org/dc/elements/1.1/">
+		<channel>
+			<title>Example</title>
+			<link>https://example.org/</link>
+			<language>en-us</language>
+			<item>
+				<title>Channel language</title>
+				<link>https://example.org/1</link>
+			</item>
+			<item>
+				<title>Item language</title>
+				<link>https://example.org/2</link>
+				<dc:language>fr</dc:language>
+			</item>
+		</channel>
+	</rss>`
+
+	feed, err := Parse("https://example.org/", bytes.NewReader([]byte(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, expected := range []string{"en-us", "fr"} {
+		if feed.Entries[i].Language != expected {
+			t.Errorf("Incorrect entry language, got %q instead of %q", feed.Entries[i].Language, expected)
+		}
+	}
+}
@@ -10,6 +10,19 @@ import (
 	"strings"
 )
 
+// podcastItemElement contains the elements of the Podcasting 2.0 namespace used by the items.
+//
+// Specs: https://github.com/Podcastindex-org/podcast-namespace/blob/main/docs/1.0.md
+type podcastItemElement struct {
+	// Transcripts are the links to the transcripts or the captions of the episode.
+	Transcripts []podcastTranscript `xml:"https://podcastindex.org/namespace/1.0 transcript"`
+}
+
+type podcastTranscript struct {
+	URL  string `xml:"url,attr"`
+	Type string `xml:"type,attr"`
+}
+
 var errInvalidDurationFormat = errors.New("rss: invalid duration format")
 
 func getDurationInMinutes(rawDuration string) (int, error) {
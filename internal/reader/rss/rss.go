@@ -167,6 +167,7 @@ type rssItem struct {
 	atomLinks
 	itunes.ItunesItemElement
 	googleplay.GooglePlayItemElement
+	podcastItemElement
 }
 
 type rssAuthor struct {
@@ -0,0 +1,114 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package rules // import "miniflux.app/v2/internal/reader/rules"
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+
+	"miniflux.app/v2/internal/model"
+)
+
+// Action is executed on the entries matching the condition of a rule.
+type Action interface {
+	apply(entry *model.Entry, result *Result)
+	String() string
+}
+
+type blockAction struct{}
+
+func (blockAction) apply(_ *model.Entry, result *Result) { result.Blocked = true }
+
+func (blockAction) String() string { return "block" }
+
+type stopAction struct{}
+
+func (stopAction) apply(*model.Entry, *Result) {}
+
+func (stopAction) String() string { return "stop" }
+
+type markReadAction struct{}
+
+func (markReadAction) apply(entry *model.Entry, _ *Result) { entry.Status = model.EntryStatusRead }
+
+func (markReadAction) String() string { return "mark_read" }
+
+type starAction struct{}
+
+func (starAction) apply(entry *model.Entry, _ *Result) { entry.Starred = true }
+
+func (starAction) String() string { return "star" }
+
+type sendAction struct {
+	integration string
+}
+
+func (a sendAction) apply(_ *model.Entry, result *Result) {
+	if !slices.Contains(result.SendToIntegrations, a.integration) {
+		result.SendToIntegrations = append(result.SendToIntegrations, a.integration)
+	}
+}
+
+func (a sendAction) String() string { return "send " + quote(a.integration) }
+
+type tagAction struct {
+	tag string
+}
+
+func (a tagAction) apply(entry *model.Entry, _ *Result) {
+	if !slices.Contains(entry.Tags, a.tag) {
+		entry.Tags = append(entry.Tags, a.tag)
+	}
+}
+
+func (a tagAction) String() string { return "tag " + quote(a.tag) }
+
+type voteAction struct {
+	vote int
+}
+
+func (a voteAction) apply(entry *model.Entry, _ *Result) { entry.Vote = a.vote }
+
+func (a voteAction) String() string {
+	switch a.vote {
+	case 1:
+		return "vote up"
+	case -1:
+		return "vote down"
+	}
+	return "vote 0"
+}
+
+type scoreAction struct {
+	score int64
+}
+
+func (a scoreAction) apply(entry *model.Entry, _ *Result) { entry.Score = a.score }
+
+func (a scoreAction) String() string { return "score " + strconv.FormatInt(a.score, 10) }
+
+// rewriteAction defers the content rewrite rules until the entry content is final.
+type rewriteAction struct {
+	rules string
+}
+
+func (a rewriteAction) apply(_ *model.Entry, result *Result) {
+	result.ContentRewriteRules = append(result.ContentRewriteRules, a.rules)
+}
+
+func (a rewriteAction) String() string { return "rewrite " + quote(a.rules) }
+
+type rewriteURLAction struct {
+	regex       *regexp.Regexp
+	replacement string
+}
+
+func (a rewriteURLAction) apply(entry *model.Entry, _ *Result) {
+	entry.URL = a.regex.ReplaceAllString(entry.URL, a.replacement)
+}
+
+func (a rewriteURLAction) String() string {
+	return "rewrite_url " + quote(a.regex.String()) + " " + quote(a.replacement)
+}
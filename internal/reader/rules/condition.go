@@ -0,0 +1,240 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package rules // import "miniflux.app/v2/internal/reader/rules"
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"miniflux.app/v2/internal/model"
+)
+
+// Operator precedences, used to add parentheses when printing conditions.
+const (
+	precedenceOr = iota + 1
+	precedenceAnd
+	precedenceNot
+	precedenceAtom
+)
+
+// Condition is a boolean expression evaluated against an entry.
+type Condition interface {
+	Match(entry *model.Entry) bool
+	String() string
+	precedence() int
+}
+
+type andCondition struct {
+	left, right Condition
+}
+
+func (c *andCondition) Match(entry *model.Entry) bool {
+	return c.left.Match(entry) && c.right.Match(entry)
+}
+
+func (c *andCondition) String() string {
+	return wrap(c.left, precedenceAnd) + " and " + wrap(c.right, precedenceAnd)
+}
+
+func (c *andCondition) precedence() int { return precedenceAnd }
+
+type orCondition struct {
+	left, right Condition
+}
+
+func (c *orCondition) Match(entry *model.Entry) bool {
+	return c.left.Match(entry) || c.right.Match(entry)
+}
+
+func (c *orCondition) String() string {
+	return wrap(c.left, precedenceOr) + " or " + wrap(c.right, precedenceOr)
+}
+
+func (c *orCondition) precedence() int { return precedenceOr }
+
+type notCondition struct {
+	condition Condition
+}
+
+func (c *notCondition) Match(entry *model.Entry) bool {
+	return !c.condition.Match(entry)
+}
+
+func (c *notCondition) String() string {
+	return "not " + wrap(c.condition, precedenceNot)
+}
+
+func (c *notCondition) precedence() int { return precedenceNot }
+
+type constantCondition bool
+
+func (c constantCondition) Match(*model.Entry) bool { return bool(c) }
+
+func (c constantCondition) String() string { return strconv.FormatBool(bool(c)) }
+
+func (c constantCondition) precedence() int { return precedenceAtom }
+
+// textCondition compares a text field, or each item of a list field, with a value.
+type textCondition struct {
+	field    string
+	operator string
+	value    string
+	regex    *regexp.Regexp
+}
+
+func (c *textCondition) Match(entry *model.Entry) bool {
+	var values []string
+	switch c.field {
+	case "title":
+		values = []string{entry.Title}
+	case "url":
+		values = []string{entry.URL}
+	case "comments_url":
+		values = []string{entry.CommentsURL}
+	case "author":
+		values = []string{entry.Author}
+	case "content":
+		values = []string{entry.Content}
+	case "tag":
+		values = entry.Tags
+	case "language":
+		values = []string{entry.Language}
+	case "enclosure":
+		for _, enclosure := range entry.Enclosures {
+			values = append(values, enclosure.MimeType)
+		}
+	}
+
+	negated := c.operator == "!~" || c.operator == "!="
+	for _, value := range values {
+		if c.matchValue(value) {
+			return !negated
+		}
+	}
+	return negated
+}
+
+func (c *textCondition) matchValue(value string) bool {
+	switch c.operator {
+	case "~", "!~":
+		return c.regex.MatchString(value)
+	case "=", "!=":
+		return strings.EqualFold(value, c.value)
+	case "contains":
+		return strings.Contains(strings.ToLower(value), strings.ToLower(c.value))
+	}
+	return false
+}
+
+func (c *textCondition) String() string {
+	return c.field + " " + c.operator + " " + quote(c.value)
+}
+
+func (c *textCondition) precedence() int { return precedenceAtom }
+
+type scoreCondition struct {
+	operator string
+	value    int64
+}
+
+func (c *scoreCondition) Match(entry *model.Entry) bool {
+	return compare(c.operator, entry.Score-c.value)
+}
+
+func (c *scoreCondition) String() string {
+	return "score " + c.operator + " " + strconv.FormatInt(c.value, 10)
+}
+
+func (c *scoreCondition) precedence() int { return precedenceAtom }
+
+// dateCondition compares the publication date with a day, or with the current time when the date is zero.
+type dateCondition struct {
+	operator string
+	date     time.Time
+}
+
+func (c *dateCondition) Match(entry *model.Entry) bool {
+	target := c.date
+	if target.IsZero() {
+		target = time.Now()
+	}
+	return compare(c.operator, int64(entry.Date.Compare(target)))
+}
+
+func (c *dateCondition) String() string {
+	if c.date.IsZero() {
+		return "date " + c.operator + " now"
+	}
+	return "date " + c.operator + " " + c.date.Format(time.DateOnly)
+}
+
+func (c *dateCondition) precedence() int { return precedenceAtom }
+
+type ageCondition struct {
+	operator string
+	duration time.Duration
+	text     string
+}
+
+func (c *ageCondition) Match(entry *model.Entry) bool {
+	return compare(c.operator, int64(time.Since(entry.Date)-c.duration))
+}
+
+func (c *ageCondition) String() string {
+	return "age " + c.operator + " " + c.text
+}
+
+func (c *ageCondition) precedence() int { return precedenceAtom }
+
+// compare applies a comparison operator to the sign of a difference.
+func compare(operator string, difference int64) bool {
+	switch operator {
+	case "=":
+		return difference == 0
+	case "!=":
+		return difference != 0
+	case ">":
+		return difference > 0
+	case ">=":
+		return difference >= 0
+	case "<":
+		return difference < 0
+	case "<=":
+		return difference <= 0
+	}
+	return false
+}
+
+func wrap(condition Condition, precedence int) string {
+	if condition.precedence() < precedence {
+		return "(" + condition.String() + ")"
+	}
+	return condition.String()
+}
+
+// quote returns a string literal, using a raw string when the value contains backslashes, like most regular expressions.
+func quote(value string) string {
+	if strings.Contains(value, `\`) && !strings.ContainsAny(value, "`\n") {
+		return "`" + value + "`"
+	}
+	return strconv.Quote(value)
+}
+
+// parseDuration parses durations like "30d" in addition to the units supported by time.ParseDuration.
+func parseDuration(duration string) (time.Duration, error) {
+	if daysStr, ok := strings.CutSuffix(duration, "d"); ok {
+		days := 0
+		if daysStr != "" {
+			var err error
+			days, err = strconv.Atoi(daysStr)
+			if err != nil {
+				return 0, err
+			}
+		}
+		return time.Duration(days) * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(duration)
+}
@@ -0,0 +1,215 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package rules // import "miniflux.app/v2/internal/reader/rules"
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/rewrite"
+)
+
+// Names of the legacy fields translated into rules.
+const (
+	LegacyBlockFilterEntryRules = "block_filter_entry_rules"
+	LegacyKeepFilterEntryRules  = "keep_filter_entry_rules"
+	LegacyBlocklistRules        = "blocklist_rules"
+	LegacyKeeplistRules         = "keeplist_rules"
+	LegacyURLRewriteRules       = "urlrewrite_rules"
+)
+
+// FromLegacy translates the legacy filter and URL rewrite fields into rules, preserving their behavior:
+// block filter rules, then the blocklists, then the keep filter rules or the keeplist, then the URL rewrite rule.
+//
+// The block and keep rules of the category are applied in addition to the user and feed rules.
+// The keeplist and URL rewrite rules of the category are used when the feed does not define its own.
+func FromLegacy(user *model.User, feed *model.Feed) Rules {
+	category := feed.Category
+	if category == nil {
+		category = &model.Category{}
+	}
+
+	var rules Rules
+
+	rules = append(rules, fromLegacyFilterRules(SourceUser, LegacyBlockFilterEntryRules, user.BlockFilterEntryRules)...)
+	rules = append(rules, fromLegacyFilterRules(SourceCategory, LegacyBlockFilterEntryRules, category.BlockFilterEntryRules)...)
+	rules = append(rules, fromLegacyFilterRules(SourceFeed, LegacyBlockFilterEntryRules, feed.BlockFilterEntryRules)...)
+
+	for _, blocklist := range []struct{ source, pattern string }{
+		{SourceCategory, category.BlocklistRules},
+		{SourceFeed, feed.BlocklistRules},
+	} {
+		if condition := fromLegacyRegexRules(blocklist.pattern); condition != nil {
+			rules = append(rules, &Rule{
+				Condition: condition,
+				Actions:   []Action{blockAction{}},
+				Source:    blocklist.source,
+				Legacy:    LegacyBlocklistRules,
+				Line:      1,
+			})
+		}
+	}
+
+	var keepRules Rules
+	keepRules = append(keepRules, fromLegacyFilterRules(SourceUser, LegacyKeepFilterEntryRules, user.KeepFilterEntryRules)...)
+	keepRules = append(keepRules, fromLegacyFilterRules(SourceCategory, LegacyKeepFilterEntryRules, category.KeepFilterEntryRules)...)
+	keepRules = append(keepRules, fromLegacyFilterRules(SourceFeed, LegacyKeepFilterEntryRules, feed.KeepFilterEntryRules)...)
+	if len(keepRules) > 0 {
+		// Entries that do not match any keep rule are blocked.
+		condition := keepRules[0].Condition
+		for _, rule := range keepRules[1:] {
+			condition = &orCondition{condition, rule.Condition}
+		}
+		rules = append(rules, &Rule{
+			Condition: &notCondition{condition},
+			Actions:   []Action{blockAction{}},
+			Source:    keepRules[len(keepRules)-1].Source,
+			Legacy:    LegacyKeepFilterEntryRules,
+		})
+	} else if condition := fromLegacyRegexRules(feed.EffectiveKeeplistRules()); condition != nil {
+		rules = append(rules, &Rule{
+			Condition: &notCondition{condition},
+			Actions:   []Action{blockAction{}},
+			Source:    legacySource(feed.KeeplistRules),
+			Legacy:    LegacyKeeplistRules,
+			Line:      1,
+		})
+	}
+
+	if pattern, replacement, ok := rewrite.ParseURLRewriteRule(feed.EffectiveUrlRewriteRules()); ok {
+		if regex, err := regexp.Compile(pattern); err == nil {
+			rules = append(rules, &Rule{
+				Condition: constantCondition(true),
+				Actions:   []Action{rewriteURLAction{regex, replacement}},
+				Source:    legacySource(feed.UrlRewriteRules),
+				Legacy:    LegacyURLRewriteRules,
+				Line:      1,
+			})
+		}
+	}
+
+	return rules
+}
+
+// legacySource returns the source of a feed setting inherited from the category when empty.
+func legacySource(feedValue string) string {
+	if feedValue == "" {
+		return SourceCategory
+	}
+	return SourceFeed
+}
+
+// fromLegacyFilterRules translates rules written as "EntryTitle=(?i)miniflux", one per line, to block rules.
+// Rules with an invalid value never match, like before.
+func fromLegacyFilterRules(source, legacy, text string) Rules {
+	var rules Rules
+	for i, line := range strings.Split(strings.TrimSpace(text), "\n") {
+		fieldType, value, found := strings.Cut(strings.TrimSpace(line), "=")
+		if !found {
+			continue
+		}
+
+		rules = append(rules, &Rule{
+			Condition: fromLegacyFilterRule(strings.TrimSpace(fieldType), strings.TrimSpace(value)),
+			Actions:   []Action{blockAction{}},
+			Source:    source,
+			Legacy:    legacy,
+			Line:      i + 1,
+		})
+	}
+	return rules
+}
+
+func fromLegacyFilterRule(fieldType, value string) Condition {
+	switch fieldType {
+	case "EntryTitle":
+		return regexCondition("title", value)
+	case "EntryURL":
+		return regexCondition("url", value)
+	case "EntryCommentsURL":
+		return regexCondition("comments_url", value)
+	case "EntryContent":
+		return regexCondition("content", value)
+	case "EntryAuthor":
+		return regexCondition("author", value)
+	case "EntryTag":
+		return regexCondition("tag", value)
+	case "EntryLanguage":
+		return regexCondition("language", value)
+	case "EntryDate":
+		return fromLegacyDateRule(value)
+	}
+	return constantCondition(false)
+}
+
+func fromLegacyDateRule(value string) Condition {
+	if value == "future" {
+		return &dateCondition{operator: ">"}
+	}
+
+	ruleType, inputDate, _ := strings.Cut(value, ":")
+	switch ruleType {
+	case "before", "after":
+		date, err := time.Parse(time.DateOnly, inputDate)
+		if err != nil {
+			break
+		}
+		if ruleType == "before" {
+			return &dateCondition{"<", date}
+		}
+		return &dateCondition{">", date}
+	case "between":
+		start, end, _ := strings.Cut(inputDate, ",")
+		startDate, err := time.Parse(time.DateOnly, start)
+		if err != nil {
+			break
+		}
+		endDate, err := time.Parse(time.DateOnly, end)
+		if err != nil {
+			break
+		}
+		return &andCondition{&dateCondition{">", startDate}, &dateCondition{"<", endDate}}
+	case "max-age":
+		duration, err := parseDuration(inputDate)
+		if err != nil {
+			break
+		}
+		return &ageCondition{">", duration, inputDate}
+	}
+	return constantCondition(false)
+}
+
+// fromLegacyRegexRules translates a blocklist or keeplist regex, matched against the URL, title, author and tags.
+// It returns nil when the regex is empty or invalid, such rules being ignored.
+func fromLegacyRegexRules(pattern string) Condition {
+	if pattern == "" {
+		return nil
+	}
+
+	regex, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil
+	}
+
+	var condition Condition
+	for _, field := range []string{"url", "title", "author", "tag"} {
+		fieldCondition := &textCondition{field: field, operator: "~", value: pattern, regex: regex}
+		if condition == nil {
+			condition = fieldCondition
+		} else {
+			condition = &orCondition{condition, fieldCondition}
+		}
+	}
+	return condition
+}
+
+func regexCondition(field, pattern string) Condition {
+	regex, err := regexp.Compile(pattern)
+	if err != nil {
+		return constantCondition(false)
+	}
+	return &textCondition{field: field, operator: "~", value: pattern, regex: regex}
+}
@@ -0,0 +1,414 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package rules // import "miniflux.app/v2/internal/reader/rules"
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"miniflux.app/v2/internal/model"
+)
+
+// The translated rules must block the same entries as the legacy filters did.
+func TestFromLegacyBlocksLikeLegacyFilters(t *testing.T) {
+	type settings struct {
+		userBlock, userKeep, feedBlock, feedKeep, blocklist, keeplist string
+	}
+
+	scenarios := []struct {
+		settings settings
+		blocked  [3]bool
+	}{
+		{settings{}, [3]bool{false, false, false}},
+		{settings{userBlock: "EntryTitle=(?i)go"}, [3]bool{true, false, false}},
+		{settings{userBlock: "EntryTitle=(?i)rust"}, [3]bool{false, false, false}},
+		{settings{feedBlock: "EntryURL=example\\.org"}, [3]bool{true, false, false}},
+		{settings{feedBlock: "EntryCommentsURL=#comments"}, [3]bool{true, false, false}},
+		{settings{feedBlock: "EntryContent=happy"}, [3]bool{true, false, false}},
+		{settings{feedBlock: "EntryAuthor=(?i)team"}, [3]bool{true, false, false}},
+		{settings{feedBlock: "EntryTag=^rel"}, [3]bool{true, false, false}},
+		{settings{feedBlock: "EntryTag=^sports$"}, [3]bool{false, true, false}},
+		{settings{feedBlock: "EntryLanguage=^en$"}, [3]bool{true, false, false}},
+		{settings{userKeep: "EntryLanguage=^(de|fr)$"}, [3]bool{true, true, true}},
+		{settings{feedBlock: "EntryDate=future"}, [3]bool{false, false, true}},
+		{settings{feedBlock: "EntryDate=before:2000-01-01"}, [3]bool{false, false, false}},
+		{settings{feedBlock: "EntryDate=after:2000-01-01"}, [3]bool{true, true, true}},
+		{settings{feedBlock: "EntryDate=between:2000-01-01,2100-01-01"}, [3]bool{true, true, true}},
+		{settings{feedBlock: "EntryDate=between:2000-01-01"}, [3]bool{false, false, false}},
+		{settings{feedBlock: "EntryDate=max-age:1d"}, [3]bool{true, true, false}},
+		{settings{feedBlock: "EntryDate=max-age:7d"}, [3]bool{false, true, false}},
+		{settings{feedBlock: "EntryDate=max-age:invalid"}, [3]bool{false, false, false}},
+		{settings{feedBlock: "EntryTitle=["}, [3]bool{false, false, false}},
+		{settings{feedBlock: "Unknown=value"}, [3]bool{false, false, false}},
+		{settings{feedBlock: "invalid line\nEntryAuthor=Team"}, [3]bool{true, false, false}},
+		{settings{userKeep: "EntryTitle=(?i)go"}, [3]bool{false, true, true}},
+		{settings{userKeep: "EntryTitle=(?i)rust"}, [3]bool{true, true, true}},
+		{settings{userKeep: "EntryTitle=(?i)rust", feedKeep: "EntryTag=golang"}, [3]bool{false, true, true}},
+		{settings{userKeep: "EntryTitle=["}, [3]bool{true, true, true}},
+		{settings{userKeep: "Unknown=value"}, [3]bool{true, true, true}},
+		{settings{userKeep: "EntryTitle=(?i)rust", keeplist: "golang"}, [3]bool{true, true, true}},
+		{settings{userBlock: "EntryTitle=Go", userKeep: "EntryTitle=Go"}, [3]bool{true, true, true}},
+		{settings{blocklist: "(?i)golang"}, [3]bool{true, false, false}},
+		{settings{blocklist: "(?i)python"}, [3]bool{false, false, true}},
+		{settings{blocklist: "["}, [3]bool{false, false, false}},
+		{settings{keeplist: "(?i)golang"}, [3]bool{false, true, true}},
+		{settings{keeplist: "(?i)python"}, [3]bool{true, true, false}},
+		{settings{keeplist: "["}, [3]bool{false, false, false}},
+		{settings{blocklist: "python", keeplist: "python"}, [3]bool{true, true, true}},
+	}
+
+	entries := []*model.Entry{
+		newTestEntry(),
+		{Title: "Sports results", URL: "https://example.com/sports", Tags: []string{"sports"}, Date: time.Now().Add(-10 * 24 * time.Hour)},
+		{Title: "Python news", Author: "Guido", Date: time.Now().Add(time.Hour)},
+	}
+
+	for _, scenario := range scenarios {
+		user := &model.User{BlockFilterEntryRules: scenario.settings.userBlock, KeepFilterEntryRules: scenario.settings.userKeep}
+		feed := &model.Feed{
+			BlockFilterEntryRules: scenario.settings.feedBlock,
+			KeepFilterEntryRules:  scenario.settings.feedKeep,
+			BlocklistRules:        scenario.settings.blocklist,
+			KeeplistRules:         scenario.settings.keeplist,
+		}
+		rules := FromLegacy(user, feed)
+
+		for i, entry := range entries {
+			if blocked, _ := rules.Blocks(entry); blocked != scenario.blocked[i] {
+				t.Errorf(`Settings %+v on entry %q: expected blocked=%v, got %v with rules:\n%s`, scenario.settings, entry.Title, scenario.blocked[i], blocked, rules)
+			}
+		}
+	}
+}
+
+func TestFromLegacyURLRewriteRules(t *testing.T) {
+	feed := &model.Feed{UrlRewriteRules: `rewrite("^http://example\.org/(.*)$"|"https://example.com/$1")`}
+	rules := FromLegacy(&model.User{}, feed)
+
+	if len(rules) != 1 || rules[0].Legacy != LegacyURLRewriteRules {
+		t.Fatalf(`Expected one URL rewrite rule, got %d`, len(rules))
+	}
+
+	expected := "if true then rewrite_url `^http://example\\.org/(.*)$` \"https://example.com/$1\""
+	if rules[0].String() != expected {
+		t.Errorf(`Expected %s, got %s`, expected, rules[0].String())
+	}
+
+	entry := newTestEntry()
+	rules.Apply(entry)
+	if entry.URL != "https://example.com/go-1.26" {
+		t.Errorf(`Unexpected URL: %s`, entry.URL)
+	}
+
+	for _, invalidRule := range []string{`rewrite("["|"x")`, `replace("a"|"b")`} {
+		if rules := FromLegacy(&model.User{}, &model.Feed{UrlRewriteRules: invalidRule}); len(rules) != 0 {
+			t.Errorf(`The invalid rule %q should be ignored`, invalidRule)
+		}
+	}
+}
+
+func TestFromLegacyRulesAreParsable(t *testing.T) {
+	user := &model.User{
+		BlockFilterEntryRules: "EntryTitle=(?i)\"quoted\"\nEntryDate=between:2024-01-01,2024-12-31\nEntryDate=max-age:30d",
+		KeepFilterEntryRules:  "EntryTag=golang\nEntryURL=[",
+	}
+	feed := &model.Feed{BlocklistRules: `\d+`}
+
+	text := FromLegacy(user, feed).String()
+	if _, err := Parse(text); err != nil {
+		t.Errorf(`The translated rules should be parsable: %v\n%s`, err, text)
+	}
+}
+
+func TestFromLegacyCategoryRules(t *testing.T) {
+	user := &model.User{}
+	category := &model.Category{
+		BlockFilterEntryRules: "EntryTitle=(?i)rust",
+		BlocklistRules:        "sponsored",
+		KeeplistRules:         "(?i)go",
+		UrlRewriteRules:       `rewrite("^http:"|"https:")`,
+	}
+	feed := &model.Feed{
+		Category:              category,
+		BlockFilterEntryRules: "EntryAuthor=(?i)nobody",
+	}
+
+	rules := FromLegacy(user, feed)
+
+	var sources []string
+	for _, rule := range rules {
+		sources = append(sources, rule.Source+":"+rule.Field())
+	}
+	expected := []string{
+		"category:block_filter_entry_rules",
+		"feed:block_filter_entry_rules",
+		"category:blocklist_rules",
+		"category:keeplist_rules",
+		"category:urlrewrite_rules",
+	}
+	if !slices.Equal(sources, expected) {
+		t.Fatalf(`Unexpected rules: %v`, sources)
+	}
+
+	entry := newTestEntry()
+	if result := rules.Apply(entry); result.Blocked || entry.URL != "https://example.org/go-1.26" {
+		t.Errorf(`The entry should be kept and its URL rewritten: blocked=%v url=%s`, result.Blocked, entry.URL)
+	}
+
+	// The feed keeplist and URL rewrite rules replace the ones of the category.
+	feed.KeeplistRules = "(?i)rust"
+	feed.UrlRewriteRules = `rewrite("example"|"example2")`
+	rules = FromLegacy(user, feed)
+
+	entry = newTestEntry()
+	if blocked, rule := rules.Blocks(entry); !blocked || rule.Source != SourceFeed || rule.Legacy != LegacyKeeplistRules {
+		t.Errorf(`The entry should be blocked by the feed keeplist`)
+	}
+}
+
+func newLegacyTestEntry() *model.Entry {
+	return &model.Entry{
+		Title:       "Test Entry Title",
+		URL:         "https://example.com/test-entry",
+		CommentsURL: "https://example.com/test-entry/comments",
+		Content:     "This is the test entry content",
+		Author:      "Test Author",
+		Date:        time.Now(),
+		Tags:        []string{"golang", "testing", "miniflux"},
+		Language:    "en",
+	}
+}
+
+func isBlockedByLegacyRules(user *model.User, feed *model.Feed, entry *model.Entry) bool {
+	blocked, _ := ForFeed(user, feed).Blocks(entry)
+	return blocked
+}
+
+func TestFromLegacyFilterRuleFields(t *testing.T) {
+	specialEntry := &model.Entry{
+		Title:   "Test [Special] (Characters) & Symbols!",
+		URL:     "https://example.com/test?param=value&other=123",
+		Content: "Content with <html> tags and $pecial characters",
+		Author:  "Author@domain.com",
+		Tags:    []string{"c++", "c#", ".net"},
+	}
+	emptyEntry := &model.Entry{Tags: []string{}}
+
+	scenarios := []struct {
+		rule     string
+		entry    *model.Entry
+		expected bool
+	}{
+		{"EntryTitle=Test", nil, true},
+		{"EntryTitle=NoMatch", nil, false},
+		{"EntryURL=example\\.com", nil, true},
+		{"EntryURL=nomatch\\.com", nil, false},
+		{"EntryCommentsURL=comments", nil, true},
+		{"EntryCommentsURL=nomatch", nil, false},
+		{"EntryContent=test.*content", nil, true},
+		{"EntryAuthor=Test.*Author", nil, true},
+		{"EntryTag=golang", nil, true},
+		{"EntryTag=python", nil, false},
+		{"EntryLanguage=^(en|fr)$", nil, true},
+		{"EntryLanguage=^de$", nil, false},
+		{"UnknownType=test", nil, false},
+		{"EntryTitle=\\[Special\\]", specialEntry, true},
+		{"EntryTitle=\\(Characters\\)", specialEntry, true},
+		{"EntryURL=param=value", specialEntry, true},
+		{"EntryContent=<html>", specialEntry, true},
+		{"EntryAuthor=@domain\\.com", specialEntry, true},
+		{"EntryTag=c\\+\\+", specialEntry, true},
+		{"EntryTag=c#", specialEntry, true},
+		{"EntryTitle=.*", emptyEntry, true},
+		{"EntryTitle=^$", emptyEntry, true},
+		{"EntryURL=^$", emptyEntry, true},
+		{"EntryTag=anything", emptyEntry, false},
+		{"EntryDate=future", emptyEntry, false},
+	}
+
+	for _, scenario := range scenarios {
+		entry := scenario.entry
+		if entry == nil {
+			entry = newLegacyTestEntry()
+		}
+
+		feed := &model.Feed{BlockFilterEntryRules: scenario.rule}
+		if blocked := isBlockedByLegacyRules(&model.User{}, feed, entry); blocked != scenario.expected {
+			t.Errorf(`Rule %q on entry %q: expected blocked=%v, got %v`, scenario.rule, entry.Title, scenario.expected, blocked)
+		}
+	}
+}
+
+func TestFromLegacyDateRules(t *testing.T) {
+	now := time.Now()
+	testDate := time.Date(2023, 6, 15, 12, 0, 0, 0, time.UTC)
+	exactDate := time.Date(2023, 6, 15, 0, 0, 0, 0, time.UTC)
+
+	scenarios := []struct {
+		pattern  string
+		date     time.Time
+		expected bool
+	}{
+		{"future", now.Add(time.Hour), true},
+		{"future", now.Add(-time.Hour), false},
+		{"before:2023-07-01", testDate, true},
+		{"before:2023-06-01", testDate, false},
+		{"before:invalid-date", testDate, false},
+		{"after:2023-06-01", testDate, true},
+		{"after:2023-07-01", testDate, false},
+		{"after:invalid-date", testDate, false},
+		{"between:2023-06-01,2023-07-01", testDate, true},
+		{"between:2023-07-01,2023-08-01", testDate, false},
+		{"between:2023-06-01", testDate, false},
+		{"between:invalid,2023-07-01", testDate, false},
+		{"between:2023-06-01,invalid", testDate, false},
+		{"between:2023-06-15,2023-06-15", testDate, false},
+		{"between:2023-06-15,2023-06-15", exactDate, false},
+		{"before:2023-06-15", exactDate, false},
+		{"after:2023-06-15", exactDate, false},
+		{"before:2023-06-15", exactDate.Add(-time.Second), true},
+		{"after:2023-06-15", exactDate.Add(time.Second), true},
+		{"max-age:1d", now.Add(-2 * 24 * time.Hour), true},
+		{"max-age:3d", now.Add(-2 * 24 * time.Hour), false},
+		{"max-age:24h", now.Add(-25 * time.Hour), true},
+		{"max-age:invalid", testDate, false},
+		{"invalid-pattern", testDate, false},
+		{"unknown:value", testDate, false},
+	}
+
+	for _, scenario := range scenarios {
+		entry := newLegacyTestEntry()
+		entry.Date = scenario.date
+
+		feed := &model.Feed{BlockFilterEntryRules: "EntryDate=" + scenario.pattern}
+		if blocked := isBlockedByLegacyRules(&model.User{}, feed, entry); blocked != scenario.expected {
+			t.Errorf(`Date rule %q on %v: expected blocked=%v, got %v`, scenario.pattern, scenario.date, scenario.expected, blocked)
+		}
+	}
+}
+
+func TestFromLegacyBlockAndKeepRulesPrecedence(t *testing.T) {
+	scenarios := []struct {
+		name, block, keep, blocklist, keeplist string
+		expected                               bool
+	}{
+		{name: "no rules"},
+		{name: "matching block rule", block: "EntryTitle=Test", expected: true},
+		{name: "non-matching block rule", block: "EntryTitle=NonMatching"},
+		{name: "block rule before matching keep rule", block: "EntryTitle=Test", keep: "EntryTitle=Test", expected: true},
+		{name: "block rule before non-matching keep rule", block: "EntryTitle=Test", keep: "EntryTitle=NonMatching", expected: true},
+		{name: "matching keep rule", keep: "EntryTitle=Test"},
+		{name: "non-matching keep rule", keep: "EntryTitle=NonMatching", expected: true},
+		{name: "non-matching block and keep rules", block: "EntryTitle=NonMatching", keep: "EntryTitle=NonMatching", expected: true},
+		{name: "one of the keep rules matches", keep: "EntryTitle=NonMatching\nEntryAuthor=Test"},
+		{name: "no keep rule matches", keep: "EntryTitle=NonMatching1\nEntryAuthor=NonMatching2", expected: true},
+		{name: "matching blocklist", blocklist: "Test.*Title", expected: true},
+		{name: "blocklist before matching keeplist", blocklist: "Test.*Title", keeplist: "Test.*Title", expected: true},
+		{name: "block rule before blocklist and keep rules", block: "EntryAuthor=Test.*Author", keep: "EntryTitle=Test.*Title", blocklist: "golang", keeplist: "testing", expected: true},
+		{name: "blocklist before matching keep rule", keep: "EntryTitle=Test.*Title", blocklist: "golang", keeplist: "testing", expected: true},
+		{name: "matching keep rule replaces keeplist", keep: "EntryTitle=Test.*Title", keeplist: "NonMatching"},
+		{name: "non-matching keep rule replaces keeplist", keep: "EntryTitle=NonMatching", keeplist: "testing", expected: true},
+		{name: "keeplist matches title", keeplist: "Test.*Title"},
+		{name: "keeplist matches URL", keeplist: "example\\.com"},
+		{name: "keeplist matches author", keeplist: "Test.*Author"},
+		{name: "keeplist matches tag", keeplist: "golang"},
+		{name: "keeplist does not match", keeplist: "NonMatchingPattern", expected: true},
+		{name: "blocklist matches complex regex", blocklist: "^Test.*Entry.*Title$", expected: true},
+	}
+
+	for _, scenario := range scenarios {
+		user := &model.User{BlockFilterEntryRules: scenario.block, KeepFilterEntryRules: scenario.keep}
+		feed := &model.Feed{BlocklistRules: scenario.blocklist, KeeplistRules: scenario.keeplist}
+		if blocked := isBlockedByLegacyRules(user, feed, newLegacyTestEntry()); blocked != scenario.expected {
+			t.Errorf(`%s: expected blocked=%v, got %v`, scenario.name, scenario.expected, blocked)
+		}
+	}
+}
+
+func TestFromLegacyInvalidRegex(t *testing.T) {
+	for _, pattern := range []string{"[", "[abc", "(unclosed", "*invalid"} {
+		// Invalid blocklist and keeplist regexes are ignored.
+		feed := &model.Feed{BlocklistRules: pattern}
+		if isBlockedByLegacyRules(&model.User{}, feed, newLegacyTestEntry()) {
+			t.Errorf(`The invalid blocklist %q should not block entries`, pattern)
+		}
+
+		feed = &model.Feed{KeeplistRules: pattern}
+		if isBlockedByLegacyRules(&model.User{}, feed, newLegacyTestEntry()) {
+			t.Errorf(`The invalid keeplist %q should not block entries`, pattern)
+		}
+
+		// Filter rules with an invalid regex never match.
+		feed = &model.Feed{BlockFilterEntryRules: "EntryTitle=" + pattern}
+		if isBlockedByLegacyRules(&model.User{}, feed, newLegacyTestEntry()) {
+			t.Errorf(`The invalid block rule %q should not block entries`, pattern)
+		}
+
+		feed = &model.Feed{KeepFilterEntryRules: "EntryTitle=" + pattern}
+		if !isBlockedByLegacyRules(&model.User{}, feed, newLegacyTestEntry()) {
+			t.Errorf(`The invalid keep rule %q should not keep entries`, pattern)
+		}
+	}
+}
+
+func TestFromLegacyFilterRuleLines(t *testing.T) {
+	scenarios := []struct {
+		text     string
+		expected int
+	}{
+		{"\n\n\n", 0},
+		{"   \n   \t   \n", 0},
+		{"EntryTitle=", 1},
+		{"=value", 1},
+		{"EntryTitle=test1\nEntryAuthor=author1\n\nEntryURL=example1", 3},
+	}
+
+	for _, scenario := range scenarios {
+		if rules := FromLegacy(&model.User{BlockFilterEntryRules: scenario.text}, &model.Feed{}); len(rules) != scenario.expected {
+			t.Errorf(`Rules %q: expected %d rules, got %d`, scenario.text, scenario.expected, len(rules))
+		}
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	scenarios := []struct {
+		duration string
+		expected time.Duration
+		valid    bool
+	}{
+		{"1d", 24 * time.Hour, true},
+		{"30d", 30 * 24 * time.Hour, true},
+		{"0d", 0, true},
+		{"d", 0, true},
+		{"999d", 999 * 24 * time.Hour, true},
+		{"24h", 24 * time.Hour, true},
+		{"60m", 60 * time.Minute, true},
+		{"30s", 30 * time.Second, true},
+		{"500ms", 500 * time.Millisecond, true},
+		{"1000us", 1000 * time.Microsecond, true},
+		{"1000ns", 1000 * time.Nanosecond, true},
+		{"1h30m", time.Hour + 30*time.Minute, true},
+		{"30m45s", 30*time.Minute + 45*time.Second, true},
+		{"1.5h", time.Hour + 30*time.Minute, true},
+		{"-1h", -time.Hour, true},
+		{"0", 0, true},
+		{"invalid_d", 0, false},
+		{"invalid", 0, false},
+		{"", 0, false},
+	}
+
+	for _, scenario := range scenarios {
+		duration, err := parseDuration(scenario.duration)
+		if scenario.valid && err != nil {
+			t.Errorf(`Unexpected error for %q: %v`, scenario.duration, err)
+		}
+		if !scenario.valid && err == nil {
+			t.Errorf(`Expected an error for %q`, scenario.duration)
+		}
+		if scenario.valid && duration != scenario.expected {
+			t.Errorf(`Duration %q: expected %v, got %v`, scenario.duration, scenario.expected, duration)
+		}
+	}
+}
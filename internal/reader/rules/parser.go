@@ -0,0 +1,384 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package rules // import "miniflux.app/v2/internal/reader/rules"
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"miniflux.app/v2/internal/model"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenString
+	tokenSymbol
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+}
+
+func (t token) String() string {
+	if t.kind == tokenEOF {
+		return "end of line"
+	}
+	return strconv.Quote(t.value)
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("_-+.:", r)
+}
+
+func tokenize(line string) ([]token, error) {
+	var tokens []token
+	runes := []rune(line)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '"' || r == '`':
+			end := i + 1
+			for end < len(runes) && runes[end] != r {
+				if r == '"' && runes[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(runes) {
+				return nil, errors.New("unterminated string")
+			}
+			value, err := strconv.Unquote(string(runes[i : end+1]))
+			if err != nil {
+				return nil, fmt.Errorf("invalid string %s", string(runes[i:end+1]))
+			}
+			tokens = append(tokens, token{tokenString, value})
+			i = end + 1
+		case strings.ContainsRune("(),~", r):
+			tokens = append(tokens, token{tokenSymbol, string(r)})
+			i++
+		case strings.ContainsRune("!=<>", r):
+			symbol := string(r)
+			if i+1 < len(runes) && (runes[i+1] == '=' || (r == '!' && runes[i+1] == '~')) {
+				symbol += string(runes[i+1])
+			}
+			if symbol == "!" {
+				return nil, errors.New(`unexpected "!"`)
+			}
+			tokens = append(tokens, token{tokenSymbol, symbol})
+			i += len(symbol)
+		case isWordRune(r):
+			end := i
+			for end < len(runes) && isWordRune(runes[end]) {
+				end++
+			}
+			tokens = append(tokens, token{tokenWord, string(runes[i:end])})
+			i = end
+		default:
+			return nil, fmt.Errorf("unexpected character %q", r)
+		}
+	}
+	return tokens, nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return token{kind: tokenEOF}
+}
+
+func (p *parser) next() token {
+	t := p.peek()
+	if p.pos < len(p.tokens) {
+		p.pos++
+	}
+	return t
+}
+
+// keyword consumes the next token if it is the given keyword.
+func (p *parser) keyword(word string) bool {
+	if t := p.peek(); t.kind == tokenWord && strings.EqualFold(t.value, word) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) symbol(symbol string) bool {
+	if t := p.peek(); t.kind == tokenSymbol && t.value == symbol {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func parseRule(line string) (*Rule, error) {
+	tokens, err := tokenize(line)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	p.keyword("if")
+
+	condition, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if !p.keyword("then") {
+		return nil, fmt.Errorf(`expected "then", got %s`, p.peek())
+	}
+
+	rule := &Rule{Condition: condition}
+	for {
+		action, err := p.parseAction()
+		if err != nil {
+			return nil, err
+		}
+		rule.Actions = append(rule.Actions, action)
+
+		if !p.symbol(",") {
+			break
+		}
+	}
+
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, fmt.Errorf("unexpected %s", t)
+	}
+	return rule, nil
+}
+
+func (p *parser) parseOr() (Condition, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("or") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &orCondition{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (Condition, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("and") {
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &andCondition{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseNot() (Condition, error) {
+	if p.keyword("not") {
+		condition, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &notCondition{condition}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (Condition, error) {
+	if p.symbol("(") {
+		condition, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.symbol(")") {
+			return nil, fmt.Errorf(`expected ")", got %s`, p.peek())
+		}
+		return condition, nil
+	}
+
+	t := p.next()
+	if t.kind != tokenWord {
+		return nil, fmt.Errorf("expected a condition, got %s", t)
+	}
+
+	field := strings.ToLower(t.value)
+	switch field {
+	case "true":
+		return constantCondition(true), nil
+	case "false":
+		return constantCondition(false), nil
+	case "title", "url", "comments_url", "author", "content", "tag", "enclosure", "language":
+		return p.parseTextCondition(field)
+	case "score":
+		operator, err := p.parseOperator("=", "!=", ">", ">=", "<", "<=")
+		if err != nil {
+			return nil, err
+		}
+		value, err := strconv.ParseInt(p.next().value, 10, 64)
+		if err != nil {
+			return nil, errors.New("score must be compared with an integer")
+		}
+		return &scoreCondition{operator, value}, nil
+	case "date":
+		operator, err := p.parseOperator(">", ">=", "<", "<=")
+		if err != nil {
+			return nil, err
+		}
+		value := p.next().value
+		if strings.EqualFold(value, "now") {
+			return &dateCondition{operator: operator}, nil
+		}
+		date, err := time.Parse(time.DateOnly, value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid date %q, expected YYYY-MM-DD or now", value)
+		}
+		return &dateCondition{operator, date}, nil
+	case "age":
+		operator, err := p.parseOperator(">", ">=", "<", "<=")
+		if err != nil {
+			return nil, err
+		}
+		value := p.next().value
+		duration, err := parseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid duration %q", value)
+		}
+		return &ageCondition{operator, duration, value}, nil
+	}
+	return nil, fmt.Errorf("unknown field %s", t)
+}
+
+func (p *parser) parseOperator(operators ...string) (string, error) {
+	t := p.next()
+	for _, operator := range operators {
+		if strings.EqualFold(t.value, operator) && t.kind != tokenString {
+			return operator, nil
+		}
+	}
+	return "", fmt.Errorf("expected one of %s, got %s", strings.Join(operators, " "), t)
+}
+
+func (p *parser) parseTextCondition(field string) (Condition, error) {
+	operator, err := p.parseOperator("~", "!~", "=", "!=", "contains")
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := p.parseString()
+	if err != nil {
+		return nil, err
+	}
+
+	condition := &textCondition{field: field, operator: operator, value: value}
+	if operator == "~" || operator == "!~" {
+		if condition.regex, err = regexp.Compile(value); err != nil {
+			return nil, fmt.Errorf("invalid regular expression %q: %v", value, err)
+		}
+	}
+	return condition, nil
+}
+
+func (p *parser) parseString() (string, error) {
+	t := p.next()
+	if t.kind != tokenString && t.kind != tokenWord {
+		return "", fmt.Errorf("expected a value, got %s", t)
+	}
+	return t.value, nil
+}
+
+func (p *parser) parseAction() (Action, error) {
+	t := p.next()
+	if t.kind != tokenWord {
+		return nil, fmt.Errorf("expected an action, got %s", t)
+	}
+
+	switch name := strings.ToLower(t.value); name {
+	case "block":
+		return blockAction{}, nil
+	case "stop":
+		return stopAction{}, nil
+	case "mark_read":
+		return markReadAction{}, nil
+	case "star":
+		return starAction{}, nil
+	case "send":
+		name, err := p.parseString()
+		if err != nil {
+			return nil, err
+		}
+		if name = strings.ToLower(name); !model.IsSendEntryIntegration(name) {
+			return nil, fmt.Errorf("unknown integration %q", name)
+		}
+		return sendAction{name}, nil
+	case "tag":
+		tag, err := p.parseString()
+		if err != nil {
+			return nil, err
+		}
+		if tag = strings.TrimSpace(tag); tag == "" {
+			return nil, errors.New("tag name is empty")
+		}
+		return tagAction{tag}, nil
+	case "vote":
+		switch value := strings.ToLower(p.next().value); value {
+		case "up", "1", "+1":
+			return voteAction{1}, nil
+		case "down", "-1":
+			return voteAction{-1}, nil
+		case "0", "none":
+			return voteAction{0}, nil
+		default:
+			return nil, fmt.Errorf("invalid vote %q, expected up, down or 0", value)
+		}
+	case "score":
+		value, err := strconv.ParseInt(p.next().value, 10, 64)
+		if err != nil {
+			return nil, errors.New("score must be an integer")
+		}
+		return scoreAction{value}, nil
+	case "rewrite":
+		rules, err := p.parseString()
+		if err != nil {
+			return nil, err
+		}
+		return rewriteAction{rules}, nil
+	case "rewrite_url":
+		pattern, err := p.parseString()
+		if err != nil {
+			return nil, err
+		}
+		replacement, err := p.parseString()
+		if err != nil {
+			return nil, err
+		}
+		regex, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regular expression %q: %v", pattern, err)
+		}
+		return rewriteURLAction{regex, replacement}, nil
+	}
+	return nil, fmt.Errorf("unknown action %s", t)
+}
@@ -0,0 +1,119 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package rules // import "miniflux.app/v2/internal/reader/rules"
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseValidRules(t *testing.T) {
+	scenarios := map[string]string{
+		`title ~ "(?i)golang" then block`:                                  `if title ~ "(?i)golang" then block`,
+		`IF title ~ "a" THEN block`:                                        `if title ~ "a" then block`,
+		"if url ~ `\\d+$` then mark_read":                                  "if url ~ `\\d+$` then mark_read",
+		`if author = "Rob" and not tag contains "go" then star, stop`:      `if author = "Rob" and not tag contains "go" then star, stop`,
+		`if (title ~ "a" or url ~ "b") and score >= 10 then tag "x"`:       `if (title ~ "a" or url ~ "b") and score >= 10 then tag "x"`,
+		`if title ~ "a" or url ~ "b" and score < -1 then vote down`:        `if title ~ "a" or url ~ "b" and score < -1 then vote down`,
+		`if not (title ~ "a" or title ~ "b") then block`:                   `if not (title ~ "a" or title ~ "b") then block`,
+		`if enclosure ~ "^audio/" then vote up, score 5`:                   `if enclosure ~ "^audio/" then vote up, score 5`,
+		`if date > now or date < 2024-01-31 then block`:                    `if date > now or date < 2024-01-31 then block`,
+		`if age > 30d then mark_read`:                                      `if age > 30d then mark_read`,
+		`if true then rewrite "add_image_title", send Wallabag`:            `if true then rewrite "add_image_title", send "wallabag"`,
+		`if url ~ "example" then rewrite_url "^http:" "https:"`:            `if url ~ "example" then rewrite_url "^http:" "https:"`,
+		`if comments_url != "" and content !~ "sponsored" then vote 0`:     `if comments_url != "" and content !~ "sponsored" then vote 0`,
+		`if false then rewrite "replace(\"a\"|\"b\")"`:                     `if false then rewrite "replace(\"a\"|\"b\")"`,
+		`if title=golang then tag go`:                                      `if title = "golang" then tag "go"`,
+		`if not not title ~ "a" then block`:                                `if not not title ~ "a" then block`,
+		`if title ~ "a" and (url ~ "b" and author ~ "c") then block`:       `if title ~ "a" and url ~ "b" and author ~ "c" then block`,
+		`if score = 0 or score != 1 or score > 2 or score <= 3 then block`: `if score = 0 or score != 1 or score > 2 or score <= 3 then block`,
+	}
+
+	for input, expected := range scenarios {
+		rules, err := Parse(input)
+		if err != nil {
+			t.Errorf(`Parsing %q should not fail: %v`, input, err)
+			continue
+		}
+
+		if len(rules) != 1 {
+			t.Errorf(`Parsing %q should return one rule, got %d`, input, len(rules))
+			continue
+		}
+
+		if result := rules[0].String(); result != expected {
+			t.Errorf(`Parsing %q should return %q, got %q`, input, expected, result)
+		}
+
+		// The string representation must be parsed to the same rule.
+		if reparsed, err := Parse(rules[0].String()); err != nil || reparsed[0].String() != expected {
+			t.Errorf(`The string representation of %q should be parsable, got %v`, input, err)
+		}
+	}
+}
+
+func TestParseInvalidRules(t *testing.T) {
+	scenarios := []string{
+		`title ~ "a"`,
+		`title ~ "a" then`,
+		`title ~ "a" then explode`,
+		`title ~ "[" then block`,
+		`title > "a" then block`,
+		`size > 1 then block`,
+		`title ~ "a then block`,
+		`(title ~ "a" then block`,
+		`title ~ "a" and then block`,
+		`score > high then block`,
+		`date > yesterday then block`,
+		`date = now then block`,
+		`age > 30x then block`,
+		`true then vote sideways`,
+		`true then score many`,
+		`true then tag ""`,
+		`true then send`,
+		`true then send "telegram_bot"`,
+		`true then rewrite_url "[" "x"`,
+		`true then block extra`,
+		`true then block,`,
+		`title ! "a" then block`,
+		`title ~ "a" then block; star`,
+	}
+
+	for _, input := range scenarios {
+		if _, err := Parse(input); err == nil {
+			t.Errorf(`Parsing %q should fail`, input)
+		}
+	}
+}
+
+func TestParseErrorLine(t *testing.T) {
+	text := "# Comment\n\nif title ~ \"a\" then block\nif title ~ \"[\" then block\n"
+
+	_, err := Parse(text)
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf(`Expected a parse error, got %v`, err)
+	}
+
+	if parseErr.Line != 4 {
+		t.Errorf(`Expected the error on line 4, got %d`, parseErr.Line)
+	}
+}
+
+func TestCompileIgnoresInvalidRules(t *testing.T) {
+	text := "if title ~ \"a\" then block\nthis is not a rule\r\n  # comment\nif url ~ \"b\" then star\r\n"
+
+	rules := Compile(SourceFeed, text)
+	if len(rules) != 2 {
+		t.Fatalf(`Expected 2 rules, got %d`, len(rules))
+	}
+
+	if rules[0].Line != 1 || rules[1].Line != 4 {
+		t.Errorf(`Unexpected line numbers: %d, %d`, rules[0].Line, rules[1].Line)
+	}
+
+	if rules[1].Source != SourceFeed {
+		t.Errorf(`Expected the feed source, got %q`, rules[1].Source)
+	}
+}
@@ -0,0 +1,212 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package rules implements the entry rule engine.
+//
+// Rules are written one per line, evaluated in order, and have the following form:
+//
+//	[if] <condition> then <action>[, <action>...]
+//
+// Conditions compare entry fields and can be combined with "and", "or", "not" and parentheses:
+//
+//	title, url, comments_url, author, content, tag, enclosure, language: ~ !~ = != contains
+//	score: = != > >= < <=
+//	date: > >= < <= followed by a YYYY-MM-DD date or "now"
+//	age: > >= < <= followed by a duration such as 30d or 12h
+//
+// Regular expressions use the RE2 syntax. Text values are double-quoted Go strings or
+// back-quoted raw strings. The "=" operator is case-insensitive. The tag field matches
+// the entry tags, the enclosure field matches the enclosure MIME types and the language
+// field matches the detected language code of the entry, such as "en" or "fr".
+//
+// Available actions are: block, mark_read, star, tag "name", vote up|down|0,
+// score N, rewrite "content rewrite rules", rewrite_url "pattern" "replacement",
+// send "integration", and stop. The integration is the lowercase name of a third-party
+// service that saves entries, such as "wallabag" or "pinboard", and must be enabled.
+//
+// The mark_read, star and send actions only apply to new entries: updated entries keep
+// their status and are not sent again.
+//
+// Lines starting with "#" are comments. Evaluation stops after a block or stop action.
+//
+// The legacy filter fields (block and keep rules, blocklist and keeplist rules, URL rewrite rules)
+// are translated into rules evaluated before the user, category and feed rules.
+// Feed content rewrite rules are not translated because they replace the predefined rules of the website.
+package rules // import "miniflux.app/v2/internal/reader/rules"
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"miniflux.app/v2/internal/model"
+)
+
+// Rule sources.
+const (
+	SourceUser     = "user"
+	SourceCategory = "category"
+	SourceFeed     = "feed"
+)
+
+// Rule is a condition and the list of actions executed when an entry matches the condition.
+type Rule struct {
+	Condition Condition
+	Actions   []Action
+
+	// Source is the owner of the rule: user, category or feed.
+	Source string
+
+	// Legacy is the name of the legacy field the rule was translated from, if any.
+	Legacy string
+
+	// Line is the line number of the rule in its source field, starting at 1.
+	Line int
+}
+
+// Field returns the name of the field holding the rule.
+func (r *Rule) Field() string {
+	if r.Legacy != "" {
+		return r.Legacy
+	}
+	return "entry_rules"
+}
+
+func (r *Rule) String() string {
+	actions := make([]string, len(r.Actions))
+	for i, action := range r.Actions {
+		actions[i] = action.String()
+	}
+	return "if " + r.Condition.String() + " then " + strings.Join(actions, ", ")
+}
+
+// Rules is an ordered list of rules.
+type Rules []*Rule
+
+func (r Rules) String() string {
+	lines := make([]string, len(r))
+	for i, rule := range r {
+		lines[i] = rule.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Result holds the outcome of the rules evaluation.
+type Result struct {
+	Blocked   bool
+	BlockedBy *Rule
+	Matched   Rules
+
+	// ContentRewriteRules are the content rewrite rules to apply once the entry content is final.
+	ContentRewriteRules []string
+
+	// SendToIntegrations are the names of the third-party services the entry must be sent to.
+	SendToIntegrations []string
+}
+
+// Apply evaluates the rules in order and executes the actions of the matching rules on the entry.
+func (r Rules) Apply(entry *model.Entry) *Result {
+	result := &Result{}
+	for _, rule := range r {
+		if !rule.Condition.Match(entry) {
+			continue
+		}
+
+		result.Matched = append(result.Matched, rule)
+		for _, action := range rule.Actions {
+			action.apply(entry, result)
+			if result.Blocked {
+				result.BlockedBy = rule
+				return result
+			}
+			if _, ok := action.(stopAction); ok {
+				return result
+			}
+		}
+	}
+	return result
+}
+
+// DryRun evaluates the rules on a copy of the entry.
+func (r Rules) DryRun(entry *model.Entry) *Result {
+	clone := *entry
+	clone.Tags = append([]string(nil), entry.Tags...)
+	return r.Apply(&clone)
+}
+
+// Blocks reports whether the rules block the entry, without modifying it.
+func (r Rules) Blocks(entry *model.Entry) (bool, *Rule) {
+	result := r.DryRun(entry)
+	return result.Blocked, result.BlockedBy
+}
+
+// ParseError is returned when a rule cannot be parsed.
+type ParseError struct {
+	Line int
+	Err  error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Parse parses the given rules and returns an error for the first invalid line.
+func Parse(text string) (Rules, error) {
+	var rules Rules
+	for i, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		rule, err := parseRule(line)
+		if err != nil {
+			return nil, &ParseError{Line: i + 1, Err: err}
+		}
+		rule.Line = i + 1
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// Compile parses the given rules and ignores invalid lines.
+func Compile(source, text string) Rules {
+	var rules Rules
+	for i, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		rule, err := parseRule(line)
+		if err != nil {
+			slog.Warn("Ignoring invalid entry rule",
+				slog.String("source", source),
+				slog.Int("line", i+1),
+				slog.String("rule", line),
+				slog.Any("error", err),
+			)
+			continue
+		}
+		rule.Source = source
+		rule.Line = i + 1
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+// ForFeed returns the rules applied to the entries of a feed:
+// the translated legacy rules, then the user, category and feed rules.
+func ForFeed(user *model.User, feed *model.Feed) Rules {
+	rules := FromLegacy(user, feed)
+	rules = append(rules, Compile(SourceUser, user.EntryRules)...)
+	if feed.Category != nil {
+		rules = append(rules, Compile(SourceCategory, feed.Category.EntryRules)...)
+	}
+	rules = append(rules, Compile(SourceFeed, feed.EntryRules)...)
+	return rules
+}
@@ -0,0 +1,212 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package rules // import "miniflux.app/v2/internal/reader/rules"
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"miniflux.app/v2/internal/model"
+)
+
+func newTestEntry() *model.Entry {
+	return &model.Entry{
+		Title:       "Go 1.26 is released",
+		URL:         "http://example.org/go-1.26",
+		CommentsURL: "http://example.org/go-1.26#comments",
+		Author:      "The Go Team",
+		Content:     "<p>Today the Go team is happy to announce the release of Go 1.26.</p>",
+		Date:        time.Now().Add(-48 * time.Hour),
+		Status:      model.EntryStatusUnread,
+		Tags:        []string{"golang", "release"},
+		Score:       42,
+		Language:    "en",
+		Enclosures: model.EnclosureList{
+			{URL: "http://example.org/episode.mp3", MimeType: "audio/mpeg"},
+		},
+	}
+}
+
+func mustParse(t *testing.T, text string) Rules {
+	t.Helper()
+	rules, err := Parse(text)
+	if err != nil {
+		t.Fatalf(`Unable to parse rules: %v`, err)
+	}
+	return rules
+}
+
+func TestConditions(t *testing.T) {
+	scenarios := map[string]bool{
+		`title ~ "(?i)^go"`:                  true,
+		`title !~ "(?i)^go"`:                 false,
+		`title = "go 1.26 IS RELEASED"`:      true,
+		`title != "go 1.26 is released"`:     false,
+		`title contains "RELEASED"`:          true,
+		`url ~ "^https:"`:                    false,
+		`comments_url contains "#comments"`:  true,
+		`author = "the go team"`:             true,
+		`content ~ "happy"`:                  true,
+		`tag = "golang"`:                     true,
+		`tag = "rust"`:                       false,
+		`tag != "rust"`:                      true,
+		`tag != "golang"`:                    false,
+		`enclosure ~ "^audio/"`:              true,
+		`enclosure ~ "^video/"`:              false,
+		`language = "EN"`:                    true,
+		`language ~ "^(de|fr)$"`:             false,
+		`score = 42`:                         true,
+		`score > 42`:                         false,
+		`score >= 42`:                        true,
+		`score < 0`:                          false,
+		`date < now`:                         true,
+		`date > now`:                         false,
+		`date > 2000-01-01`:                  true,
+		`age > 1d`:                           true,
+		`age > 3d`:                           false,
+		`age < 72h`:                          true,
+		`true`:                               true,
+		`false`:                              false,
+		`not false`:                          true,
+		`title ~ "Go" and tag = "rust"`:      false,
+		`title ~ "Go" or tag = "rust"`:       true,
+		`not (title ~ "Go" or tag = "rust")`: false,
+		`tag = "rust" or tag = "release" and false`:   false,
+		`(tag = "rust" or tag = "release") and true`:  true,
+		`not title ~ "Rust" and author contains "go"`: true,
+	}
+
+	entry := newTestEntry()
+	for condition, expected := range scenarios {
+		rules := mustParse(t, "if "+condition+" then block")
+		if result := rules[0].Condition.Match(entry); result != expected {
+			t.Errorf(`Condition %q should return %v, got %v`, condition, expected, result)
+		}
+	}
+}
+
+func TestApplyActions(t *testing.T) {
+	rules := mustParse(t, `
+		if tag = "golang" then tag "go", tag "golang", star, vote up
+		if score > 10 then score 100, mark_read
+		if true then rewrite "add_image_title", rewrite_url "^http:" "https:", send "wallabag", send "pinboard", send "wallabag"
+	`)
+
+	entry := newTestEntry()
+	result := rules.Apply(entry)
+
+	if result.Blocked {
+		t.Error(`The entry should not be blocked`)
+	}
+
+	if len(result.Matched) != 3 {
+		t.Errorf(`Expected 3 matched rules, got %d`, len(result.Matched))
+	}
+
+	if !slices.Equal(entry.Tags, []string{"golang", "release", "go"}) {
+		t.Errorf(`Unexpected tags: %v`, entry.Tags)
+	}
+
+	if !entry.Starred || entry.Vote != 1 || entry.Score != 100 || entry.Status != model.EntryStatusRead {
+		t.Errorf(`Unexpected entry state: starred=%v vote=%d score=%d status=%s`, entry.Starred, entry.Vote, entry.Score, entry.Status)
+	}
+
+	if entry.URL != "https://example.org/go-1.26" {
+		t.Errorf(`Unexpected URL: %s`, entry.URL)
+	}
+
+	if !slices.Equal(result.ContentRewriteRules, []string{"add_image_title"}) {
+		t.Errorf(`Unexpected content rewrite rules: %v`, result.ContentRewriteRules)
+	}
+
+	if !slices.Equal(result.SendToIntegrations, []string{"wallabag", "pinboard"}) {
+		t.Errorf(`Unexpected integrations: %v`, result.SendToIntegrations)
+	}
+}
+
+func TestApplyStopsAfterBlock(t *testing.T) {
+	rules := mustParse(t, `
+		if title ~ "Go" then star
+		if tag = "release" then block, tag "never"
+		if true then mark_read
+	`)
+
+	entry := newTestEntry()
+	result := rules.Apply(entry)
+
+	if !result.Blocked || result.BlockedBy != rules[1] {
+		t.Fatalf(`The entry should be blocked by the second rule`)
+	}
+
+	if slices.Contains(entry.Tags, "never") || entry.Status == model.EntryStatusRead {
+		t.Error(`No action should be executed after a block action`)
+	}
+}
+
+func TestApplyStopAction(t *testing.T) {
+	rules := mustParse(t, `
+		if tag = "release" then star, stop, tag "skipped"
+		if true then block
+	`)
+
+	entry := newTestEntry()
+	result := rules.Apply(entry)
+
+	if result.Blocked {
+		t.Error(`The stop action should prevent the evaluation of the next rules`)
+	}
+
+	if !entry.Starred || slices.Contains(entry.Tags, "skipped") {
+		t.Errorf(`Unexpected entry state: starred=%v tags=%v`, entry.Starred, entry.Tags)
+	}
+}
+
+func TestBlocksDoesNotModifyEntry(t *testing.T) {
+	rules := mustParse(t, `
+		if true then tag "new", rewrite_url "example" "example2"
+		if tag = "new" then block
+	`)
+
+	entry := newTestEntry()
+	blocked, rule := rules.Blocks(entry)
+	if !blocked || rule != rules[1] {
+		t.Fatal(`The entry should be blocked by the second rule`)
+	}
+
+	if slices.Contains(entry.Tags, "new") || entry.URL != "http://example.org/go-1.26" {
+		t.Errorf(`The entry should not be modified: tags=%v url=%s`, entry.Tags, entry.URL)
+	}
+}
+
+func TestForFeed(t *testing.T) {
+	user := &model.User{
+		BlockFilterEntryRules: "EntryTitle=(?i)rust",
+		EntryRules:            `if tag = "golang" then star`,
+	}
+	feed := &model.Feed{
+		Category:   &model.Category{EntryRules: `if true then tag "category"`},
+		EntryRules: `if true then tag "feed"`,
+	}
+
+	rules := ForFeed(user, feed)
+	if len(rules) != 4 {
+		t.Fatalf(`Expected 4 rules, got %d`, len(rules))
+	}
+
+	sources := []string{rules[0].Source, rules[1].Source, rules[2].Source, rules[3].Source}
+	if !slices.Equal(sources, []string{SourceUser, SourceUser, SourceCategory, SourceFeed}) {
+		t.Errorf(`Unexpected rule sources: %v`, sources)
+	}
+
+	if rules[0].Legacy != LegacyBlockFilterEntryRules || rules[1].Legacy != "" {
+		t.Errorf(`Only the first rule should be a legacy rule`)
+	}
+
+	entry := newTestEntry()
+	rules.Apply(entry)
+	if !entry.Starred || !slices.Equal(entry.Tags, []string{"golang", "release", "category", "feed"}) {
+		t.Errorf(`Unexpected entry state: starred=%v tags=%v`, entry.Starred, entry.Tags)
+	}
+}
@@ -33,6 +33,18 @@ func (c imageCandidates) String() string {
 	return strings.Join(htmlCandidates, ", ")
 }
 
+// NewResponsiveImageCandidates returns the image candidates of the variants of an image resized to each width.
+func NewResponsiveImageCandidates(widths []int, resizedImageURL func(width int) string) imageCandidates {
+	candidates := make(imageCandidates, 0, len(widths))
+	for _, width := range widths {
+		candidates = append(candidates, &imageCandidate{
+			ImageURL:   resizedImageURL(width),
+			Descriptor: strconv.Itoa(width) + "w",
+		})
+	}
+	return candidates
+}
+
 // ParseSrcSetAttribute returns the list of image candidates from the set.
 // Parsing behavior follows the WebKit HTMLSrcsetParser implementation.
 // https://html.spec.whatwg.org/#parse-a-srcset-attribute
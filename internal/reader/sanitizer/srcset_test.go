@@ -3,7 +3,10 @@
 
 package sanitizer
 
-import "testing"
+import (
+	"strconv"
+	"testing"
+)
 
 func assertCandidates(t *testing.T, input string, expectedCount int, expectedString string) {
 	t.Helper()
@@ -162,3 +165,17 @@ func TestParseSrcSetAttributeInvalidCandidates(t *testing.T) {
 		})
 	}
 }
+
+func TestNewResponsiveImageCandidates(t *testing.T) {
+	candidates := NewResponsiveImageCandidates([]int{480, 960}, func(width int) string {
+		return "/proxy/image.jpg?w=" + strconv.Itoa(width)
+	})
+
+	expected := `/proxy/image.jpg?w=480 480w, /proxy/image.jpg?w=960 960w`
+	if output := candidates.String(); output != expected {
+		t.Fatalf("Unexpected srcset, got %q instead of %q", output, expected)
+	}
+
+	// The generated srcset must be parsed back by the sanitizer.
+	assertCandidates(t, expected, 2, expected)
+}
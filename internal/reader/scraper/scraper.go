@@ -13,30 +13,147 @@ import (
 	"miniflux.app/v2/internal/reader/encoding"
 	"miniflux.app/v2/internal/reader/fetcher"
 	"miniflux.app/v2/internal/reader/readability"
+	"miniflux.app/v2/internal/reader/sanitizer"
 	"miniflux.app/v2/internal/urllib"
 
 	"github.com/PuerkitoBio/goquery"
 )
 
+// Result holds the outcome of a website scraping.
+type Result struct {
+	// EffectiveURL is the URL of the page after redirects.
+	EffectiveURL string
+
+	// BaseURL is the base URL of the extracted content.
+	BaseURL string
+
+	// Content is the extracted HTML, not sanitized.
+	Content string
+
+	// Rules are the CSS selectors used to extract the content, empty when readability is used.
+	Rules string
+
+	// PredefinedRules are the CSS selectors predefined for the website, if any.
+	PredefinedRules string
+}
+
 func ScrapeWebsite(requestBuilder *fetcher.RequestBuilder, pageURL, rules string) (baseURL string, extractedContent string, err error) {
+	result, err := Scrape(requestBuilder, pageURL, rules)
+	if err != nil {
+		return "", "", err
+	}
+	return result.BaseURL, result.Content, nil
+}
+
+// Scrape downloads the web page and extracts its content with the given scraper rules.
+// The predefined rules of the website are used when no rules are given, and readability otherwise.
+//
+// The next pages of a paginated article are downloaded and extracted the same way,
+// up to the configured maximum number of pages, and their content is appended.
+func Scrape(requestBuilder *fetcher.RequestBuilder, pageURL, rules string) (*Result, error) {
+	if rules != "" {
+		if err := ValidateRules(rules); err != nil {
+			return nil, err
+		}
+	}
+
+	effectiveURL, document, err := fetchDocument(requestBuilder, pageURL)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{
+		EffectiveURL:    effectiveURL,
+		PredefinedRules: getPredefinedScraperRules(effectiveURL),
+	}
+
+	if rules == "" {
+		rules = result.PredefinedRules
+	}
+
+	// The entry URL could redirect somewhere else.
+	if urllib.Domain(pageURL) == urllib.Domain(effectiveURL) && rules != "" {
+		slog.Debug("Extracting content with custom rules",
+			"url", effectiveURL,
+			"rules", rules,
+		)
+		result.Rules = rules
+	} else {
+		slog.Debug("Extracting content with readability",
+			"url", effectiveURL,
+		)
+	}
+
+	maxPages := config.Opts.ScraperMaxPages()
+	visitedURLs := map[string]bool{pageURL: true, effectiveURL: true}
+	currentURL := effectiveURL
+
+	for pageNumber := 1; ; pageNumber++ {
+		// The next page link must be found before the extraction modifies the document.
+		nextPageURL := ""
+		if pageNumber < maxPages {
+			nextPageURL = readability.NextPageURL(document, currentURL, pageNumber)
+		}
+
+		baseURL, content := extractContent(document, result.Rules)
+		if baseURL == "" {
+			baseURL = currentURL
+		} else {
+			slog.Debug("Using base URL from HTML document", "base_url", baseURL)
+		}
+
+		if pageNumber == 1 {
+			result.BaseURL = baseURL
+			result.Content = content
+		} else {
+			// The relative URLs of the next pages would be resolved against the first page.
+			result.Content += resolveRelativeURLs(baseURL, content)
+		}
+
+		if nextPageURL == "" || visitedURLs[nextPageURL] {
+			break
+		}
+		visitedURLs[nextPageURL] = true
+
+		slog.Debug("Scraping next page",
+			slog.String("website_url", effectiveURL),
+			slog.String("next_page_url", nextPageURL),
+			slog.Int("page_number", pageNumber+1),
+		)
+
+		nextEffectiveURL, nextDocument, err := fetchDocument(requestBuilder, nextPageURL)
+		if err != nil {
+			slog.Warn("Unable to scrape next page",
+				slog.String("website_url", effectiveURL),
+				slog.String("next_page_url", nextPageURL),
+				slog.Any("error", err),
+			)
+			break
+		}
+
+		if urllib.Domain(nextEffectiveURL) != urllib.Domain(effectiveURL) {
+			break
+		}
+
+		visitedURLs[nextEffectiveURL] = true
+		currentURL = nextEffectiveURL
+		document = nextDocument
+	}
+
+	return result, nil
+}
+
+func fetchDocument(requestBuilder *fetcher.RequestBuilder, pageURL string) (effectiveURL string, document *goquery.Document, err error) {
 	responseHandler := fetcher.NewResponseHandler(requestBuilder.ExecuteRequest(pageURL))
 	defer responseHandler.Close()
 
 	if localizedError := responseHandler.LocalizedError(); localizedError != nil {
 		slog.Warn("Unable to scrape website", slog.String("website_url", pageURL), slog.Any("error", localizedError.Error()))
-		return "", "", localizedError.Error()
+		return "", nil, localizedError.Error()
 	}
 
 	if !isAllowedContentType(responseHandler.ContentType()) {
-		return "", "", fmt.Errorf("scraper: this resource is not a HTML document (%s)", responseHandler.ContentType())
-	}
-
-	// The entry URL could redirect somewhere else.
-	sameSite := urllib.Domain(pageURL) == urllib.Domain(responseHandler.EffectiveURL())
-	pageURL = responseHandler.EffectiveURL()
-
-	if rules == "" {
-		rules = getPredefinedScraperRules(pageURL)
+		return "", nil, fmt.Errorf("scraper: this resource is not a HTML document (%s)", responseHandler.ContentType())
 	}
 
 	htmlDocumentReader, err := encoding.NewCharsetReader(
@@ -45,29 +162,22 @@ func ScrapeWebsite(requestBuilder *fetcher.RequestBuilder, pageURL, rules string
 	)
 
 	if err != nil {
-		return "", "", fmt.Errorf("scraper: unable to read HTML document with charset reader: %v", err)
+		return "", nil, fmt.Errorf("scraper: unable to read HTML document with charset reader: %v", err)
 	}
 
-	if sameSite && rules != "" {
-		slog.Debug("Extracting content with custom rules",
-			"url", pageURL,
-			"rules", rules,
-		)
-		baseURL, extractedContent, err = findContentUsingCustomRules(htmlDocumentReader, rules)
-	} else {
-		slog.Debug("Extracting content with readability",
-			"url", pageURL,
-		)
-		baseURL, extractedContent, err = readability.ExtractContent(htmlDocumentReader)
+	document, err = goquery.NewDocumentFromReader(htmlDocumentReader)
+	if err != nil {
+		return "", nil, fmt.Errorf("scraper: unable to parse HTML document: %v", err)
 	}
 
-	if baseURL == "" {
-		baseURL = pageURL
-	} else {
-		slog.Debug("Using base URL from HTML document", "base_url", baseURL)
-	}
+	return responseHandler.EffectiveURL(), document, nil
+}
 
-	return baseURL, extractedContent, nil
+func extractContent(document *goquery.Document, rules string) (baseURL string, extractedContent string) {
+	if rules != "" {
+		return findContentInDocument(document, rules)
+	}
+	return readability.ExtractContentFromDocument(document)
 }
 
 func findContentUsingCustomRules(page io.Reader, rules string) (baseURL string, extractedContent string, err error) {
@@ -76,6 +186,11 @@ func findContentUsingCustomRules(page io.Reader, rules string) (baseURL string,
 		return "", "", err
 	}
 
+	baseURL, extractedContent = findContentInDocument(document, rules)
+	return baseURL, extractedContent, nil
+}
+
+func findContentInDocument(document *goquery.Document, rules string) (baseURL string, extractedContent string) {
 	if hrefValue, exists := document.FindMatcher(goquery.Single("head base")).Attr("href"); exists {
 		hrefValue = strings.TrimSpace(hrefValue)
 		if urllib.IsAbsoluteURL(hrefValue) {
@@ -83,13 +198,58 @@ func findContentUsingCustomRules(page io.Reader, rules string) (baseURL string,
 		}
 	}
 
-	document.Find(rules).Each(func(i int, s *goquery.Selection) {
-		if content, err := goquery.OuterHtml(s); err == nil {
-			extractedContent += content
+	parsedRules, err := parseRules(rules)
+	if err != nil {
+		slog.Debug("Unable to parse scraper rules", slog.String("rules", rules), slog.Any("error", err))
+		return baseURL, ""
+	}
+
+	extractedContent = parsedRules.extract(document)
+
+	// Rules made of exclusions only remove elements before the extraction with readability.
+	if len(parsedRules.selectors) == 0 {
+		readabilityBaseURL, readabilityContent := readability.ExtractContentFromDocument(document)
+		if baseURL == "" {
+			baseURL = readabilityBaseURL
+		}
+		extractedContent = readabilityContent
+	}
+
+	return baseURL, extractedContent
+}
+
+// resolveRelativeURLs makes the links and the media sources of an HTML fragment absolute.
+func resolveRelativeURLs(baseURL, content string) string {
+	document, err := goquery.NewDocumentFromReader(strings.NewReader(content))
+	if err != nil {
+		return content
+	}
+
+	document.Find("[href], [src], [poster]").Each(func(_ int, s *goquery.Selection) {
+		for _, attribute := range [...]string{"href", "src", "poster"} {
+			if value, exists := s.Attr(attribute); exists {
+				if absoluteURL, err := urllib.ResolveToAbsoluteURL(baseURL, strings.TrimSpace(value)); err == nil {
+					s.SetAttr(attribute, absoluteURL)
+				}
+			}
 		}
 	})
 
-	return baseURL, extractedContent, nil
+	document.Find("[srcset]").Each(func(_ int, s *goquery.Selection) {
+		candidates := sanitizer.ParseSrcSetAttribute(s.AttrOr("srcset", ""))
+		for _, candidate := range candidates {
+			if absoluteURL, err := urllib.ResolveToAbsoluteURL(baseURL, candidate.ImageURL); err == nil {
+				candidate.ImageURL = absoluteURL
+			}
+		}
+		s.SetAttr("srcset", candidates.String())
+	})
+
+	output, err := document.Find("body").Html()
+	if err != nil {
+		return content
+	}
+	return output
 }
 
 func getPredefinedScraperRules(websiteURL string) string {
@@ -5,9 +5,14 @@ package scraper // import "miniflux.app/v2/internal/reader/scraper"
 
 import (
 	"bytes"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"strings"
 	"testing"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/reader/fetcher"
 )
 
 func TestGetPredefinedRules(t *testing.T) {
@@ -137,3 +142,107 @@ func TestParseMissingBaseURLWithCustomRules(t *testing.T) {
 		t.Errorf(`Unexpected base URL, got %q instead of ""`, baseURL)
 	}
 }
+
+func TestScrapeReportsRulesAndEffectiveURL(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("FETCHER_ALLOW_PRIVATE_NETWORKS", "1")
+
+	var err error
+	parser := config.NewConfigParser()
+	config.Opts, err = parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Config parsing failure: %v`, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/old", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/article", http.StatusFound)
+	})
+	mux.HandleFunc("/article", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(`<html><body><nav>Menu</nav><article><p>Content</p></article></body></html>`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	result, err := Scrape(fetcher.NewRequestBuilder(), server.URL+"/old", "article")
+	if err != nil {
+		t.Fatalf(`Scraping failed: %v`, err)
+	}
+
+	if result.EffectiveURL != server.URL+"/article" {
+		t.Errorf(`Unexpected effective URL, got %q`, result.EffectiveURL)
+	}
+
+	if result.Rules != "article" {
+		t.Errorf(`Unexpected rules, got %q instead of "article"`, result.Rules)
+	}
+
+	if result.PredefinedRules != "" {
+		t.Errorf(`Unexpected predefined rules, got %q`, result.PredefinedRules)
+	}
+
+	if result.Content != `<article><p>Content</p></article>` {
+		t.Errorf(`Unexpected content, got %q`, result.Content)
+	}
+}
+
+func TestScrapeFollowsNextPages(t *testing.T) {
+	os.Clearenv()
+	os.Setenv("FETCHER_ALLOW_PRIVATE_NETWORKS", "1")
+	os.Setenv("SCRAPER_MAX_PAGES", "3")
+
+	var err error
+	parser := config.NewConfigParser()
+	config.Opts, err = parser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Config parsing failure: %v`, err)
+	}
+
+	pages := map[string]string{
+		"/article":        `<link rel="next" href="/article/page/2"><article><p>One</p></article>`,
+		"/article/page/2": `<div class="pagination"><a href="/article">1</a> <a href="/article/page/3">3</a></div><article><p>Two <img src="image.png"></p></article>`,
+		"/article/page/3": `<div class="pagination"><a href="/article/page/4">4</a></div><article><p>Three</p></article>`,
+		"/article/page/4": `<article><p>Four</p></article>`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, found := pages[r.URL.Path]
+		if !found {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(`<html><body>` + page + `</body></html>`))
+	}))
+	defer server.Close()
+
+	result, err := Scrape(fetcher.NewRequestBuilder(), server.URL+"/article", "article")
+	if err != nil {
+		t.Fatalf(`Scraping failed: %v`, err)
+	}
+
+	expected := `<article><p>One</p></article>` +
+		`<article><p>Two <img src="` + server.URL + `/article/page/image.png"/></p></article>` +
+		`<article><p>Three</p></article>`
+	if result.Content != expected {
+		t.Errorf(`Unexpected content, got %q instead of %q`, result.Content, expected)
+	}
+
+	if result.BaseURL != server.URL+"/article" {
+		t.Errorf(`Unexpected base URL, got %q`, result.BaseURL)
+	}
+}
+
+func TestResolveRelativeURLs(t *testing.T) {
+	scenarios := map[string]string{
+		`<a href="/page/3">3</a>`:                               `<a href="https://example.org/page/3">3</a>`,
+		`<video poster="poster.jpg" src="v.mp4"></video>`:       `<video poster="https://example.org/article/poster.jpg" src="https://example.org/article/v.mp4"></video>`,
+		`<img srcset="a.png 1x, /b.png 2x, https://cdn/c.png">`: `<img srcset="https://example.org/article/a.png 1x, https://example.org/b.png 2x, https://cdn/c.png"/>`,
+	}
+
+	for input, expected := range scenarios {
+		if result := resolveRelativeURLs("https://example.org/article/page", input); result != expected {
+			t.Errorf(`Unexpected output for %q, got %q instead of %q`, input, result, expected)
+		}
+	}
+}
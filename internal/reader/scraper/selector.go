@@ -0,0 +1,268 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package scraper // import "miniflux.app/v2/internal/reader/scraper"
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"path"
+	"regexp"
+	"strings"
+
+	"miniflux.app/v2/internal/urllib"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/andybalholm/cascadia"
+)
+
+var attributeNameRegex = regexp.MustCompile(`^[A-Za-z_][-A-Za-z0-9_:.]*$`)
+
+// unquotedAttributeValueRegex matches attribute selectors with an unquoted value, like [property=og:image].
+var unquotedAttributeValueRegex = regexp.MustCompile(`\[\s*([-\w|]+)\s*([~|^$*]?=)\s*([^\]"'\s]+)\s*\]`)
+
+var imageExtensions = [...]string{".avif", ".gif", ".jpeg", ".jpg", ".png", ".svg", ".webp"}
+
+// selector extracts the elements matching a CSS selector, or the value of one of their attributes.
+type selector struct {
+	css       string
+	attribute string
+}
+
+// scraperRules are the parsed scraper rules of a website.
+//
+// The rules are a comma-separated list of CSS selectors or XPath expressions starting with "/".
+// A selector followed by @name extracts the value of the attribute instead of the element,
+// for example meta[property=og:image]@content or //meta[@property='og:image']/@content.
+// Selectors prefixed with "-" are exclusions: the matching elements are removed from the page
+// before the extraction, for example "article -.ads -.related".
+type scraperRules struct {
+	selectors  []*selector
+	exclusions []string
+}
+
+// ValidateRules returns an error when the scraper rules cannot be parsed.
+func ValidateRules(rules string) error {
+	_, err := parseRules(rules)
+	return err
+}
+
+func parseRules(rules string) (*scraperRules, error) {
+	parsedRules := &scraperRules{}
+
+	for _, item := range splitOutsideBrackets(rules, ',') {
+		var expression []string
+		for _, token := range splitOutsideBrackets(strings.Join(strings.Fields(item), " "), ' ') {
+			exclusion, found := strings.CutPrefix(token, "-")
+			if !found {
+				expression = append(expression, token)
+				continue
+			}
+
+			selectors, err := compileExpression(exclusion)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, selector := range selectors {
+				if selector.attribute != "" {
+					return nil, fmt.Errorf("scraper: attributes cannot be excluded: %q", token)
+				}
+				parsedRules.exclusions = append(parsedRules.exclusions, selector.css)
+			}
+		}
+
+		if len(expression) == 0 {
+			continue
+		}
+
+		selectors, err := compileExpression(strings.Join(expression, " "))
+		if err != nil {
+			return nil, err
+		}
+		parsedRules.selectors = append(parsedRules.selectors, selectors...)
+	}
+
+	if len(parsedRules.selectors) == 0 && len(parsedRules.exclusions) == 0 {
+		return nil, errors.New("scraper: empty rules")
+	}
+
+	return parsedRules, nil
+}
+
+// compileExpression converts a CSS selector or an XPath expression into selectors.
+func compileExpression(expression string) ([]*selector, error) {
+	expression = strings.TrimSpace(expression)
+	if expression == "" {
+		return nil, errors.New("scraper: empty selector")
+	}
+
+	var selectors []*selector
+	if strings.HasPrefix(expression, "/") || strings.HasPrefix(expression, "./") {
+		for _, path := range splitOutsideBrackets(expression, '|') {
+			css, attribute, err := xpathToCSS(path)
+			if err != nil {
+				return nil, err
+			}
+			selectors = append(selectors, &selector{css: css, attribute: attribute})
+		}
+	} else {
+		css, attribute := expression, ""
+		if index := lastIndexOutsideBrackets(expression, '@'); index >= 0 {
+			css, attribute = strings.TrimSpace(expression[:index]), strings.TrimSpace(expression[index+1:])
+			if css == "" {
+				return nil, fmt.Errorf("scraper: missing selector before the attribute: %q", expression)
+			}
+		}
+		// Values that are not CSS identifiers must be quoted, but rules are often written without quotes.
+		css = unquotedAttributeValueRegex.ReplaceAllString(css, `[$1$2"$3"]`)
+		selectors = append(selectors, &selector{css: css, attribute: attribute})
+	}
+
+	for _, selector := range selectors {
+		if selector.attribute != "" && !attributeNameRegex.MatchString(selector.attribute) {
+			return nil, fmt.Errorf("scraper: invalid attribute name %q", selector.attribute)
+		}
+		if _, err := cascadia.ParseGroup(selector.css); err != nil {
+			return nil, fmt.Errorf("scraper: invalid selector %q: %v", selector.css, err)
+		}
+	}
+
+	return selectors, nil
+}
+
+// extract removes the excluded elements from the document and returns the HTML of the selected elements and attributes.
+func (r *scraperRules) extract(document *goquery.Document) string {
+	if len(r.exclusions) > 0 {
+		document.Find(strings.Join(r.exclusions, ", ")).Remove()
+	}
+
+	var extractedContent strings.Builder
+	for i := 0; i < len(r.selectors); {
+		if r.selectors[i].attribute != "" {
+			attribute := r.selectors[i].attribute
+			document.Find(r.selectors[i].css).Each(func(_ int, s *goquery.Selection) {
+				if value, exists := s.Attr(attribute); exists {
+					extractedContent.WriteString(attributeToHTML(s, attribute, value))
+				}
+			})
+			i++
+			continue
+		}
+
+		// Consecutive element selectors are matched together to keep the elements in the document order.
+		var group []string
+		for ; i < len(r.selectors) && r.selectors[i].attribute == ""; i++ {
+			group = append(group, r.selectors[i].css)
+		}
+
+		document.Find(strings.Join(group, ", ")).Each(func(_ int, s *goquery.Selection) {
+			if content, err := goquery.OuterHtml(s); err == nil {
+				extractedContent.WriteString(content)
+			}
+		})
+	}
+
+	return extractedContent.String()
+}
+
+// attributeToHTML converts an attribute value into HTML: an image, a link, or a paragraph.
+func attributeToHTML(s *goquery.Selection, attribute, value string) string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return ""
+	}
+
+	escapedValue := html.EscapeString(value)
+	if !urllib.IsAbsoluteURL(value) && !strings.HasPrefix(value, "/") {
+		return "<p>" + escapedValue + "</p>"
+	}
+
+	if isImageAttribute(s, attribute, value) {
+		return `<img src="` + escapedValue + `">`
+	}
+
+	return `<a href="` + escapedValue + `">` + escapedValue + `</a>`
+}
+
+func isImageAttribute(s *goquery.Selection, attribute, value string) bool {
+	if goquery.NodeName(s) == "img" || attribute == "poster" {
+		return true
+	}
+
+	// <meta property="og:image" content="...">, <meta name="twitter:image" content="...">...
+	for _, name := range [...]string{"property", "name", "itemprop", "rel"} {
+		if nameValue, exists := s.Attr(name); exists && strings.Contains(strings.ToLower(nameValue), "image") {
+			return true
+		}
+	}
+
+	urlPath := value
+	if index := strings.IndexAny(urlPath, "?#"); index >= 0 {
+		urlPath = urlPath[:index]
+	}
+	extension := strings.ToLower(path.Ext(urlPath))
+	for _, imageExtension := range imageExtensions {
+		if extension == imageExtension {
+			return true
+		}
+	}
+
+	return false
+}
+
+// splitOutsideBrackets splits the string around the separator, except inside brackets,
+// parentheses and quotes. Empty parts are dropped.
+func splitOutsideBrackets(input string, separator byte) []string {
+	var parts []string
+	start := 0
+	for _, index := range indexesOutsideBrackets(input, separator) {
+		parts = append(parts, input[start:index])
+		start = index + 1
+	}
+	parts = append(parts, input[start:])
+
+	nonEmptyParts := parts[:0]
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			nonEmptyParts = append(nonEmptyParts, part)
+		}
+	}
+	return nonEmptyParts
+}
+
+func lastIndexOutsideBrackets(input string, character byte) int {
+	indexes := indexesOutsideBrackets(input, character)
+	if len(indexes) == 0 {
+		return -1
+	}
+	return indexes[len(indexes)-1]
+}
+
+func indexesOutsideBrackets(input string, character byte) []int {
+	var indexes []int
+	var quote byte
+	depth := 0
+
+	for i := 0; i < len(input); i++ {
+		switch c := input[i]; {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '[' || c == '(':
+			depth++
+		case c == ']' || c == ')':
+			depth--
+		case c == character && depth == 0:
+			indexes = append(indexes, i)
+		}
+	}
+
+	return indexes
+}
@@ -0,0 +1,156 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package scraper // import "miniflux.app/v2/internal/reader/scraper"
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPredefinedRulesAreValid(t *testing.T) {
+	for domain, rules := range predefinedRules {
+		if err := ValidateRules(rules); err != nil {
+			t.Errorf(`The predefined rules of %q are invalid: %v`, domain, err)
+		}
+	}
+}
+
+func TestValidateRules(t *testing.T) {
+	scenarios := map[string]bool{
+		"article":                     true,
+		"div.content > p, .author":    true,
+		`div[data-role="main, body"]`: true,
+		"article -.ads -.related":     true,
+		"-.ads":                       true,
+		"meta[property=og:image]@content, article": true,
+		"//article//p":                            true,
+		"//meta[@property='og:image']/@content":   true,
+		"//div[@id='main'] | //aside":             true,
+		"article -//div[contains(@class, 'ads')]": true,
+		"":                      false,
+		"div[":                  false,
+		"@content":              false,
+		"article -meta@content": false,
+		"meta@content=x":        false,
+		"//div/text()":          false,
+		"//div[position() > 2]": false,
+		"//div[@class='a'":      false,
+	}
+
+	for rules, valid := range scenarios {
+		err := ValidateRules(rules)
+		if valid && err != nil {
+			t.Errorf(`The rules %q should be valid: %v`, rules, err)
+		}
+		if !valid && err == nil {
+			t.Errorf(`The rules %q should be invalid`, rules)
+		}
+	}
+}
+
+func TestXPathToCSS(t *testing.T) {
+	scenarios := []struct {
+		xpath     string
+		css       string
+		attribute string
+	}{
+		{"//article", "article", ""},
+		{"//div/p", "div > p", ""},
+		{"/html/body//article", "html:root > body article", ""},
+		{".//section", "section", ""},
+		{"//div[@id='main']//p[2]", `div[id="main"] p:nth-of-type(2)`, ""},
+		{"//li[last()]", "li:last-of-type", ""},
+		{`//div[contains(@class, "post") and not-a]`, "", ""},
+		{"//div[contains(@class, 'post') and @data-id]", `div[class*="post"][data-id]`, ""},
+		{"//a[starts-with(@href, 'https://')]", `a[href^="https://"]`, ""},
+		{"//p[@class!='ad']", `p:not([class="ad"])`, ""},
+		{"//*[@itemprop='image']/@src", `*[itemprop="image"]`, "src"},
+	}
+
+	for _, scenario := range scenarios {
+		css, attribute, err := xpathToCSS(scenario.xpath)
+		if scenario.css == "" {
+			if err == nil {
+				t.Errorf(`The XPath expression %q should be rejected, got %q`, scenario.xpath, css)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf(`Unable to translate %q: %v`, scenario.xpath, err)
+			continue
+		}
+
+		if css != scenario.css || attribute != scenario.attribute {
+			t.Errorf(`Unexpected translation of %q, got %q@%q instead of %q@%q`, scenario.xpath, css, attribute, scenario.css, scenario.attribute)
+		}
+	}
+}
+
+func TestExtractWithExclusions(t *testing.T) {
+	html := `<article><p>Content</p><div class="ads">Ad</div><aside class="related">Related</aside></article>`
+
+	_, content, err := findContentUsingCustomRules(strings.NewReader(html), "article -.ads -.related")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := `<article><p>Content</p></article>`; content != expected {
+		t.Errorf(`Unexpected content, got %q instead of %q`, content, expected)
+	}
+}
+
+func TestExtractAttributes(t *testing.T) {
+	html := `<html><head>
+		<meta property="og:image" content="https://example.org/hero.jpg">
+		<meta name="author" content="Jane &amp; John">
+		</head><body>
+		<article><p>Content</p></article>
+		<a class="download" href="https://example.org/file">Download</a>
+		</body></html>`
+
+	_, content, err := findContentUsingCustomRules(strings.NewReader(html), "meta[property=og:image]@content, meta[name=author]@content, article, //a[@class='download']/@href")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `<img src="https://example.org/hero.jpg">` +
+		`<p>Jane &amp; John</p>` +
+		`<article><p>Content</p></article>` +
+		`<a href="https://example.org/file">https://example.org/file</a>`
+	if content != expected {
+		t.Errorf(`Unexpected content, got %q instead of %q`, content, expected)
+	}
+}
+
+func TestExtractKeepsDocumentOrder(t *testing.T) {
+	html := `<h1>Title</h1><div class="content">Body</div>`
+
+	_, content, err := findContentUsingCustomRules(strings.NewReader(html), ".content, //h1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := `<h1>Title</h1><div class="content">Body</div>`; content != expected {
+		t.Errorf(`Unexpected content, got %q instead of %q`, content, expected)
+	}
+}
+
+func TestExtractWithExclusionsOnly(t *testing.T) {
+	html := `<html><body><article><p>` + strings.Repeat("This is the content of the article. ", 20) + `</p>` +
+		`<p class="promo">` + strings.Repeat("Subscribe to our newsletter. ", 20) + `</p></article></body></html>`
+
+	_, content, err := findContentUsingCustomRules(strings.NewReader(html), "-.promo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(content, "This is the content of the article.") {
+		t.Errorf(`The content should be extracted with readability, got %q`, content)
+	}
+
+	if strings.Contains(content, "newsletter") {
+		t.Errorf(`The excluded element should be removed, got %q`, content)
+	}
+}
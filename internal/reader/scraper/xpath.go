@@ -0,0 +1,245 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package scraper // import "miniflux.app/v2/internal/reader/scraper"
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var xpathNameRegex = regexp.MustCompile(`^[A-Za-z_][-A-Za-z0-9_]*`)
+
+// xpathToCSS translates an XPath location path into a CSS selector.
+//
+// Only a subset of XPath 1.0 is supported: the child (/) and descendant (//) axes,
+// element names and *, a final /@attribute step, and the following predicates,
+// combined with "and": [N], [last()], [@name], [@name='value'], [@name!='value'],
+// [contains(@name, 'value')] and [starts-with(@name, 'value')].
+func xpathToCSS(expression string) (css string, attribute string, err error) {
+	p := &xpathParser{input: strings.TrimSpace(expression)}
+
+	// A relative path is evaluated from the document.
+	if rest, found := strings.CutPrefix(p.input, "."); found && strings.HasPrefix(rest, "/") {
+		p.input = rest
+	}
+
+	var builder strings.Builder
+	for stepIndex := 0; !p.done(); stepIndex++ {
+		var combinator string
+		switch {
+		case p.consume("//"):
+			combinator = " "
+		case p.consume("/"):
+			combinator = " > "
+		default:
+			return "", "", p.errorf("expected / or //")
+		}
+
+		if p.consume("@") {
+			name := p.name()
+			if name == "" {
+				return "", "", p.errorf("expected an attribute name")
+			}
+			if !p.done() || stepIndex == 0 {
+				return "", "", p.errorf("the attribute must be the last step")
+			}
+			attribute = name
+			break
+		}
+
+		step, err := p.step()
+		if err != nil {
+			return "", "", err
+		}
+
+		switch {
+		case stepIndex == 0 && combinator == " > ":
+			// An absolute path starts from the root element.
+			builder.WriteString(step + ":root")
+		case stepIndex == 0:
+			builder.WriteString(step)
+		default:
+			builder.WriteString(combinator + step)
+		}
+	}
+
+	if builder.Len() == 0 {
+		return "", "", errors.New("scraper: empty XPath expression")
+	}
+
+	return builder.String(), attribute, nil
+}
+
+type xpathParser struct {
+	input    string
+	position int
+}
+
+func (p *xpathParser) done() bool {
+	return p.position >= len(p.input)
+}
+
+func (p *xpathParser) consume(prefix string) bool {
+	if strings.HasPrefix(p.input[p.position:], prefix) {
+		p.position += len(prefix)
+		return true
+	}
+	return false
+}
+
+func (p *xpathParser) skipSpaces() {
+	for !p.done() && p.input[p.position] == ' ' {
+		p.position++
+	}
+}
+
+func (p *xpathParser) name() string {
+	name := xpathNameRegex.FindString(p.input[p.position:])
+	p.position += len(name)
+	return name
+}
+
+func (p *xpathParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("scraper: unsupported XPath expression %q at position %d: %s", p.input, p.position, fmt.Sprintf(format, args...))
+}
+
+// step parses an element name and its predicates.
+func (p *xpathParser) step() (string, error) {
+	var step string
+	if p.consume("*") {
+		step = "*"
+	} else {
+		step = strings.ToLower(p.name())
+		if step == "" {
+			return "", p.errorf("expected an element name")
+		}
+	}
+
+	for p.consume("[") {
+		for {
+			p.skipSpaces()
+			condition, err := p.condition()
+			if err != nil {
+				return "", err
+			}
+			step += condition
+
+			p.skipSpaces()
+			if p.consume("]") {
+				break
+			}
+			if !p.consume("and ") {
+				return "", p.errorf("expected ] or and")
+			}
+		}
+	}
+
+	return step, nil
+}
+
+// condition parses a predicate condition.
+func (p *xpathParser) condition() (string, error) {
+	switch {
+	case p.consume("last()"):
+		return ":last-of-type", nil
+
+	case p.consume("contains("):
+		return p.function("*=")
+
+	case p.consume("starts-with("):
+		return p.function("^=")
+
+	case p.consume("@"):
+		name := p.name()
+		if name == "" {
+			return "", p.errorf("expected an attribute name")
+		}
+
+		p.skipSpaces()
+		operator := ""
+		switch {
+		case p.consume("!="):
+			operator = "!="
+		case p.consume("="):
+			operator = "="
+		default:
+			return "[" + name + "]", nil
+		}
+
+		p.skipSpaces()
+		value, err := p.literal()
+		if err != nil {
+			return "", err
+		}
+
+		if operator == "!=" {
+			return ":not([" + name + "=" + value + "])", nil
+		}
+		return "[" + name + "=" + value + "]", nil
+	}
+
+	digits := 0
+	for p.position+digits < len(p.input) && p.input[p.position+digits] >= '0' && p.input[p.position+digits] <= '9' {
+		digits++
+	}
+	if digits > 0 {
+		index, _ := strconv.Atoi(p.input[p.position : p.position+digits])
+		p.position += digits
+		return ":nth-of-type(" + strconv.Itoa(index) + ")", nil
+	}
+
+	return "", p.errorf("unsupported predicate")
+}
+
+// function parses the arguments of contains() and starts-with().
+func (p *xpathParser) function(operator string) (string, error) {
+	p.skipSpaces()
+	if !p.consume("@") {
+		return "", p.errorf("expected an attribute")
+	}
+
+	name := p.name()
+	if name == "" {
+		return "", p.errorf("expected an attribute name")
+	}
+
+	p.skipSpaces()
+	if !p.consume(",") {
+		return "", p.errorf("expected ,")
+	}
+
+	p.skipSpaces()
+	value, err := p.literal()
+	if err != nil {
+		return "", err
+	}
+
+	p.skipSpaces()
+	if !p.consume(")") {
+		return "", p.errorf("expected )")
+	}
+
+	return "[" + name + operator + value + "]", nil
+}
+
+// literal parses a quoted string and returns it as a CSS string.
+func (p *xpathParser) literal() (string, error) {
+	if p.done() || (p.input[p.position] != '\'' && p.input[p.position] != '"') {
+		return "", p.errorf("expected a quoted string")
+	}
+
+	quote := p.input[p.position]
+	end := strings.IndexByte(p.input[p.position+1:], quote)
+	if end < 0 {
+		return "", p.errorf("unterminated string")
+	}
+
+	value := p.input[p.position+1 : p.position+1+end]
+	p.position += end + 2
+
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`, nil
+}
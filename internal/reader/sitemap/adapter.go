@@ -0,0 +1,174 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package sitemap // import "miniflux.app/v2/internal/reader/sitemap"
+
+import (
+	"html"
+	"log/slog"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"miniflux.app/v2/internal/crypto"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/date"
+	"miniflux.app/v2/internal/urllib"
+)
+
+// maxEntries is the number of most recent URLs kept from a sitemap.
+// Large websites publish tens of thousands of URLs and we are only interested in the latest ones.
+const maxEntries = 100
+
+// undatedEntryDate is the date of the URLs without publication or modification date.
+var undatedEntryDate = time.Unix(0, 0).UTC()
+
+type sitemapAdapter struct {
+	urlSet *sitemapURLSet
+}
+
+func (s *sitemapAdapter) buildFeed(baseURL string) *model.Feed {
+	feed := &model.Feed{
+		FeedURL: strings.TrimSpace(baseURL),
+		SiteURL: urllib.RootURL(strings.TrimSpace(baseURL)),
+	}
+
+	feed.Title = urllib.DomainWithoutWWW(feed.SiteURL)
+	if feed.Title == "" {
+		feed.Title = feed.FeedURL
+	}
+
+	for _, sitemapURL := range s.urlSet.URLs {
+		loc := strings.TrimSpace(sitemapURL.Loc)
+		if loc == "" {
+			continue
+		}
+
+		entry := model.NewEntry()
+
+		// Populate the entry URL.
+		if entryURL, err := urllib.ResolveToAbsoluteURL(feed.SiteURL, loc); err == nil {
+			entry.URL = entryURL
+		} else {
+			entry.URL = loc
+		}
+
+		entry.Hash = crypto.SHA256(entry.URL)
+
+		// Populate the entry date from the news publication date, or from the last modification date.
+		// Pages without date, usually static pages, get a fixed date: they keep their position on every refresh
+		// and come after the dated articles.
+		entry.Date = undatedEntryDate
+		for _, value := range []string{sitemapURL.newsPublicationDate(), sitemapURL.LastMod} {
+			value = strings.TrimSpace(value)
+			if value == "" {
+				continue
+			}
+
+			if entryDate, err := date.Parse(value); err != nil {
+				slog.Debug("Unable to parse date from sitemap",
+					slog.String("date", value),
+					slog.String("loc", loc),
+					slog.Any("error", err),
+				)
+			} else {
+				entry.Date = entryDate
+				break
+			}
+		}
+
+		// Populate the entry title.
+		if sitemapURL.News != nil {
+			entry.Title = html.UnescapeString(strings.TrimSpace(sitemapURL.News.Title))
+		}
+
+		if entry.Title == "" {
+			for _, image := range sitemapURL.Images {
+				if title := strings.TrimSpace(image.Title); title != "" {
+					entry.Title = html.UnescapeString(title)
+					break
+				}
+			}
+		}
+
+		if entry.Title == "" {
+			entry.Title = titleFromURL(entry.URL)
+		}
+
+		// Populate the entry author and tags from the news extension.
+		if sitemapURL.News != nil {
+			entry.Author = strings.TrimSpace(sitemapURL.News.Publication.Name)
+
+			for keyword := range strings.SplitSeq(sitemapURL.News.Keywords, ",") {
+				if keyword = strings.TrimSpace(keyword); keyword != "" && !slices.Contains(entry.Tags, keyword) {
+					entry.Tags = append(entry.Tags, keyword)
+				}
+			}
+		}
+
+		// Populate the entry enclosures from the image extension.
+		// The content is left empty: sitemaps only list URLs, the crawler can fetch the page content.
+		for _, image := range sitemapURL.Images {
+			imageURL := strings.TrimSpace(image.Loc)
+			if imageURL == "" {
+				continue
+			}
+
+			if absoluteImageURL, err := urllib.ResolveToAbsoluteURL(entry.URL, imageURL); err == nil {
+				imageURL = absoluteImageURL
+			}
+
+			entry.Enclosures = append(entry.Enclosures, &model.Enclosure{URL: imageURL, MimeType: urllib.ImageMimeType(imageURL)})
+		}
+
+		feed.Entries = append(feed.Entries, entry)
+	}
+
+	sortAndTruncateEntries(feed)
+
+	return feed
+}
+
+func (s *sitemapURL) newsPublicationDate() string {
+	if s.News == nil {
+		return ""
+	}
+	return s.News.PublicationDate
+}
+
+// sortAndTruncateEntries orders entries by date, the most recent first, and keeps only the latest ones.
+func sortAndTruncateEntries(feed *model.Feed) {
+	slices.SortStableFunc(feed.Entries, func(a, b *model.Entry) int {
+		return b.Date.Compare(a.Date)
+	})
+
+	if len(feed.Entries) > maxEntries {
+		feed.Entries = feed.Entries[:maxEntries]
+	}
+}
+
+// titleFromURL builds a human readable title from the last segment of the URL path.
+func titleFromURL(entryURL string) string {
+	parsedURL, err := url.Parse(entryURL)
+	if err != nil {
+		return entryURL
+	}
+
+	segment := path.Base(strings.TrimSuffix(parsedURL.Path, "/"))
+	segment = strings.TrimSuffix(segment, path.Ext(segment))
+	if segment == "" || segment == "." || segment == "/" {
+		return entryURL
+	}
+
+	title := strings.Join(strings.FieldsFunc(segment, func(r rune) bool {
+		return r == '-' || r == '_' || r == '+'
+	}), " ")
+
+	if title == "" {
+		return entryURL
+	}
+
+	return strings.ToUpper(title[:1]) + title[1:]
+}
@@ -0,0 +1,116 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package sitemap // import "miniflux.app/v2/internal/reader/sitemap"
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/date"
+	"miniflux.app/v2/internal/reader/fetcher"
+	"miniflux.app/v2/internal/reader/xml"
+	"miniflux.app/v2/internal/urllib"
+)
+
+// maxChildSitemaps is the number of most recent sitemaps downloaded from a sitemap index.
+const maxChildSitemaps = 3
+
+// Parse returns a normalized feed struct from a sitemap.
+func Parse(baseURL string, data io.ReadSeeker) (*model.Feed, error) {
+	urlSet := new(sitemapURLSet)
+	if err := xml.NewXMLDecoder(data).Decode(urlSet); err != nil {
+		return nil, fmt.Errorf("sitemap: unable to parse sitemap: %w", err)
+	}
+
+	adapter := &sitemapAdapter{urlSet}
+	return adapter.buildFeed(baseURL), nil
+}
+
+// ParseIndex returns a normalized feed struct from a sitemap index.
+// The most recent child sitemaps are downloaded with the given request builder and merged into a single feed.
+func ParseIndex(requestBuilder *fetcher.RequestBuilder, baseURL string, data io.ReadSeeker) (*model.Feed, error) {
+	childURLs, err := parseIndexLocations(baseURL, data)
+	if err != nil {
+		return nil, err
+	}
+
+	urlSet := new(sitemapURLSet)
+	for _, childURL := range childURLs {
+		childURLSet, err := fetchURLSet(requestBuilder, childURL)
+		if err != nil {
+			slog.Warn("Unable to fetch child sitemap",
+				slog.String("sitemap_index_url", baseURL),
+				slog.String("sitemap_url", childURL),
+				slog.Any("error", err),
+			)
+			continue
+		}
+		urlSet.URLs = append(urlSet.URLs, childURLSet.URLs...)
+	}
+
+	adapter := &sitemapAdapter{urlSet}
+	return adapter.buildFeed(baseURL), nil
+}
+
+// parseIndexLocations returns the absolute URLs of the most recently modified sitemaps listed in an index.
+func parseIndexLocations(baseURL string, data io.ReadSeeker) ([]string, error) {
+	index := new(sitemapIndex)
+	if err := xml.NewXMLDecoder(data).Decode(index); err != nil {
+		return nil, fmt.Errorf("sitemap: unable to parse sitemap index: %w", err)
+	}
+
+	// Sitemaps without modification date are considered older than the ones having one.
+	slices.SortStableFunc(index.Sitemaps, func(a, b sitemapIndexEntry) int {
+		aDate, _ := date.Parse(strings.TrimSpace(a.LastMod))
+		bDate, _ := date.Parse(strings.TrimSpace(b.LastMod))
+		return bDate.Compare(aDate)
+	})
+
+	var locations []string
+	for _, sitemap := range index.Sitemaps {
+		loc := strings.TrimSpace(sitemap.Loc)
+		if loc == "" {
+			continue
+		}
+
+		absoluteURL, err := urllib.ResolveToAbsoluteURL(baseURL, loc)
+		if err != nil || slices.Contains(locations, absoluteURL) {
+			continue
+		}
+
+		locations = append(locations, absoluteURL)
+		if len(locations) == maxChildSitemaps {
+			break
+		}
+	}
+
+	return locations, nil
+}
+
+func fetchURLSet(requestBuilder *fetcher.RequestBuilder, sitemapURL string) (*sitemapURLSet, error) {
+	responseHandler := fetcher.NewResponseHandler(requestBuilder.ExecuteRequest(sitemapURL))
+	defer responseHandler.Close()
+
+	if localizedError := responseHandler.LocalizedError(); localizedError != nil {
+		return nil, localizedError.Error()
+	}
+
+	responseBody, localizedError := responseHandler.ReadBody(config.Opts.HTTPClientMaxBodySize())
+	if localizedError != nil {
+		return nil, localizedError.Error()
+	}
+
+	urlSet := new(sitemapURLSet)
+	if err := xml.NewXMLDecoder(bytes.NewReader(responseBody)).Decode(urlSet); err != nil {
+		return nil, fmt.Errorf("sitemap: unable to parse sitemap: %w", err)
+	}
+
+	return urlSet, nil
+}
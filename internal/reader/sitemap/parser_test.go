@@ -0,0 +1,252 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package sitemap // import "miniflux.app/v2/internal/reader/sitemap"
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseSitemap(t *testing.T) {
+	data := `<?xml version="1.0" encoding="UTF-8"?>
+	<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+		<url>
+			<loc>https://www.example.org/blog/older-post/</loc>
+			<lastmod>2024-01-01</lastmod>
+		</url>
+		<url>
+			<loc>https://www.example.org/blog/newer_post.html</loc>
+			<lastmod>2024-02-01T10:00:00+00:00</lastmod>
+		</url>
+		<url>
+			<loc>/relative-post</loc>
+		</url>
+		<url>
+			<loc></loc>
+		</url>
+	</urlset>`
+
+	feed, err := Parse("https://www.example.org/sitemap.xml", bytes.NewReader([]byte(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if feed.Title != "example.org" {
+		t.Errorf("Incorrect title, got: %s", feed.Title)
+	}
+
+	if feed.FeedURL != "https://www.example.org/sitemap.xml" {
+		t.Errorf("Incorrect feed URL, got: %s", feed.FeedURL)
+	}
+
+	if feed.SiteURL != "https://www.example.org/" {
+		t.Errorf("Incorrect site URL, got: %s", feed.SiteURL)
+	}
+
+	if len(feed.Entries) != 3 {
+		t.Fatalf("Incorrect number of entries, got: %d", len(feed.Entries))
+	}
+
+	if feed.Entries[0].URL != "https://www.example.org/blog/newer_post.html" {
+		t.Errorf("Incorrect entry URL, got: %s", feed.Entries[0].URL)
+	}
+
+	if feed.Entries[0].Title != "Newer post" {
+		t.Errorf("Incorrect entry title, got: %s", feed.Entries[0].Title)
+	}
+
+	expectedDate := time.Date(2024, time.February, 1, 10, 0, 0, 0, time.UTC)
+	if !feed.Entries[0].Date.Equal(expectedDate) {
+		t.Errorf("Incorrect entry date, got: %v", feed.Entries[0].Date)
+	}
+
+	if feed.Entries[1].Title != "Older post" {
+		t.Errorf("Incorrect entry title, got: %s", feed.Entries[1].Title)
+	}
+
+	if feed.Entries[1].Hash == "" || feed.Entries[1].Hash == feed.Entries[0].Hash {
+		t.Errorf("Incorrect entry hash, got: %s", feed.Entries[1].Hash)
+	}
+
+	// The entry without date has a fixed date, so it comes last and keeps its date on every refresh.
+	if feed.Entries[2].URL != "https://www.example.org/relative-post" {
+		t.Errorf("Incorrect entry URL, got: %s", feed.Entries[2].URL)
+	}
+
+	if !feed.Entries[2].Date.Equal(undatedEntryDate) {
+		t.Errorf("Incorrect entry date, got: %v", feed.Entries[2].Date)
+	}
+}
+
+func TestParseSitemapWithNewsExtension(t *testing.T) {
+	data := `<?xml version="1.0" encoding="UTF-8"?>
+	<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9" xmlns:news="http://www.google.com/schemas/sitemap-news/0.9">
+		<url>
+			<loc>https://example.org/business/article55.html</loc>
+			<lastmod>2008-01-01</lastmod>
+			<news:news>
+				<news:publication>
+					<news:name>The Example Times</news:name>
+					<news:language>en</news:language>
+				</news:publication>
+				<news:publication_date>2008-12-23</news:publication_date>
+				<news:title>Companies A &amp; B in Merger Talks</news:title>
+				<news:keywords>business, merger, business</news:keywords>
+			</news:news>
+		</url>
+	</urlset>`
+
+	feed, err := Parse("https://example.org/news-sitemap.xml", bytes.NewReader([]byte(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(feed.Entries) != 1 {
+		t.Fatalf("Incorrect number of entries, got: %d", len(feed.Entries))
+	}
+
+	entry := feed.Entries[0]
+	if entry.Title != "Companies A & B in Merger Talks" {
+		t.Errorf("Incorrect entry title, got: %s", entry.Title)
+	}
+
+	if entry.Author != "The Example Times" {
+		t.Errorf("Incorrect entry author, got: %s", entry.Author)
+	}
+
+	expectedDate := time.Date(2008, time.December, 23, 0, 0, 0, 0, time.UTC)
+	if !entry.Date.Equal(expectedDate) {
+		t.Errorf("Incorrect entry date, got: %v", entry.Date)
+	}
+
+	if len(entry.Tags) != 2 || entry.Tags[0] != "business" || entry.Tags[1] != "merger" {
+		t.Errorf("Incorrect entry tags, got: %v", entry.Tags)
+	}
+}
+
+func TestParseSitemapWithImageExtension(t *testing.T) {
+	data := `<?xml version="1.0" encoding="UTF-8"?>
+	<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9" xmlns:image="http://www.google.com/schemas/sitemap-image/1.1">
+		<url>
+			<loc>https://example.org/sample1.html</loc>
+			<image:image>
+				<image:loc>https://example.org/image.jpg</image:loc>
+				<image:title>Sample Image</image:title>
+			</image:image>
+			<image:image>
+				<image:loc>/photo.jpg</image:loc>
+			</image:image>
+		</url>
+	</urlset>`
+
+	feed, err := Parse("https://example.org/sitemap.xml", bytes.NewReader([]byte(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(feed.Entries) != 1 {
+		t.Fatalf("Incorrect number of entries, got: %d", len(feed.Entries))
+	}
+
+	entry := feed.Entries[0]
+	if entry.Title != "Sample Image" {
+		t.Errorf("Incorrect entry title, got: %s", entry.Title)
+	}
+
+	if len(entry.Enclosures) != 2 {
+		t.Fatalf("Incorrect number of enclosures, got: %d", len(entry.Enclosures))
+	}
+
+	if entry.Enclosures[1].URL != "https://example.org/photo.jpg" {
+		t.Errorf("Incorrect enclosure URL, got: %s", entry.Enclosures[1].URL)
+	}
+
+	if entry.Enclosures[0].MimeType != "image/jpeg" {
+		t.Errorf("Incorrect enclosure mime type, got: %s", entry.Enclosures[0].MimeType)
+	}
+}
+
+func TestParseSitemapKeepsOnlyMostRecentEntries(t *testing.T) {
+	var data strings.Builder
+	data.WriteString(`<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">`)
+	for i := range maxEntries + 10 {
+		fmt.Fprintf(&data, `<url><loc>https://example.org/%d</loc><lastmod>%s</lastmod></url>`, i, time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, i).Format("2006-01-02"))
+	}
+	data.WriteString(`</urlset>`)
+
+	feed, err := Parse("https://example.org/sitemap.xml", strings.NewReader(data.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(feed.Entries) != maxEntries {
+		t.Fatalf("Incorrect number of entries, got: %d", len(feed.Entries))
+	}
+
+	if feed.Entries[0].URL != fmt.Sprintf("https://example.org/%d", maxEntries+9) {
+		t.Errorf("Incorrect first entry, got: %s", feed.Entries[0].URL)
+	}
+}
+
+func TestParseSitemapIndexLocations(t *testing.T) {
+	data := `<?xml version="1.0" encoding="UTF-8"?>
+	<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+		<sitemap>
+			<loc>https://example.org/sitemap-2023.xml</loc>
+			<lastmod>2023-12-31</lastmod>
+		</sitemap>
+		<sitemap>
+			<loc>/sitemap-pages.xml</loc>
+		</sitemap>
+		<sitemap>
+			<loc>https://example.org/sitemap-2024.xml</loc>
+			<lastmod>2024-12-31</lastmod>
+		</sitemap>
+		<sitemap>
+			<loc>https://example.org/sitemap-2022.xml</loc>
+			<lastmod>2022-12-31</lastmod>
+		</sitemap>
+	</sitemapindex>`
+
+	locations, err := parseIndexLocations("https://example.org/sitemap_index.xml", strings.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"https://example.org/sitemap-2024.xml",
+		"https://example.org/sitemap-2023.xml",
+		"https://example.org/sitemap-2022.xml",
+	}
+
+	if len(locations) != len(expected) {
+		t.Fatalf("Incorrect number of locations, got: %v", locations)
+	}
+
+	for i := range expected {
+		if locations[i] != expected[i] {
+			t.Errorf("Incorrect location #%d, got: %s", i, locations[i])
+		}
+	}
+}
+
+func TestTitleFromURL(t *testing.T) {
+	scenarios := map[string]string{
+		"https://example.org/":                   "https://example.org/",
+		"https://example.org/my-first-post/":     "My first post",
+		"https://example.org/2024/01/hello.html": "Hello",
+		"https://example.org/search?q=something": "Search",
+		"https://example.org/under_score+plus":   "Under score plus",
+		"https://example.org/-":                  "https://example.org/-",
+	}
+
+	for input, expected := range scenarios {
+		if result := titleFromURL(input); result != expected {
+			t.Errorf(`Unexpected result for %q, got %q instead of %q`, input, result, expected)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package sitemap // import "miniflux.app/v2/internal/reader/sitemap"
+
+import (
+	"encoding/xml"
+)
+
+// Specs: https://www.sitemaps.org/protocol.html
+type sitemapURLSet struct {
+	XMLName xml.Name     `xml:"urlset"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+type sitemapURL struct {
+	// Loc is the URL of the page.
+	Loc string `xml:"loc"`
+
+	// LastMod is the date of last modification of the page, in W3C Datetime format.
+	LastMod string `xml:"lastmod"`
+
+	// News contains the Google News extension elements.
+	// Specs: https://developers.google.com/search/docs/crawling-indexing/sitemaps/news-sitemap
+	News *sitemapNews `xml:"http://www.google.com/schemas/sitemap-news/0.9 news"`
+
+	// Images contains the Google image extension elements.
+	// Specs: https://developers.google.com/search/docs/crawling-indexing/sitemaps/image-sitemaps
+	Images []sitemapImage `xml:"http://www.google.com/schemas/sitemap-image/1.1 image"`
+}
+
+type sitemapNews struct {
+	Publication struct {
+		Name     string `xml:"http://www.google.com/schemas/sitemap-news/0.9 name"`
+		Language string `xml:"http://www.google.com/schemas/sitemap-news/0.9 language"`
+	} `xml:"http://www.google.com/schemas/sitemap-news/0.9 publication"`
+	PublicationDate string `xml:"http://www.google.com/schemas/sitemap-news/0.9 publication_date"`
+	Title           string `xml:"http://www.google.com/schemas/sitemap-news/0.9 title"`
+	Keywords        string `xml:"http://www.google.com/schemas/sitemap-news/0.9 keywords"`
+}
+
+type sitemapImage struct {
+	Loc     string `xml:"http://www.google.com/schemas/sitemap-image/1.1 loc"`
+	Title   string `xml:"http://www.google.com/schemas/sitemap-image/1.1 title"`
+	Caption string `xml:"http://www.google.com/schemas/sitemap-image/1.1 caption"`
+}
+
+// Specs: https://www.sitemaps.org/protocol.html#index
+type sitemapIndex struct {
+	XMLName  xml.Name            `xml:"sitemapindex"`
+	Sitemaps []sitemapIndexEntry `xml:"sitemap"`
+}
+
+type sitemapIndexEntry struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod"`
+}
@@ -0,0 +1,364 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package snapshot // import "miniflux.app/v2/internal/reader/snapshot"
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"mime"
+	"regexp"
+	"strings"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/reader/encoding"
+	"miniflux.app/v2/internal/reader/fetcher"
+	"miniflux.app/v2/internal/urllib"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+)
+
+// maxStylesheetDepth limits the number of nested @import rules that are inlined.
+const maxStylesheetDepth = 3
+
+var (
+	cssURLRegex    = regexp.MustCompile(`url\(\s*(?:"([^"]*)"|'([^']*)'|([^'")\s]*))\s*\)`)
+	cssImportRegex = regexp.MustCompile(`@import\s+(?:"([^"]*)"|'([^']*)')`)
+)
+
+// removedElements are the active or embedded elements that are not archived.
+var removedElements = []string{
+	"script",
+	"noscript",
+	"iframe",
+	"frame",
+	"frameset",
+	"object",
+	"embed",
+	"applet",
+	"base",
+	"meta[http-equiv]",
+	"meta[charset]",
+	`link:not([rel~="stylesheet"])`,
+	"picture source",
+}
+
+// Snapshot is a self-contained copy of a web page.
+type Snapshot struct {
+	// URL is the URL of the page after redirects.
+	URL string
+
+	// Content is the HTML document, with its stylesheets and images inlined.
+	Content string
+}
+
+// Create downloads a web page and inlines its stylesheets, images and fonts,
+// so the page can be displayed without network access.
+//
+// Scripts, frames and embedded objects are removed. The resources are inlined as data URLs
+// until maxSize bytes are reached, the remaining ones are linked with absolute URLs.
+func Create(requestBuilder *fetcher.RequestBuilder, pageURL string, maxSize int64) (*Snapshot, error) {
+	responseHandler := fetcher.NewResponseHandler(requestBuilder.ExecuteRequest(pageURL))
+	defer responseHandler.Close()
+
+	if localizedError := responseHandler.LocalizedError(); localizedError != nil {
+		return nil, localizedError.Error()
+	}
+
+	contentType := strings.ToLower(responseHandler.ContentType())
+	if !strings.HasPrefix(contentType, "text/html") && !strings.HasPrefix(contentType, "application/xhtml+xml") {
+		return nil, fmt.Errorf("snapshot: this resource is not a HTML document (%s)", responseHandler.ContentType())
+	}
+
+	htmlDocumentReader, err := encoding.NewCharsetReader(responseHandler.Body(config.Opts.HTTPClientMaxBodySize()), responseHandler.ContentType())
+	if err != nil {
+		return nil, fmt.Errorf("snapshot: unable to read HTML document with charset reader: %v", err)
+	}
+
+	document, err := goquery.NewDocumentFromReader(htmlDocumentReader)
+	if err != nil {
+		return nil, fmt.Errorf("snapshot: unable to parse HTML document: %v", err)
+	}
+
+	effectiveURL := responseHandler.EffectiveURL()
+	baseURL := effectiveURL
+	if hrefValue, exists := document.FindMatcher(goquery.Single("head base")).Attr("href"); exists {
+		if absoluteURL, err := urllib.ResolveToAbsoluteURL(effectiveURL, strings.TrimSpace(hrefValue)); err == nil {
+			baseURL = absoluteURL
+		}
+	}
+
+	a := &archiver{
+		requestBuilder: requestBuilder,
+		remainingSize:  maxSize - int64(len(document.Text())),
+		cache:          make(map[string]string),
+	}
+	a.archiveDocument(document, baseURL)
+
+	output, err := document.Html()
+	if err != nil {
+		return nil, fmt.Errorf("snapshot: unable to render HTML document: %v", err)
+	}
+
+	return &Snapshot{URL: effectiveURL, Content: output}, nil
+}
+
+type archiver struct {
+	requestBuilder *fetcher.RequestBuilder
+	remainingSize  int64
+
+	// cache holds the data URLs of the resources already downloaded.
+	cache map[string]string
+}
+
+func (a *archiver) archiveDocument(document *goquery.Document, baseURL string) {
+	document.Find(strings.Join(removedElements, ", ")).Remove()
+
+	document.Find("*").Each(func(_ int, s *goquery.Selection) {
+		removeEventHandlers(s)
+	})
+
+	// The document is re-encoded in UTF-8.
+	document.Find("head").PrependHtml(`<meta charset="utf-8">`)
+
+	document.Find(`link[rel~="stylesheet"][href]`).Each(func(_ int, s *goquery.Selection) {
+		href, _ := s.Attr("href")
+		stylesheetURL, err := urllib.ResolveToAbsoluteURL(baseURL, strings.TrimSpace(href))
+		if err != nil {
+			s.Remove()
+			return
+		}
+
+		stylesheet, err := a.fetchStylesheet(stylesheetURL, 0)
+		if err != nil {
+			slog.Debug("Unable to archive stylesheet", slog.String("stylesheet_url", stylesheetURL), slog.Any("error", err))
+			s.SetAttr("href", stylesheetURL)
+			return
+		}
+
+		style := `<style`
+		if media, exists := s.Attr("media"); exists {
+			style += ` media="` + html.EscapeString(media) + `"`
+		}
+		s.ReplaceWithHtml(style + `>` + stylesheet + `</style>`)
+	})
+
+	document.Find("style").Each(func(_ int, s *goquery.Selection) {
+		// goquery escapes the text, but the content of a <style> element is not HTML.
+		stylesheet := a.archiveStylesheet(s.Text(), baseURL, 0)
+		s.Empty()
+		s.Nodes[0].AppendChild(&html.Node{Type: html.TextNode, Data: stylesheet})
+	})
+
+	document.Find("[style]").Each(func(_ int, s *goquery.Selection) {
+		style, _ := s.Attr("style")
+		s.SetAttr("style", a.archiveStylesheet(style, baseURL, 0))
+	})
+
+	document.Find("img").Each(func(_ int, s *goquery.Selection) {
+		source := imageSource(s)
+		s.RemoveAttr("srcset")
+		s.RemoveAttr("sizes")
+		s.RemoveAttr("loading")
+
+		if source == "" {
+			return
+		}
+		if imageURL, err := urllib.ResolveToAbsoluteURL(baseURL, source); err == nil {
+			s.SetAttr("src", a.archiveResource(imageURL))
+		}
+	})
+
+	document.Find("video[poster]").Each(func(_ int, s *goquery.Selection) {
+		poster, _ := s.Attr("poster")
+		if posterURL, err := urllib.ResolveToAbsoluteURL(baseURL, strings.TrimSpace(poster)); err == nil {
+			s.SetAttr("poster", a.archiveResource(posterURL))
+		}
+	})
+
+	// Links and media files are kept online.
+	document.Find("a[href], area[href], video[src], audio[src], source[src], track[src]").Each(func(_ int, s *goquery.Selection) {
+		for _, attribute := range [...]string{"href", "src"} {
+			value, exists := s.Attr(attribute)
+			if !exists {
+				continue
+			}
+
+			value = strings.TrimSpace(value)
+			if strings.HasPrefix(value, "#") {
+				continue
+			}
+
+			if absoluteURL, err := urllib.ResolveToAbsoluteURL(baseURL, value); err == nil && urllib.IsAbsoluteURL(absoluteURL) {
+				s.SetAttr(attribute, absoluteURL)
+			} else {
+				s.RemoveAttr(attribute)
+			}
+		}
+	})
+}
+
+// archiveStylesheet inlines the resources referenced by a stylesheet.
+func (a *archiver) archiveStylesheet(stylesheet, baseURL string, depth int) string {
+	stylesheet = cssImportRegex.ReplaceAllStringFunc(stylesheet, func(match string) string {
+		importURL := firstSubmatch(cssImportRegex, match)
+		return `@import url("` + a.archiveImport(baseURL, importURL, depth) + `")`
+	})
+
+	return cssURLRegex.ReplaceAllStringFunc(stylesheet, func(match string) string {
+		resourceURL := firstSubmatch(cssURLRegex, match)
+		if resourceURL == "" || strings.HasPrefix(resourceURL, "data:") || strings.HasPrefix(resourceURL, "#") {
+			return match
+		}
+
+		absoluteURL, err := urllib.ResolveToAbsoluteURL(baseURL, resourceURL)
+		if err != nil {
+			return match
+		}
+
+		// Imported stylesheets can also be written as @import url(...).
+		if strings.HasSuffix(strings.ToLower(strings.SplitN(absoluteURL, "?", 2)[0]), ".css") {
+			return `url("` + a.archiveImport(baseURL, resourceURL, depth) + `")`
+		}
+
+		return `url("` + a.archiveResource(absoluteURL) + `")`
+	})
+}
+
+func (a *archiver) archiveImport(baseURL, importURL string, depth int) string {
+	absoluteURL, err := urllib.ResolveToAbsoluteURL(baseURL, importURL)
+	if err != nil {
+		return importURL
+	}
+
+	if depth >= maxStylesheetDepth {
+		return absoluteURL
+	}
+
+	stylesheet, err := a.fetchStylesheet(absoluteURL, depth+1)
+	if err != nil {
+		return absoluteURL
+	}
+
+	return "data:text/css;base64," + base64.StdEncoding.EncodeToString([]byte(stylesheet))
+}
+
+// fetchStylesheet downloads a stylesheet and inlines its resources.
+func (a *archiver) fetchStylesheet(stylesheetURL string, depth int) (string, error) {
+	body, _, err := a.fetch(stylesheetURL)
+	if err != nil {
+		return "", err
+	}
+
+	// The stylesheet is embedded into a <style> element.
+	stylesheet := strings.ReplaceAll(string(body), "</style", `<\/style`)
+	return a.archiveStylesheet(stylesheet, stylesheetURL, depth), nil
+}
+
+// archiveResource returns the resource as a data URL, or its absolute URL when it cannot be downloaded.
+func (a *archiver) archiveResource(resourceURL string) string {
+	if !urllib.IsAbsoluteURL(resourceURL) {
+		return resourceURL
+	}
+
+	if dataURL, found := a.cache[resourceURL]; found {
+		return dataURL
+	}
+
+	body, contentType, err := a.fetch(resourceURL)
+	if err != nil {
+		slog.Debug("Unable to archive resource", slog.String("resource_url", resourceURL), slog.Any("error", err))
+		return resourceURL
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || mediaType == "" {
+		mediaType = "application/octet-stream"
+	}
+
+	dataURL := "data:" + mediaType + ";base64," + base64.StdEncoding.EncodeToString(body)
+	a.cache[resourceURL] = dataURL
+	return dataURL
+}
+
+func (a *archiver) fetch(resourceURL string) ([]byte, string, error) {
+	if a.remainingSize <= 0 {
+		return nil, "", errors.New("snapshot: maximum size reached")
+	}
+
+	responseHandler := fetcher.NewResponseHandler(a.requestBuilder.ExecuteRequest(resourceURL))
+	defer responseHandler.Close()
+
+	if localizedError := responseHandler.LocalizedError(); localizedError != nil {
+		return nil, "", localizedError.Error()
+	}
+
+	body, localizedError := responseHandler.ReadBody(a.remainingSize)
+	if localizedError != nil {
+		return nil, "", localizedError.Error()
+	}
+
+	// Base64 encoding makes the resources a third larger.
+	a.remainingSize -= int64(base64.StdEncoding.EncodedLen(len(body)))
+
+	return body, responseHandler.ContentType(), nil
+}
+
+// imageSource returns the source of an image, including the ones loaded lazily.
+func imageSource(s *goquery.Selection) string {
+	source := strings.TrimSpace(s.AttrOr("src", ""))
+	if source != "" && !strings.HasPrefix(source, "data:") {
+		return source
+	}
+
+	for _, attribute := range [...]string{"data-src", "data-lazy-src", "data-original"} {
+		if value := strings.TrimSpace(s.AttrOr(attribute, "")); value != "" {
+			return value
+		}
+	}
+
+	if srcset := strings.TrimSpace(s.AttrOr("srcset", "")); srcset != "" {
+		if fields := strings.Fields(srcset); len(fields) > 0 {
+			return strings.TrimSuffix(fields[0], ",")
+		}
+	}
+
+	return source
+}
+
+// removeEventHandlers removes the JavaScript attributes of an element.
+func removeEventHandlers(s *goquery.Selection) {
+	for _, node := range s.Nodes {
+		attributes := node.Attr[:0]
+		for _, attribute := range node.Attr {
+			if strings.HasPrefix(strings.ToLower(attribute.Key), "on") {
+				continue
+			}
+			if isURLAttribute(attribute.Key) && strings.HasPrefix(strings.ToLower(strings.TrimSpace(attribute.Val)), "javascript:") {
+				continue
+			}
+			attributes = append(attributes, attribute)
+		}
+		node.Attr = attributes
+	}
+}
+
+func isURLAttribute(name string) bool {
+	switch strings.ToLower(name) {
+	case "href", "src", "action", "formaction", "xlink:href":
+		return true
+	}
+	return false
+}
+
+func firstSubmatch(regex *regexp.Regexp, input string) string {
+	for _, submatch := range regex.FindStringSubmatch(input)[1:] {
+		if submatch != "" {
+			return strings.TrimSpace(submatch)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,125 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package snapshot // import "miniflux.app/v2/internal/reader/snapshot"
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/reader/fetcher"
+)
+
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	os.Clearenv()
+	os.Setenv("FETCHER_ALLOW_PRIVATE_NETWORKS", "1")
+
+	var err error
+	config.Opts, err = config.NewConfigParser().ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Config parsing failure: %v`, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/article", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(`<html><head>
+			<link rel="stylesheet" href="/style.css" media="screen">
+			<link rel="preload" href="/font.woff2">
+			<script src="/app.js"></script>
+			</head><body onload="track()">
+			<article>
+				<p style="background: url('/bg.png')">Content</p>
+				<img src="/image.png" srcset="/image-2x.png 2x">
+				<img src="data:image/gif;base64,R0lGODlh" data-src="/lazy.png">
+				<a href="/other" onclick="track()">Other</a>
+				<a href="javascript:alert(1)">Bad</a>
+				<iframe src="/embed"></iframe>
+			</article>
+			</body></html>`))
+	})
+	mux.HandleFunc("/style.css", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/css")
+		w.Write([]byte(`@import "print.css"; body { background: url(images/bg.png); }`))
+	})
+	mux.HandleFunc("/print.css", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/css")
+		w.Write([]byte(`p { color: black; }`))
+	})
+	for _, path := range []string{"/bg.png", "/images/bg.png", "/image.png", "/lazy.png"} {
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "image/png")
+			w.Write([]byte("PNG"))
+		})
+	}
+
+	return httptest.NewServer(mux)
+}
+
+func TestCreateInlinesResources(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	snapshot, err := Create(fetcher.NewRequestBuilder(), server.URL+"/article", 1024*1024)
+	if err != nil {
+		t.Fatalf(`Snapshot failed: %v`, err)
+	}
+
+	if snapshot.URL != server.URL+"/article" {
+		t.Errorf(`Unexpected URL, got %q`, snapshot.URL)
+	}
+
+	for _, expected := range []string{
+		`<meta charset="utf-8"/>`,
+		`<style media="screen">@import url("data:text/css;base64,`,
+		`body { background: url("data:image/png;base64,UE5H"); }`,
+		`<p style="background: url(&#34;data:image/png;base64,UE5H&#34;)">`,
+		`<img src="data:image/png;base64,UE5H"/>`,
+		`<img src="data:image/png;base64,UE5H" data-src="/lazy.png"/>`,
+		`<a href="` + server.URL + `/other">Other</a>`,
+		`<a>Bad</a>`,
+	} {
+		if !strings.Contains(snapshot.Content, expected) {
+			t.Errorf(`The snapshot should contain %q, got %q`, expected, snapshot.Content)
+		}
+	}
+
+	for _, unexpected := range []string{"<script", "<iframe", "onload", "onclick", "preload", "srcset"} {
+		if strings.Contains(snapshot.Content, unexpected) {
+			t.Errorf(`The snapshot should not contain %q, got %q`, unexpected, snapshot.Content)
+		}
+	}
+}
+
+func TestCreateLinksResourcesOverMaxSize(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	snapshot, err := Create(fetcher.NewRequestBuilder(), server.URL+"/article", 1)
+	if err != nil {
+		t.Fatalf(`Snapshot failed: %v`, err)
+	}
+
+	if !strings.Contains(snapshot.Content, `<img src="`+server.URL+`/image.png"/>`) {
+		t.Errorf(`The image should be linked, got %q`, snapshot.Content)
+	}
+
+	if !strings.Contains(snapshot.Content, `<link rel="stylesheet" href="`+server.URL+`/style.css" media="screen"/>`) {
+		t.Errorf(`The stylesheet should be linked, got %q`, snapshot.Content)
+	}
+}
+
+func TestCreateRejectsNonHTMLDocuments(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	if _, err := Create(fetcher.NewRequestBuilder(), server.URL+"/style.css", 1024*1024); err == nil {
+		t.Error(`A stylesheet should not be archived`)
+	}
+}
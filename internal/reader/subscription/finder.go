@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"log/slog"
 	"net/url"
+	"slices"
 	"strings"
 
 	"miniflux.app/v2/internal/config"
@@ -121,6 +122,15 @@ func (f *subscriptionFinder) FindSubscriptions(websiteURL, rssBridgeURL string,
 		return subscriptions, nil
 	}
 
+	// Step 7) Fallback to the website sitemaps when no feed is available.
+	slog.Debug("Try to detect sitemaps", slog.String("website_url", websiteURL))
+	if subscriptions, localizedError := f.findSubscriptionsFromSitemaps(websiteURL); localizedError != nil {
+		return nil, localizedError
+	} else if len(subscriptions) > 0 {
+		slog.Debug("Subscriptions found from sitemaps", slog.String("website_url", websiteURL), slog.Any("subscriptions", subscriptions))
+		return subscriptions, nil
+	}
+
 	return nil, nil
 }
 
@@ -237,6 +247,83 @@ func (f *subscriptionFinder) findSubscriptionsFromWellKnownURLs(websiteURL strin
 	return subscriptions, nil
 }
 
+func (f *subscriptionFinder) findSubscriptionsFromSitemaps(websiteURL string) (Subscriptions, *locale.LocalizedErrorWrapper) {
+	websiteURLRoot := urllib.RootURL(websiteURL)
+
+	// Sitemaps declared in robots.txt take precedence over the conventional locations.
+	candidateURLs := f.findSitemapURLsFromRobotsTxt(websiteURLRoot)
+	for _, knownURL := range []string{"sitemap.xml", "sitemap_index.xml", "news-sitemap.xml"} {
+		if fullURL, err := urllib.ResolveToAbsoluteURL(websiteURLRoot, knownURL); err == nil && !slices.Contains(candidateURLs, fullURL) {
+			candidateURLs = append(candidateURLs, fullURL)
+		}
+	}
+
+	var subscriptions Subscriptions
+	for _, candidateURL := range candidateURLs {
+		responseHandler := fetcher.NewResponseHandler(f.requestBuilder.ExecuteRequest(candidateURL))
+		if localizedError := responseHandler.LocalizedError(); localizedError != nil {
+			responseHandler.Close()
+			slog.Debug("Ignore invalid sitemap URL during feed discovery",
+				slog.String("sitemap_url", candidateURL),
+				slog.Any("error", localizedError.Error()),
+			)
+			continue
+		}
+
+		responseBody, localizedError := responseHandler.ReadBody(config.Opts.HTTPClientMaxBodySize())
+		responseHandler.Close()
+		if localizedError != nil {
+			continue
+		}
+
+		if feedFormat, _ := parser.DetectFeedFormat(bytes.NewReader(responseBody)); feedFormat == parser.FormatSitemap || feedFormat == parser.FormatSitemapIndex {
+			subscriptions = append(subscriptions, NewSubscription(candidateURL, candidateURL, feedFormat))
+		}
+	}
+
+	return subscriptions, nil
+}
+
+// findSitemapURLsFromRobotsTxt returns the sitemap URLs declared with the "Sitemap" directive of the robots.txt file.
+func (f *subscriptionFinder) findSitemapURLsFromRobotsTxt(websiteURLRoot string) []string {
+	robotsTxtURL, err := urllib.ResolveToAbsoluteURL(websiteURLRoot, "robots.txt")
+	if err != nil {
+		return nil
+	}
+
+	responseHandler := fetcher.NewResponseHandler(f.requestBuilder.ExecuteRequest(robotsTxtURL))
+	defer responseHandler.Close()
+
+	if localizedError := responseHandler.LocalizedError(); localizedError != nil {
+		return nil
+	}
+
+	responseBody, localizedError := responseHandler.ReadBody(config.Opts.HTTPClientMaxBodySize())
+	if localizedError != nil {
+		return nil
+	}
+
+	return parseSitemapDirectives(websiteURLRoot, responseBody)
+}
+
+func parseSitemapDirectives(websiteURLRoot string, robotsTxt []byte) []string {
+	var sitemapURLs []string
+	for line := range strings.Lines(string(robotsTxt)) {
+		directive, value, found := strings.Cut(strings.TrimSpace(line), ":")
+		if !found || !strings.EqualFold(strings.TrimSpace(directive), "sitemap") {
+			continue
+		}
+
+		sitemapURL, err := urllib.ResolveToAbsoluteURL(websiteURLRoot, strings.TrimSpace(value))
+		if err != nil || slices.Contains(sitemapURLs, sitemapURL) {
+			continue
+		}
+
+		sitemapURLs = append(sitemapURLs, sitemapURL)
+	}
+	return sitemapURLs
+}
+
 func (f *subscriptionFinder) findSubscriptionsFromRSSBridge(websiteURL, rssBridgeURL string, rssBridgeToken string) (Subscriptions, *locale.LocalizedErrorWrapper) {
 	slog.Debug("Trying to detect feeds using RSS-Bridge",
 		slog.String("website_url", websiteURL),
@@ -510,3 +510,30 @@ func TestFindCanonicalURLNotFound(t *testing.T) {
 		t.Errorf(`Expected effective URL when canonical not found, got %q`, canonicalURL)
 	}
 }
+
+func TestParseSitemapDirectives(t *testing.T) {
+	robotsTxt := `User-agent: *
+Disallow: /admin/
+
+Sitemap: https://example.org/sitemap_index.xml
+sitemap:/news-sitemap.xml
+# Sitemap: https://example.org/commented.xml
+Sitemap: https://example.org/sitemap_index.xml
+`
+
+	sitemapURLs := parseSitemapDirectives("https://example.org/", []byte(robotsTxt))
+	expected := []string{
+		"https://example.org/sitemap_index.xml",
+		"https://example.org/news-sitemap.xml",
+	}
+
+	if len(sitemapURLs) != len(expected) {
+		t.Fatalf(`Unexpected sitemap URLs: %v`, sitemapURLs)
+	}
+
+	for i := range expected {
+		if sitemapURLs[i] != expected[i] {
+			t.Errorf(`Unexpected sitemap URL #%d, got %q instead of %q`, i, sitemapURLs[i], expected[i])
+		}
+	}
+}
@@ -0,0 +1,220 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package summary // import "miniflux.app/v2/internal/reader/summary"
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/http/client"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/sanitizer"
+	"miniflux.app/v2/internal/storage"
+	"miniflux.app/v2/internal/version"
+)
+
+var (
+	// ErrNotConfigured is returned when no summary API is configured.
+	ErrNotConfigured = errors.New("summary: the summary API is not configured")
+
+	// ErrTokenBudgetExceeded is returned when the daily token budget of the user is reached.
+	ErrTokenBudgetExceeded = errors.New("summary: the daily token budget is exceeded")
+)
+
+// The reasoning models return their chain of thought before the answer.
+var reasoningBlockRegex = regexp.MustCompile(`(?s)<think>.*?</think>`)
+
+// DefaultPromptTemplate is used when the category of the entry does not define a prompt.
+const DefaultPromptTemplate = `Summarize the following article in three sentences at most.
+Write the summary in the language of the article and reply with the summary only.
+
+Title: {{ .Title }}
+
+{{ .Content }}`
+
+// PromptData contains the fields available in the prompt templates.
+type PromptData struct {
+	Title    string
+	URL      string
+	Author   string
+	Feed     string
+	Category string
+	Language string
+	Content  string
+}
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type chatCompletionRequest struct {
+	Model       string        `json:"model"`
+	Messages    []chatMessage `json:"messages"`
+	MaxTokens   int           `json:"max_tokens"`
+	Temperature float64       `json:"temperature"`
+	Stream      bool          `json:"stream"`
+}
+
+type chatCompletionResponse struct {
+	Choices []struct {
+		Message chatMessage `json:"message"`
+	} `json:"choices"`
+	Usage struct {
+		TotalTokens int `json:"total_tokens"`
+	} `json:"usage"`
+}
+
+// ValidatePromptTemplate checks that the prompt template can be rendered.
+func ValidatePromptTemplate(prompt string) error {
+	_, err := renderPrompt(prompt, &PromptData{})
+	return err
+}
+
+// SummarizeEntry requests a summary of the entry from the summary API and stores it in entry.Summary.
+// The number of tokens used is added to the daily usage of the user.
+func SummarizeEntry(store *storage.Storage, feed *model.Feed, entry *model.Entry) error {
+	if budget := config.Opts.SummaryDailyTokenBudget(); budget > 0 {
+		usage, err := store.SummaryTokenUsage(feed.UserID)
+		if err != nil {
+			return err
+		}
+		if usage >= int64(budget) {
+			return ErrTokenBudgetExceeded
+		}
+	}
+
+	data := newPromptData(feed, entry, config.Opts.SummaryMaxInputTokens())
+	if data.Content == "" {
+		return errors.New("summary: the entry has no content")
+	}
+
+	promptTemplate := DefaultPromptTemplate
+	if feed.Category != nil && strings.TrimSpace(feed.Category.SummaryPrompt) != "" {
+		promptTemplate = feed.Category.SummaryPrompt
+	}
+
+	prompt, err := renderPrompt(promptTemplate, data)
+	if err != nil {
+		return err
+	}
+
+	summary, tokens, err := requestSummary(prompt)
+	if err != nil {
+		return err
+	}
+
+	if err := store.AddSummaryTokenUsage(feed.UserID, tokens); err != nil {
+		slog.Warn("Unable to record the summary token usage", slog.Any("error", err))
+	}
+
+	entry.Summary = summary
+	return nil
+}
+
+func newPromptData(feed *model.Feed, entry *model.Entry, maxInputTokens int) *PromptData {
+	data := &PromptData{
+		Title:    entry.Title,
+		URL:      entry.URL,
+		Author:   entry.Author,
+		Feed:     feed.Title,
+		Language: entry.Language,
+		Content:  truncateText(strings.Join(strings.Fields(sanitizer.StripTags(entry.Content)), " "), maxInputTokens),
+	}
+
+	if feed.Category != nil {
+		data.Category = feed.Category.Title
+	}
+
+	return data
+}
+
+// truncateText keeps approximately the given number of tokens, a token being about four characters.
+func truncateText(text string, maxTokens int) string {
+	runes := []rune(text)
+	if maxChars := maxTokens * 4; len(runes) > maxChars {
+		return string(runes[:maxChars])
+	}
+	return text
+}
+
+func renderPrompt(promptTemplate string, data *PromptData) (string, error) {
+	tpl, err := template.New("prompt").Parse(promptTemplate)
+	if err != nil {
+		return "", fmt.Errorf("summary: invalid prompt template: %v", err)
+	}
+
+	var buffer strings.Builder
+	if err := tpl.Execute(&buffer, data); err != nil {
+		return "", fmt.Errorf("summary: invalid prompt template: %v", err)
+	}
+
+	return buffer.String(), nil
+}
+
+func requestSummary(prompt string) (string, int, error) {
+	requestBody, err := json.Marshal(&chatCompletionRequest{
+		Model:       config.Opts.SummaryModel(),
+		Messages:    []chatMessage{{Role: "user", Content: prompt}},
+		MaxTokens:   config.Opts.SummaryMaxOutputTokens(),
+		Temperature: 0.2,
+	})
+	if err != nil {
+		return "", 0, fmt.Errorf("summary: unable to encode request body: %v", err)
+	}
+
+	request, err := http.NewRequest(http.MethodPost, config.Opts.SummaryAPIURL()+"/chat/completions", bytes.NewReader(requestBody))
+	if err != nil {
+		return "", 0, fmt.Errorf("summary: unable to create request: %v", err)
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", "Miniflux/"+version.Version)
+	if apiKey := config.Opts.SummaryAPIKey(); apiKey != "" {
+		request.Header.Set("Authorization", "Bearer "+apiKey)
+	}
+
+	// The summary API is defined by the administrator and usually runs on the same host or network.
+	httpClient := client.NewClientWithOptions(client.Options{Timeout: config.Opts.SummaryTimeout()})
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return "", 0, fmt.Errorf("summary: unable to send request: %v", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return "", 0, fmt.Errorf("summary: incorrect response status code %d", response.StatusCode)
+	}
+
+	var completion chatCompletionResponse
+	body := io.LimitReader(response.Body, config.Opts.HTTPClientMaxBodySize())
+	if err := json.NewDecoder(body).Decode(&completion); err != nil {
+		return "", 0, fmt.Errorf("summary: unable to decode response: %v", err)
+	}
+
+	if len(completion.Choices) == 0 {
+		return "", 0, errors.New("summary: the response has no choice")
+	}
+
+	summary := strings.TrimSpace(reasoningBlockRegex.ReplaceAllString(completion.Choices[0].Message.Content, ""))
+	if summary == "" {
+		return "", 0, errors.New("summary: the summary is empty")
+	}
+
+	tokens := completion.Usage.TotalTokens
+	if tokens <= 0 {
+		tokens = (len([]rune(prompt)) + len([]rune(summary))) / 4
+	}
+
+	return summary, tokens, nil
+}
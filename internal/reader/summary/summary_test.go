@@ -0,0 +1,141 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package summary // import "miniflux.app/v2/internal/reader/summary"
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/model"
+)
+
+func configureSummaryAPI(t *testing.T, apiURL string) {
+	t.Helper()
+
+	os.Clearenv()
+	os.Setenv("SUMMARY_API_URL", apiURL)
+	os.Setenv("SUMMARY_API_KEY", "secret")
+	os.Setenv("SUMMARY_MODEL", "test-model")
+	os.Setenv("SUMMARY_MAX_OUTPUT_TOKENS", "100")
+
+	var err error
+	config.Opts, err = config.NewConfigParser().ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatalf(`Config parsing failure: %v`, err)
+	}
+}
+
+func TestRequestSummary(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/chat/completions" {
+			t.Errorf(`Unexpected path: %q`, r.URL.Path)
+		}
+
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			t.Errorf(`Unexpected authorization header: %q`, r.Header.Get("Authorization"))
+		}
+
+		var request chatCompletionRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Errorf(`Unable to decode request: %v`, err)
+		}
+
+		if request.Model != "test-model" || request.MaxTokens != 100 || len(request.Messages) != 1 || request.Messages[0].Content != "Summarize this." {
+			t.Errorf(`Unexpected request: %+v`, request)
+		}
+
+		w.Write([]byte(`{"choices": [{"message": {"role": "assistant", "content": "<think>Some reasoning.</think>\n A short summary. "}}], "usage": {"total_tokens": 42}}`))
+	}))
+	defer server.Close()
+
+	configureSummaryAPI(t, server.URL+"/v1/")
+
+	summary, tokens, err := requestSummary("Summarize this.")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if summary != "A short summary." {
+		t.Errorf(`Unexpected summary: %q`, summary)
+	}
+
+	if tokens != 42 {
+		t.Errorf(`Unexpected token count: %d`, tokens)
+	}
+}
+
+func TestRequestSummaryWithoutUsage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"choices": [{"message": {"role": "assistant", "content": "Summary"}}]}`))
+	}))
+	defer server.Close()
+
+	configureSummaryAPI(t, server.URL)
+
+	_, tokens, err := requestSummary(strings.Repeat("a", 29))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// (29 + 7) / 4 with the summary.
+	if tokens != 9 {
+		t.Errorf(`The token count should be estimated, got %d`, tokens)
+	}
+}
+
+func TestRequestSummaryErrors(t *testing.T) {
+	for name, body := range map[string]string{
+		"no choice":     `{"choices": []}`,
+		"empty summary": `{"choices": [{"message": {"content": "<think>Reasoning only.</think>"}}]}`,
+		"invalid JSON":  `not JSON`,
+	} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(body))
+		}))
+
+		configureSummaryAPI(t, server.URL)
+		if _, _, err := requestSummary("prompt"); err == nil {
+			t.Errorf(`An error should be returned for %s`, name)
+		}
+
+		server.Close()
+	}
+}
+
+func TestRenderPrompt(t *testing.T) {
+	feed := &model.Feed{Title: "Some Feed", Category: &model.Category{Title: "News"}}
+	entry := &model.Entry{Title: "Title", URL: "https://example.org/", Content: "<p>Some <b>bold</b>\n\n text.</p>"}
+
+	prompt, err := renderPrompt("{{ .Feed }}/{{ .Category }}: {{ .Title }} ({{ .URL }}) {{ .Content }}", newPromptData(feed, entry, 100))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := "Some Feed/News: Title (https://example.org/) Some bold text."; prompt != expected {
+		t.Errorf(`Unexpected prompt: got %q instead of %q`, prompt, expected)
+	}
+
+	if _, err := renderPrompt(DefaultPromptTemplate, newPromptData(feed, entry, 100)); err != nil {
+		t.Errorf(`The default prompt template should be valid: %v`, err)
+	}
+
+	if err := ValidatePromptTemplate("{{ .Missing }}"); err == nil {
+		t.Error(`A prompt template with an unknown field should be invalid`)
+	}
+}
+
+func TestTruncateText(t *testing.T) {
+	if text := truncateText("héllo world", 1); text != "héll" {
+		t.Errorf(`Unexpected truncated text: %q`, text)
+	}
+
+	if text := truncateText("short", 10); text != "short" {
+		t.Errorf(`The text should not be truncated: %q`, text)
+	}
+}
@@ -0,0 +1,207 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package search parses the query language used to search entries.
+//
+// A query is a list of space-separated terms. Plain words and "quoted phrases" are matched against the
+// full-text index, and field operators narrow down the results:
+//
+//	feed:name          feed title contains name (or feed ID)
+//	category:name      category title contains name (or category ID)
+//	tag:name           entry has the feed tag or user tag
+//	author:name        author contains name
+//	is:unread          also is:read, is:starred, is:saved and is:shared
+//	vote:+1            also vote:-1 and vote:0
+//	score:>70          also >=, <, <= and =
+//	before:2024-01-31  published before this day
+//	after:2024-01-01   published on or after this day
+//
+// Any term can be negated with a leading dash, for example -tag:sports or -"breaking news".
+// Operator values containing spaces must be quoted, for example feed:"Hacker News".
+// Terms with an unknown field or an invalid value are searched as plain text.
+package search // import "miniflux.app/v2/internal/search"
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Fields supported by the query language.
+const (
+	FieldFeed     = "feed"
+	FieldCategory = "category"
+	FieldTag      = "tag"
+	FieldAuthor   = "author"
+	FieldIs       = "is"
+	FieldVote     = "vote"
+	FieldScore    = "score"
+	FieldBefore   = "before"
+	FieldAfter    = "after"
+)
+
+// Query is a parsed search query.
+type Query struct {
+	// Text is the full-text part of the query, using the web search syntax understood by websearch_to_tsquery.
+	Text    string
+	Filters []*Filter
+}
+
+// Filter is a field operator of the query.
+type Filter struct {
+	Field    string
+	Operator string
+	Value    string
+	Number   int64
+	Date     time.Time
+	Negated  bool
+}
+
+// IsEmpty returns true if the query has neither text nor filters.
+func (q *Query) IsEmpty() bool {
+	return q.Text == "" && len(q.Filters) == 0
+}
+
+type token struct {
+	raw     string
+	negated bool
+	quoted  bool
+	field   string
+	value   string
+}
+
+// Parse parses a search query. It never fails: invalid operators are searched as plain text.
+func Parse(input string) *Query {
+	query := &Query{}
+	var textTerms []string
+
+	for _, tok := range tokenize(input) {
+		if tok.field != "" {
+			if filter := parseFilter(tok); filter != nil {
+				query.Filters = append(query.Filters, filter)
+				continue
+			}
+		}
+
+		term := tok.raw
+		if tok.field == "" && tok.quoted {
+			term = `"` + tok.value + `"`
+		}
+		if tok.negated {
+			term = "-" + term
+		}
+		textTerms = append(textTerms, term)
+	}
+
+	query.Text = strings.Join(textTerms, " ")
+	return query
+}
+
+func parseFilter(tok token) *Filter {
+	filter := &Filter{Field: tok.field, Operator: "=", Value: tok.value, Negated: tok.negated}
+	if filter.Value == "" {
+		return nil
+	}
+
+	switch tok.field {
+	case FieldFeed, FieldCategory, FieldTag, FieldAuthor:
+		if number, err := strconv.ParseInt(filter.Value, 10, 64); err == nil && !tok.quoted {
+			filter.Number = number
+		}
+	case FieldIs:
+		filter.Value = strings.ToLower(filter.Value)
+		switch filter.Value {
+		case "unread", "read", "starred", "saved", "shared":
+		default:
+			return nil
+		}
+	case FieldVote:
+		vote, err := strconv.ParseInt(filter.Value, 10, 64)
+		if err != nil || vote < -1 || vote > 1 {
+			return nil
+		}
+		filter.Number = vote
+	case FieldScore:
+		for _, operator := range []string{">=", "<=", ">", "<", "="} {
+			if value, found := strings.CutPrefix(filter.Value, operator); found {
+				filter.Operator = operator
+				filter.Value = value
+				break
+			}
+		}
+		score, err := strconv.ParseInt(filter.Value, 10, 64)
+		if err != nil {
+			return nil
+		}
+		filter.Number = score
+	case FieldBefore, FieldAfter:
+		date, err := time.Parse("2006-01-02", filter.Value)
+		if err != nil {
+			return nil
+		}
+		filter.Date = date
+	default:
+		return nil
+	}
+
+	return filter
+}
+
+func tokenize(input string) []token {
+	var tokens []token
+	runes := []rune(input)
+
+	for i := 0; i < len(runes); {
+		if isSpace(runes[i]) {
+			i++
+			continue
+		}
+
+		tok := token{}
+		if runes[i] == '-' && i+1 < len(runes) && !isSpace(runes[i+1]) {
+			tok.negated = true
+			i++
+		}
+
+		var raw, value strings.Builder
+		fieldDone := false
+		for i < len(runes) && !isSpace(runes[i]) {
+			switch {
+			case runes[i] == '"':
+				// Quoted strings may contain spaces; an unterminated quote extends to the end of the input.
+				end := i + 1
+				for end < len(runes) && runes[end] != '"' {
+					end++
+				}
+				quoted := string(runes[i+1 : min(end, len(runes))])
+				raw.WriteString(`"` + quoted + `"`)
+				value.WriteString(quoted)
+				tok.quoted = true
+				i = min(end+1, len(runes))
+			case runes[i] == ':' && !fieldDone && !tok.quoted && value.Len() > 0:
+				tok.field = strings.ToLower(value.String())
+				value.Reset()
+				raw.WriteRune(runes[i])
+				fieldDone = true
+				i++
+			default:
+				raw.WriteRune(runes[i])
+				value.WriteRune(runes[i])
+				i++
+			}
+		}
+
+		tok.raw = raw.String()
+		tok.value = value.String()
+		if tok.raw == "" {
+			continue
+		}
+		tokens = append(tokens, tok)
+	}
+
+	return tokens
+}
+
+func isSpace(r rune) bool {
+	return r == ' ' || r == '\t' || r == '\n' || r == '\r'
+}
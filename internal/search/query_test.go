@@ -0,0 +1,120 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package search // import "miniflux.app/v2/internal/search"
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseEmptyQuery(t *testing.T) {
+	if query := Parse("   "); !query.IsEmpty() {
+		t.Errorf(`Expected an empty query, got %+v`, query)
+	}
+}
+
+func TestParsePlainText(t *testing.T) {
+	query := Parse(`golang   generics`)
+	if query.Text != "golang generics" {
+		t.Errorf(`Unexpected text: %q`, query.Text)
+	}
+	if len(query.Filters) != 0 {
+		t.Errorf(`Expected no filters, got %d`, len(query.Filters))
+	}
+}
+
+func TestParsePhrasesAndNegation(t *testing.T) {
+	query := Parse(`"release notes" -beta -"release candidate"`)
+	expected := `"release notes" -beta -"release candidate"`
+	if query.Text != expected {
+		t.Errorf(`Unexpected text: got %q instead of %q`, query.Text, expected)
+	}
+}
+
+func TestParseUnterminatedQuote(t *testing.T) {
+	query := Parse(`"release notes`)
+	if query.Text != `"release notes"` {
+		t.Errorf(`Unexpected text: %q`, query.Text)
+	}
+}
+
+func TestParseFilters(t *testing.T) {
+	query := Parse(`feed:"Hacker News" category:tech tag:go author:rob is:unread is:STARRED vote:+1 score:>70 before:2024-01-31 after:2024-01-01 kubernetes`)
+
+	if query.Text != "kubernetes" {
+		t.Errorf(`Unexpected text: %q`, query.Text)
+	}
+
+	expected := []Filter{
+		{Field: FieldFeed, Operator: "=", Value: "Hacker News"},
+		{Field: FieldCategory, Operator: "=", Value: "tech"},
+		{Field: FieldTag, Operator: "=", Value: "go"},
+		{Field: FieldAuthor, Operator: "=", Value: "rob"},
+		{Field: FieldIs, Operator: "=", Value: "unread"},
+		{Field: FieldIs, Operator: "=", Value: "starred"},
+		{Field: FieldVote, Operator: "=", Value: "+1", Number: 1},
+		{Field: FieldScore, Operator: ">", Value: "70", Number: 70},
+		{Field: FieldBefore, Operator: "=", Value: "2024-01-31", Date: time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC)},
+		{Field: FieldAfter, Operator: "=", Value: "2024-01-01", Date: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	if len(query.Filters) != len(expected) {
+		t.Fatalf(`Expected %d filters, got %d`, len(expected), len(query.Filters))
+	}
+
+	for i, filter := range query.Filters {
+		if *filter != expected[i] {
+			t.Errorf(`Unexpected filter #%d: got %+v instead of %+v`, i, *filter, expected[i])
+		}
+	}
+}
+
+func TestParseNegatedFilter(t *testing.T) {
+	query := Parse(`-tag:sports -is:read`)
+	if len(query.Filters) != 2 || !query.Filters[0].Negated || !query.Filters[1].Negated {
+		t.Fatalf(`Expected two negated filters, got %+v`, query.Filters)
+	}
+	if query.Text != "" {
+		t.Errorf(`Expected no text, got %q`, query.Text)
+	}
+}
+
+func TestParseNumericIdentifiers(t *testing.T) {
+	query := Parse(`feed:42 category:"2024"`)
+	if query.Filters[0].Number != 42 {
+		t.Errorf(`Expected feed ID 42, got %d`, query.Filters[0].Number)
+	}
+	if query.Filters[1].Number != 0 {
+		t.Errorf(`Expected quoted category to be a title, got ID %d`, query.Filters[1].Number)
+	}
+}
+
+func TestParseScoreOperators(t *testing.T) {
+	scenarios := map[string]string{
+		"score:>=5": ">=",
+		"score:<=5": "<=",
+		"score:<5":  "<",
+		"score:=5":  "=",
+		"score:5":   "=",
+	}
+
+	for input, operator := range scenarios {
+		query := Parse(input)
+		if len(query.Filters) != 1 || query.Filters[0].Operator != operator || query.Filters[0].Number != 5 {
+			t.Errorf(`Unexpected filters for %q: %+v`, input, query.Filters)
+		}
+	}
+}
+
+func TestParseInvalidFiltersAreText(t *testing.T) {
+	query := Parse(`https://example.org is:something vote:2 score:high before:yesterday tag:`)
+	if len(query.Filters) != 0 {
+		t.Errorf(`Expected no filters, got %+v`, query.Filters)
+	}
+
+	expected := `https://example.org is:something vote:2 score:high before:yesterday tag:`
+	if query.Text != expected {
+		t.Errorf(`Unexpected text: got %q instead of %q`, query.Text, expected)
+	}
+}
@@ -12,6 +12,32 @@ import (
 	"miniflux.app/v2/internal/model"
 )
 
+const categoryColumns = `id, user_id, title, hide_globally, entry_rules, scraper_rules, rewrite_rules, urlrewrite_rules, blocklist_rules, keeplist_rules, block_filter_entry_rules, keep_filter_entry_rules, crawler, user_agent, fetch_via_proxy, proxy_url, enrichment_processors, summarize_entries, summary_prompt`
+
+func categoryDestinations(category *model.Category) []any {
+	return []any{
+		&category.ID,
+		&category.UserID,
+		&category.Title,
+		&category.HideGlobally,
+		&category.EntryRules,
+		&category.ScraperRules,
+		&category.RewriteRules,
+		&category.UrlRewriteRules,
+		&category.BlocklistRules,
+		&category.KeeplistRules,
+		&category.BlockFilterEntryRules,
+		&category.KeepFilterEntryRules,
+		&category.Crawler,
+		&category.UserAgent,
+		&category.FetchViaProxy,
+		&category.ProxyURL,
+		&category.EnrichmentProcessors,
+		&category.SummarizeEntries,
+		&category.SummaryPrompt,
+	}
+}
+
 // AnotherCategoryExists checks if another category exists with the same title.
 func (s *Storage) AnotherCategoryExists(userID, categoryID int64, title string) bool {
 	var result bool
@@ -40,8 +66,8 @@ func (s *Storage) CategoryIDExists(userID, categoryID int64) bool {
 func (s *Storage) Category(userID, categoryID int64) (*model.Category, error) {
 	var category model.Category
 
-	query := `SELECT id, user_id, title, hide_globally FROM categories WHERE user_id=$1 AND id=$2`
-	err := s.db.QueryRow(query, userID, categoryID).Scan(&category.ID, &category.UserID, &category.Title, &category.HideGlobally)
+	query := `SELECT ` + categoryColumns + ` FROM categories WHERE user_id=$1 AND id=$2`
+	err := s.db.QueryRow(query, userID, categoryID).Scan(categoryDestinations(&category)...)
 
 	switch {
 	case err == sql.ErrNoRows:
@@ -55,10 +81,10 @@ func (s *Storage) Category(userID, categoryID int64) (*model.Category, error) {
 
 // FirstCategory returns the first category for the given user.
 func (s *Storage) FirstCategory(userID int64) (*model.Category, error) {
-	query := `SELECT id, user_id, title, hide_globally FROM categories WHERE user_id=$1 ORDER BY title ASC LIMIT 1`
+	query := `SELECT ` + categoryColumns + ` FROM categories WHERE user_id=$1 ORDER BY title ASC LIMIT 1`
 
 	var category model.Category
-	err := s.db.QueryRow(query, userID).Scan(&category.ID, &category.UserID, &category.Title, &category.HideGlobally)
+	err := s.db.QueryRow(query, userID).Scan(categoryDestinations(&category)...)
 
 	switch {
 	case err == sql.ErrNoRows:
@@ -74,8 +100,8 @@ func (s *Storage) FirstCategory(userID int64) (*model.Category, error) {
 func (s *Storage) CategoryByTitle(userID int64, title string) (*model.Category, error) {
 	var category model.Category
 
-	query := `SELECT id, user_id, title, hide_globally FROM categories WHERE user_id=$1 AND title=$2`
-	err := s.db.QueryRow(query, userID, title).Scan(&category.ID, &category.UserID, &category.Title, &category.HideGlobally)
+	query := `SELECT ` + categoryColumns + ` FROM categories WHERE user_id=$1 AND title=$2`
+	err := s.db.QueryRow(query, userID, title).Scan(categoryDestinations(&category)...)
 
 	switch {
 	case err == sql.ErrNoRows:
@@ -89,7 +115,7 @@ func (s *Storage) CategoryByTitle(userID int64, title string) (*model.Category,
 
 // Categories returns all categories that belongs to the given user.
 func (s *Storage) Categories(userID int64) (model.Categories, error) {
-	query := `SELECT id, user_id, title, hide_globally FROM categories WHERE user_id=$1 ORDER BY title ASC`
+	query := `SELECT ` + categoryColumns + ` FROM categories WHERE user_id=$1 ORDER BY title ASC`
 	rows, err := s.db.Query(query, userID)
 	if err != nil {
 		return nil, fmt.Errorf(`store: unable to fetch categories: %v`, err)
@@ -99,7 +125,7 @@ func (s *Storage) Categories(userID int64) (model.Categories, error) {
 	categories := make(model.Categories, 0)
 	for rows.Next() {
 		var category model.Category
-		if err := rows.Scan(&category.ID, &category.UserID, &category.Title, &category.HideGlobally); err != nil {
+		if err := rows.Scan(categoryDestinations(&category)...); err != nil {
 			return nil, fmt.Errorf(`store: unable to fetch category row: %v`, err)
 		}
 
@@ -113,10 +139,7 @@ func (s *Storage) Categories(userID int64) (model.Categories, error) {
 func (s *Storage) CategoriesWithFeedCount(userID int64, sortOrder string) (model.Categories, error) {
 	query := `
 		SELECT
-			c.id,
-			c.user_id,
-			c.title,
-			c.hide_globally,
+			` + categoryColumns + `,
 			(SELECT count(*) FROM feeds WHERE feeds.category_id=c.id) AS count,
 			(SELECT count(*)
 			   FROM feeds
@@ -149,7 +172,7 @@ func (s *Storage) CategoriesWithFeedCount(userID int64, sortOrder string) (model
 	categories := make(model.Categories, 0)
 	for rows.Next() {
 		var category model.Category
-		if err := rows.Scan(&category.ID, &category.UserID, &category.Title, &category.HideGlobally, &category.FeedCount, &category.TotalUnread); err != nil {
+		if err := rows.Scan(append(categoryDestinations(&category), &category.FeedCount, &category.TotalUnread)...); err != nil {
 			return nil, fmt.Errorf(`store: unable to fetch category row: %v`, err)
 		}
 
@@ -164,27 +187,52 @@ func (s *Storage) CreateCategory(userID int64, request *model.CategoryCreationRe
 	var category model.Category
 
 	query := `
-		INSERT INTO categories
-			(user_id, title, hide_globally)
-		VALUES
-			($1, $2, $3)
-		RETURNING
-			id,
+		INSERT INTO categories (
 			user_id,
 			title,
-			hide_globally
+			hide_globally,
+			entry_rules,
+			scraper_rules,
+			rewrite_rules,
+			urlrewrite_rules,
+			blocklist_rules,
+			keeplist_rules,
+			block_filter_entry_rules,
+			keep_filter_entry_rules,
+			crawler,
+			user_agent,
+			fetch_via_proxy,
+			proxy_url,
+			enrichment_processors,
+			summarize_entries,
+			summary_prompt
+		)
+		VALUES
+			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
+		RETURNING
+			` + categoryColumns + `
 	`
 	err := s.db.QueryRow(
 		query,
 		userID,
 		request.Title,
 		request.HideGlobally,
-	).Scan(
-		&category.ID,
-		&category.UserID,
-		&category.Title,
-		&category.HideGlobally,
-	)
+		request.EntryRules,
+		request.ScraperRules,
+		request.RewriteRules,
+		request.UrlRewriteRules,
+		request.BlocklistRules,
+		request.KeeplistRules,
+		request.BlockFilterEntryRules,
+		request.KeepFilterEntryRules,
+		request.Crawler,
+		request.UserAgent,
+		request.FetchViaProxy,
+		request.ProxyURL,
+		request.EnrichmentProcessors,
+		request.SummarizeEntries,
+		request.SummaryPrompt,
+	).Scan(categoryDestinations(&category)...)
 
 	if err != nil {
 		return nil, fmt.Errorf(`store: unable to create category %q for user ID %d: %v`, request.Title, userID, err)
@@ -195,11 +243,49 @@ func (s *Storage) CreateCategory(userID int64, request *model.CategoryCreationRe
 
 // UpdateCategory updates an existing category.
 func (s *Storage) UpdateCategory(category *model.Category) error {
-	query := `UPDATE categories SET title=$1, hide_globally=$2 WHERE id=$3 AND user_id=$4`
+	query := `
+		UPDATE
+			categories
+		SET
+			title=$1,
+			hide_globally=$2,
+			entry_rules=$3,
+			scraper_rules=$4,
+			rewrite_rules=$5,
+			urlrewrite_rules=$6,
+			blocklist_rules=$7,
+			keeplist_rules=$8,
+			block_filter_entry_rules=$9,
+			keep_filter_entry_rules=$10,
+			crawler=$11,
+			user_agent=$12,
+			fetch_via_proxy=$13,
+			proxy_url=$14,
+			enrichment_processors=$15,
+			summarize_entries=$16,
+			summary_prompt=$17
+		WHERE
+			id=$18 AND user_id=$19
+	`
 	_, err := s.db.Exec(
 		query,
 		category.Title,
 		category.HideGlobally,
+		category.EntryRules,
+		category.ScraperRules,
+		category.RewriteRules,
+		category.UrlRewriteRules,
+		category.BlocklistRules,
+		category.KeeplistRules,
+		category.BlockFilterEntryRules,
+		category.KeepFilterEntryRules,
+		category.Crawler,
+		category.UserAgent,
+		category.FetchViaProxy,
+		category.ProxyURL,
+		category.EnrichmentProcessors,
+		category.SummarizeEntries,
+		category.SummaryPrompt,
 		category.ID,
 		category.UserID,
 	)
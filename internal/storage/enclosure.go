@@ -23,7 +23,11 @@ func (s *Storage) GetEnclosures(entryID int64) (model.EnclosureList, error) {
 			url,
 			size,
 			mime_type,
-		    media_progression
+		    media_progression,
+			EXISTS(SELECT 1 FROM enclosure_mirrors m WHERE m.enclosure_id=enclosures.id AND m.error_msg='' AND m.evicted='f'),
+			thumbnail_url,
+			duration,
+			channel_name
 		FROM
 			enclosures
 		WHERE
@@ -48,6 +52,10 @@ func (s *Storage) GetEnclosures(entryID int64) (model.EnclosureList, error) {
 			&enclosure.Size,
 			&enclosure.MimeType,
 			&enclosure.MediaProgression,
+			&enclosure.Mirrored,
+			&enclosure.ThumbnailURL,
+			&enclosure.Duration,
+			&enclosure.ChannelName,
 		)
 
 		if err != nil {
@@ -69,7 +77,11 @@ func (s *Storage) GetEnclosuresForEntries(entryIDs []int64) (map[int64]model.Enc
 			url,
 			size,
 			mime_type,
-		    media_progression
+		    media_progression,
+			EXISTS(SELECT 1 FROM enclosure_mirrors m WHERE m.enclosure_id=enclosures.id AND m.error_msg='' AND m.evicted='f'),
+			thumbnail_url,
+			duration,
+			channel_name
 		FROM
 			enclosures
 		WHERE
@@ -94,6 +106,10 @@ func (s *Storage) GetEnclosuresForEntries(entryIDs []int64) (map[int64]model.Enc
 			&enclosure.Size,
 			&enclosure.MimeType,
 			&enclosure.MediaProgression,
+			&enclosure.Mirrored,
+			&enclosure.ThumbnailURL,
+			&enclosure.Duration,
+			&enclosure.ChannelName,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("store: unable to scan enclosure row: %w", err)
@@ -114,7 +130,11 @@ func (s *Storage) GetEnclosure(enclosureID int64) (*model.Enclosure, error) {
 			url,
 			size,
 			mime_type,
-		    media_progression
+		    media_progression,
+			EXISTS(SELECT 1 FROM enclosure_mirrors m WHERE m.enclosure_id=enclosures.id AND m.error_msg='' AND m.evicted='f'),
+			thumbnail_url,
+			duration,
+			channel_name
 		FROM
 			enclosures
 		WHERE
@@ -133,6 +153,10 @@ func (s *Storage) GetEnclosure(enclosureID int64) (*model.Enclosure, error) {
 		&enclosure.Size,
 		&enclosure.MimeType,
 		&enclosure.MediaProgression,
+		&enclosure.Mirrored,
+		&enclosure.ThumbnailURL,
+		&enclosure.Duration,
+		&enclosure.ChannelName,
 	)
 
 	if err == sql.ErrNoRows {
@@ -144,6 +168,47 @@ func (s *Storage) GetEnclosure(enclosureID int64) (*model.Enclosure, error) {
 	return &enclosure, nil
 }
 
+// VideoEnclosure returns the enclosure describing the video of a stored entry, if any.
+func (s *Storage) VideoEnclosure(feedID int64, entryHash string) (*model.Enclosure, error) {
+	query := `
+		SELECT
+			e.url,
+			e.size,
+			e.mime_type,
+			e.thumbnail_url,
+			e.duration,
+			e.channel_name
+		FROM
+			enclosures e
+		JOIN
+			entries ON entries.id=e.entry_id
+		WHERE
+			entries.feed_id=$1 AND
+			entries.hash=$2 AND
+			(e.thumbnail_url <> '' OR e.duration > 0 OR e.channel_name <> '')
+		ORDER BY e.id ASC
+		LIMIT 1
+	`
+
+	var enclosure model.Enclosure
+	err := s.db.QueryRow(query, feedID, entryHash).Scan(
+		&enclosure.URL,
+		&enclosure.Size,
+		&enclosure.MimeType,
+		&enclosure.ThumbnailURL,
+		&enclosure.Duration,
+		&enclosure.ChannelName,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch video enclosure: %v`, err)
+	}
+
+	return &enclosure, nil
+}
+
 func (s *Storage) createEnclosure(tx *sql.Tx, enclosure *model.Enclosure) error {
 	enclosureURL := strings.TrimSpace(enclosure.URL)
 	if enclosureURL == "" {
@@ -152,9 +217,9 @@ func (s *Storage) createEnclosure(tx *sql.Tx, enclosure *model.Enclosure) error
 
 	query := `
 		INSERT INTO enclosures
-			(url, size, mime_type, entry_id, user_id, media_progression)
+			(url, size, mime_type, entry_id, user_id, media_progression, thumbnail_url, duration, channel_name, transcript)
 		VALUES
-			($1, $2, $3, $4, $5, $6)
+			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 		ON CONFLICT (user_id, entry_id, md5(url)) DO NOTHING
 		RETURNING
 			id
@@ -167,6 +232,10 @@ func (s *Storage) createEnclosure(tx *sql.Tx, enclosure *model.Enclosure) error
 		enclosure.EntryID,
 		enclosure.UserID,
 		enclosure.MediaProgression,
+		enclosure.ThumbnailURL,
+		enclosure.Duration,
+		enclosure.ChannelName,
+		enclosure.Transcript,
 	).Scan(&enclosure.ID); err != nil && err != sql.ErrNoRows {
 		return fmt.Errorf(`store: unable to create enclosure: %w`, err)
 	}
@@ -0,0 +1,287 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"miniflux.app/v2/internal/model"
+
+	"github.com/lib/pq"
+)
+
+// SaveEnclosureMirror creates or replaces the local copy of an enclosure.
+func (s *Storage) SaveEnclosureMirror(mirror *model.EnclosureMirror) error {
+	query := `
+		INSERT INTO enclosure_mirrors
+			(enclosure_id, user_id, mime_type, size, error_msg, evicted, created_at)
+		VALUES
+			($1, $2, $3, $4, $5, 'f', now())
+		ON CONFLICT (enclosure_id) DO UPDATE SET
+			mime_type=EXCLUDED.mime_type,
+			size=EXCLUDED.size,
+			error_msg=EXCLUDED.error_msg,
+			evicted=EXCLUDED.evicted,
+			created_at=EXCLUDED.created_at
+		RETURNING
+			created_at
+	`
+	err := s.db.QueryRow(
+		query,
+		mirror.EnclosureID,
+		mirror.UserID,
+		mirror.MimeType,
+		mirror.Size,
+		mirror.Error,
+	).Scan(&mirror.CreatedAt)
+	if err != nil {
+		return fmt.Errorf(`store: unable to save mirror of enclosure #%d: %v`, mirror.EnclosureID, err)
+	}
+
+	return nil
+}
+
+// EnclosureMirror returns the local copy of an enclosure, or nil when the enclosure is not mirrored.
+func (s *Storage) EnclosureMirror(enclosureID int64) (*model.EnclosureMirror, error) {
+	query := `
+		SELECT
+			enclosure_id, user_id, mime_type, size, error_msg, evicted, created_at
+		FROM
+			enclosure_mirrors
+		WHERE
+			enclosure_id=$1 AND error_msg='' AND evicted='f'
+	`
+
+	var mirror model.EnclosureMirror
+	err := s.db.QueryRow(query, enclosureID).Scan(
+		&mirror.EnclosureID,
+		&mirror.UserID,
+		&mirror.MimeType,
+		&mirror.Size,
+		&mirror.Error,
+		&mirror.Evicted,
+		&mirror.CreatedAt,
+	)
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf(`store: unable to fetch mirror of enclosure #%d: %v`, enclosureID, err)
+	}
+
+	return &mirror, nil
+}
+
+// EntriesWithEnclosuresToMirror returns the entries of the feeds with enclosure mirroring enabled,
+// with their audio, video and PDF enclosures that are not downloaded yet.
+//
+// The enclosures of starred entries are always downloaded, the others only for the most recent
+// entries within the limit of the feed. Failed downloads are retried after the retry interval.
+func (s *Storage) EntriesWithEnclosuresToMirror(limit int) (model.Entries, error) {
+	query := `
+		WITH candidates AS (
+			SELECT
+				en.id,
+				en.user_id,
+				en.entry_id,
+				en.url,
+				en.mime_type,
+				en.size,
+				e.feed_id,
+				e.starred,
+				f.mirror_enclosures_limit,
+				dense_rank() OVER (PARTITION BY e.feed_id, e.starred ORDER BY e.published_at DESC, e.id DESC) AS entry_rank
+			FROM
+				enclosures en
+			JOIN
+				entries e ON e.id=en.entry_id
+			JOIN
+				feeds f ON f.id=e.feed_id
+			WHERE
+				f.mirror_enclosures='t' AND
+				en.url <> '' AND
+				(en.mime_type ILIKE 'audio/%' OR en.mime_type ILIKE 'video/%' OR en.mime_type ILIKE 'application/pdf')
+		)
+		SELECT
+			c.id, c.user_id, c.entry_id, c.url, c.mime_type, c.size, c.feed_id
+		FROM
+			candidates c
+		LEFT JOIN
+			enclosure_mirrors m ON m.enclosure_id=c.id
+		WHERE
+			(c.starred OR c.mirror_enclosures_limit = 0 OR c.entry_rank <= c.mirror_enclosures_limit) AND
+			(m.enclosure_id IS NULL OR (m.error_msg <> '' AND m.evicted='f' AND m.created_at < now() - $1::interval))
+		ORDER BY
+			c.starred DESC, c.entry_rank ASC
+		LIMIT $2
+	`
+
+	rows, err := s.db.Query(
+		query,
+		fmt.Sprintf("%d seconds", int(model.EnclosureMirrorRetryInterval.Seconds())),
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch enclosures to mirror: %v`, err)
+	}
+	defer rows.Close()
+
+	entries := make(model.Entries, 0)
+	entriesByID := make(map[int64]*model.Entry)
+	for rows.Next() {
+		var enclosure model.Enclosure
+		var feedID int64
+		err := rows.Scan(
+			&enclosure.ID,
+			&enclosure.UserID,
+			&enclosure.EntryID,
+			&enclosure.URL,
+			&enclosure.MimeType,
+			&enclosure.Size,
+			&feedID,
+		)
+		if err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch enclosure to mirror: %v`, err)
+		}
+
+		entry, found := entriesByID[enclosure.EntryID]
+		if !found {
+			entry = &model.Entry{ID: enclosure.EntryID, UserID: enclosure.UserID, FeedID: feedID}
+			entriesByID[entry.ID] = entry
+			entries = append(entries, entry)
+		}
+		entry.Enclosures = append(entry.Enclosures, &enclosure)
+	}
+
+	return entries, nil
+}
+
+// EnclosureMirrorsToRemove returns the downloaded enclosures exceeding the retention limits:
+// the enclosures of feeds with enclosure mirroring disabled, and of the entries over the limit of their feed. Starred entries are exempt from the feed limit.
+//
+// When maxSizePerUser is greater than zero, the oldest enclosures exceeding the storage limit of their user
+// are returned as well, with Evicted set to true. The enclosures of starred entries are evicted last.
+//
+// When userID is greater than zero, only the enclosures of this user are returned.
+func (s *Storage) EnclosureMirrorsToRemove(userID, maxSizePerUser int64) ([]*model.EnclosureMirror, error) {
+	query := `
+		WITH mirrors AS (
+			SELECT
+				m.enclosure_id,
+				m.user_id,
+				m.size,
+				f.mirror_enclosures,
+				f.mirror_enclosures_limit,
+				e.starred,
+				dense_rank() OVER (PARTITION BY e.feed_id, e.starred ORDER BY e.published_at DESC, e.id DESC) AS entry_rank,
+				sum(m.size) OVER (PARTITION BY m.user_id ORDER BY e.starred DESC, e.published_at DESC, e.id DESC, m.enclosure_id DESC) AS cumulative_size
+			FROM
+				enclosure_mirrors m
+			JOIN
+				enclosures en ON en.id=m.enclosure_id
+			JOIN
+				entries e ON e.id=en.entry_id
+			JOIN
+				feeds f ON f.id=e.feed_id
+			WHERE
+				m.error_msg='' AND m.evicted='f' AND ($2::bigint = 0 OR m.user_id=$2::bigint)
+		)
+		SELECT
+			enclosure_id,
+			user_id,
+			size,
+			NOT (
+				mirror_enclosures='f' OR
+				(NOT starred AND mirror_enclosures_limit > 0 AND entry_rank > mirror_enclosures_limit)
+			) AS evicted
+		FROM
+			mirrors
+		WHERE
+			mirror_enclosures='f' OR
+			(NOT starred AND mirror_enclosures_limit > 0 AND entry_rank > mirror_enclosures_limit) OR
+			($1::bigint > 0 AND cumulative_size > $1::bigint)
+	`
+
+	rows, err := s.db.Query(query, maxSizePerUser, userID)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch enclosure mirrors to remove: %v`, err)
+	}
+	defer rows.Close()
+
+	mirrors := make([]*model.EnclosureMirror, 0)
+	for rows.Next() {
+		var mirror model.EnclosureMirror
+		if err := rows.Scan(&mirror.EnclosureID, &mirror.UserID, &mirror.Size, &mirror.Evicted); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch enclosure mirror to remove: %v`, err)
+		}
+		mirrors = append(mirrors, &mirror)
+	}
+
+	return mirrors, nil
+}
+
+// RemoveEnclosureMirrors deletes the local copy of the given enclosures.
+// The enclosures can be downloaded again if they come back within the retention limits.
+func (s *Storage) RemoveEnclosureMirrors(enclosureIDs []int64) error {
+	_, err := s.db.Exec(`DELETE FROM enclosure_mirrors WHERE enclosure_id = ANY($1)`, pq.Array(enclosureIDs))
+	if err != nil {
+		return fmt.Errorf(`store: unable to remove enclosure mirrors: %v`, err)
+	}
+	return nil
+}
+
+// EvictEnclosureMirrors marks the given enclosures as removed to stay under the storage limit,
+// so they are not downloaded again until their entry is starred.
+func (s *Storage) EvictEnclosureMirrors(enclosureIDs []int64) error {
+	_, err := s.db.Exec(`UPDATE enclosure_mirrors SET evicted='t', size=0 WHERE enclosure_id = ANY($1)`, pq.Array(enclosureIDs))
+	if err != nil {
+		return fmt.Errorf(`store: unable to evict enclosure mirrors: %v`, err)
+	}
+	return nil
+}
+
+// resetEvictedEnclosureMirrors forgets the evicted enclosures of the given starred entries,
+// so they are downloaded again.
+func (s *Storage) resetEvictedEnclosureMirrors(userID int64, entryIDs []int64) error {
+	query := `
+		DELETE FROM
+			enclosure_mirrors
+		WHERE
+			evicted='t' AND
+			enclosure_id IN (
+				SELECT en.id FROM enclosures en JOIN entries e ON e.id=en.entry_id
+				WHERE e.user_id=$1 AND e.id=ANY($2) AND e.starred='t'
+			)
+	`
+	if _, err := s.db.Exec(query, userID, pq.Array(entryIDs)); err != nil {
+		return fmt.Errorf(`store: unable to reset evicted enclosure mirrors: %v`, err)
+	}
+	return nil
+}
+
+// MirroredEnclosureIDs returns the IDs of the enclosures with a local copy, grouped by user.
+func (s *Storage) MirroredEnclosureIDs() (map[int64]map[int64]bool, error) {
+	rows, err := s.db.Query(`SELECT user_id, enclosure_id FROM enclosure_mirrors WHERE error_msg='' AND evicted='f'`)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch mirrored enclosures: %v`, err)
+	}
+	defer rows.Close()
+
+	enclosureIDs := make(map[int64]map[int64]bool)
+	for rows.Next() {
+		var userID, enclosureID int64
+		if err := rows.Scan(&userID, &enclosureID); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch mirrored enclosure: %v`, err)
+		}
+		if enclosureIDs[userID] == nil {
+			enclosureIDs[userID] = make(map[int64]bool)
+		}
+		enclosureIDs[userID][enclosureID] = true
+	}
+
+	return enclosureIDs, nil
+}
@@ -0,0 +1,155 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"fmt"
+
+	"miniflux.app/v2/internal/model"
+
+	"github.com/lib/pq"
+)
+
+// SaveEnclosureText creates or replaces the text extracted from an enclosure.
+// The text of all the enclosures of the entry is copied to the entry and added to its search index.
+func (s *Storage) SaveEnclosureText(enclosureText *model.EnclosureText) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf(`store: unable to start transaction: %v`, err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO enclosure_texts
+			(enclosure_id, user_id, text_content, error_msg, created_at)
+		VALUES
+			($1, $2, $3, $4, now())
+		ON CONFLICT (enclosure_id) DO UPDATE SET
+			text_content=EXCLUDED.text_content,
+			error_msg=EXCLUDED.error_msg,
+			created_at=EXCLUDED.created_at
+		RETURNING
+			created_at
+	`
+	err = tx.QueryRow(
+		query,
+		enclosureText.EnclosureID,
+		enclosureText.UserID,
+		enclosureText.Text,
+		enclosureText.Error,
+	).Scan(&enclosureText.CreatedAt)
+	if err != nil {
+		return fmt.Errorf(`store: unable to save text of enclosure #%d: %v`, enclosureText.EnclosureID, err)
+	}
+
+	if enclosureText.Text != "" {
+		var language string
+		query = `SELECT e.language FROM entries e JOIN enclosures en ON en.entry_id=e.id WHERE en.id=$1`
+		if err := tx.QueryRow(query, enclosureText.EnclosureID).Scan(&language); err != nil {
+			return fmt.Errorf(`store: unable to fetch entry of enclosure #%d: %v`, enclosureText.EnclosureID, err)
+		}
+
+		query = `
+			UPDATE
+				entries e
+			SET
+				attachment_text = t.text_content,
+				document_vectors = ` + documentVectorsExpression(
+			"$2",
+			"substring(e.title for 200000)",
+			"substring(coalesce(e.content, '') for 500000)",
+			"e.summary",
+			"t.text_content",
+		) + `
+			FROM (
+				SELECT
+					en.entry_id,
+					string_agg(et.text_content, E'\n' ORDER BY en.id) AS text_content
+				FROM
+					enclosures en
+				JOIN
+					enclosure_texts et ON et.enclosure_id=en.id
+				WHERE
+					en.entry_id=(SELECT entry_id FROM enclosures WHERE id=$1) AND et.text_content <> ''
+				GROUP BY
+					en.entry_id
+			) t
+			WHERE
+				e.id=t.entry_id
+		`
+		if _, err := tx.Exec(query, enclosureText.EnclosureID, textSearchConfig(language)); err != nil {
+			return fmt.Errorf(`store: unable to index text of enclosure #%d: %v`, enclosureText.EnclosureID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf(`store: unable to commit transaction: %v`, err)
+	}
+
+	return nil
+}
+
+// EntriesWithEnclosuresToExtract returns the most recent entries with enclosures of the given MIME types
+// whose text is not extracted yet. Only the PDF files and the podcast transcripts are extracted,
+// the other text files being usually web pages. Failed extractions are retried after the retry interval.
+func (s *Storage) EntriesWithEnclosuresToExtract(mimeTypes []string, limit int) (model.Entries, error) {
+	query := `
+		SELECT
+			en.id, en.user_id, en.entry_id, en.url, en.mime_type, en.size, e.feed_id
+		FROM
+			enclosures en
+		JOIN
+			entries e ON e.id=en.entry_id
+		LEFT JOIN
+			enclosure_texts t ON t.enclosure_id=en.id
+		WHERE
+			en.url <> '' AND
+			(en.transcript OR lower(trim(split_part(en.mime_type, ';', 1))) = 'application/pdf') AND
+			lower(trim(split_part(en.mime_type, ';', 1))) = ANY($1) AND
+			(t.enclosure_id IS NULL OR (t.error_msg <> '' AND t.created_at < now() - $2::interval))
+		ORDER BY
+			e.published_at DESC, e.id DESC
+		LIMIT $3
+	`
+
+	rows, err := s.db.Query(
+		query,
+		pq.Array(mimeTypes),
+		fmt.Sprintf("%d seconds", int(model.EnclosureTextRetryInterval.Seconds())),
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch enclosures to extract: %v`, err)
+	}
+	defer rows.Close()
+
+	entries := make(model.Entries, 0)
+	entriesByID := make(map[int64]*model.Entry)
+	for rows.Next() {
+		var enclosure model.Enclosure
+		var feedID int64
+		err := rows.Scan(
+			&enclosure.ID,
+			&enclosure.UserID,
+			&enclosure.EntryID,
+			&enclosure.URL,
+			&enclosure.MimeType,
+			&enclosure.Size,
+			&feedID,
+		)
+		if err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch enclosure to extract: %v`, err)
+		}
+
+		entry, found := entriesByID[enclosure.EntryID]
+		if !found {
+			entry = &model.Entry{ID: enclosure.EntryID, UserID: enclosure.UserID, FeedID: feedID}
+			entriesByID[entry.ID] = entry
+			entries = append(entries, entry)
+		}
+		entry.Enclosures = append(entry.Enclosures, &enclosure)
+	}
+
+	return entries, nil
+}
@@ -99,7 +99,7 @@ func (s *Storage) UpdateEntryTitleAndContent(entry *model.Entry) error {
 			title=$1,
 			content=$2,
 			reading_time=$3,
-			document_vectors = setweight(to_tsvector($4), 'A') || setweight(to_tsvector($5), 'B')
+			document_vectors = ` + documentVectorsExpression("$8", "$4", "$5", "summary", "attachment_text") + `
 		WHERE
 			id=$6 AND user_id=$7
 	`
@@ -112,13 +112,43 @@ func (s *Storage) UpdateEntryTitleAndContent(entry *model.Entry) error {
 		truncatedTitle,
 		truncatedContent,
 		entry.ID,
-		entry.UserID); err != nil {
+		entry.UserID,
+		textSearchConfig(entry.Language)); err != nil {
 		return fmt.Errorf(`store: unable to update entry #%d: %v`, entry.ID, err)
 	}
 
 	return nil
 }
 
+// UpdateEntrySummary updates the summary of an entry and its search index.
+// The entry is no longer waiting for a summary.
+func (s *Storage) UpdateEntrySummary(entry *model.Entry) error {
+	truncatedTitle, truncatedContent := truncateTitleAndContentForTSVectorField(entry.Title, entry.Content)
+	query := `
+		UPDATE
+			entries
+		SET
+			summary=$1,
+			summary_pending='f',
+			document_vectors = ` + documentVectorsExpression("$6", "$2", "$3", "$1", "attachment_text") + `
+		WHERE
+			id=$4 AND user_id=$5
+	`
+
+	if _, err := s.db.Exec(
+		query,
+		entry.Summary,
+		truncatedTitle,
+		truncatedContent,
+		entry.ID,
+		entry.UserID,
+		textSearchConfig(entry.Language)); err != nil {
+		return fmt.Errorf(`store: unable to update the summary of entry #%d: %v`, entry.ID, err)
+	}
+
+	return nil
+}
+
 // createEntry add a new entry.
 func (s *Storage) createEntry(tx *sql.Tx, entry *model.Entry) error {
 	truncatedTitle, truncatedContent := truncateTitleAndContentForTSVectorField(entry.Title, entry.Content)
@@ -140,7 +170,14 @@ func (s *Storage) createEntry(tx *sql.Tx, entry *model.Entry) error {
 				reading_time,
 				changed_at,
 				document_vectors,
-				tags
+				tags,
+				fingerprint,
+				status,
+				starred,
+				score,
+				vote,
+				summary,
+				language
 			)
 		SELECT
 			$1,
@@ -154,8 +191,15 @@ func (s *Storage) createEntry(tx *sql.Tx, entry *model.Entry) error {
 			$9,
 			$10,
 			now(),
-			setweight(to_tsvector($11), 'A') || setweight(to_tsvector($12), 'B'),
-			$13
+			` + documentVectorsExpression("$21", "$11", "$12", "$19", "''") + `,
+			$13,
+			$14,
+			$15,
+			$16,
+			$17,
+			$18,
+			$19,
+			$20
 		WHERE NOT EXISTS (
 			SELECT 1 FROM entry_tombstones WHERE feed_id=$9 AND hash=$2
 		)
@@ -177,6 +221,14 @@ func (s *Storage) createEntry(tx *sql.Tx, entry *model.Entry) error {
 		truncatedTitle,
 		truncatedContent,
 		pq.Array(entry.Tags),
+		entryFingerprint(entry),
+		entryStatusOrDefault(entry),
+		entry.Starred,
+		entry.Score,
+		entry.Vote,
+		entry.Summary,
+		entry.Language,
+		textSearchConfig(entry.Language),
 	).Scan(
 		&entry.ID,
 		&entry.Status,
@@ -205,11 +257,13 @@ func (s *Storage) createEntry(tx *sql.Tx, entry *model.Entry) error {
 // updateEntry updates an entry when a feed is refreshed.
 // Note: we do not update the published date because some feeds do not contains any date,
 // it default to time.Now() which could change the order of items on the history page.
-func (s *Storage) updateEntry(tx *sql.Tx, entry *model.Entry) error {
+func (s *Storage) updateEntry(tx *sql.Tx, entry *model.Entry, markUnreadOnRevision bool) error {
+	// The previous title and content are returned for the revisions, the stored language is kept
+	// when the language of the entry is unknown, and the stored summary unless it has been replaced.
 	truncatedTitle, truncatedContent := truncateTitleAndContentForTSVectorField(entry.Title, entry.Content)
 	query := `
 		UPDATE
-			entries
+			entries e
 		SET
 			title=$1,
 			url=$2,
@@ -217,13 +271,29 @@ func (s *Storage) updateEntry(tx *sql.Tx, entry *model.Entry) error {
 			content=$4,
 			author=$5,
 			reading_time=$6,
-			document_vectors = setweight(to_tsvector($7), 'A') || setweight(to_tsvector($8), 'B'),
-			tags=$12
+			document_vectors = ` + documentVectorsExpression(
+		textSearchConfigExpression("COALESCE(NULLIF($15, ''), p.language)"),
+		"$7",
+		"$8",
+		"CASE WHEN $16 THEN $14 ELSE e.summary END",
+		"e.attachment_text",
+	) + `,
+			tags=$12,
+			fingerprint=$13,
+			summary=CASE WHEN $16 THEN $14 ELSE e.summary END,
+			language=COALESCE(NULLIF($15, ''), p.language)
+		FROM (
+			SELECT id, title, content, language
+			FROM entries
+			WHERE user_id=$9 AND feed_id=$10 AND hash=$11
+			FOR UPDATE
+		) p
 		WHERE
-			user_id=$9 AND feed_id=$10 AND hash=$11
+			e.id=p.id
 		RETURNING
-			id
+			e.id, p.title, p.content
 	`
+	var previousTitle, previousContent string
 	err := tx.QueryRow(
 		query,
 		entry.Title,
@@ -238,11 +308,19 @@ func (s *Storage) updateEntry(tx *sql.Tx, entry *model.Entry) error {
 		entry.FeedID,
 		entry.Hash,
 		pq.Array(entry.Tags),
-	).Scan(&entry.ID)
+		entryFingerprint(entry),
+		entry.Summary,
+		entry.Language,
+		entry.SummaryUpdated,
+	).Scan(&entry.ID, &previousTitle, &previousContent)
 	if err != nil {
 		return fmt.Errorf(`store: unable to update entry %q: %v`, entry.URL, err)
 	}
 
+	if err := s.recordEntryRevision(tx, entry, previousTitle, previousContent, markUnreadOnRevision); err != nil {
+		return err
+	}
+
 	for _, enclosure := range entry.Enclosures {
 		enclosure.UserID = entry.UserID
 		enclosure.EntryID = entry.ID
@@ -251,6 +329,19 @@ func (s *Storage) updateEntry(tx *sql.Tx, entry *model.Entry) error {
 	return s.updateEnclosures(tx, entry)
 }
 
+// entryFingerprint returns the entry fingerprint as a nullable signed integer, the PostgreSQL bigint type being signed.
+func entryFingerprint(entry *model.Entry) sql.NullInt64 {
+	return sql.NullInt64{Int64: int64(entry.Fingerprint), Valid: entry.Fingerprint != 0}
+}
+
+// entryStatusOrDefault returns the status of a new entry, entries are unread unless the processor decided otherwise.
+func entryStatusOrDefault(entry *model.Entry) string {
+	if entry.Status == "" {
+		return model.EntryStatusUnread
+	}
+	return entry.Status
+}
+
 // entryExists checks if an entry already exists based on its hash when refreshing a feed.
 func (s *Storage) entryExists(tx *sql.Tx, entry *model.Entry) (bool, error) {
 	var result bool
@@ -354,7 +445,8 @@ func (s *Storage) GetReadTime(feedID int64, entryHash string) int {
 }
 
 // RefreshFeedEntries updates feed entries while refreshing a feed.
-func (s *Storage) RefreshFeedEntries(userID, feedID int64, entries model.Entries, updateExistingEntries bool) (newEntries model.Entries, err error) {
+// When markUnreadOnRevision is true, existing entries whose text changed substantially are marked as unread again.
+func (s *Storage) RefreshFeedEntries(userID, feedID int64, entries model.Entries, updateExistingEntries, markUnreadOnRevision bool) (newEntries model.Entries, err error) {
 	for _, entry := range entries {
 		entry.UserID = userID
 		entry.FeedID = feedID
@@ -374,7 +466,7 @@ func (s *Storage) RefreshFeedEntries(userID, feedID int64, entries model.Entries
 
 		if entryExists {
 			if updateExistingEntries {
-				err = s.updateEntry(tx, entry)
+				err = s.updateEntry(tx, entry, markUnreadOnRevision)
 			}
 		} else {
 			err = s.createEntry(tx, entry)
@@ -447,20 +539,40 @@ func (s *Storage) ArchiveEntries(status string, interval time.Duration, limit in
 }
 
 // SetEntriesStatus update the status of the given list of entries.
+// The changes of several entries at once are recorded as bulk changes.
 func (s *Storage) SetEntriesStatus(userID int64, entryIDs []int64, status string) error {
+	return s.setEntriesStatus(userID, entryIDs, status, true)
+}
+
+// MarkBlockedEntriesAsRead marks as read the entries blocked by the rules of the user.
+// No status event is recorded, the entries were not read by the user.
+func (s *Storage) MarkBlockedEntriesAsRead(userID int64, entryIDs []int64) error {
+	return s.setEntriesStatus(userID, entryIDs, model.EntryStatusRead, false)
+}
+
+func (s *Storage) setEntriesStatus(userID int64, entryIDs []int64, status string, recordEvents bool) error {
 	clearSavedForLater := status == model.EntryStatusRead
 	query := `
-		UPDATE
-			entries
-		SET
-			status=$1::entry_status,
-			saved_for_later=CASE WHEN $4 THEN false ELSE saved_for_later END,
-			changed_at=now()
-		WHERE
-			user_id=$2 AND
-			id=ANY($3)
+		WITH previous AS (
+			SELECT id, status
+			FROM entries
+			WHERE user_id=$2 AND id=ANY($3)
+			FOR UPDATE
+		), updated AS (
+			UPDATE entries e
+			SET
+				status=$1::entry_status,
+				saved_for_later=CASE WHEN $4 THEN false ELSE e.saved_for_later END,
+				read_at=CASE WHEN $1::entry_status='read' THEN now() ELSE e.read_at END,
+				changed_at=now()
+			FROM previous p
+			WHERE e.id=p.id
+			RETURNING e.id, e.user_id, e.feed_id, e.reading_time, e.status, p.status AS previous_status
+		)
+		INSERT INTO entry_status_events (user_id, entry_id, feed_id, status, reading_time, bulk)
+		SELECT user_id, id, feed_id, status, reading_time, cardinality($3) > 1 FROM updated WHERE $5 AND status <> previous_status
 		`
-	if _, err := s.db.Exec(query, status, userID, pq.Array(entryIDs), clearSavedForLater); err != nil {
+	if _, err := s.db.Exec(query, status, userID, pq.Array(entryIDs), clearSavedForLater, recordEvents); err != nil {
 		return fmt.Errorf(`store: unable to update entries statuses %v: %v`, entryIDs, err)
 	}
 
@@ -468,19 +580,28 @@ func (s *Storage) SetEntriesStatus(userID int64, entryIDs []int64, status string
 }
 
 // SetEntriesStatusAndCountVisible updates the status of the given entries and returns how many are visible in global views.
+// The changes of several entries at once are recorded as bulk changes.
 func (s *Storage) SetEntriesStatusAndCountVisible(userID int64, entryIDs []int64, status string) (int, error) {
 	clearSavedForLater := status == model.EntryStatusRead
 	query := `
-		WITH updated AS (
-			UPDATE entries
+		WITH previous AS (
+			SELECT id, status
+			FROM entries
+			WHERE user_id=$2 AND id=ANY($3)
+			FOR UPDATE
+		), updated AS (
+			UPDATE entries e
 			SET
 				status=$1::entry_status,
-				saved_for_later=CASE WHEN $4 THEN false ELSE saved_for_later END,
+				saved_for_later=CASE WHEN $4 THEN false ELSE e.saved_for_later END,
+				read_at=CASE WHEN $1::entry_status='read' THEN now() ELSE e.read_at END,
 				changed_at=now()
-			WHERE
-				user_id=$2 AND
-				id=ANY($3)
-			RETURNING feed_id
+			FROM previous p
+			WHERE e.id=p.id
+			RETURNING e.id, e.user_id, e.feed_id, e.reading_time, e.status, p.status AS previous_status
+		), events AS (
+			INSERT INTO entry_status_events (user_id, entry_id, feed_id, status, reading_time, bulk)
+			SELECT user_id, id, feed_id, status, reading_time, cardinality($3) > 1 FROM updated WHERE status <> previous_status
 		)
 		SELECT count(*)
 		FROM updated u
@@ -514,8 +635,14 @@ func (s *Storage) SaveEntryForLater(userID int64, entryID int64) (int, error) {
 			FROM target t
 			WHERE e.id=t.id
 			RETURNING
+				e.id,
+				e.user_id,
 				t.feed_id,
+				e.reading_time,
 				t.status <> $1::entry_status AS became_unread
+		), events AS (
+			INSERT INTO entry_status_events (user_id, entry_id, feed_id, status, reading_time)
+			SELECT user_id, id, feed_id, $1::entry_status, reading_time FROM updated WHERE became_unread
 		)
 		SELECT
 			count(*),
@@ -554,27 +681,29 @@ func (s *Storage) SetEntriesStarredState(userID int64, entryIDs []int64, starred
 		return errors.New(`store: nothing has been updated`)
 	}
 
+	if starred {
+		return s.resetEvictedEnclosureMirrors(userID, entryIDs)
+	}
+
 	return nil
 }
 
-// ToggleStarred toggles entry starred value.
-func (s *Storage) ToggleStarred(userID int64, entryID int64) error {
-	query := `UPDATE entries SET starred = NOT starred, changed_at=now() WHERE user_id=$1 AND id=$2`
-	result, err := s.db.Exec(query, userID, entryID)
-	if err != nil {
-		return fmt.Errorf(`store: unable to toggle starred flag for entry #%d: %v`, entryID, err)
-	}
-
-	count, err := result.RowsAffected()
-	if err != nil {
-		return fmt.Errorf(`store: unable to toggle starred flag for entry #%d: %v`, entryID, err)
+// ToggleStarred toggles entry starred value and returns the new value.
+func (s *Storage) ToggleStarred(userID int64, entryID int64) (bool, error) {
+	var starred bool
+	query := `UPDATE entries SET starred = NOT starred, changed_at=now() WHERE user_id=$1 AND id=$2 RETURNING starred`
+	err := s.db.QueryRow(query, userID, entryID).Scan(&starred)
+	switch {
+	case err == sql.ErrNoRows:
+		return false, errors.New(`store: nothing has been updated`)
+	case err != nil:
+		return false, fmt.Errorf(`store: unable to toggle starred flag for entry #%d: %v`, entryID, err)
 	}
 
-	if count == 0 {
-		return errors.New(`store: nothing has been updated`)
+	if !starred {
+		return false, nil
 	}
-
-	return nil
+	return true, s.resetEvictedEnclosureMirrors(userID, []int64{entryID})
 }
 
 // UpdateEntryVote updates the vote value for an entry.
@@ -618,7 +747,16 @@ func (s *Storage) FlushHistory(userID int64) error {
 
 // MarkAllAsRead updates all user entries to the read status.
 func (s *Storage) MarkAllAsRead(userID int64) error {
-	query := `UPDATE entries SET status=$1, saved_for_later=false, changed_at=now() WHERE user_id=$2 AND status=$3`
+	query := `
+		WITH updated AS (
+			UPDATE entries
+			SET status=$1, saved_for_later=false, read_at=now(), changed_at=now()
+			WHERE user_id=$2 AND status=$3
+			RETURNING id, user_id, feed_id, reading_time
+		)
+		INSERT INTO entry_status_events (user_id, entry_id, feed_id, status, reading_time, bulk)
+		SELECT user_id, id, feed_id, $1, reading_time, 't' FROM updated
+	`
 	result, err := s.db.Exec(query, model.EntryStatusRead, userID, model.EntryStatusUnread)
 	if err != nil {
 		return fmt.Errorf(`store: unable to mark all entries as read: %v`, err)
@@ -636,14 +774,21 @@ func (s *Storage) MarkAllAsRead(userID int64) error {
 // MarkAllAsReadBeforeDate updates all user entries to the read status before the given date.
 func (s *Storage) MarkAllAsReadBeforeDate(userID int64, before time.Time) error {
 	query := `
-		UPDATE
-			entries
-		SET
-			status=$1,
-			saved_for_later=false,
-			changed_at=now()
-		WHERE
-			user_id=$2 AND status=$3 AND published_at < $4
+		WITH updated AS (
+			UPDATE
+				entries
+			SET
+				status=$1,
+				saved_for_later=false,
+				read_at=now(),
+				changed_at=now()
+			WHERE
+				user_id=$2 AND status=$3 AND published_at < $4
+			RETURNING
+				id, user_id, feed_id, reading_time
+		)
+		INSERT INTO entry_status_events (user_id, entry_id, feed_id, status, reading_time, bulk)
+		SELECT user_id, id, feed_id, $1, reading_time, 't' FROM updated
 	`
 	result, err := s.db.Exec(query, model.EntryStatusRead, userID, model.EntryStatusUnread, before)
 	if err != nil {
@@ -661,19 +806,26 @@ func (s *Storage) MarkAllAsReadBeforeDate(userID int64, before time.Time) error
 // MarkGloballyVisibleFeedsAsRead updates all user entries to the read status.
 func (s *Storage) MarkGloballyVisibleFeedsAsRead(userID int64) error {
 	query := `
-		UPDATE
-			entries
-		SET
-			status=$1,
-			saved_for_later=false,
-			changed_at=now()
-		FROM
-			feeds
-		WHERE
-			entries.feed_id = feeds.id
-			AND entries.user_id=$2
-			AND entries.status=$3
-			AND feeds.hide_globally=$4
+		WITH updated AS (
+			UPDATE
+				entries
+			SET
+				status=$1,
+				saved_for_later=false,
+				read_at=now(),
+				changed_at=now()
+			FROM
+				feeds
+			WHERE
+				entries.feed_id = feeds.id
+				AND entries.user_id=$2
+				AND entries.status=$3
+				AND feeds.hide_globally=$4
+			RETURNING
+				entries.id, entries.user_id, entries.feed_id, entries.reading_time
+		)
+		INSERT INTO entry_status_events (user_id, entry_id, feed_id, status, reading_time, bulk)
+		SELECT user_id, id, feed_id, $1, reading_time, 't' FROM updated
 	`
 	result, err := s.db.Exec(query, model.EntryStatusRead, userID, model.EntryStatusUnread, false)
 	if err != nil {
@@ -692,14 +844,21 @@ func (s *Storage) MarkGloballyVisibleFeedsAsRead(userID int64) error {
 // MarkFeedAsRead updates all feed entries to the read status.
 func (s *Storage) MarkFeedAsRead(userID, feedID int64, before time.Time) error {
 	query := `
-		UPDATE
-			entries
-		SET
-			status=$1,
-			saved_for_later=false,
-			changed_at=now()
-		WHERE
-			user_id=$2 AND feed_id=$3 AND status=$4 AND published_at < $5
+		WITH updated AS (
+			UPDATE
+				entries
+			SET
+				status=$1,
+				saved_for_later=false,
+				read_at=now(),
+				changed_at=now()
+			WHERE
+				user_id=$2 AND feed_id=$3 AND status=$4 AND published_at < $5
+			RETURNING
+				id, user_id, feed_id, reading_time
+		)
+		INSERT INTO entry_status_events (user_id, entry_id, feed_id, status, reading_time, bulk)
+		SELECT user_id, id, feed_id, $1, reading_time, 't' FROM updated
 	`
 	result, err := s.db.Exec(query, model.EntryStatusRead, userID, feedID, model.EntryStatusUnread, before)
 	if err != nil {
@@ -720,24 +879,31 @@ func (s *Storage) MarkFeedAsRead(userID, feedID int64, before time.Time) error {
 // MarkCategoryAsRead updates all category entries to the read status.
 func (s *Storage) MarkCategoryAsRead(userID, categoryID int64, before time.Time) error {
 	query := `
-		UPDATE
-			entries
-		SET
-			status=$1,
-			saved_for_later=false,
-			changed_at=now()
-		FROM
-			feeds
-		WHERE
-			feed_id=feeds.id
-		AND
-			feeds.user_id=$2
-		AND
-			status=$3
-		AND
-			published_at < $4
-		AND
-			feeds.category_id=$5
+		WITH updated AS (
+			UPDATE
+				entries
+			SET
+				status=$1,
+				saved_for_later=false,
+				read_at=now(),
+				changed_at=now()
+			FROM
+				feeds
+			WHERE
+				feed_id=feeds.id
+			AND
+				feeds.user_id=$2
+			AND
+				status=$3
+			AND
+				published_at < $4
+			AND
+				feeds.category_id=$5
+			RETURNING
+				entries.id, entries.user_id, entries.feed_id, entries.reading_time
+		)
+		INSERT INTO entry_status_events (user_id, entry_id, feed_id, status, reading_time, bulk)
+		SELECT user_id, id, feed_id, $1, reading_time, 't' FROM updated
 	`
 	result, err := s.db.Exec(query, model.EntryStatusRead, userID, model.EntryStatusUnread, before, categoryID)
 	if err != nil {
@@ -792,6 +958,7 @@ func (s *Storage) UnshareEntry(userID int64, entryID int64) (err error) {
 func truncateTitleAndContentForTSVectorField(title, content string) (string, string) {
 	// The length of a tsvector (lexemes + positions) must be less than 1 megabyte.
 	// We don't need to index the entire content, and we need to keep a buffer for the positions.
+	// The summary is limited to 20000 characters directly in the queries.
 	return truncateStringForTSVectorField(title, 200000), truncateStringForTSVectorField(content, 500000)
 }
 
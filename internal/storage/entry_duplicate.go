@@ -0,0 +1,143 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"database/sql"
+	"fmt"
+
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/fingerprint"
+)
+
+// FindDuplicateEntry returns the ID of the oldest recent entry from another feed of the user that is a near-duplicate
+// of the given fingerprint, or 0 if there is none.
+func (s *Storage) FindDuplicateEntry(userID, feedID int64, entryFingerprint uint64) (int64, error) {
+	if entryFingerprint == 0 {
+		return 0, nil
+	}
+
+	// Near-duplicates share at least one 16-bit band, the exact distance is checked below.
+	bands := fingerprint.Bands(entryFingerprint)
+	query := `
+		SELECT
+			id,
+			fingerprint
+		FROM
+			entries
+		WHERE
+			user_id=$1 AND
+			feed_id <> $2 AND
+			fingerprint IS NOT NULL AND
+			created_at > now() - interval '7 days' AND
+			(
+				(fingerprint & 65535)=$3 OR
+				((fingerprint >> 16) & 65535)=$4 OR
+				((fingerprint >> 32) & 65535)=$5 OR
+				((fingerprint >> 48) & 65535)=$6
+			)
+		ORDER BY
+			created_at ASC, id ASC
+	`
+	rows, err := s.db.Query(query, userID, feedID, bands[0], bands[1], bands[2], bands[3])
+	if err != nil {
+		return 0, fmt.Errorf(`store: unable to find duplicate entries: %v`, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var entryID, candidate int64
+		if err := rows.Scan(&entryID, &candidate); err != nil {
+			return 0, fmt.Errorf(`store: unable to fetch duplicate entry row: %v`, err)
+		}
+
+		if fingerprint.IsNearDuplicate(entryFingerprint, uint64(candidate)) {
+			return entryID, nil
+		}
+	}
+
+	return 0, nil
+}
+
+// CreateEntryDuplicate records that the given entry was also published by another feed.
+func (s *Storage) CreateEntryDuplicate(entryID, feedID int64, url string) error {
+	return createEntryDuplicate(s.db, entryID, feedID, url)
+}
+
+// MergeDuplicateEntry records that the given entry was also published by another feed, and adds a tombstone
+// for the copy so it is not ingested on the next refresh.
+func (s *Storage) MergeDuplicateEntry(entryID, feedID int64, hash, url string) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf(`store: unable to start transaction: %v`, err)
+	}
+	defer tx.Rollback()
+
+	if err := createEntryDuplicate(tx, entryID, feedID, url); err != nil {
+		return err
+	}
+
+	query := `INSERT INTO entry_tombstones (feed_id, hash) VALUES ($1, $2) ON CONFLICT (feed_id, hash) DO NOTHING`
+	if _, err := tx.Exec(query, feedID, hash); err != nil {
+		return fmt.Errorf(`store: unable to create tombstone for duplicate entry: %v`, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf(`store: unable to commit transaction: %v`, err)
+	}
+
+	return nil
+}
+
+// EntryDuplicates returns the other feeds where the given entry was published.
+func (s *Storage) EntryDuplicates(entryID int64) ([]*model.EntryDuplicate, error) {
+	query := `
+		SELECT
+			d.feed_id,
+			f.title,
+			d.url
+		FROM
+			entry_duplicates d
+		JOIN
+			feeds f ON f.id=d.feed_id
+		WHERE
+			d.entry_id=$1
+		ORDER BY
+			d.created_at ASC
+	`
+	rows, err := s.db.Query(query, entryID)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch duplicates of entry #%d: %v`, entryID, err)
+	}
+	defer rows.Close()
+
+	var duplicates []*model.EntryDuplicate
+	for rows.Next() {
+		var duplicate model.EntryDuplicate
+		if err := rows.Scan(&duplicate.FeedID, &duplicate.FeedTitle, &duplicate.URL); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch entry duplicate row: %v`, err)
+		}
+		duplicates = append(duplicates, &duplicate)
+	}
+
+	return duplicates, nil
+}
+
+type execer interface {
+	Exec(query string, args ...any) (sql.Result, error)
+}
+
+func createEntryDuplicate(db execer, entryID, feedID int64, url string) error {
+	query := `
+		INSERT INTO entry_duplicates
+			(entry_id, feed_id, url)
+		VALUES
+			($1, $2, $3)
+		ON CONFLICT (entry_id, feed_id) DO NOTHING
+	`
+	if _, err := db.Exec(query, entryID, feedID, url); err != nil {
+		return fmt.Errorf(`store: unable to create duplicate of entry #%d: %v`, entryID, err)
+	}
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/search"
 )
 
 // entryPaginationBuilder is a builder for entry prev/next queries.
@@ -23,12 +24,11 @@ type entryPaginationBuilder struct {
 	direction       string
 }
 
-// WithSearchQuery adds full-text search query to the condition.
+// WithSearchQuery adds the conditions of a search query to the condition.
 func (e *entryPaginationBuilder) WithSearchQuery(query string) {
-	if query != "" {
-		e.conditions = append(e.conditions, fmt.Sprintf("e.document_vectors @@ plainto_tsquery($%d)", len(e.args)+1))
-		e.args = append(e.args, query)
-	}
+	conditions, args, _ := searchConditions(search.Parse(query), e.args)
+	e.conditions = append(e.conditions, conditions...)
+	e.args = args
 }
 
 // WithStarred adds starred to the condition.
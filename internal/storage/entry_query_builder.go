@@ -6,6 +6,7 @@ package storage // import "miniflux.app/v2/internal/storage"
 import (
 	"database/sql"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -13,19 +14,24 @@ import (
 	"github.com/lib/pq"
 
 	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/search"
 	"miniflux.app/v2/internal/timezone"
 )
 
 // EntryQueryBuilder builds a SQL query to fetch entries.
 type EntryQueryBuilder struct {
-	store           *Storage
-	args            []any
-	conditions      []string
-	sortExpressions []string
-	limit           int
-	offset          int
-	fetchEnclosures bool
-	excludeContent  bool
+	store              *Storage
+	args               []any
+	conditions         []string
+	sortExpressions    []string
+	limit              int
+	offset             int
+	fetchEnclosures    bool
+	fetchDuplicates    bool
+	excludeContent     bool
+	searchTextArg      int
+	sortBySearchRank   bool
+	searchRankPosition int
 }
 
 // WithEnclosures fetches enclosures for each entry.
@@ -34,6 +40,12 @@ func (e *EntryQueryBuilder) WithEnclosures() *EntryQueryBuilder {
 	return e
 }
 
+// WithDuplicates fetches the other feeds where the entry was published, when a single entry is returned.
+func (e *EntryQueryBuilder) WithDuplicates() *EntryQueryBuilder {
+	e.fetchDuplicates = true
+	return e
+}
+
 // WithoutContent excludes the content column from the query results,
 // replacing it with an empty string. This significantly reduces data
 // transfer from PostgreSQL on list pages where content is not displayed.
@@ -42,18 +54,19 @@ func (e *EntryQueryBuilder) WithoutContent() *EntryQueryBuilder {
 	return e
 }
 
-// WithSearchQuery adds full-text search query to the condition.
+// WithSearchQuery adds the conditions of a search query, see the search package for the syntax.
+// When the query contains text, entries are returned with a highlighted snippet and a rank,
+// and they can be sorted with the "relevance" order. Unless that order is requested,
+// the rank is added to the sorting at the position of the call.
 func (e *EntryQueryBuilder) WithSearchQuery(query string) *EntryQueryBuilder {
-	if query != "" {
-		nArgs := len(e.args) + 1
-		e.conditions = append(e.conditions, fmt.Sprintf("e.document_vectors @@ plainto_tsquery($%d)", nArgs))
-		e.args = append(e.args, query)
+	conditions, args, textArg := searchConditions(search.Parse(query), e.args)
+	e.conditions = append(e.conditions, conditions...)
+	e.args = args
 
-		// 0.0000001 = 0.1 / (seconds_in_a_day)
-		e.WithSorting(
-			fmt.Sprintf("ts_rank(document_vectors, plainto_tsquery($%d)) - extract (epoch from now() - published_at)::float * 0.0000001", nArgs),
-			"DESC",
-		)
+	if textArg > 0 {
+		e.searchTextArg = textArg
+		e.sortBySearchRank = true
+		e.searchRankPosition = len(e.sortExpressions)
 	}
 	return e
 }
@@ -211,6 +224,15 @@ func (e *EntryQueryBuilder) WithTags(tags []string) *EntryQueryBuilder {
 	return e
 }
 
+// WithLanguage filter by entry language, the primary subtag of the language tag such as "en" or "fr".
+func (e *EntryQueryBuilder) WithLanguage(language string) *EntryQueryBuilder {
+	if language != "" {
+		e.conditions = append(e.conditions, "e.language = $"+strconv.Itoa(len(e.args)+1))
+		e.args = append(e.args, language)
+	}
+	return e
+}
+
 // WithoutStatus set the entry status that should not be returned.
 func (e *EntryQueryBuilder) WithoutStatus(status string) *EntryQueryBuilder {
 	if status != "" {
@@ -301,6 +323,13 @@ func (e *EntryQueryBuilder) GetEntry() (*model.Entry, error) {
 		return nil, err
 	}
 
+	if e.fetchDuplicates {
+		entries[0].AlsoIn, err = e.store.EntryDuplicates(entries[0].ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return entries[0], nil
 }
 
@@ -340,12 +369,17 @@ func (e *EntryQueryBuilder) fetchEntries(withCount bool) (model.Entries, int, er
 			e.author,
 			e.share_code,
 			` + e.contentColumn() + `,
+			e.summary,
+			e.language,
 			e.status,
 			e.starred,
 			e.saved_for_later,
 			e.reading_time,
 			e.created_at,
 			e.changed_at,
+			e.revised_at,
+			e.read_at,
+			(SELECT es.created_at FROM entry_snapshots es WHERE es.entry_id=e.id AND es.size > 0) AS snapshot_at,
 			e.tags,
 			e.score,
 			e.vote,
@@ -362,12 +396,22 @@ func (e *EntryQueryBuilder) fetchEntries(withCount bool) (model.Entries, int, er
 			f.crawler,
 			f.user_agent,
 			f.cookie,
+			f.fetch_via_proxy,
+			f.proxy_url,
+			c.scraper_rules as category_scraper_rules,
+			c.rewrite_rules as category_rewrite_rules,
+			c.crawler as category_crawler,
+			c.user_agent as category_user_agent,
+			c.fetch_via_proxy as category_fetch_via_proxy,
+			c.proxy_url as category_proxy_url,
 			f.hide_globally,
 			f.no_media_player,
 			f.webhook_url,
+			f.snapshot_entries,
 			fi.icon_id,
 			i.external_id AS icon_external_id,
 			u.timezone
+			` + e.searchColumns() + `
 		FROM
 			entries e
 		LEFT JOIN
@@ -397,6 +441,7 @@ func (e *EntryQueryBuilder) fetchEntries(withCount bool) (model.Entries, int, er
 		var iconID sql.NullInt64
 		var externalIconID sql.NullString
 		var tz string
+		var crawler, fetchViaProxy *bool
 
 		entry := model.NewEntry()
 
@@ -412,12 +457,17 @@ func (e *EntryQueryBuilder) fetchEntries(withCount bool) (model.Entries, int, er
 			&entry.Author,
 			&entry.ShareCode,
 			&entry.Content,
+			&entry.Summary,
+			&entry.Language,
 			&entry.Status,
 			&entry.Starred,
 			&entry.SavedForLater,
 			&entry.ReadingTime,
 			&entry.CreatedAt,
 			&entry.ChangedAt,
+			&entry.RevisedAt,
+			&entry.ReadAt,
+			&entry.SnapshotAt,
 			pq.Array(&entry.Tags),
 			&entry.Score,
 			&entry.Vote,
@@ -431,12 +481,21 @@ func (e *EntryQueryBuilder) fetchEntries(withCount bool) (model.Entries, int, er
 			&entry.Feed.Category.HideGlobally,
 			&entry.Feed.ScraperRules,
 			&entry.Feed.RewriteRules,
-			&entry.Feed.Crawler,
+			&crawler,
 			&entry.Feed.UserAgent,
 			&entry.Feed.Cookie,
+			&fetchViaProxy,
+			&entry.Feed.ProxyURL,
+			&entry.Feed.Category.ScraperRules,
+			&entry.Feed.Category.RewriteRules,
+			&entry.Feed.Category.Crawler,
+			&entry.Feed.Category.UserAgent,
+			&entry.Feed.Category.FetchViaProxy,
+			&entry.Feed.Category.ProxyURL,
 			&entry.Feed.HideGlobally,
 			&entry.Feed.NoMediaPlayer,
 			&entry.Feed.WebhookURL,
+			&entry.Feed.SnapshotEntries,
 			&iconID,
 			&externalIconID,
 			&tz,
@@ -446,12 +505,20 @@ func (e *EntryQueryBuilder) fetchEntries(withCount bool) (model.Entries, int, er
 			dest = append([]any{&totalCount}, dest...)
 		}
 
+		if e.searchTextArg > 0 {
+			dest = append(dest, &entry.Snippet, &entry.SearchRank)
+		}
+
 		err := rows.Scan(dest...)
 
 		if err != nil {
 			return nil, 0, fmt.Errorf("store: unable to fetch entry row: %v", err)
 		}
 
+		entry.Feed.CrawlerOverride = model.FeedSettingOverride(crawler)
+		entry.Feed.FetchViaProxyOverride = model.FeedSettingOverride(fetchViaProxy)
+		entry.Feed.ResolveInheritedSettings()
+
 		if iconID.Valid && externalIconID.Valid && externalIconID.String != "" {
 			entry.Feed.Icon.FeedID = entry.FeedID
 			entry.Feed.Icon.IconID = iconID.Int64
@@ -464,7 +531,20 @@ func (e *EntryQueryBuilder) fetchEntries(withCount bool) (model.Entries, int, er
 		entry.Date = timezone.Convert(tz, entry.Date)
 		entry.CreatedAt = timezone.Convert(tz, entry.CreatedAt)
 		entry.ChangedAt = timezone.Convert(tz, entry.ChangedAt)
+		if entry.RevisedAt != nil {
+			revisedAt := timezone.Convert(tz, *entry.RevisedAt)
+			entry.RevisedAt = &revisedAt
+		}
+		if entry.ReadAt != nil {
+			readAt := timezone.Convert(tz, *entry.ReadAt)
+			entry.ReadAt = &readAt
+		}
+		if entry.SnapshotAt != nil {
+			snapshotAt := timezone.Convert(tz, *entry.SnapshotAt)
+			entry.SnapshotAt = &snapshotAt
+		}
 		entry.Feed.CheckedAt = timezone.Convert(tz, entry.Feed.CheckedAt)
+		entry.Snippet = highlightedSnippet(entry.Snippet)
 
 		entry.Feed.ID = entry.FeedID
 		entry.Feed.UserID = entry.UserID
@@ -533,15 +613,65 @@ func (e *EntryQueryBuilder) contentColumn() string {
 	return "e.content"
 }
 
+// searchColumns returns the snippet and rank columns when the query contains search text.
+func (e *EntryQueryBuilder) searchColumns() string {
+	if e.searchTextArg == 0 {
+		return ""
+	}
+
+	query := entrySearchTSQuery(fmt.Sprintf("$%d", e.searchTextArg))
+	return fmt.Sprintf(`,
+			ts_headline(%[1]s, regexp_replace(e.content, '<[^>]*>', ' ', 'g'), %[2]s, '%[3]s') AS snippet,
+			ts_rank_cd(e.document_vectors, %[2]s) AS search_rank`,
+		entryTextSearchConfig,
+		query,
+		snippetOptions,
+	)
+}
+
 func (e *EntryQueryBuilder) buildCondition() string {
 	return strings.Join(e.conditions, " AND ")
 }
 
+// searchRankSorting returns the default order of the search results, recent entries first among the matches of equal relevance.
+// 0.0000001 = 0.1 / (seconds_in_a_day)
+func (e *EntryQueryBuilder) searchRankSorting() string {
+	return fmt.Sprintf(
+		"ts_rank_cd(e.document_vectors, %s) - extract (epoch from now() - e.published_at)::float * 0.0000001 DESC",
+		entrySearchTSQuery(fmt.Sprintf("$%d", e.searchTextArg)),
+	)
+}
+
 func (e *EntryQueryBuilder) buildSorting() string {
 	var parts string
 
-	if len(e.sortExpressions) > 0 {
-		parts += " ORDER BY " + strings.Join(e.sortExpressions, ", ")
+	explicitRelevance := slices.ContainsFunc(e.sortExpressions, func(expression string) bool {
+		return strings.HasPrefix(expression, model.RelevanceSortingOrder+" ")
+	})
+
+	sortExpressions := make([]string, 0, len(e.sortExpressions)+1)
+	for index, expression := range e.sortExpressions {
+		if index == e.searchRankPosition && e.sortBySearchRank && !explicitRelevance {
+			sortExpressions = append(sortExpressions, e.searchRankSorting())
+		}
+
+		// The relevance order is resolved here because the search query may be added after the sorting.
+		if direction, found := strings.CutPrefix(expression, model.RelevanceSortingOrder+" "); found {
+			if e.searchTextArg == 0 {
+				expression = model.DefaultSortingOrder + " " + direction
+			} else {
+				expression = fmt.Sprintf("ts_rank_cd(e.document_vectors, %s) %s", entrySearchTSQuery(fmt.Sprintf("$%d", e.searchTextArg)), direction)
+			}
+		}
+		sortExpressions = append(sortExpressions, expression)
+	}
+
+	if e.searchRankPosition == len(e.sortExpressions) && e.sortBySearchRank && !explicitRelevance {
+		sortExpressions = append(sortExpressions, e.searchRankSorting())
+	}
+
+	if len(sortExpressions) > 0 {
+		parts += " ORDER BY " + strings.Join(sortExpressions, ", ")
 	}
 
 	if e.limit > 0 {
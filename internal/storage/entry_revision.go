@@ -0,0 +1,114 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"database/sql"
+	"fmt"
+	"slices"
+	"strings"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/diff"
+)
+
+// substantialRevisionRatio is the proportion of changed text above which an entry is marked as unread again
+// when the feed has mark_unread_on_entry_revision enabled.
+const substantialRevisionRatio = 0.1
+
+// EntryRevisions returns the previous revisions of an entry, the most recent first.
+func (s *Storage) EntryRevisions(userID, entryID int64) (model.EntryRevisions, error) {
+	query := `
+		SELECT
+			r.id,
+			r.entry_id,
+			r.title,
+			r.content,
+			r.created_at
+		FROM
+			entry_revisions r
+		JOIN
+			entries e ON e.id=r.entry_id
+		WHERE
+			e.user_id=$1 AND r.entry_id=$2
+		ORDER BY
+			r.created_at DESC, r.id DESC
+	`
+	rows, err := s.db.Query(query, userID, entryID)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch revisions of entry #%d: %v`, entryID, err)
+	}
+	defer rows.Close()
+
+	revisions := make(model.EntryRevisions, 0)
+	for rows.Next() {
+		var revision model.EntryRevision
+		if err := rows.Scan(
+			&revision.ID,
+			&revision.EntryID,
+			&revision.Title,
+			&revision.Content,
+			&revision.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch entry revision row: %v`, err)
+		}
+		revisions = append(revisions, &revision)
+	}
+
+	return revisions, nil
+}
+
+// recordEntryRevision keeps the previous title and content of an entry when a feed refresh changes its text.
+// Changes limited to markup or whitespace are not considered as revisions.
+func (s *Storage) recordEntryRevision(tx *sql.Tx, entry *model.Entry, previousTitle, previousContent string, markUnread bool) error {
+	limit := config.Opts.EntryRevisionsLimit()
+	if limit <= 0 {
+		return nil
+	}
+
+	titleChanged := strings.TrimSpace(previousTitle) != strings.TrimSpace(entry.Title)
+	if !titleChanged && slices.Equal(diff.Paragraphs(previousContent), diff.Paragraphs(entry.Content)) {
+		return nil
+	}
+
+	query := `INSERT INTO entry_revisions (entry_id, title, content) VALUES ($1, $2, $3)`
+	if _, err := tx.Exec(query, entry.ID, previousTitle, previousContent); err != nil {
+		return fmt.Errorf(`store: unable to create revision of entry #%d: %v`, entry.ID, err)
+	}
+
+	query = `
+		DELETE FROM
+			entry_revisions
+		WHERE
+			entry_id=$1 AND id NOT IN (
+				SELECT id FROM entry_revisions WHERE entry_id=$1 ORDER BY created_at DESC, id DESC LIMIT $2
+			)
+	`
+	if _, err := tx.Exec(query, entry.ID, limit); err != nil {
+		return fmt.Errorf(`store: unable to prune revisions of entry #%d: %v`, entry.ID, err)
+	}
+
+	query = `UPDATE entries SET revised_at=now() WHERE id=$1`
+	if markUnread && (titleChanged || diff.ChangeRatio(previousContent, entry.Content) >= substantialRevisionRatio) {
+		query = `
+			WITH previous AS (
+				SELECT id, status FROM entries WHERE id=$1 FOR UPDATE
+			), updated AS (
+				UPDATE entries e
+				SET revised_at=now(), status='unread', changed_at=now()
+				FROM previous p
+				WHERE e.id=p.id
+				RETURNING e.id, e.user_id, e.feed_id, e.reading_time, e.status, p.status AS previous_status
+			)
+			INSERT INTO entry_status_events (user_id, entry_id, feed_id, status, reading_time)
+			SELECT user_id, id, feed_id, status, reading_time FROM updated WHERE status <> previous_status
+		`
+	}
+	if _, err := tx.Exec(query, entry.ID); err != nil {
+		return fmt.Errorf(`store: unable to update revision date of entry #%d: %v`, entry.ID, err)
+	}
+
+	return nil
+}
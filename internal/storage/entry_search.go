@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/search"
+)
+
+// snippetOptions configures ts_headline to return up to two short fragments with the matches wrapped in mark tags.
+const snippetOptions = `StartSel=<mark>, StopSel=</mark>, MinWords=15, MaxWords=35, MaxFragments=2, FragmentDelimiter=" ... "`
+
+// searchConditions compiles a parsed search query into SQL conditions.
+// Placeholders are numbered after the given arguments, which are returned with the new ones appended.
+// textArg is the placeholder index of the full-text query, or 0 if the query has no text.
+func searchConditions(query *search.Query, args []any) (conditions []string, _ []any, textArg int) {
+	placeholder := func(value any) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if query.Text != "" {
+		conditions = append(conditions, searchCondition(placeholder(query.Text)))
+		textArg = len(args)
+	}
+
+	for _, filter := range query.Filters {
+		var condition string
+
+		switch filter.Field {
+		case search.FieldFeed:
+			if filter.Number > 0 {
+				condition = "e.feed_id = " + placeholder(filter.Number)
+			} else {
+				condition = "e.feed_id IN (SELECT id FROM feeds WHERE user_id=e.user_id AND title ILIKE " + placeholder(containsPattern(filter.Value)) + ")"
+			}
+		case search.FieldCategory:
+			if filter.Number > 0 {
+				condition = "e.feed_id IN (SELECT id FROM feeds WHERE user_id=e.user_id AND category_id=" + placeholder(filter.Number) + ")"
+			} else {
+				condition = "e.feed_id IN (SELECT sf.id FROM feeds sf JOIN categories sc ON sc.id=sf.category_id WHERE sf.user_id=e.user_id AND sc.title ILIKE " + placeholder(containsPattern(filter.Value)) + ")"
+			}
+		case search.FieldTag:
+			tag := placeholder(filter.Value)
+			condition = fmt.Sprintf(
+				"(EXISTS (SELECT 1 FROM unnest(e.tags) t WHERE LOWER(t)=LOWER(%s)) OR e.id IN (SELECT eut.entry_id FROM entry_user_tags eut JOIN user_tags ut ON ut.id=eut.user_tag_id WHERE ut.user_id=e.user_id AND LOWER(ut.title)=LOWER(%s)))",
+				tag, tag,
+			)
+		case search.FieldAuthor:
+			condition = "e.author ILIKE " + placeholder(containsPattern(filter.Value))
+		case search.FieldIs:
+			switch filter.Value {
+			case "unread":
+				condition = "e.status = " + placeholder(model.EntryStatusUnread)
+			case "read":
+				condition = "e.status = " + placeholder(model.EntryStatusRead)
+			case "starred":
+				condition = "e.starred is true"
+			case "saved":
+				condition = "e.saved_for_later is true"
+			case "shared":
+				condition = "e.share_code <> ''"
+			}
+		case search.FieldVote:
+			condition = "e.vote = " + placeholder(filter.Number)
+		case search.FieldScore:
+			condition = "e.score " + filter.Operator + " " + placeholder(filter.Number)
+		case search.FieldBefore:
+			condition = "e.published_at < " + placeholder(filter.Date)
+		case search.FieldAfter:
+			condition = "e.published_at >= " + placeholder(filter.Date)
+		}
+
+		if condition == "" {
+			continue
+		}
+
+		if filter.Negated {
+			condition = "NOT (" + condition + ")"
+		}
+		conditions = append(conditions, condition)
+	}
+
+	return conditions, args, textArg
+}
+
+// containsPattern returns an ILIKE pattern matching values containing the given text.
+func containsPattern(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return "%" + replacer.Replace(value) + "%"
+}
+
+// highlightedSnippet returns a snippet produced by ts_headline as safe HTML where only the mark tags are kept.
+func highlightedSnippet(snippet string) string {
+	if snippet == "" {
+		return ""
+	}
+
+	snippet = html.EscapeString(html.UnescapeString(strings.Join(strings.Fields(snippet), " ")))
+	snippet = strings.ReplaceAll(snippet, "&lt;mark&gt;", "<mark>")
+	snippet = strings.ReplaceAll(snippet, "&lt;/mark&gt;", "</mark>")
+	return snippet
+}
@@ -0,0 +1,130 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"miniflux.app/v2/internal/search"
+)
+
+func TestSearchConditions(t *testing.T) {
+	conditions, args, textArg := searchConditions(search.Parse(`golang -is:read score:>=10 author:50%`), []any{int64(1)})
+
+	expectedConditions := []string{
+		searchCondition("$2"),
+		"NOT (e.status = $3)",
+		"e.score >= $4",
+		"e.author ILIKE $5",
+	}
+	if !slices.Equal(conditions, expectedConditions) {
+		t.Errorf(`Unexpected conditions: got %q instead of %q`, conditions, expectedConditions)
+	}
+
+	expectedArgs := []any{int64(1), "golang", "read", int64(10), `%50\%%`}
+	if !slices.Equal(args, expectedArgs) {
+		t.Errorf(`Unexpected arguments: got %v instead of %v`, args, expectedArgs)
+	}
+
+	if textArg != 2 {
+		t.Errorf(`Expected the text argument to be $2, got $%d`, textArg)
+	}
+}
+
+func TestSearchConditionsWithoutText(t *testing.T) {
+	conditions, args, textArg := searchConditions(search.Parse(`feed:7 is:starred`), nil)
+
+	expectedConditions := []string{"e.feed_id = $1", "e.starred is true"}
+	if !slices.Equal(conditions, expectedConditions) {
+		t.Errorf(`Unexpected conditions: got %q instead of %q`, conditions, expectedConditions)
+	}
+
+	if len(args) != 1 || textArg != 0 {
+		t.Errorf(`Unexpected arguments %v and text argument %d`, args, textArg)
+	}
+}
+
+func TestSearchConditionsWithTagAndDates(t *testing.T) {
+	conditions, args, _ := searchConditions(search.Parse(`tag:"c'est, {la} vie" after:2024-01-01 before:2024-01-31`), nil)
+
+	expectedConditions := []string{
+		"(EXISTS (SELECT 1 FROM unnest(e.tags) t WHERE LOWER(t)=LOWER($1)) OR e.id IN (SELECT eut.entry_id FROM entry_user_tags eut JOIN user_tags ut ON ut.id=eut.user_tag_id WHERE ut.user_id=e.user_id AND LOWER(ut.title)=LOWER($1)))",
+		"e.published_at >= $2",
+		"e.published_at < $3",
+	}
+	if !slices.Equal(conditions, expectedConditions) {
+		t.Errorf(`Unexpected conditions: got %q instead of %q`, conditions, expectedConditions)
+	}
+
+	// The after operator includes the given day.
+	expectedArgs := []any{`c'est, {la} vie`, time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, time.January, 31, 0, 0, 0, 0, time.UTC)}
+	if !slices.Equal(args, expectedArgs) {
+		t.Errorf(`Unexpected arguments: got %v instead of %v`, args, expectedArgs)
+	}
+}
+
+func TestHighlightedSnippet(t *testing.T) {
+	scenarios := map[string]string{
+		"":                                      "",
+		"the <mark>go</mark> language":          "the <mark>go</mark> language",
+		"a &amp; b <mark>x</mark>":              "a &amp; b <mark>x</mark>",
+		"&lt;script&gt;alert(1)&lt;/script&gt;": "&lt;script&gt;alert(1)&lt;/script&gt;",
+		"  spaced \n\t <mark>out</mark> ":       "spaced <mark>out</mark>",
+	}
+
+	for input, expected := range scenarios {
+		if result := highlightedSnippet(input); result != expected {
+			t.Errorf(`Unexpected snippet for %q: got %q instead of %q`, input, result, expected)
+		}
+	}
+}
+
+func TestBuildSortingWithRelevance(t *testing.T) {
+	builder := &EntryQueryBuilder{}
+	builder.WithSorting("relevance", "desc")
+	builder.WithSorting("id", "desc")
+
+	if result := builder.buildSorting(); result != " ORDER BY published_at desc, id desc" {
+		t.Errorf(`Relevance without search text should fall back to the default order, got %q`, result)
+	}
+
+	builder.searchTextArg = 2
+	if result := builder.buildSorting(); result != " ORDER BY ts_rank_cd(e.document_vectors, "+entrySearchTSQuery("$2")+") desc, id desc" {
+		t.Errorf(`Unexpected relevance sorting: %q`, result)
+	}
+}
+
+func TestBuildSortingWithSearchText(t *testing.T) {
+	rank := "ts_rank_cd(e.document_vectors, " + entrySearchTSQuery("$1") + ") - extract (epoch from now() - e.published_at)::float * 0.0000001 DESC"
+
+	builder := NewAnonymousQueryBuilder(nil)
+	builder.WithSorting("published_at", "desc")
+	builder.WithSearchQuery("golang")
+	if result := builder.buildSorting(); result != " ORDER BY published_at desc, "+rank {
+		t.Errorf(`The rank should be the secondary order, got %q`, result)
+	}
+
+	builder = NewAnonymousQueryBuilder(nil)
+	builder.WithSearchQuery("golang")
+	if result := builder.buildSorting(); result != " ORDER BY "+rank {
+		t.Errorf(`The rank should be the default order, got %q`, result)
+	}
+
+	builder = NewAnonymousQueryBuilder(nil)
+	builder.WithSorting("status", "asc")
+	builder.WithSearchQuery("golang")
+	builder.WithSorting("id", "desc")
+	if result := builder.buildSorting(); result != " ORDER BY status asc, "+rank+", id desc" {
+		t.Errorf(`The rank should be added at the position of the search query, got %q`, result)
+	}
+
+	builder = NewAnonymousQueryBuilder(nil)
+	builder.WithSearchQuery("golang")
+	builder.WithSorting("relevance", "asc")
+	if result := builder.buildSorting(); result != " ORDER BY ts_rank_cd(e.document_vectors, "+entrySearchTSQuery("$1")+") asc" {
+		t.Errorf(`The requested relevance order should replace the default rank, got %q`, result)
+	}
+}
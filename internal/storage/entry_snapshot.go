@@ -0,0 +1,146 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"miniflux.app/v2/internal/model"
+)
+
+// SaveEntrySnapshot creates or replaces the snapshot of an entry.
+// A failed snapshot only records its error when a previous snapshot succeeded, the previous content is kept.
+func (s *Storage) SaveEntrySnapshot(snapshot *model.EntrySnapshot) error {
+	snapshot.Size = int64(len(snapshot.Content))
+
+	query := `
+		INSERT INTO entry_snapshots AS es
+			(entry_id, user_id, url, content, size, error_msg, created_at)
+		VALUES
+			($1, $2, $3, $4, $5, $6, now())
+		ON CONFLICT (entry_id) DO UPDATE SET
+			url=CASE WHEN EXCLUDED.size > 0 OR es.size = 0 THEN EXCLUDED.url ELSE es.url END,
+			content=CASE WHEN EXCLUDED.size > 0 OR es.size = 0 THEN EXCLUDED.content ELSE es.content END,
+			size=CASE WHEN EXCLUDED.size > 0 OR es.size = 0 THEN EXCLUDED.size ELSE es.size END,
+			created_at=CASE WHEN EXCLUDED.size > 0 OR es.size = 0 THEN EXCLUDED.created_at ELSE es.created_at END,
+			error_msg=EXCLUDED.error_msg
+		RETURNING
+			url, size, created_at
+	`
+	err := s.db.QueryRow(
+		query,
+		snapshot.EntryID,
+		snapshot.UserID,
+		snapshot.URL,
+		snapshot.Content,
+		snapshot.Size,
+		snapshot.Error,
+	).Scan(&snapshot.URL, &snapshot.Size, &snapshot.CreatedAt)
+	if err != nil {
+		return fmt.Errorf(`store: unable to save snapshot of entry #%d: %v`, snapshot.EntryID, err)
+	}
+
+	return nil
+}
+
+// EntrySnapshot returns the snapshot of an entry, without its content.
+func (s *Storage) EntrySnapshot(userID, entryID int64) (*model.EntrySnapshot, error) {
+	query := `
+		SELECT
+			entry_id, user_id, url, size, error_msg, created_at
+		FROM
+			entry_snapshots
+		WHERE
+			user_id=$1 AND entry_id=$2
+	`
+
+	var snapshot model.EntrySnapshot
+	err := s.db.QueryRow(query, userID, entryID).Scan(
+		&snapshot.EntryID,
+		&snapshot.UserID,
+		&snapshot.URL,
+		&snapshot.Size,
+		&snapshot.Error,
+		&snapshot.CreatedAt,
+	)
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf(`store: unable to fetch snapshot of entry #%d: %v`, entryID, err)
+	}
+
+	return &snapshot, nil
+}
+
+// EntrySnapshotContent returns the HTML document of an entry snapshot, or an empty string.
+func (s *Storage) EntrySnapshotContent(userID, entryID int64) (string, error) {
+	var content string
+	err := s.db.QueryRow(
+		`SELECT content FROM entry_snapshots WHERE user_id=$1 AND entry_id=$2`,
+		userID,
+		entryID,
+	).Scan(&content)
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return "", nil
+	case err != nil:
+		return "", fmt.Errorf(`store: unable to fetch snapshot content of entry #%d: %v`, entryID, err)
+	}
+
+	return content, nil
+}
+
+// RemoveEntrySnapshot deletes the snapshot of an entry.
+func (s *Storage) RemoveEntrySnapshot(userID, entryID int64) error {
+	_, err := s.db.Exec(`DELETE FROM entry_snapshots WHERE user_id=$1 AND entry_id=$2`, userID, entryID)
+	if err != nil {
+		return fmt.Errorf(`store: unable to remove snapshot of entry #%d: %v`, entryID, err)
+	}
+	return nil
+}
+
+// EntriesToSnapshot returns the starred or saved entries of the feeds with snapshots enabled
+// that have no snapshot yet, or whose snapshots all failed and the last one long enough ago.
+func (s *Storage) EntriesToSnapshot(limit int) (model.Entries, error) {
+	query := `
+		SELECT
+			e.id, e.user_id, e.feed_id, e.url
+		FROM
+			entries e
+		JOIN
+			feeds f ON f.id=e.feed_id
+		LEFT JOIN
+			entry_snapshots es ON es.entry_id=e.id
+		WHERE
+			f.snapshot_entries='t' AND
+			(e.starred='t' OR e.saved_for_later='t') AND
+			e.url <> '' AND
+			(es.entry_id IS NULL OR (es.size = 0 AND es.created_at < now() - $1::interval))
+		ORDER BY
+			e.changed_at ASC
+		LIMIT $2
+	`
+
+	rows, err := s.db.Query(query, fmt.Sprintf("%d seconds", int(model.EntrySnapshotRetryInterval.Seconds())), limit)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch entries to snapshot: %v`, err)
+	}
+	defer rows.Close()
+
+	entries := make(model.Entries, 0, limit)
+	for rows.Next() {
+		var entry model.Entry
+		if err := rows.Scan(&entry.ID, &entry.UserID, &entry.FeedID, &entry.URL); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch entry to snapshot: %v`, err)
+		}
+		entries = append(entries, &entry)
+	}
+
+	return entries, nil
+}
@@ -263,10 +263,16 @@ func (s *Storage) CreateFeed(feed *model.Feed) error {
 			disable_http2,
 			description,
 			proxy_url,
-			ignore_entry_updates
+			ignore_entry_updates,
+			entry_rules,
+			mark_unread_on_entry_revision,
+			snapshot_entries,
+			mirror_enclosures,
+			mirror_enclosures_limit,
+			enrichment_processors
 		)
 		VALUES
-			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28, $29, $30, $31)
+			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28, $29, $30, $31, $32, $33, $34, $35, $36, $37)
 		RETURNING
 			id
 	`
@@ -279,7 +285,7 @@ func (s *Storage) CreateFeed(feed *model.Feed) error {
 		feed.UserID,
 		feed.EtagHeader,
 		feed.LastModifiedHeader,
-		feed.Crawler,
+		model.FeedSettingValue(feed.CrawlerOverride),
 		feed.UserAgent,
 		feed.Cookie,
 		feed.Username,
@@ -293,7 +299,7 @@ func (s *Storage) CreateFeed(feed *model.Feed) error {
 		feed.KeepFilterEntryRules,
 		feed.IgnoreHTTPCache,
 		feed.AllowSelfSignedCertificates,
-		feed.FetchViaProxy,
+		model.FeedSettingValue(feed.FetchViaProxyOverride),
 		feed.HideGlobally,
 		feed.UrlRewriteRules,
 		feed.NoMediaPlayer,
@@ -303,6 +309,12 @@ func (s *Storage) CreateFeed(feed *model.Feed) error {
 		feed.Description,
 		feed.ProxyURL,
 		feed.IgnoreEntryUpdates,
+		feed.EntryRules,
+		feed.MarkUnreadOnEntryRevision,
+		feed.SnapshotEntries,
+		feed.MirrorEnclosures,
+		feed.MirrorEnclosuresLimit,
+		feed.EnrichmentProcessors,
 	).Scan(&feed.ID)
 	if err != nil {
 		return fmt.Errorf(`store: unable to create feed %q: %v`, feed.FeedURL, err)
@@ -386,9 +398,15 @@ func (s *Storage) UpdateFeed(feed *model.Feed) (err error) {
 			pushover_enabled=$36,
 			pushover_priority=$37,
 			proxy_url=$38,
-			ignore_entry_updates=$39
+			ignore_entry_updates=$39,
+			entry_rules=$40,
+			mark_unread_on_entry_revision=$41,
+			snapshot_entries=$42,
+			mirror_enclosures=$43,
+			mirror_enclosures_limit=$44,
+			enrichment_processors=$45
 		WHERE
-			id=$40 AND user_id=$41
+			id=$46 AND user_id=$47
 	`
 	_, err = s.db.Exec(query,
 		feed.FeedURL,
@@ -406,7 +424,7 @@ func (s *Storage) UpdateFeed(feed *model.Feed) (err error) {
 		feed.KeeplistRules,
 		feed.BlockFilterEntryRules,
 		feed.KeepFilterEntryRules,
-		feed.Crawler,
+		model.FeedSettingValue(feed.CrawlerOverride),
 		feed.UserAgent,
 		feed.Cookie,
 		feed.Username,
@@ -415,7 +433,7 @@ func (s *Storage) UpdateFeed(feed *model.Feed) (err error) {
 		feed.NextCheckAt,
 		feed.IgnoreHTTPCache,
 		feed.AllowSelfSignedCertificates,
-		feed.FetchViaProxy,
+		model.FeedSettingValue(feed.FetchViaProxyOverride),
 		feed.HideGlobally,
 		feed.UrlRewriteRules,
 		feed.NoMediaPlayer,
@@ -430,6 +448,12 @@ func (s *Storage) UpdateFeed(feed *model.Feed) (err error) {
 		feed.PushoverPriority,
 		feed.ProxyURL,
 		feed.IgnoreEntryUpdates,
+		feed.EntryRules,
+		feed.MarkUnreadOnEntryRevision,
+		feed.SnapshotEntries,
+		feed.MirrorEnclosures,
+		feed.MirrorEnclosuresLimit,
+		feed.EnrichmentProcessors,
 		feed.ID,
 		feed.UserID,
 	)
@@ -165,6 +165,21 @@ func (f *feedQueryBuilder) GetFeeds() (model.Feeds, error) {
 			f.category_id,
 			c.title as category_title,
 			c.hide_globally as category_hidden,
+			c.entry_rules as category_entry_rules,
+			c.scraper_rules as category_scraper_rules,
+			c.rewrite_rules as category_rewrite_rules,
+			c.urlrewrite_rules as category_urlrewrite_rules,
+			c.blocklist_rules as category_blocklist_rules,
+			c.keeplist_rules as category_keeplist_rules,
+			c.block_filter_entry_rules as category_block_filter_entry_rules,
+			c.keep_filter_entry_rules as category_keep_filter_entry_rules,
+			c.crawler as category_crawler,
+			c.user_agent as category_user_agent,
+			c.fetch_via_proxy as category_fetch_via_proxy,
+			c.proxy_url as category_proxy_url,
+			c.enrichment_processors as category_enrichment_processors,
+			c.summarize_entries as category_summarize_entries,
+			c.summary_prompt as category_summary_prompt,
 			fi.icon_id,
 			i.external_id,
 			u.timezone,
@@ -177,7 +192,13 @@ func (f *feedQueryBuilder) GetFeeds() (model.Feeds, error) {
 			f.pushover_enabled,
 			f.pushover_priority,
 			f.proxy_url,
-			f.ignore_entry_updates
+			f.ignore_entry_updates,
+			f.entry_rules,
+			f.mark_unread_on_entry_revision,
+			f.snapshot_entries,
+			f.mirror_enclosures,
+			f.mirror_enclosures_limit,
+			f.enrichment_processors
 		FROM
 			feeds f
 		LEFT JOIN
@@ -211,6 +232,7 @@ func (f *feedQueryBuilder) GetFeeds() (model.Feeds, error) {
 		var iconID sql.NullInt64
 		var externalIconID sql.NullString
 		var tz string
+		var crawler, fetchViaProxy *bool
 		feed.Category = &model.Category{}
 
 		err := rows.Scan(
@@ -233,20 +255,35 @@ func (f *feedQueryBuilder) GetFeeds() (model.Feeds, error) {
 			&feed.KeeplistRules,
 			&feed.BlockFilterEntryRules,
 			&feed.KeepFilterEntryRules,
-			&feed.Crawler,
+			&crawler,
 			&feed.UserAgent,
 			&feed.Cookie,
 			&feed.Username,
 			&feed.Password,
 			&feed.IgnoreHTTPCache,
 			&feed.AllowSelfSignedCertificates,
-			&feed.FetchViaProxy,
+			&fetchViaProxy,
 			&feed.Disabled,
 			&feed.NoMediaPlayer,
 			&feed.HideGlobally,
 			&feed.Category.ID,
 			&feed.Category.Title,
 			&feed.Category.HideGlobally,
+			&feed.Category.EntryRules,
+			&feed.Category.ScraperRules,
+			&feed.Category.RewriteRules,
+			&feed.Category.UrlRewriteRules,
+			&feed.Category.BlocklistRules,
+			&feed.Category.KeeplistRules,
+			&feed.Category.BlockFilterEntryRules,
+			&feed.Category.KeepFilterEntryRules,
+			&feed.Category.Crawler,
+			&feed.Category.UserAgent,
+			&feed.Category.FetchViaProxy,
+			&feed.Category.ProxyURL,
+			&feed.Category.EnrichmentProcessors,
+			&feed.Category.SummarizeEntries,
+			&feed.Category.SummaryPrompt,
 			&iconID,
 			&externalIconID,
 			&tz,
@@ -260,12 +297,22 @@ func (f *feedQueryBuilder) GetFeeds() (model.Feeds, error) {
 			&feed.PushoverPriority,
 			&feed.ProxyURL,
 			&feed.IgnoreEntryUpdates,
+			&feed.EntryRules,
+			&feed.MarkUnreadOnEntryRevision,
+			&feed.SnapshotEntries,
+			&feed.MirrorEnclosures,
+			&feed.MirrorEnclosuresLimit,
+			&feed.EnrichmentProcessors,
 		)
 
 		if err != nil {
 			return nil, fmt.Errorf(`store: unable to fetch feeds row: %w`, err)
 		}
 
+		feed.CrawlerOverride = model.FeedSettingOverride(crawler)
+		feed.FetchViaProxyOverride = model.FeedSettingOverride(fetchViaProxy)
+		feed.ResolveInheritedSettings()
+
 		if iconID.Valid && externalIconID.Valid {
 			feed.Icon = &model.FeedIcon{FeedID: feed.ID, IconID: iconID.Int64, ExternalIconID: externalIconID.String}
 		} else {
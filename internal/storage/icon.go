@@ -7,6 +7,7 @@ import (
 	"database/sql"
 	"fmt"
 	"strings"
+	"time"
 
 	"miniflux.app/v2/internal/crypto"
 	"miniflux.app/v2/internal/model"
@@ -179,6 +180,73 @@ func (s *Storage) Icons(userID int64) (model.Icons, error) {
 	return icons, nil
 }
 
+// MarkFeedIconChecked records that the icon of the feed has just been downloaded, or that no icon was found.
+func (s *Storage) MarkFeedIconChecked(feedID int64) error {
+	if _, err := s.db.Exec(`UPDATE feeds SET icon_checked_at=now() WHERE id=$1`, feedID); err != nil {
+		return fmt.Errorf(`store: unable to update icon check date of feed #%d: %v`, feedID, err)
+	}
+	return nil
+}
+
+// FeedIconsToRefresh returns the enabled feeds whose icon was downloaded before the refresh interval,
+// or whose icon could not be found before the retry interval.
+func (s *Storage) FeedIconsToRefresh(refreshInterval, retryInterval time.Duration, limit int) (model.JobList, error) {
+	query := `
+		SELECT
+			f.id, f.user_id, f.feed_url
+		FROM
+			feeds f
+		LEFT JOIN
+			feed_icons fi ON fi.feed_id=f.id
+		WHERE
+			f.disabled='f' AND
+			(
+				(fi.icon_id IS NOT NULL AND f.icon_checked_at < now() - $1::interval) OR
+				(fi.icon_id IS NULL AND f.icon_checked_at < now() - $2::interval)
+			)
+		ORDER BY
+			f.icon_checked_at ASC
+		LIMIT $3
+	`
+
+	rows, err := s.db.Query(
+		query,
+		fmt.Sprintf("%d seconds", int(refreshInterval.Seconds())),
+		fmt.Sprintf("%d seconds", int(retryInterval.Seconds())),
+		limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch feed icons to refresh: %v`, err)
+	}
+	defer rows.Close()
+
+	jobs := make(model.JobList, 0, limit)
+	for rows.Next() {
+		var job model.Job
+		if err := rows.Scan(&job.FeedID, &job.UserID, &job.FeedURL); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch feed icon to refresh: %v`, err)
+		}
+		jobs = append(jobs, job)
+	}
+
+	return jobs, nil
+}
+
+// RemoveOrphanIcons deletes the icons that are no longer used by any feed.
+func (s *Storage) RemoveOrphanIcons() (int64, error) {
+	result, err := s.db.Exec(`DELETE FROM icons WHERE NOT EXISTS (SELECT 1 FROM feed_icons WHERE feed_icons.icon_id=icons.id)`)
+	if err != nil {
+		return 0, fmt.Errorf(`store: unable to remove orphan icons: %v`, err)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf(`store: unable to get the number of rows affected: %v`, err)
+	}
+
+	return count, nil
+}
+
 func normalizeMimeType(mimeType string) string {
 	mimeType = strings.ToLower(mimeType)
 	switch mimeType {
@@ -0,0 +1,316 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"miniflux.app/v2/internal/model"
+)
+
+// RecordBlockedEntry adds an entry to the blocked log of its feed and trims the log to the most recent entries.
+// It returns false if the entry is already in the log or already stored in the feed.
+func (s *Storage) RecordBlockedEntry(blockedEntry *model.BlockedEntry) (bool, error) {
+	entryJSON, err := json.Marshal(blockedEntry.Entry)
+	if err != nil {
+		return false, fmt.Errorf(`store: unable to serialize blocked entry: %v`, err)
+	}
+
+	// The entries blocked on every refresh are only inserted once, with a single query.
+	query := `
+		INSERT INTO blocked_entries
+			(user_id, feed_id, hash, title, url, entry, rule, rule_source, rule_field, rule_line, stage)
+		SELECT
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+		WHERE NOT EXISTS (
+			SELECT 1 FROM entries WHERE feed_id=$2 AND hash=$3
+		)
+		ON CONFLICT (feed_id, hash) DO NOTHING
+		RETURNING
+			id, created_at
+	`
+	err = s.db.QueryRow(
+		query,
+		blockedEntry.UserID,
+		blockedEntry.FeedID,
+		blockedEntry.Hash,
+		blockedEntry.Title,
+		blockedEntry.URL,
+		entryJSON,
+		blockedEntry.Rule.Rule,
+		blockedEntry.Rule.Source,
+		blockedEntry.Rule.Field,
+		blockedEntry.Rule.Line,
+		blockedEntry.Stage,
+	).Scan(&blockedEntry.ID, &blockedEntry.CreatedAt)
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf(`store: unable to record blocked entry %q (feed #%d): %v`, blockedEntry.URL, blockedEntry.FeedID, err)
+	}
+
+	query = `
+		DELETE FROM
+			blocked_entries
+		WHERE
+			feed_id=$1 AND
+			id NOT IN (
+				SELECT id FROM blocked_entries WHERE feed_id=$1 ORDER BY id DESC LIMIT $2
+			)
+	`
+	if _, err := s.db.Exec(query, blockedEntry.FeedID, model.MaxBlockedEntriesPerFeed); err != nil {
+		return false, fmt.Errorf(`store: unable to trim blocked entries (feed #%d): %v`, blockedEntry.FeedID, err)
+	}
+
+	return true, nil
+}
+
+// BlockedEntries returns the recently blocked entries of a feed, most recent first.
+func (s *Storage) BlockedEntries(userID, feedID int64) ([]*model.BlockedEntry, error) {
+	query := `
+		SELECT
+			id,
+			user_id,
+			feed_id,
+			hash,
+			title,
+			url,
+			rule,
+			rule_source,
+			rule_field,
+			rule_line,
+			stage,
+			created_at
+		FROM
+			blocked_entries
+		WHERE
+			user_id=$1 AND feed_id=$2
+		ORDER BY
+			id DESC
+	`
+	rows, err := s.db.Query(query, userID, feedID)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch blocked entries: %v`, err)
+	}
+	defer rows.Close()
+
+	blockedEntries := make([]*model.BlockedEntry, 0)
+	for rows.Next() {
+		blockedEntry := &model.BlockedEntry{Rule: &model.RulePreviewRule{}}
+		err := rows.Scan(
+			&blockedEntry.ID,
+			&blockedEntry.UserID,
+			&blockedEntry.FeedID,
+			&blockedEntry.Hash,
+			&blockedEntry.Title,
+			&blockedEntry.URL,
+			&blockedEntry.Rule.Rule,
+			&blockedEntry.Rule.Source,
+			&blockedEntry.Rule.Field,
+			&blockedEntry.Rule.Line,
+			&blockedEntry.Stage,
+			&blockedEntry.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch blocked entry row: %v`, err)
+		}
+		blockedEntries = append(blockedEntries, blockedEntry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch blocked entries: %v`, err)
+	}
+
+	return blockedEntries, nil
+}
+
+// BlockedEntryByID returns a blocked entry, including the entry as it was when the rule matched.
+func (s *Storage) BlockedEntryByID(userID, blockedEntryID int64) (*model.BlockedEntry, error) {
+	var entryJSON []byte
+	blockedEntry := &model.BlockedEntry{Rule: &model.RulePreviewRule{}}
+
+	query := `
+		SELECT
+			id,
+			user_id,
+			feed_id,
+			hash,
+			title,
+			url,
+			entry,
+			rule,
+			rule_source,
+			rule_field,
+			rule_line,
+			stage,
+			created_at
+		FROM
+			blocked_entries
+		WHERE
+			user_id=$1 AND id=$2
+	`
+	err := s.db.QueryRow(query, userID, blockedEntryID).Scan(
+		&blockedEntry.ID,
+		&blockedEntry.UserID,
+		&blockedEntry.FeedID,
+		&blockedEntry.Hash,
+		&blockedEntry.Title,
+		&blockedEntry.URL,
+		&entryJSON,
+		&blockedEntry.Rule.Rule,
+		&blockedEntry.Rule.Source,
+		&blockedEntry.Rule.Field,
+		&blockedEntry.Rule.Line,
+		&blockedEntry.Stage,
+		&blockedEntry.CreatedAt,
+	)
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf(`store: unable to fetch blocked entry #%d: %v`, blockedEntryID, err)
+	}
+
+	blockedEntry.Entry = &model.Entry{}
+	if err := json.Unmarshal(entryJSON, blockedEntry.Entry); err != nil {
+		return nil, fmt.Errorf(`store: unable to deserialize blocked entry #%d: %v`, blockedEntryID, err)
+	}
+
+	return blockedEntry, nil
+}
+
+// RescueBlockedEntry stores a blocked entry as unread and removes it from the blocked log.
+// The tombstone of the entry, if any, is removed. An entry already stored is left untouched.
+func (s *Storage) RescueBlockedEntry(blockedEntry *model.BlockedEntry) error {
+	entry := blockedEntry.Entry
+	entry.UserID = blockedEntry.UserID
+	entry.FeedID = blockedEntry.FeedID
+	entry.Hash = blockedEntry.Hash
+	entry.Status = model.EntryStatusUnread
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf(`store: unable to start transaction: %v`, err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM blocked_entries WHERE user_id=$1 AND id=$2`, blockedEntry.UserID, blockedEntry.ID); err != nil {
+		return fmt.Errorf(`store: unable to remove blocked entry #%d: %v`, blockedEntry.ID, err)
+	}
+
+	if _, err := tx.Exec(`DELETE FROM entry_tombstones WHERE feed_id=$1 AND hash=$2`, entry.FeedID, entry.Hash); err != nil {
+		return fmt.Errorf(`store: unable to remove entry tombstone: %v`, err)
+	}
+
+	entryID, err := s.getEntryIDByHash(tx, entry.FeedID, entry.Hash)
+	if err != nil {
+		return err
+	}
+
+	if entryID > 0 {
+		entry.ID = entryID
+	} else if err := s.createEntry(tx, entry); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf(`store: unable to commit transaction: %v`, err)
+	}
+
+	return nil
+}
+
+// RecordRuleHits adds the given number of hits to the rules of a feed.
+// The counter of a rule is reset when the rule at the same position changes.
+func (s *Storage) RecordRuleHits(userID, feedID int64, hits []*model.RuleHit) error {
+	if len(hits) == 0 {
+		return nil
+	}
+
+	sources := make([]string, len(hits))
+	fields := make([]string, len(hits))
+	lines := make([]int64, len(hits))
+	ruleTexts := make([]string, len(hits))
+	counts := make([]int64, len(hits))
+	for i, hit := range hits {
+		sources[i] = hit.Source
+		fields[i] = hit.Field
+		lines[i] = int64(hit.Line)
+		ruleTexts[i] = hit.Rule
+		counts[i] = int64(hit.Hits)
+	}
+
+	query := `
+		INSERT INTO rule_hits
+			(user_id, feed_id, rule_source, rule_field, rule_line, rule, hits)
+		SELECT
+			$1, $2, source, field, line, rule, hits
+		FROM
+			unnest($3::text[], $4::text[], $5::int[], $6::text[], $7::bigint[]) AS h(source, field, line, rule, hits)
+		ON CONFLICT (feed_id, rule_source, rule_field, rule_line) DO UPDATE SET
+			hits=CASE WHEN rule_hits.rule=EXCLUDED.rule THEN rule_hits.hits + EXCLUDED.hits ELSE EXCLUDED.hits END,
+			rule=EXCLUDED.rule,
+			last_hit_at=now()
+	`
+	_, err := s.db.Exec(
+		query,
+		userID,
+		feedID,
+		pq.Array(sources),
+		pq.Array(fields),
+		pq.Array(lines),
+		pq.Array(ruleTexts),
+		pq.Array(counts),
+	)
+	if err != nil {
+		return fmt.Errorf(`store: unable to record rule hits (feed #%d): %v`, feedID, err)
+	}
+
+	return nil
+}
+
+// RuleHits returns the hit counters of the rules of a feed.
+func (s *Storage) RuleHits(userID, feedID int64) ([]*model.RuleHit, error) {
+	query := `
+		SELECT
+			rule,
+			rule_source,
+			rule_field,
+			rule_line,
+			hits,
+			last_hit_at
+		FROM
+			rule_hits
+		WHERE
+			user_id=$1 AND feed_id=$2
+	`
+	rows, err := s.db.Query(query, userID, feedID)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch rule hits: %v`, err)
+	}
+	defer rows.Close()
+
+	hits := make([]*model.RuleHit, 0)
+	for rows.Next() {
+		var hit model.RuleHit
+		if err := rows.Scan(&hit.Rule, &hit.Source, &hit.Field, &hit.Line, &hit.Hits, &hit.LastHitAt); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch rule hit row: %v`, err)
+		}
+		hits = append(hits, &hit)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch rule hits: %v`, err)
+	}
+
+	return hits, nil
+}
@@ -0,0 +1,309 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+
+	"miniflux.app/v2/internal/model"
+)
+
+// A running rule job without progress update for this long is considered interrupted.
+// The pending jobs wait for the rule job worker and are never interrupted.
+const ruleJobStaleInterval = 10 * time.Minute
+
+// ErrRuleJobAlreadyRunning is returned when a rule job is already pending or running for the same feeds.
+var ErrRuleJobAlreadyRunning = errors.New("store: a rule job is already running")
+
+// CreateRuleJob creates a pending rule job.
+func (s *Storage) CreateRuleJob(userID int64, request *model.RuleJobRequest) (*model.RuleJob, error) {
+	job := &model.RuleJob{
+		UserID:     userID,
+		FeedID:     request.FeedID,
+		CategoryID: request.CategoryID,
+		Action:     request.Action,
+		Status:     model.RuleJobStatusPending,
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to start transaction: %v`, err)
+	}
+	defer tx.Rollback()
+
+	// An interrupted job must not prevent the creation of a new one.
+	_, err = tx.Exec(`
+		UPDATE
+			rule_jobs
+		SET
+			status=$1,
+			error_msg='interrupted',
+			finished_at=now()
+		WHERE
+			user_id=$2 AND
+			feed_id=$3 AND
+			category_id=$4 AND
+			status=$5 AND
+			updated_at < now() - $6::interval
+	`,
+		model.RuleJobStatusFailed,
+		job.UserID,
+		job.FeedID,
+		job.CategoryID,
+		model.RuleJobStatusRunning,
+		fmt.Sprintf("%d seconds", int(ruleJobStaleInterval.Seconds())),
+	)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to update interrupted rule jobs: %v`, err)
+	}
+
+	// The rule_jobs_active_idx index allows a single pending or running job for the same feeds.
+	query := `
+		INSERT INTO rule_jobs
+			(user_id, feed_id, category_id, action, status)
+		VALUES
+			($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id, feed_id, category_id) WHERE status IN ('pending', 'running') DO NOTHING
+		RETURNING
+			id, created_at
+	`
+	err = tx.QueryRow(query, job.UserID, job.FeedID, job.CategoryID, job.Action, job.Status).Scan(&job.ID, &job.CreatedAt)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil, ErrRuleJobAlreadyRunning
+	case err != nil:
+		return nil, fmt.Errorf(`store: unable to create rule job: %v`, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf(`store: unable to commit transaction: %v`, err)
+	}
+
+	return job, nil
+}
+
+// RuleJobByID returns a rule job by its ID.
+func (s *Storage) RuleJobByID(userID, jobID int64) (*model.RuleJob, error) {
+	var job model.RuleJob
+	var finishedAt sql.NullTime
+
+	query := `
+		SELECT
+			id,
+			user_id,
+			feed_id,
+			category_id,
+			action,
+			status,
+			total,
+			processed,
+			matched,
+			error_msg,
+			created_at,
+			finished_at
+		FROM
+			rule_jobs
+		WHERE
+			user_id=$1 AND id=$2
+	`
+	err := s.db.QueryRow(query, userID, jobID).Scan(
+		&job.ID,
+		&job.UserID,
+		&job.FeedID,
+		&job.CategoryID,
+		&job.Action,
+		&job.Status,
+		&job.Total,
+		&job.Processed,
+		&job.Matched,
+		&job.Error,
+		&job.CreatedAt,
+		&finishedAt,
+	)
+
+	switch {
+	case err == sql.ErrNoRows:
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf(`store: unable to fetch rule job: %v`, err)
+	}
+
+	if finishedAt.Valid {
+		job.FinishedAt = &finishedAt.Time
+	}
+
+	return &job, nil
+}
+
+// PendingRuleJobs returns the rule jobs of all users waiting to be applied, oldest first.
+func (s *Storage) PendingRuleJobs() ([]*model.RuleJob, error) {
+	query := `
+		SELECT
+			id,
+			user_id,
+			feed_id,
+			category_id,
+			action,
+			status,
+			created_at
+		FROM
+			rule_jobs
+		WHERE
+			status=$1
+		ORDER BY
+			id ASC
+	`
+	rows, err := s.db.Query(query, model.RuleJobStatusPending)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch pending rule jobs: %v`, err)
+	}
+	defer rows.Close()
+
+	var jobs []*model.RuleJob
+	for rows.Next() {
+		var job model.RuleJob
+		if err := rows.Scan(&job.ID, &job.UserID, &job.FeedID, &job.CategoryID, &job.Action, &job.Status, &job.CreatedAt); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch pending rule job: %v`, err)
+		}
+		jobs = append(jobs, &job)
+	}
+
+	return jobs, nil
+}
+
+// StartRuleJob marks a pending rule job as running.
+// It returns false if the job is no longer pending, for example when it was already started by another worker.
+func (s *Storage) StartRuleJob(job *model.RuleJob) (bool, error) {
+	query := `
+		UPDATE
+			rule_jobs
+		SET
+			status=$1,
+			updated_at=now()
+		WHERE
+			id=$2 AND user_id=$3 AND status=$4
+	`
+	result, err := s.db.Exec(query, model.RuleJobStatusRunning, job.ID, job.UserID, model.RuleJobStatusPending)
+	if err != nil {
+		return false, fmt.Errorf(`store: unable to start rule job #%d: %v`, job.ID, err)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf(`store: unable to get the number of rows affected: %v`, err)
+	}
+
+	if count == 0 {
+		return false, nil
+	}
+
+	job.Status = model.RuleJobStatusRunning
+	return true, nil
+}
+
+// UpdateRuleJob saves the status and the progress of a rule job.
+func (s *Storage) UpdateRuleJob(job *model.RuleJob) error {
+	query := `
+		UPDATE
+			rule_jobs
+		SET
+			status=$1,
+			total=$2,
+			processed=$3,
+			matched=$4,
+			error_msg=$5,
+			updated_at=now(),
+			finished_at=CASE WHEN $6 THEN now() ELSE NULL END
+		WHERE
+			id=$7 AND user_id=$8
+		RETURNING
+			finished_at
+	`
+	var finishedAt sql.NullTime
+	err := s.db.QueryRow(
+		query,
+		job.Status,
+		job.Total,
+		job.Processed,
+		job.Matched,
+		job.Error,
+		job.IsFinished(),
+		job.ID,
+		job.UserID,
+	).Scan(&finishedAt)
+	if err != nil {
+		return fmt.Errorf(`store: unable to update rule job #%d: %v`, job.ID, err)
+	}
+
+	if finishedAt.Valid {
+		job.FinishedAt = &finishedAt.Time
+	}
+
+	return nil
+}
+
+// CleanOldRuleJobs removes the rule jobs created before the given interval, and marks interrupted jobs as failed.
+func (s *Storage) CleanOldRuleJobs(interval time.Duration) (int64, error) {
+	query := `
+		UPDATE
+			rule_jobs
+		SET
+			status=$1,
+			error_msg='interrupted',
+			finished_at=now()
+		WHERE
+			status=$2 AND
+			updated_at < now() - $3::interval
+	`
+	staleInterval := fmt.Sprintf("%d seconds", int(ruleJobStaleInterval.Seconds()))
+	if _, err := s.db.Exec(query, model.RuleJobStatusFailed, model.RuleJobStatusRunning, staleInterval); err != nil {
+		return 0, fmt.Errorf(`store: unable to mark interrupted rule jobs as failed: %v`, err)
+	}
+
+	days := max(int(interval/(24*time.Hour)), 1)
+	result, err := s.db.Exec(`DELETE FROM rule_jobs WHERE created_at < now() - $1::interval`, fmt.Sprintf("%d days", days))
+	if err != nil {
+		return 0, fmt.Errorf(`store: unable to remove old rule jobs: %v`, err)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf(`store: unable to get the number of rows affected: %v`, err)
+	}
+
+	return count, nil
+}
+
+// RemoveEntriesWithTombstones deletes the given entries and records tombstones to prevent re-ingestion.
+// Starred, saved and shared entries are kept. It returns the number of removed entries.
+func (s *Storage) RemoveEntriesWithTombstones(userID int64, entryIDs []int64) (int, error) {
+	query := `
+		WITH deleted AS (
+			DELETE FROM entries
+			WHERE
+				user_id=$1 AND
+				id=ANY($2) AND
+				starred is false AND
+				saved_for_later is false AND
+				share_code=''
+			RETURNING feed_id, hash
+		), tombstones AS (
+			INSERT INTO entry_tombstones (feed_id, hash)
+			SELECT feed_id, hash FROM deleted WHERE hash <> ''
+			ON CONFLICT (feed_id, hash) DO NOTHING
+		)
+		SELECT count(*) FROM deleted
+	`
+	var count int
+	if err := s.db.QueryRow(query, userID, pq.Array(entryIDs)).Scan(&count); err != nil {
+		return 0, fmt.Errorf(`store: unable to remove entries %v: %v`, entryIDs, err)
+	}
+
+	return count, nil
+}
@@ -0,0 +1,231 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/search"
+)
+
+// SavedSearchByID returns a saved search by its ID.
+func (s *Storage) SavedSearchByID(userID, savedSearchID int64) (*model.SavedSearch, error) {
+	var savedSearch model.SavedSearch
+
+	query := `SELECT id, user_id, title, query FROM saved_searches WHERE user_id=$1 AND id=$2`
+	err := s.db.QueryRow(query, userID, savedSearchID).Scan(
+		&savedSearch.ID,
+		&savedSearch.UserID,
+		&savedSearch.Title,
+		&savedSearch.Query,
+	)
+
+	switch {
+	case err == sql.ErrNoRows:
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf(`store: unable to fetch saved search: %v`, err)
+	default:
+		return &savedSearch, nil
+	}
+}
+
+// SavedSearches returns all saved searches of a user.
+func (s *Storage) SavedSearches(userID int64) (model.SavedSearches, error) {
+	query := `SELECT id, user_id, title, query FROM saved_searches WHERE user_id=$1 ORDER BY lower(title) ASC`
+	rows, err := s.db.Query(query, userID)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch saved searches: %v`, err)
+	}
+	defer rows.Close()
+
+	savedSearches := make(model.SavedSearches, 0)
+	for rows.Next() {
+		var savedSearch model.SavedSearch
+		if err := rows.Scan(&savedSearch.ID, &savedSearch.UserID, &savedSearch.Title, &savedSearch.Query); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch saved search row: %v`, err)
+		}
+		savedSearches = append(savedSearches, &savedSearch)
+	}
+
+	return savedSearches, nil
+}
+
+// SavedSearchesWithUnreadCount returns all saved searches of a user with the number of unread entries matching each query.
+// The unread entries are counted with a single query, one filtered count per saved search.
+func (s *Storage) SavedSearchesWithUnreadCount(userID int64) (model.SavedSearches, error) {
+	savedSearches, err := s.SavedSearches(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(savedSearches) == 0 {
+		return savedSearches, nil
+	}
+
+	args := []any{userID, model.EntryStatusUnread}
+	counts := make([]string, 0, len(savedSearches))
+	unreadCounts := make([]any, 0, len(savedSearches))
+	for _, savedSearch := range savedSearches {
+		var conditions []string
+		conditions, args, _ = searchConditions(search.Parse(savedSearch.Query), args)
+		if len(conditions) == 0 {
+			conditions = []string{"true"}
+		}
+		counts = append(counts, "count(*) FILTER (WHERE "+strings.Join(conditions, " AND ")+")")
+
+		savedSearch.UnreadCount = new(int)
+		unreadCounts = append(unreadCounts, savedSearch.UnreadCount)
+	}
+
+	query := `
+		SELECT ` + strings.Join(counts, ", ") + `
+		FROM entries e
+			JOIN feeds f ON f.id = e.feed_id
+			JOIN categories c ON c.id = f.category_id
+		WHERE e.user_id=$1 AND e.status=$2
+	`
+	if err := s.db.QueryRow(query, args...).Scan(unreadCounts...); err != nil {
+		return nil, fmt.Errorf(`store: unable to count the unread entries of the saved searches: %v`, err)
+	}
+
+	return savedSearches, nil
+}
+
+// MarkSavedSearchAsRead updates the unread entries matching a search query to the read status.
+func (s *Storage) MarkSavedSearchAsRead(userID int64, searchQuery string, before time.Time) error {
+	conditions, args, _ := searchConditions(
+		search.Parse(searchQuery),
+		[]any{model.EntryStatusRead, userID, model.EntryStatusUnread, before},
+	)
+	conditions = append([]string{"e.user_id=$2", "e.status=$3", "e.published_at < $4"}, conditions...)
+
+	query := `
+		WITH updated AS (
+			UPDATE
+				entries e
+			SET
+				status=$1,
+				saved_for_later=false,
+				read_at=now(),
+				changed_at=now()
+			WHERE
+				` + strings.Join(conditions, " AND ") + `
+			RETURNING
+				e.id, e.user_id, e.feed_id, e.reading_time
+		)
+		INSERT INTO entry_status_events (user_id, entry_id, feed_id, status, reading_time, bulk)
+		SELECT user_id, id, feed_id, $1, reading_time, 't' FROM updated
+	`
+	result, err := s.db.Exec(query, args...)
+	if err != nil {
+		return fmt.Errorf(`store: unable to mark saved search entries as read: %v`, err)
+	}
+
+	count, _ := result.RowsAffected()
+	slog.Debug("Marked saved search entries as read",
+		slog.Int64("user_id", userID),
+		slog.String("query", searchQuery),
+		slog.Int64("nb_entries", count),
+		slog.String("before", before.Format(time.RFC3339)),
+	)
+
+	return nil
+}
+
+// SavedSearchIDExists checks if the given saved search exists.
+func (s *Storage) SavedSearchIDExists(userID, savedSearchID int64) bool {
+	var result bool
+	query := `SELECT true FROM saved_searches WHERE user_id=$1 AND id=$2 LIMIT 1`
+	s.db.QueryRow(query, userID, savedSearchID).Scan(&result)
+	return result
+}
+
+// SavedSearchTitleExists checks if a saved search with the given title exists.
+func (s *Storage) SavedSearchTitleExists(userID int64, title string) bool {
+	var result bool
+	query := `SELECT true FROM saved_searches WHERE user_id=$1 AND lower(title)=lower($2) LIMIT 1`
+	s.db.QueryRow(query, userID, title).Scan(&result)
+	return result
+}
+
+// AnotherSavedSearchExists checks if another saved search exists with the same title.
+func (s *Storage) AnotherSavedSearchExists(userID, savedSearchID int64, title string) bool {
+	var result bool
+	query := `SELECT true FROM saved_searches WHERE user_id=$1 AND id != $2 AND lower(title)=lower($3) LIMIT 1`
+	s.db.QueryRow(query, userID, savedSearchID, title).Scan(&result)
+	return result
+}
+
+// CreateSavedSearch creates a new saved search.
+func (s *Storage) CreateSavedSearch(userID int64, request *model.SavedSearchCreationRequest) (*model.SavedSearch, error) {
+	var savedSearch model.SavedSearch
+
+	query := `
+		INSERT INTO saved_searches
+			(user_id, title, query)
+		VALUES
+			($1, $2, $3)
+		RETURNING
+			id,
+			user_id,
+			title,
+			query
+	`
+	err := s.db.QueryRow(
+		query,
+		userID,
+		request.Title,
+		request.Query,
+	).Scan(
+		&savedSearch.ID,
+		&savedSearch.UserID,
+		&savedSearch.Title,
+		&savedSearch.Query,
+	)
+
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to create saved search %q for user ID %d: %v`, request.Title, userID, err)
+	}
+
+	return &savedSearch, nil
+}
+
+// UpdateSavedSearch updates an existing saved search.
+func (s *Storage) UpdateSavedSearch(savedSearch *model.SavedSearch) error {
+	query := `UPDATE saved_searches SET title=$1, query=$2 WHERE id=$3 AND user_id=$4`
+	_, err := s.db.Exec(query, savedSearch.Title, savedSearch.Query, savedSearch.ID, savedSearch.UserID)
+
+	if err != nil {
+		return fmt.Errorf(`store: unable to update saved search: %v`, err)
+	}
+
+	return nil
+}
+
+// RemoveSavedSearch deletes a saved search.
+func (s *Storage) RemoveSavedSearch(userID, savedSearchID int64) error {
+	query := `DELETE FROM saved_searches WHERE id = $1 AND user_id = $2`
+	result, err := s.db.Exec(query, savedSearchID, userID)
+	if err != nil {
+		return fmt.Errorf(`store: unable to remove this saved search: %v`, err)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf(`store: unable to remove this saved search: %v`, err)
+	}
+
+	if count == 0 {
+		return errors.New(`store: no saved search has been removed`)
+	}
+
+	return nil
+}
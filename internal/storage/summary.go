@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"miniflux.app/v2/internal/model"
+)
+
+// MarkEntrySummariesPending marks the given entries without summary as waiting for a summary.
+func (s *Storage) MarkEntrySummariesPending(userID int64, entryIDs []int64) error {
+	query := `UPDATE entries SET summary_pending='t' WHERE user_id=$1 AND id=ANY($2) AND summary=''`
+	if _, err := s.db.Exec(query, userID, pq.Array(entryIDs)); err != nil {
+		return fmt.Errorf(`store: unable to mark entry summaries as pending: %v`, err)
+	}
+
+	return nil
+}
+
+// EntriesWithPendingSummary returns the oldest entries of all users waiting for a summary.
+// Only the IDs of the entries, of their user and of their feed are loaded.
+func (s *Storage) EntriesWithPendingSummary(limit int) (model.Entries, error) {
+	query := `SELECT id, user_id, feed_id FROM entries WHERE summary_pending ORDER BY id ASC LIMIT $1`
+	rows, err := s.db.Query(query, limit)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch entries with a pending summary: %v`, err)
+	}
+	defer rows.Close()
+
+	entries := make(model.Entries, 0)
+	for rows.Next() {
+		var entry model.Entry
+		if err := rows.Scan(&entry.ID, &entry.UserID, &entry.FeedID); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch entry with a pending summary: %v`, err)
+		}
+		entries = append(entries, &entry)
+	}
+
+	return entries, nil
+}
+
+// ClearEntrySummaryPending removes an entry from the entries waiting for a summary.
+func (s *Storage) ClearEntrySummaryPending(userID, entryID int64) error {
+	query := `UPDATE entries SET summary_pending='f' WHERE user_id=$1 AND id=$2`
+	if _, err := s.db.Exec(query, userID, entryID); err != nil {
+		return fmt.Errorf(`store: unable to clear the pending summary of entry #%d: %v`, entryID, err)
+	}
+
+	return nil
+}
+
+// SummaryTokenUsage returns the number of tokens used today (UTC) to summarize the entries of the user.
+func (s *Storage) SummaryTokenUsage(userID int64) (int64, error) {
+	var tokens int64
+	query := `SELECT tokens FROM summary_token_usage WHERE user_id=$1 AND day=(now() AT TIME ZONE 'UTC')::date`
+	err := s.db.QueryRow(query, userID).Scan(&tokens)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return 0, nil
+	case err != nil:
+		return 0, fmt.Errorf(`store: unable to fetch the summary token usage: %v`, err)
+	}
+
+	return tokens, nil
+}
+
+// AddSummaryTokenUsage adds the given number of tokens to the usage of the user for the day (UTC).
+func (s *Storage) AddSummaryTokenUsage(userID int64, tokens int) error {
+	query := `
+		INSERT INTO summary_token_usage
+			(user_id, day, tokens)
+		VALUES
+			($1, (now() AT TIME ZONE 'UTC')::date, $2)
+		ON CONFLICT (user_id, day) DO UPDATE SET
+			tokens = summary_token_usage.tokens + EXCLUDED.tokens
+	`
+	if _, err := s.db.Exec(query, userID, tokens); err != nil {
+		return fmt.Errorf(`store: unable to record the summary token usage: %v`, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// textSearchConfigs maps the entry languages to the text search configurations shipped with PostgreSQL.
+// The entries in other languages use the default configuration of the database (default_text_search_config).
+var textSearchConfigs = map[string]string{
+	"da": "danish",
+	"de": "german",
+	"en": "english",
+	"es": "spanish",
+	"fi": "finnish",
+	"fr": "french",
+	"hu": "hungarian",
+	"it": "italian",
+	"nb": "norwegian",
+	"nl": "dutch",
+	"nn": "norwegian",
+	"no": "norwegian",
+	"pt": "portuguese",
+	"ro": "romanian",
+	"ru": "russian",
+	"sv": "swedish",
+	"tr": "turkish",
+}
+
+// textSearchConfigNames is the sorted list of the text search configurations used for the entries.
+var textSearchConfigNames = slices.Compact(slices.Sorted(maps.Values(textSearchConfigs)))
+
+// textSearchConfig returns the text search configuration of the language, or an empty string for the default configuration.
+func textSearchConfig(language string) string {
+	return textSearchConfigs[language]
+}
+
+// textSearchConfigExpression returns the SQL expression of the text search configuration of a language column,
+// for the statements where the language is only known by the database.
+func textSearchConfigExpression(language string) string {
+	var expression strings.Builder
+	expression.WriteString("CASE " + language)
+	for _, language := range slices.Sorted(maps.Keys(textSearchConfigs)) {
+		expression.WriteString(" WHEN '" + language + "' THEN '" + textSearchConfigs[language] + "'")
+	}
+	expression.WriteString(" ELSE '' END")
+	return expression.String()
+}
+
+// documentVectorsExpression returns the SQL expression of the entry search index.
+// The title is weighted more than the content and the summary, the summary being limited to 20000 characters.
+// The text of the attachments has the lowest weight and is limited to 100000 characters.
+// config is an SQL expression of the text search configuration name, empty for the default configuration.
+func documentVectorsExpression(config, title, content, summary, attachmentText string) string {
+	return fmt.Sprintf(
+		"setweight(to_tsvector(%[1]s, %[2]s), 'A') || setweight(to_tsvector(%[1]s, %[3]s), 'B') || setweight(to_tsvector(%[1]s, left(%[4]s, 20000)), 'B') || setweight(to_tsvector(%[1]s, left(%[5]s, 100000)), 'C')",
+		"COALESCE(NULLIF("+config+", '')::regconfig, get_current_ts_config())",
+		title,
+		content,
+		summary,
+		attachmentText,
+	)
+}
+
+// searchCondition returns the SQL condition matching the entries with the full-text query.
+// The query is parsed with the configuration of each entry since the words are stemmed differently in each language.
+// The first condition is a superset that can use the index: the query parsed with every configuration.
+func searchCondition(text string) string {
+	queries := make([]string, 0, len(textSearchConfigNames)+1)
+	queries = append(queries, "websearch_to_tsquery("+text+")")
+	for _, config := range textSearchConfigNames {
+		queries = append(queries, "websearch_to_tsquery('"+config+"', "+text+")")
+	}
+	return "e.document_vectors @@ (" + strings.Join(queries, " || ") + ") AND e.document_vectors @@ " + entrySearchTSQuery(text)
+}
+
+// entryTextSearchConfig is the SQL expression of the text search configuration of the entry language.
+var entryTextSearchConfig = func() string {
+	var expression strings.Builder
+	expression.WriteString("CASE e.language")
+	for _, language := range slices.Sorted(maps.Keys(textSearchConfigs)) {
+		fmt.Fprintf(&expression, " WHEN '%s' THEN '%s'::regconfig", language, textSearchConfigs[language])
+	}
+	expression.WriteString(" ELSE get_current_ts_config() END")
+	return expression.String()
+}()
+
+// entrySearchTSQuery returns the SQL expression of the full-text query parsed with the configuration of the entry language.
+func entrySearchTSQuery(text string) string {
+	return "websearch_to_tsquery(" + entryTextSearchConfig + ", " + text + ")"
+}
@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestTextSearchConfig(t *testing.T) {
+	scenarios := map[string]string{
+		"en": "english",
+		"fr": "french",
+		"de": "german",
+		"nb": "norwegian",
+		"ja": "",
+		"":   "",
+	}
+
+	for language, expected := range scenarios {
+		if result := textSearchConfig(language); result != expected {
+			t.Errorf(`Unexpected configuration for %q: got %q instead of %q`, language, result, expected)
+		}
+	}
+
+	if !slices.IsSorted(textSearchConfigNames) || len(slices.Compact(slices.Clone(textSearchConfigNames))) != len(textSearchConfigNames) {
+		t.Errorf(`The configuration names should be sorted and unique: %v`, textSearchConfigNames)
+	}
+}
+
+func TestTextSearchConfigExpression(t *testing.T) {
+	expression := textSearchConfigExpression("p.language")
+
+	for _, expected := range []string{"CASE p.language WHEN 'da' THEN 'danish'", " WHEN 'fr' THEN 'french'", " ELSE '' END"} {
+		if !strings.Contains(expression, expected) {
+			t.Errorf(`The expression should contain %q: %s`, expected, expression)
+		}
+	}
+
+	if count := strings.Count(expression, " WHEN "); count != len(textSearchConfigs) {
+		t.Errorf(`The expression should have one case per language, got %d`, count)
+	}
+}
+
+func TestDocumentVectorsExpression(t *testing.T) {
+	expected := "setweight(to_tsvector(COALESCE(NULLIF($4, '')::regconfig, get_current_ts_config()), $1), 'A') || " +
+		"setweight(to_tsvector(COALESCE(NULLIF($4, '')::regconfig, get_current_ts_config()), $2), 'B') || " +
+		"setweight(to_tsvector(COALESCE(NULLIF($4, '')::regconfig, get_current_ts_config()), left(summary, 20000)), 'B') || " +
+		"setweight(to_tsvector(COALESCE(NULLIF($4, '')::regconfig, get_current_ts_config()), left(attachment_text, 100000)), 'C')"
+
+	if result := documentVectorsExpression("$4", "$1", "$2", "summary", "attachment_text"); result != expected {
+		t.Errorf(`Unexpected expression: got %q instead of %q`, result, expected)
+	}
+}
+
+func TestSearchCondition(t *testing.T) {
+	condition := searchCondition("$2")
+
+	for _, expected := range []string{
+		"e.document_vectors @@ (websearch_to_tsquery($2) || websearch_to_tsquery('danish', $2) || ",
+		"websearch_to_tsquery('turkish', $2)) AND ",
+		"e.document_vectors @@ websearch_to_tsquery(CASE e.language WHEN 'da' THEN 'danish'::regconfig",
+		"WHEN 'fr' THEN 'french'::regconfig",
+		"ELSE get_current_ts_config() END, $2)",
+	} {
+		if !strings.Contains(condition, expected) {
+			t.Errorf(`The condition %q should contain %q`, condition, expected)
+		}
+	}
+}
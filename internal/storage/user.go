@@ -99,7 +99,9 @@ func (s *Storage) CreateUser(userCreationRequest *model.UserCreationRequest) (*m
 			always_open_external_links,
 			open_external_links_in_new_tab,
 			show_voting_buttons,
-			show_feed_tags
+			show_feed_tags,
+			duplicate_entries_action,
+			entry_rules
 	`
 
 	tx, err := s.db.Begin()
@@ -148,6 +150,8 @@ func (s *Storage) CreateUser(userCreationRequest *model.UserCreationRequest) (*m
 		&user.OpenExternalLinksInNewTab,
 		&user.ShowVotingButtons,
 		&user.ShowFeedTags,
+		&user.DuplicateEntriesAction,
+		&user.EntryRules,
 	)
 	if err != nil {
 		tx.Rollback()
@@ -217,9 +221,11 @@ func (s *Storage) UpdateUser(user *model.User) error {
 				always_open_external_links=$30,
 				open_external_links_in_new_tab=$31,
 				show_voting_buttons=$32,
-				show_feed_tags=$33
+				show_feed_tags=$33,
+				duplicate_entries_action=$34,
+				entry_rules=$35
 			WHERE
-				id=$34
+				id=$36
 		`
 
 		_, err = s.db.Exec(
@@ -257,6 +263,8 @@ func (s *Storage) UpdateUser(user *model.User) error {
 			user.OpenExternalLinksInNewTab,
 			user.ShowVotingButtons,
 			user.ShowFeedTags,
+			user.DuplicateEntriesAction,
+			user.EntryRules,
 			user.ID,
 		)
 		if err != nil {
@@ -296,9 +304,11 @@ func (s *Storage) UpdateUser(user *model.User) error {
 				always_open_external_links=$29,
 				open_external_links_in_new_tab=$30,
 				show_voting_buttons=$31,
-				show_feed_tags=$32
+				show_feed_tags=$32,
+				duplicate_entries_action=$33,
+				entry_rules=$34
 			WHERE
-				id=$33
+				id=$35
 		`
 
 		_, err := s.db.Exec(
@@ -335,6 +345,8 @@ func (s *Storage) UpdateUser(user *model.User) error {
 			user.OpenExternalLinksInNewTab,
 			user.ShowVotingButtons,
 			user.ShowFeedTags,
+			user.DuplicateEntriesAction,
+			user.EntryRules,
 			user.ID,
 		)
 
@@ -393,7 +405,9 @@ func (s *Storage) UserByID(userID int64) (*model.User, error) {
 			always_open_external_links,
 			open_external_links_in_new_tab,
 			show_voting_buttons,
-			show_feed_tags
+			show_feed_tags,
+			duplicate_entries_action,
+			entry_rules
 		FROM
 			users
 		WHERE
@@ -439,7 +453,9 @@ func (s *Storage) UserByUsername(username string) (*model.User, error) {
 			always_open_external_links,
 			open_external_links_in_new_tab,
 			show_voting_buttons,
-			show_feed_tags
+			show_feed_tags,
+			duplicate_entries_action,
+			entry_rules
 		FROM
 			users
 		WHERE
@@ -485,7 +501,9 @@ func (s *Storage) UserByField(field, value string) (*model.User, error) {
 			always_open_external_links,
 			open_external_links_in_new_tab,
 			show_voting_buttons,
-			show_feed_tags
+			show_feed_tags,
+			duplicate_entries_action,
+			entry_rules
 		FROM
 			users
 		WHERE
@@ -539,7 +557,9 @@ func (s *Storage) UserByAPIKey(token string) (*model.User, error) {
 			u.always_open_external_links,
 			u.open_external_links_in_new_tab,
 			u.show_voting_buttons,
-			u.show_feed_tags
+			u.show_feed_tags,
+			u.duplicate_entries_action,
+			u.entry_rules
 		FROM
 			users u
 		LEFT JOIN
@@ -587,6 +607,8 @@ func (s *Storage) fetchUser(query string, args ...any) (*model.User, error) {
 		&user.OpenExternalLinksInNewTab,
 		&user.ShowVotingButtons,
 		&user.ShowFeedTags,
+		&user.DuplicateEntriesAction,
+		&user.EntryRules,
 	)
 
 	if err == sql.ErrNoRows {
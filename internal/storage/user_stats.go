@@ -0,0 +1,239 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package storage // import "miniflux.app/v2/internal/storage"
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"time"
+
+	"miniflux.app/v2/internal/model"
+
+	"github.com/lib/pq"
+)
+
+// UserStats returns the reading statistics of a user, the periods starting in the timezone of the user.
+func (s *Storage) UserStats(userID int64, timezone string) (*model.UserStats, error) {
+	var stats model.UserStats
+	var err error
+
+	if stats.Days, err = s.userStatsPeriods(userID, timezone, "day", model.UserStatsDays); err != nil {
+		return nil, err
+	}
+
+	if stats.Weeks, err = s.userStatsPeriods(userID, timezone, "week", model.UserStatsWeeks); err != nil {
+		return nil, err
+	}
+
+	if stats.Months, err = s.userStatsPeriods(userID, timezone, "month", model.UserStatsMonths); err != nil {
+		return nil, err
+	}
+
+	if stats.TopFeeds, stats.TopCategories, err = s.userStatsRankings(userID); err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT
+			count(*) FILTER (WHERE vote > 0),
+			count(*) FILTER (WHERE vote < 0),
+			count(*) FILTER (WHERE vote = 0)
+		FROM
+			entries
+		WHERE
+			user_id=$1
+	`
+	err = s.db.QueryRow(query, userID).Scan(&stats.Votes.Upvoted, &stats.Votes.Downvoted, &stats.Votes.NotVoted)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch the votes of user #%d: %v`, userID, err)
+	}
+
+	query = `
+		SELECT
+			count(*),
+			percentile_disc(ARRAY[0.5, 0.9, 0.99]) WITHIN GROUP (ORDER BY extract(epoch FROM now() - created_at)::bigint)
+		FROM
+			entries
+		WHERE
+			user_id=$1 AND status=$2
+	`
+	var percentiles pq.Int64Array
+	err = s.db.QueryRow(query, userID, model.EntryStatusUnread).Scan(&stats.UnreadAge.Count, &percentiles)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch the age of the unread entries of user #%d: %v`, userID, err)
+	}
+	if len(percentiles) == 3 {
+		stats.UnreadAge.P50, stats.UnreadAge.P90, stats.UnreadAge.P99 = percentiles[0], percentiles[1], percentiles[2]
+	}
+
+	return &stats, nil
+}
+
+// userStatsPeriods returns the activity of the last days, weeks or months, the oldest period first.
+// The periods without activity are included.
+func (s *Storage) userStatsPeriods(userID int64, timezone, unit string, count int) ([]*model.UserStatsPeriod, error) {
+	query := `
+		WITH periods AS (
+			SELECT generate_series(
+				date_trunc($2, now() AT TIME ZONE $3) - ($4::int - 1) * ('1 ' || $2)::interval,
+				date_trunc($2, now() AT TIME ZONE $3),
+				('1 ' || $2)::interval
+			) AS period
+		), events AS (
+			SELECT
+				date_trunc($2, created_at AT TIME ZONE $3) AS period,
+				count(*) FILTER (WHERE status='read') AS read_count,
+				coalesce(sum(reading_time) FILTER (WHERE status='read' AND NOT bulk), 0) AS reading_time,
+				count(*) FILTER (WHERE status='unread') AS unread_count
+			FROM
+				entry_status_events
+			WHERE
+				user_id=$1 AND created_at >= (SELECT min(period) FROM periods) AT TIME ZONE $3
+			GROUP BY
+				1
+		), new_entries AS (
+			SELECT
+				date_trunc($2, created_at AT TIME ZONE $3) AS period,
+				count(*) AS entry_count
+			FROM
+				entries
+			WHERE
+				user_id=$1 AND created_at >= (SELECT min(period) FROM periods) AT TIME ZONE $3
+			GROUP BY
+				1
+		)
+		SELECT
+			p.period,
+			coalesce(e.read_count, 0),
+			coalesce(e.reading_time, 0),
+			coalesce(e.unread_count, 0),
+			coalesce(n.entry_count, 0)
+		FROM
+			periods p
+		LEFT JOIN
+			events e ON e.period=p.period
+		LEFT JOIN
+			new_entries n ON n.period=p.period
+		ORDER BY
+			p.period ASC
+	`
+
+	rows, err := s.db.Query(query, userID, unit, timezone, count)
+	if err != nil {
+		return nil, fmt.Errorf(`store: unable to fetch the reading statistics of user #%d: %v`, userID, err)
+	}
+	defer rows.Close()
+
+	periods := make([]*model.UserStatsPeriod, 0, count)
+	for rows.Next() {
+		var period model.UserStatsPeriod
+		var date time.Time
+		if err := rows.Scan(&date, &period.Read, &period.ReadingTime, &period.Unread, &period.NewEntries); err != nil {
+			return nil, fmt.Errorf(`store: unable to fetch the reading statistics of user #%d: %v`, userID, err)
+		}
+
+		// The backlog grows with the new entries and the entries marked as unread again, and shrinks with the entries read.
+		period.Date = date.Format(time.DateOnly)
+		period.BacklogGrowth = period.NewEntries + period.Unread - period.Read
+		periods = append(periods, &period)
+	}
+
+	return periods, nil
+}
+
+// userStatsRankings returns the feeds and the categories with the most entries read one by one and starred.
+func (s *Storage) userStatsRankings(userID int64) ([]*model.UserStatsFeed, []*model.UserStatsCategory, error) {
+	query := `
+		WITH reads AS (
+			SELECT
+				feed_id,
+				count(*) AS read_count
+			FROM
+				entry_status_events
+			WHERE
+				user_id=$1 AND status='read' AND NOT bulk
+			GROUP BY
+				feed_id
+		), starred AS (
+			SELECT
+				feed_id,
+				count(*) AS starred_count
+			FROM
+				entries
+			WHERE
+				user_id=$1 AND starred='t'
+			GROUP BY
+				feed_id
+		)
+		SELECT
+			f.id,
+			f.title,
+			c.id,
+			c.title,
+			coalesce(r.read_count, 0),
+			coalesce(s.starred_count, 0)
+		FROM
+			feeds f
+		JOIN
+			categories c ON c.id=f.category_id
+		LEFT JOIN
+			reads r ON r.feed_id=f.id
+		LEFT JOIN
+			starred s ON s.feed_id=f.id
+		WHERE
+			f.user_id=$1 AND (r.read_count > 0 OR s.starred_count > 0)
+	`
+
+	rows, err := s.db.Query(query, userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf(`store: unable to fetch the top feeds of user #%d: %v`, userID, err)
+	}
+	defer rows.Close()
+
+	feeds := make([]*model.UserStatsFeed, 0)
+	categories := make([]*model.UserStatsCategory, 0)
+	categoriesByID := make(map[int64]*model.UserStatsCategory)
+	for rows.Next() {
+		var feed model.UserStatsFeed
+		if err := rows.Scan(&feed.FeedID, &feed.FeedTitle, &feed.CategoryID, &feed.CategoryTitle, &feed.Read, &feed.Starred); err != nil {
+			return nil, nil, fmt.Errorf(`store: unable to fetch the top feeds of user #%d: %v`, userID, err)
+		}
+		feeds = append(feeds, &feed)
+
+		category, found := categoriesByID[feed.CategoryID]
+		if !found {
+			category = &model.UserStatsCategory{CategoryID: feed.CategoryID, CategoryTitle: feed.CategoryTitle}
+			categoriesByID[feed.CategoryID] = category
+			categories = append(categories, category)
+		}
+		category.Read += feed.Read
+		category.Starred += feed.Starred
+	}
+
+	slices.SortFunc(feeds, func(a, b *model.UserStatsFeed) int {
+		return cmp.Or(cmp.Compare(b.Read, a.Read), cmp.Compare(b.Starred, a.Starred), cmp.Compare(a.FeedTitle, b.FeedTitle))
+	})
+	slices.SortFunc(categories, func(a, b *model.UserStatsCategory) int {
+		return cmp.Or(cmp.Compare(b.Read, a.Read), cmp.Compare(b.Starred, a.Starred), cmp.Compare(a.CategoryTitle, b.CategoryTitle))
+	})
+
+	return feeds[:min(len(feeds), model.UserStatsTopLimit)], categories[:min(len(categories), model.UserStatsTopLimit)], nil
+}
+
+// CleanOldEntryStatusEvents removes the status changes older than the interval, and returns the number of removed events.
+func (s *Storage) CleanOldEntryStatusEvents(interval time.Duration) (int64, error) {
+	days := max(int(interval/(24*time.Hour)), 1)
+	result, err := s.db.Exec(`DELETE FROM entry_status_events WHERE created_at < now() - $1::interval`, fmt.Sprintf("%d days", days))
+	if err != nil {
+		return 0, fmt.Errorf(`store: unable to remove old entry status events: %v`, err)
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf(`store: unable to get the number of rows affected: %v`, err)
+	}
+
+	return count, nil
+}
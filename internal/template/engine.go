@@ -47,10 +47,11 @@ func (e *Engine) ParseTemplates() {
 		"create_api_key.html":          {"layout.html", "settings_menu.html"},
 		"create_category.html":         {"layout.html"},
 		"create_user.html":             {"layout.html", "settings_menu.html"},
-		"edit_category.html":           {"layout.html", "settings_menu.html"},
-		"edit_feed.html":               {"layout.html"},
+		"edit_category.html":           {"layout.html", "rule_preview.html", "settings_menu.html"},
+		"edit_feed.html":               {"layout.html", "rule_preview.html"},
 		"edit_user.html":               {"layout.html", "settings_menu.html"},
 		"entry.html":                   {"layout.html"},
+		"entry_revisions.html":         {"layout.html"},
 		"feed_entries.html":            {"item_meta.html", "layout.html", "pagination.html"},
 		"feeds.html":                   {"feed_list.html", "feed_menu.html", "item_meta.html", "layout.html", "pagination.html"},
 		"history_entries.html":         {"item_meta.html", "layout.html", "pagination.html"},
@@ -58,15 +59,21 @@ func (e *Engine) ParseTemplates() {
 		"integrations.html":            {"layout.html", "settings_menu.html"},
 		"login.html":                   {"layout.html"},
 		"offline.html":                 {},
+		"saved_searches.html":          {"layout.html"},
+		"rule_job.html":                {"layout.html"},
+		"saved_search_entries.html":    {"item_meta.html", "layout.html", "pagination.html"},
 		"search.html":                  {"item_meta.html", "layout.html", "pagination.html"},
 		"sessions.html":                {"layout.html", "settings_menu.html"},
-		"settings.html":                {"layout.html", "settings_menu.html"},
+		"settings.html":                {"layout.html", "rule_preview.html", "settings_menu.html"},
 		"shared_entries.html":          {"layout.html", "pagination.html"},
+		"stats.html":                   {"layout.html", "settings_menu.html"},
 		"tag_entries.html":             {"item_meta.html", "layout.html", "pagination.html"},
 		"to_review_entries.html":       {"item_meta.html", "layout.html", "pagination.html"},
 		"user_tags.html":               {"layout.html"},
 		"user_tag_entries.html":        {"item_meta.html", "layout.html", "pagination.html"},
 		"create_user_tag.html":         {"layout.html", "settings_menu.html"},
+		"create_saved_search.html":     {"layout.html"},
+		"edit_saved_search.html":       {"layout.html"},
 		"edit_user_tag.html":           {"layout.html", "settings_menu.html"},
 		"unread_entries.html":          {"item_meta.html", "layout.html", "pagination.html"},
 		"users.html":                   {"layout.html", "settings_menu.html"},
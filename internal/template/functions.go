@@ -44,6 +44,7 @@ func (f *funcMap) Map() template.FuncMap {
 		"rootURL":          config.Opts.RootURL,
 		"disableLocalAuth": config.Opts.DisableLocalAuth,
 		"oidcProviderName": config.Opts.OAuth2OIDCProviderName,
+		"hasSummaryAPI":    config.Opts.HasSummaryAPI,
 		"hasOAuth2Provider": func(provider string) bool {
 			return config.Opts.OAuth2Provider() == provider
 		},
@@ -78,6 +79,7 @@ func (f *funcMap) Map() template.FuncMap {
 
 			return link
 		},
+		"mirrorURL": mediaproxy.MirrorRelativeURL,
 		"mustBeProxyfied": func(mediaType string) bool {
 			return slices.Contains(config.Opts.MediaProxyResourceTypes(), mediaType)
 		},
@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"log/slog"
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response"
+	"miniflux.app/v2/internal/reader/processor"
+)
+
+func (h *handler) rescueBlockedEntry(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		response.HTMLServerError(w, r, err)
+		return
+	}
+
+	feed, err := h.store.FeedByID(user.ID, request.RouteInt64Param(r, "feedID"))
+	if err != nil {
+		response.HTMLServerError(w, r, err)
+		return
+	}
+
+	blockedEntry, err := h.store.BlockedEntryByID(user.ID, request.RouteInt64Param(r, "blockedEntryID"))
+	if err != nil {
+		response.HTMLServerError(w, r, err)
+		return
+	}
+
+	if feed == nil || blockedEntry == nil || blockedEntry.FeedID != feed.ID {
+		response.HTMLNotFound(w, r)
+		return
+	}
+
+	if err := processor.RescueBlockedEntry(h.store, user, feed, blockedEntry); err != nil {
+		response.HTMLServerError(w, r, err)
+		return
+	}
+
+	slog.Info("Rescued a blocked entry from the web ui",
+		slog.Int64("user_id", user.ID),
+		slog.Int64("feed_id", feed.ID),
+		slog.Int64("entry_id", blockedEntry.Entry.ID),
+		slog.String("rule", blockedEntry.Rule.Rule),
+	)
+
+	response.HTMLRedirect(w, r, h.routePath("/feed/%d/entry/%d", feed.ID, blockedEntry.Entry.ID))
+}
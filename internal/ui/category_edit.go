@@ -6,8 +6,10 @@ package ui // import "miniflux.app/v2/internal/ui"
 import (
 	"net/http"
 
+	"miniflux.app/v2/internal/config"
 	"miniflux.app/v2/internal/http/request"
 	"miniflux.app/v2/internal/http/response"
+	"miniflux.app/v2/internal/model"
 	"miniflux.app/v2/internal/ui/form"
 	"miniflux.app/v2/internal/ui/view"
 )
@@ -30,18 +32,42 @@ func (h *handler) showEditCategoryPage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	categoryForm := form.CategoryForm{
-		Title:        category.Title,
-		HideGlobally: category.HideGlobally,
+	categoryForm := &form.CategoryForm{
+		Title:                 category.Title,
+		HideGlobally:          category.HideGlobally,
+		EntryRules:            category.EntryRules,
+		ScraperRules:          category.ScraperRules,
+		RewriteRules:          category.RewriteRules,
+		UrlRewriteRules:       category.UrlRewriteRules,
+		BlocklistRules:        category.BlocklistRules,
+		KeeplistRules:         category.KeeplistRules,
+		BlockFilterEntryRules: category.BlockFilterEntryRules,
+		KeepFilterEntryRules:  category.KeepFilterEntryRules,
+		Crawler:               category.Crawler,
+		UserAgent:             category.UserAgent,
+		FetchViaProxy:         category.FetchViaProxy,
+		ProxyURL:              category.ProxyURL,
+		EnrichmentProcessors:  category.EnrichmentProcessors,
+		SummarizeEntries:      category.SummarizeEntries,
+		SummaryPrompt:         category.SummaryPrompt,
 	}
 
-	view := view.New(h.tpl, r)
-	view.Set("form", categoryForm)
-	view.Set("category", category)
-	view.Set("menu", "categories")
-	view.Set("user", user)
-	view.Set("countUnread", h.store.CountUnreadEntries(user.ID))
-	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
+	view := h.editCategoryView(r, user, category, categoryForm)
 
 	response.HTML(w, r, view.Render("edit_category"))
 }
+
+// editCategoryView returns the category edition page view with the given form.
+func (h *handler) editCategoryView(r *http.Request, user *model.User, category *model.Category, categoryForm *form.CategoryForm) *view.View {
+	v := view.New(h.tpl, r)
+	v.Set("form", categoryForm)
+	v.Set("category", category)
+	v.Set("menu", "categories")
+	v.Set("user", user)
+	v.Set("countUnread", h.store.CountUnreadEntries(user.ID))
+	v.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
+	v.Set("defaultUserAgent", config.Opts.HTTPClientUserAgent())
+	v.Set("hasProxyConfigured", config.Opts.HasHTTPClientProxyURLConfigured())
+	v.Set("enrichmentProcessors", config.Opts.EnrichmentProcessorNames())
+	return v
+}
@@ -36,6 +36,7 @@ func (h *handler) showCategoryEntriesAllPage(w http.ResponseWriter, r *http.Requ
 	builder.WithSorting(user.EntryOrder, user.EntryDirection)
 	builder.WithSorting("id", user.EntryDirection)
 	builder.WithoutContent()
+	builder.WithEnclosures()
 	builder.WithOffset(offset)
 	builder.WithLimit(user.EntriesPerPage)
 
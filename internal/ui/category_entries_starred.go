@@ -37,6 +37,7 @@ func (h *handler) showCategoryEntriesStarredPage(w http.ResponseWriter, r *http.
 	builder.WithSorting("id", user.EntryDirection)
 	builder.WithStarred(true)
 	builder.WithoutContent()
+	builder.WithEnclosures()
 	builder.WithOffset(offset)
 	builder.WithLimit(user.EntriesPerPage)
 
@@ -24,8 +24,15 @@ func (h *handler) showCategoryListPage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	savedSearches, err := h.store.SavedSearchesWithUnreadCount(user.ID)
+	if err != nil {
+		response.HTMLServerError(w, r, err)
+		return
+	}
+
 	view := view.New(h.tpl, r)
 	view.Set("categories", categories)
+	view.Set("savedSearches", savedSearches)
 	view.Set("total", len(categories))
 	view.Set("menu", "categories")
 	view.Set("user", user)
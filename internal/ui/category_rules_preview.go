@@ -0,0 +1,66 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/processor"
+	"miniflux.app/v2/internal/ui/form"
+	"miniflux.app/v2/internal/validator"
+)
+
+func (h *handler) previewCategoryRules(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		response.HTMLServerError(w, r, err)
+		return
+	}
+
+	categoryID := request.RouteInt64Param(r, "categoryID")
+	category, err := h.store.Category(request.UserID(r), categoryID)
+	if err != nil {
+		response.HTMLServerError(w, r, err)
+		return
+	}
+
+	if category == nil {
+		response.HTMLNotFound(w, r)
+		return
+	}
+
+	categoryForm := form.NewCategoryForm(r)
+
+	view := h.editCategoryView(r, user, category, categoryForm)
+
+	rulePreviewRequest := &model.RulePreviewRequest{
+		CategoryID: category.ID,
+		Category: &model.CategoryModificationRequest{
+			UrlRewriteRules:       new(categoryForm.UrlRewriteRules),
+			BlocklistRules:        new(categoryForm.BlocklistRules),
+			KeeplistRules:         new(categoryForm.KeeplistRules),
+			BlockFilterEntryRules: new(categoryForm.BlockFilterEntryRules),
+			KeepFilterEntryRules:  new(categoryForm.KeepFilterEntryRules),
+			EntryRules:            new(categoryForm.EntryRules),
+		},
+	}
+
+	if validationErr := validator.ValidateRulePreview(h.store, user.ID, rulePreviewRequest); validationErr != nil {
+		view.Set("errorMessage", validationErr.Translate(user.Language))
+		response.HTML(w, r, view.Render("edit_category"))
+		return
+	}
+
+	rulePreview, err := processor.PreviewRules(h.store, user.ID, rulePreviewRequest)
+	if err != nil {
+		response.HTMLServerError(w, r, err)
+		return
+	}
+
+	view.Set("rulePreview", rulePreview)
+	response.HTML(w, r, view.Render("edit_category"))
+}
@@ -6,11 +6,11 @@ package ui // import "miniflux.app/v2/internal/ui"
 import (
 	"net/http"
 
+	"miniflux.app/v2/internal/config"
 	"miniflux.app/v2/internal/http/request"
 	"miniflux.app/v2/internal/http/response"
 	"miniflux.app/v2/internal/model"
 	"miniflux.app/v2/internal/ui/form"
-	"miniflux.app/v2/internal/ui/view"
 	"miniflux.app/v2/internal/validator"
 )
 
@@ -35,17 +35,34 @@ func (h *handler) updateCategory(w http.ResponseWriter, r *http.Request) {
 
 	categoryForm := form.NewCategoryForm(r)
 
-	view := view.New(h.tpl, r)
-	view.Set("form", categoryForm)
-	view.Set("category", category)
-	view.Set("menu", "categories")
-	view.Set("user", user)
-	view.Set("countUnread", h.store.CountUnreadEntries(user.ID))
-	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
+	view := h.editCategoryView(r, user, category, categoryForm)
 
 	categoryRequest := &model.CategoryModificationRequest{
-		Title:        new(categoryForm.Title),
-		HideGlobally: new(categoryForm.HideGlobally),
+		Title:                 new(categoryForm.Title),
+		HideGlobally:          new(categoryForm.HideGlobally),
+		EntryRules:            new(categoryForm.EntryRules),
+		ScraperRules:          new(categoryForm.ScraperRules),
+		RewriteRules:          new(categoryForm.RewriteRules),
+		UrlRewriteRules:       new(categoryForm.UrlRewriteRules),
+		BlocklistRules:        new(categoryForm.BlocklistRules),
+		KeeplistRules:         new(categoryForm.KeeplistRules),
+		BlockFilterEntryRules: new(categoryForm.BlockFilterEntryRules),
+		KeepFilterEntryRules:  new(categoryForm.KeepFilterEntryRules),
+		Crawler:               new(categoryForm.Crawler),
+		UserAgent:             new(categoryForm.UserAgent),
+		FetchViaProxy:         new(categoryForm.FetchViaProxy),
+		ProxyURL:              new(categoryForm.ProxyURL),
+	}
+
+	// The enrichment processors field is not displayed when no processor is configured.
+	if config.Opts.HasEnrichmentProcessors() {
+		categoryRequest.EnrichmentProcessors = new(categoryForm.EnrichmentProcessors)
+	}
+
+	// The summary fields are not displayed when the summary API is not configured.
+	if config.Opts.HasSummaryAPI() {
+		categoryRequest.SummarizeEntries = new(categoryForm.SummarizeEntries)
+		categoryRequest.SummaryPrompt = new(categoryForm.SummaryPrompt)
 	}
 
 	if validationErr := validator.ValidateCategoryModification(h.store, user.ID, category.ID, categoryRequest); validationErr != nil {
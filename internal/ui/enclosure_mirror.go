@@ -0,0 +1,84 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response"
+	"miniflux.app/v2/internal/mediaproxy"
+	"miniflux.app/v2/internal/reader/mirror"
+)
+
+func (h *handler) showEnclosureMirror(w http.ResponseWriter, r *http.Request) {
+	directory := config.Opts.EnclosureMirrorDir()
+	if directory == "" {
+		response.HTMLNotFound(w, r)
+		return
+	}
+
+	enclosureID := request.RouteInt64Param(r, "enclosureID")
+	decodedDigest, err := base64.URLEncoding.DecodeString(request.RouteStringParam(r, "encodedDigest"))
+	if err != nil {
+		response.HTMLBadRequest(w, r, errors.New("unable to decode this digest"))
+		return
+	}
+
+	if !mediaproxy.VerifyMirrorURL(enclosureID, decodedDigest) {
+		response.HTMLForbidden(w, r)
+		return
+	}
+
+	enclosureMirror, err := h.store.EnclosureMirror(enclosureID)
+	if err != nil {
+		response.HTMLServerError(w, r, err)
+		return
+	}
+
+	if enclosureMirror == nil {
+		response.HTMLNotFound(w, r)
+		return
+	}
+
+	enclosure, err := h.store.GetEnclosure(enclosureID)
+	if err != nil {
+		response.HTMLServerError(w, r, err)
+		return
+	}
+
+	if enclosure == nil {
+		response.HTMLNotFound(w, r)
+		return
+	}
+
+	file, err := os.Open(mirror.Path(directory, enclosureMirror.UserID, enclosureMirror.EnclosureID))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			response.HTMLNotFound(w, r)
+		} else {
+			response.HTMLServerError(w, r, err)
+		}
+		return
+	}
+	defer file.Close()
+
+	var filename string
+	if parsedURL, err := url.Parse(enclosure.URL); err == nil {
+		if baseName := path.Base(parsedURL.Path); baseName != "" && baseName != "." && baseName != "/" {
+			filename = baseName
+		}
+	}
+
+	etag := fmt.Sprintf("%d-%d", enclosureMirror.EnclosureID, enclosureMirror.CreatedAt.Unix())
+	lastModified := enclosureMirror.CreatedAt.UTC().Format(http.TimeFormat)
+	writeMediaContent(w, r, enclosureMirror.MimeType, lastModified, file, etag, filename)
+}
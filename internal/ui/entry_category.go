@@ -26,6 +26,7 @@ func (h *handler) showCategoryEntryPage(w http.ResponseWriter, r *http.Request)
 	builder := h.store.NewEntryQueryBuilder(user.ID)
 	builder.WithCategoryID(categoryID)
 	builder.WithEntryID(entryID)
+	builder.WithDuplicates()
 
 	entry, err := builder.GetEntry()
 	if err != nil {
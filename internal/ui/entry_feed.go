@@ -26,6 +26,7 @@ func (h *handler) showFeedEntryPage(w http.ResponseWriter, r *http.Request) {
 	builder := h.store.NewEntryQueryBuilder(user.ID)
 	builder.WithFeedID(feedID)
 	builder.WithEntryID(entryID)
+	builder.WithDuplicates()
 
 	entry, err := builder.GetEntry()
 	if err != nil {
@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/diff"
+	"miniflux.app/v2/internal/ui/view"
+)
+
+// entryRevisionChange describes the changes introduced by the version that replaced a revision.
+type entryRevisionChange struct {
+	Revision     *model.EntryRevision
+	TitleLines   []diff.Line
+	ContentLines []diff.Line
+}
+
+func (h *handler) showEntryRevisionsPage(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		response.HTMLServerError(w, r, err)
+		return
+	}
+
+	entryID := request.RouteInt64Param(r, "entryID")
+	builder := h.store.NewEntryQueryBuilder(user.ID)
+	builder.WithEntryID(entryID)
+
+	entry, err := builder.GetEntry()
+	if err != nil {
+		response.HTMLServerError(w, r, err)
+		return
+	}
+
+	if entry == nil {
+		response.HTMLNotFound(w, r)
+		return
+	}
+
+	revisions, err := h.store.EntryRevisions(user.ID, entry.ID)
+	if err != nil {
+		response.HTMLServerError(w, r, err)
+		return
+	}
+
+	// Revisions are sorted from the most recent, so each one is compared with the version that replaced it.
+	changes := make([]entryRevisionChange, 0, len(revisions))
+	newerTitle, newerContent := entry.Title, entry.Content
+	for _, revision := range revisions {
+		changes = append(changes, entryRevisionChange{
+			Revision:     revision,
+			TitleLines:   diff.Compute(revision.Title, newerTitle),
+			ContentLines: diff.Compute(revision.Content, newerContent),
+		})
+		newerTitle, newerContent = revision.Title, revision.Content
+	}
+
+	view := view.New(h.tpl, r)
+	view.Set("entry", entry)
+	view.Set("changes", changes)
+	view.Set("menu", "feeds")
+	view.Set("user", user)
+	view.Set("countUnread", h.store.CountUnreadEntries(user.ID))
+	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
+
+	response.HTML(w, r, view.Render("entry_revisions"))
+}
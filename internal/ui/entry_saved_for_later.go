@@ -23,6 +23,7 @@ func (h *handler) showSavedForLaterEntryPage(w http.ResponseWriter, r *http.Requ
 	entryID := request.RouteInt64Param(r, "entryID")
 	builder := h.store.NewEntryQueryBuilder(user.ID)
 	builder.WithEntryID(entryID)
+	builder.WithDuplicates()
 	builder.WithSavedForLater(true)
 	builder.WithStatus(model.EntryStatusUnread)
 	builder.WithGloballyVisible()
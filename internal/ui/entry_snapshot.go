@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response"
+	"miniflux.app/v2/internal/reader/processor"
+)
+
+func (h *handler) showEntrySnapshot(w http.ResponseWriter, r *http.Request) {
+	content, err := h.store.EntrySnapshotContent(request.UserID(r), request.RouteInt64Param(r, "entryID"))
+	if err != nil {
+		response.HTMLServerError(w, r, err)
+		return
+	}
+
+	if content == "" {
+		response.HTMLNotFound(w, r)
+		return
+	}
+
+	builder := response.NewBuilder(w, r)
+	builder.WithHeader("Content-Security-Policy", response.ContentSecurityPolicyForSnapshots)
+	builder.WithHeader("Content-Type", "text/html; charset=utf-8")
+	builder.WithHeader("Cache-Control", "private, no-cache")
+	builder.WithBodyAsString(content)
+	builder.Write()
+}
+
+func (h *handler) createEntrySnapshot(w http.ResponseWriter, r *http.Request) {
+	userID := request.UserID(r)
+	builder := h.store.NewEntryQueryBuilder(userID)
+	builder.WithEntryID(request.RouteInt64Param(r, "entryID"))
+
+	entry, err := builder.GetEntry()
+	if err != nil {
+		response.HTMLServerError(w, r, err)
+		return
+	}
+
+	if entry == nil {
+		response.HTMLNotFound(w, r)
+		return
+	}
+
+	feed, err := h.store.FeedByID(userID, entry.FeedID)
+	if err != nil {
+		response.HTMLServerError(w, r, err)
+		return
+	}
+
+	if feed == nil {
+		response.HTMLNotFound(w, r)
+		return
+	}
+
+	if _, err := processor.SnapshotEntry(h.store, feed, entry); err != nil {
+		response.HTMLBadRequest(w, r, err)
+		return
+	}
+
+	response.HTMLRedirect(w, r, h.routePath("/entry/%d/snapshot", entry.ID))
+}
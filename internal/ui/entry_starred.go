@@ -23,6 +23,7 @@ func (h *handler) showStarredEntryPage(w http.ResponseWriter, r *http.Request) {
 	entryID := request.RouteInt64Param(r, "entryID")
 	builder := h.store.NewEntryQueryBuilder(user.ID)
 	builder.WithEntryID(entryID)
+	builder.WithDuplicates()
 
 	entry, err := builder.GetEntry()
 	if err != nil {
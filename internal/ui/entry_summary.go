@@ -0,0 +1,55 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"errors"
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response"
+	"miniflux.app/v2/internal/reader/processor"
+	"miniflux.app/v2/internal/reader/summary"
+)
+
+func (h *handler) summarizeEntry(w http.ResponseWriter, r *http.Request) {
+	loggedUserID := request.UserID(r)
+
+	entryBuilder := h.store.NewEntryQueryBuilder(loggedUserID)
+	entryBuilder.WithEntryID(request.RouteInt64Param(r, "entryID"))
+
+	entry, err := entryBuilder.GetEntry()
+	if err != nil {
+		response.JSONServerError(w, r, err)
+		return
+	}
+
+	if entry == nil {
+		response.JSONNotFound(w, r)
+		return
+	}
+
+	feed, err := h.store.FeedByID(loggedUserID, entry.FeedID)
+	if err != nil {
+		response.JSONServerError(w, r, err)
+		return
+	}
+
+	if feed == nil {
+		response.JSONNotFound(w, r)
+		return
+	}
+
+	err = processor.SummarizeEntry(h.store, feed, entry)
+	switch {
+	case errors.Is(err, summary.ErrNotConfigured), errors.Is(err, summary.ErrTokenBudgetExceeded):
+		response.JSONBadRequest(w, r, err)
+		return
+	case err != nil:
+		response.JSONServerError(w, r, err)
+		return
+	}
+
+	response.JSON(w, r, map[string]string{"summary": entry.Summary})
+}
@@ -23,6 +23,7 @@ func (h *handler) showToReviewEntryPage(w http.ResponseWriter, r *http.Request)
 	entryID := request.RouteInt64Param(r, "entryID")
 	builder := h.store.NewEntryQueryBuilder(user.ID)
 	builder.WithEntryID(entryID)
+	builder.WithDuplicates()
 	builder.WithStatus(model.EntryStatusUnread)
 	builder.WithVote(0)
 	builder.WithGloballyVisible()
@@ -8,14 +8,21 @@ import (
 
 	"miniflux.app/v2/internal/http/request"
 	"miniflux.app/v2/internal/http/response"
+	"miniflux.app/v2/internal/reader/mirror"
 )
 
 func (h *handler) toggleStarred(w http.ResponseWriter, r *http.Request) {
 	entryID := request.RouteInt64Param(r, "entryID")
-	if err := h.store.ToggleStarred(request.UserID(r), entryID); err != nil {
+	starred, err := h.store.ToggleStarred(request.UserID(r), entryID)
+	if err != nil {
 		response.JSONServerError(w, r, err)
 		return
 	}
 
+	// The enclosures of starred entries are always mirrored.
+	if starred {
+		mirror.Request()
+	}
+
 	response.JSON(w, r, "OK")
 }
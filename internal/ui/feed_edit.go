@@ -9,6 +9,9 @@ import (
 	"miniflux.app/v2/internal/config"
 	"miniflux.app/v2/internal/http/request"
 	"miniflux.app/v2/internal/http/response"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/processor"
+	"miniflux.app/v2/internal/reader/rules"
 	"miniflux.app/v2/internal/ui/form"
 	"miniflux.app/v2/internal/ui/view"
 )
@@ -32,13 +35,7 @@ func (h *handler) showEditFeedPage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	categories, err := h.store.Categories(user.ID)
-	if err != nil {
-		response.HTMLServerError(w, r, err)
-		return
-	}
-
-	feedForm := form.FeedForm{
+	feedForm := &form.FeedForm{
 		SiteURL:                     feed.SiteURL,
 		FeedURL:                     feed.FeedURL,
 		Title:                       feed.Title,
@@ -50,8 +47,14 @@ func (h *handler) showEditFeedPage(w http.ResponseWriter, r *http.Request) {
 		KeeplistRules:               feed.KeeplistRules,
 		BlockFilterEntryRules:       feed.BlockFilterEntryRules,
 		KeepFilterEntryRules:        feed.KeepFilterEntryRules,
-		Crawler:                     feed.Crawler,
+		Crawler:                     feed.CrawlerOverride,
 		IgnoreEntryUpdates:          feed.IgnoreEntryUpdates,
+		EntryRules:                  feed.EntryRules,
+		MarkUnreadOnEntryRevision:   feed.MarkUnreadOnEntryRevision,
+		SnapshotEntries:             feed.SnapshotEntries,
+		MirrorEnclosures:            feed.MirrorEnclosures,
+		MirrorEnclosuresLimit:       feed.MirrorEnclosuresLimit,
+		EnrichmentProcessors:        feed.EnrichmentProcessors,
 		UserAgent:                   feed.UserAgent,
 		Cookie:                      feed.Cookie,
 		CategoryID:                  feed.Category.ID,
@@ -59,7 +62,7 @@ func (h *handler) showEditFeedPage(w http.ResponseWriter, r *http.Request) {
 		Password:                    feed.Password,
 		IgnoreHTTPCache:             feed.IgnoreHTTPCache,
 		AllowSelfSignedCertificates: feed.AllowSelfSignedCertificates,
-		FetchViaProxy:               feed.FetchViaProxy,
+		FetchViaProxy:               feed.FetchViaProxyOverride,
 		Disabled:                    feed.Disabled,
 		NoMediaPlayer:               feed.NoMediaPlayer,
 		HideGlobally:                feed.HideGlobally,
@@ -75,16 +78,40 @@ func (h *handler) showEditFeedPage(w http.ResponseWriter, r *http.Request) {
 		ProxyURL:                    feed.ProxyURL,
 	}
 
-	view := view.New(h.tpl, r)
-	view.Set("form", feedForm)
-	view.Set("categories", categories)
-	view.Set("feed", feed)
-	view.Set("menu", "feeds")
-	view.Set("user", user)
-	view.Set("countUnread", h.store.CountUnreadEntries(user.ID))
-	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
-	view.Set("defaultUserAgent", config.Opts.HTTPClientUserAgent())
-	view.Set("hasProxyConfigured", config.Opts.HasHTTPClientProxyURLConfigured())
+	view, err := h.editFeedView(r, user, feed, feedForm)
+	if err != nil {
+		response.HTMLServerError(w, r, err)
+		return
+	}
 
 	response.HTML(w, r, view.Render("edit_feed"))
 }
+
+// editFeedView returns the feed edition page view with the given form.
+func (h *handler) editFeedView(r *http.Request, user *model.User, feed *model.Feed, feedForm *form.FeedForm) (*view.View, error) {
+	categories, err := h.store.Categories(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	ruleAudit, err := processor.FeedRuleAudit(h.store, user, feed)
+	if err != nil {
+		return nil, err
+	}
+
+	v := view.New(h.tpl, r)
+	v.Set("form", feedForm)
+	v.Set("categories", categories)
+	v.Set("feed", feed)
+	v.Set("menu", "feeds")
+	v.Set("user", user)
+	v.Set("countUnread", h.store.CountUnreadEntries(user.ID))
+	v.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
+	v.Set("defaultUserAgent", config.Opts.HTTPClientUserAgent())
+	v.Set("hasProxyConfigured", config.Opts.HasHTTPClientProxyURLConfigured())
+	v.Set("hasEnclosureMirror", config.Opts.EnclosureMirrorDir() != "")
+	v.Set("enrichmentProcessors", config.Opts.EnrichmentProcessorNames())
+	v.Set("legacyRules", rules.FromLegacy(user, feed).String())
+	v.Set("ruleAudit", ruleAudit)
+	return v, nil
+}
@@ -40,6 +40,7 @@ func (h *handler) showFeedEntriesPage(w http.ResponseWriter, r *http.Request) {
 	builder.WithOffset(offset)
 	builder.WithLimit(user.EntriesPerPage)
 	builder.WithoutContent()
+	builder.WithEnclosures()
 
 	entries, count, err := builder.GetEntriesWithCount()
 	if err != nil {
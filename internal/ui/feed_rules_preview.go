@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/processor"
+	"miniflux.app/v2/internal/ui/form"
+	"miniflux.app/v2/internal/validator"
+)
+
+func (h *handler) previewFeedRules(w http.ResponseWriter, r *http.Request) {
+	loggedUser, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		response.HTMLServerError(w, r, err)
+		return
+	}
+
+	feedID := request.RouteInt64Param(r, "feedID")
+	feed, err := h.store.FeedByID(loggedUser.ID, feedID)
+	if err != nil {
+		response.HTMLServerError(w, r, err)
+		return
+	}
+
+	if feed == nil {
+		response.HTMLNotFound(w, r)
+		return
+	}
+
+	feedForm := form.NewFeedForm(r)
+
+	view, err := h.editFeedView(r, loggedUser, feed, feedForm)
+	if err != nil {
+		response.HTMLServerError(w, r, err)
+		return
+	}
+
+	rulePreviewRequest := &model.RulePreviewRequest{
+		FeedID: feed.ID,
+		Feed: &model.FeedModificationRequest{
+			BlocklistRules:        new(feedForm.BlocklistRules),
+			KeeplistRules:         new(feedForm.KeeplistRules),
+			UrlRewriteRules:       new(feedForm.UrlRewriteRules),
+			BlockFilterEntryRules: new(feedForm.BlockFilterEntryRules),
+			KeepFilterEntryRules:  new(feedForm.KeepFilterEntryRules),
+			EntryRules:            new(feedForm.EntryRules),
+		},
+	}
+
+	if validationErr := validator.ValidateRulePreview(h.store, loggedUser.ID, rulePreviewRequest); validationErr != nil {
+		view.Set("errorMessage", validationErr.Translate(loggedUser.Language))
+		response.HTML(w, r, view.Render("edit_feed"))
+		return
+	}
+
+	rulePreview, err := processor.PreviewRules(h.store, loggedUser.ID, rulePreviewRequest)
+	if err != nil {
+		response.HTMLServerError(w, r, err)
+		return
+	}
+
+	view.Set("rulePreview", rulePreview)
+	response.HTML(w, r, view.Render("edit_feed"))
+}
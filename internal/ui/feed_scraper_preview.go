@@ -0,0 +1,91 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response"
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/processor"
+	"miniflux.app/v2/internal/ui/form"
+	"miniflux.app/v2/internal/validator"
+)
+
+func (h *handler) previewFeedScraper(w http.ResponseWriter, r *http.Request) {
+	loggedUser, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		response.HTMLServerError(w, r, err)
+		return
+	}
+
+	feedID := request.RouteInt64Param(r, "feedID")
+	feed, err := h.store.FeedByID(loggedUser.ID, feedID)
+	if err != nil {
+		response.HTMLServerError(w, r, err)
+		return
+	}
+
+	if feed == nil {
+		response.HTMLNotFound(w, r)
+		return
+	}
+
+	feedForm := form.NewFeedForm(r)
+
+	// Test the most recent entry of the feed by default.
+	pageURL := r.FormValue("scraper_preview_url")
+	if pageURL == "" {
+		builder := h.store.NewEntryQueryBuilder(loggedUser.ID)
+		builder.WithFeedID(feed.ID)
+		builder.WithSorting("published_at", "DESC")
+		builder.WithLimit(1)
+		entry, err := builder.GetEntry()
+		if err != nil {
+			response.HTMLServerError(w, r, err)
+			return
+		}
+		if entry != nil {
+			pageURL = entry.URL
+		}
+	}
+
+	view, err := h.editFeedView(r, loggedUser, feed, feedForm)
+	if err != nil {
+		response.HTMLServerError(w, r, err)
+		return
+	}
+	view.Set("scraperPreviewURL", pageURL)
+
+	scraperPreviewRequest := &model.ScraperPreviewRequest{
+		URL:                         pageURL,
+		FeedID:                      feed.ID,
+		ScraperRules:                feedForm.ScraperRules,
+		RewriteRules:                feedForm.RewriteRules,
+		UserAgent:                   feedForm.UserAgent,
+		Cookie:                      feedForm.Cookie,
+		AllowSelfSignedCertificates: feedForm.AllowSelfSignedCertificates,
+		DisableHTTP2:                feedForm.DisableHTTP2,
+		FetchViaProxyOverride:       feedForm.FetchViaProxy,
+		ProxyURL:                    feedForm.ProxyURL,
+	}
+
+	if validationErr := validator.ValidateScraperPreview(h.store, loggedUser.ID, scraperPreviewRequest); validationErr != nil {
+		view.Set("errorMessage", validationErr.Translate(loggedUser.Language))
+		response.HTML(w, r, view.Render("edit_feed"))
+		return
+	}
+
+	scraperPreview, err := processor.PreviewScraper(h.store, loggedUser, scraperPreviewRequest)
+	if err != nil {
+		view.Set("errorMessage", locale.NewLocalizedError("error.unable_to_preview_scraper", err).Translate(loggedUser.Language))
+		response.HTML(w, r, view.Render("edit_feed"))
+		return
+	}
+
+	view.Set("scraperPreview", scraperPreview)
+	response.HTML(w, r, view.Render("edit_feed"))
+}
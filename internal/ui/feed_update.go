@@ -11,7 +11,6 @@ import (
 	"miniflux.app/v2/internal/http/response"
 	"miniflux.app/v2/internal/model"
 	"miniflux.app/v2/internal/ui/form"
-	"miniflux.app/v2/internal/ui/view"
 	"miniflux.app/v2/internal/validator"
 )
 
@@ -34,24 +33,25 @@ func (h *handler) updateFeed(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	categories, err := h.store.Categories(loggedUser.ID)
+	feedForm := form.NewFeedForm(r)
+
+	// The enclosure mirroring fields are not displayed when the mirror directory is not configured.
+	if config.Opts.EnclosureMirrorDir() == "" {
+		feedForm.MirrorEnclosures = feed.MirrorEnclosures
+		feedForm.MirrorEnclosuresLimit = feed.MirrorEnclosuresLimit
+	}
+
+	// The enrichment processors field is not displayed when no processor is configured.
+	if !config.Opts.HasEnrichmentProcessors() {
+		feedForm.EnrichmentProcessors = feed.EnrichmentProcessors
+	}
+
+	view, err := h.editFeedView(r, loggedUser, feed, feedForm)
 	if err != nil {
 		response.HTMLServerError(w, r, err)
 		return
 	}
 
-	feedForm := form.NewFeedForm(r)
-
-	view := view.New(h.tpl, r)
-	view.Set("form", feedForm)
-	view.Set("categories", categories)
-	view.Set("feed", feed)
-	view.Set("menu", "feeds")
-	view.Set("user", loggedUser)
-	view.Set("countUnread", h.store.CountUnreadEntries(loggedUser.ID))
-	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(loggedUser.ID))
-	view.Set("defaultUserAgent", config.Opts.HTTPClientUserAgent())
-
 	feedModificationRequest := &model.FeedModificationRequest{
 		FeedURL:         model.OptionalString(feedForm.FeedURL),
 		SiteURL:         model.OptionalString(feedForm.SiteURL),
@@ -62,6 +62,12 @@ func (h *handler) updateFeed(w http.ResponseWriter, r *http.Request) {
 		KeeplistRules:   model.OptionalString(feedForm.KeeplistRules),
 		UrlRewriteRules: model.OptionalString(feedForm.UrlRewriteRules),
 		ProxyURL:        model.OptionalString(feedForm.ProxyURL),
+		EntryRules:      model.OptionalString(feedForm.EntryRules),
+	}
+
+	// The enrichment processors field is not displayed when no processor is configured.
+	if config.Opts.HasEnrichmentProcessors() {
+		feedModificationRequest.EnrichmentProcessors = new(feedForm.EnrichmentProcessors)
 	}
 
 	if validationErr := validator.ValidateFeedModification(h.store, loggedUser.ID, feed.ID, feedModificationRequest); validationErr != nil {
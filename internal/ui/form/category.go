@@ -5,18 +5,51 @@ package form // import "miniflux.app/v2/internal/ui/form"
 
 import (
 	"net/http"
+	"strings"
+
+	"miniflux.app/v2/internal/model"
 )
 
 // CategoryForm represents a feed form in the UI
 type CategoryForm struct {
-	Title        string
-	HideGlobally bool
+	Title                 string
+	HideGlobally          bool
+	EntryRules            string
+	ScraperRules          string
+	RewriteRules          string
+	UrlRewriteRules       string
+	BlocklistRules        string
+	KeeplistRules         string
+	BlockFilterEntryRules string
+	KeepFilterEntryRules  string
+	Crawler               bool
+	UserAgent             string
+	FetchViaProxy         bool
+	ProxyURL              string
+	EnrichmentProcessors  string
+	SummarizeEntries      bool
+	SummaryPrompt         string
 }
 
 // NewCategoryForm returns a new CategoryForm.
 func NewCategoryForm(r *http.Request) *CategoryForm {
 	return &CategoryForm{
-		Title:        r.FormValue("title"),
-		HideGlobally: r.FormValue("hide_globally") == "1",
+		Title:                 r.FormValue("title"),
+		HideGlobally:          r.FormValue("hide_globally") == "1",
+		EntryRules:            r.FormValue("entry_rules"),
+		ScraperRules:          r.FormValue("scraper_rules"),
+		RewriteRules:          r.FormValue("rewrite_rules"),
+		UrlRewriteRules:       r.FormValue("urlrewrite_rules"),
+		BlocklistRules:        r.FormValue("blocklist_rules"),
+		KeeplistRules:         r.FormValue("keeplist_rules"),
+		BlockFilterEntryRules: r.FormValue("block_filter_entry_rules"),
+		KeepFilterEntryRules:  r.FormValue("keep_filter_entry_rules"),
+		Crawler:               r.FormValue("crawler") == "1",
+		UserAgent:             r.FormValue("user_agent"),
+		FetchViaProxy:         r.FormValue("fetch_via_proxy") == "1",
+		ProxyURL:              r.FormValue("proxy_url"),
+		EnrichmentProcessors:  strings.Join(model.ParseEnrichmentProcessorNames(r.FormValue("enrichment_processors")), ","),
+		SummarizeEntries:      r.FormValue("summarize_entries") == "1",
+		SummaryPrompt:         strings.TrimSpace(r.FormValue("summary_prompt")),
 	}
 }
@@ -6,6 +6,7 @@ package form // import "miniflux.app/v2/internal/ui/form"
 import (
 	"net/http"
 	"strconv"
+	"strings"
 
 	"miniflux.app/v2/internal/model"
 )
@@ -23,8 +24,14 @@ type FeedForm struct {
 	KeeplistRules               string
 	BlockFilterEntryRules       string
 	KeepFilterEntryRules        string
-	Crawler                     bool
+	Crawler                     string
 	IgnoreEntryUpdates          bool
+	EntryRules                  string
+	MarkUnreadOnEntryRevision   bool
+	SnapshotEntries             bool
+	MirrorEnclosures            bool
+	MirrorEnclosuresLimit       int
+	EnrichmentProcessors        string
 	UserAgent                   string
 	Cookie                      string
 	CategoryID                  int64
@@ -32,7 +39,7 @@ type FeedForm struct {
 	Password                    string
 	IgnoreHTTPCache             bool
 	AllowSelfSignedCertificates bool
-	FetchViaProxy               bool
+	FetchViaProxy               string
 	Disabled                    bool
 	NoMediaPlayer               bool
 	HideGlobally                bool
@@ -62,8 +69,14 @@ func (f FeedForm) Merge(feed *model.Feed) *model.Feed {
 	feed.KeeplistRules = f.KeeplistRules
 	feed.BlockFilterEntryRules = f.BlockFilterEntryRules
 	feed.KeepFilterEntryRules = f.KeepFilterEntryRules
-	feed.Crawler = f.Crawler
+	feed.CrawlerOverride = f.Crawler
 	feed.IgnoreEntryUpdates = f.IgnoreEntryUpdates
+	feed.EntryRules = f.EntryRules
+	feed.MarkUnreadOnEntryRevision = f.MarkUnreadOnEntryRevision
+	feed.SnapshotEntries = f.SnapshotEntries
+	feed.MirrorEnclosures = f.MirrorEnclosures
+	feed.MirrorEnclosuresLimit = f.MirrorEnclosuresLimit
+	feed.EnrichmentProcessors = f.EnrichmentProcessors
 	feed.UserAgent = f.UserAgent
 	feed.Cookie = f.Cookie
 	feed.ParsingErrorCount = 0
@@ -72,7 +85,7 @@ func (f FeedForm) Merge(feed *model.Feed) *model.Feed {
 	feed.Password = f.Password
 	feed.IgnoreHTTPCache = f.IgnoreHTTPCache
 	feed.AllowSelfSignedCertificates = f.AllowSelfSignedCertificates
-	feed.FetchViaProxy = f.FetchViaProxy
+	feed.FetchViaProxyOverride = f.FetchViaProxy
 	feed.Disabled = f.Disabled
 	feed.NoMediaPlayer = f.NoMediaPlayer
 	feed.HideGlobally = f.HideGlobally
@@ -105,6 +118,11 @@ func NewFeedForm(r *http.Request) *FeedForm {
 		pushoverPriority = 0
 	}
 
+	mirrorEnclosuresLimit, err := strconv.Atoi(r.FormValue("mirror_enclosures_limit"))
+	if err != nil || mirrorEnclosuresLimit < 0 {
+		mirrorEnclosuresLimit = 0
+	}
+
 	return &FeedForm{
 		FeedURL:                     r.FormValue("feed_url"),
 		SiteURL:                     r.FormValue("site_url"),
@@ -119,14 +137,20 @@ func NewFeedForm(r *http.Request) *FeedForm {
 		KeeplistRules:               r.FormValue("keeplist_rules"),
 		BlockFilterEntryRules:       r.FormValue("block_filter_entry_rules"),
 		KeepFilterEntryRules:        r.FormValue("keep_filter_entry_rules"),
-		Crawler:                     r.FormValue("crawler") == "1",
+		Crawler:                     r.FormValue("crawler"),
 		IgnoreEntryUpdates:          r.FormValue("ignore_entry_updates") == "1",
+		EntryRules:                  r.FormValue("entry_rules"),
+		MarkUnreadOnEntryRevision:   r.FormValue("mark_unread_on_entry_revision") == "1",
+		SnapshotEntries:             r.FormValue("snapshot_entries") == "1",
+		MirrorEnclosures:            r.FormValue("mirror_enclosures") == "1",
+		MirrorEnclosuresLimit:       mirrorEnclosuresLimit,
+		EnrichmentProcessors:        strings.Join(model.ParseEnrichmentProcessorNames(r.FormValue("enrichment_processors")), ","),
 		CategoryID:                  int64(categoryID),
 		Username:                    r.FormValue("feed_username"),
 		Password:                    r.FormValue("feed_password"),
 		IgnoreHTTPCache:             r.FormValue("ignore_http_cache") == "1",
 		AllowSelfSignedCertificates: r.FormValue("allow_self_signed_certificates") == "1",
-		FetchViaProxy:               r.FormValue("fetch_via_proxy") == "1",
+		FetchViaProxy:               r.FormValue("fetch_via_proxy"),
 		Disabled:                    r.FormValue("disabled") == "1",
 		NoMediaPlayer:               r.FormValue("no_media_player") == "1",
 		HideGlobally:                r.FormValue("hide_globally") == "1",
@@ -0,0 +1,23 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package form // import "miniflux.app/v2/internal/ui/form"
+
+import (
+	"net/http"
+	"strings"
+)
+
+// SavedSearchForm represents a saved search form in the UI.
+type SavedSearchForm struct {
+	Title string
+	Query string
+}
+
+// NewSavedSearchForm returns a new SavedSearchForm.
+func NewSavedSearchForm(r *http.Request) *SavedSearchForm {
+	return &SavedSearchForm{
+		Title: strings.TrimSpace(r.FormValue("title")),
+		Query: strings.TrimSpace(r.FormValue("query")),
+	}
+}
@@ -57,6 +57,8 @@ type SettingsForm struct {
 	OpenExternalLinksInNewTab bool
 	ShowVotingButtons         bool
 	ShowFeedTags              bool
+	DuplicateEntriesAction    string
+	EntryRules                string
 }
 
 // MarkAsReadBehavior returns the MarkReadBehavior from the given MarkReadOnView and MarkReadOnMediaPlayerCompletion values.
@@ -124,6 +126,8 @@ func (s *SettingsForm) Merge(user *model.User) *model.User {
 	user.OpenExternalLinksInNewTab = s.OpenExternalLinksInNewTab
 	user.ShowVotingButtons = s.ShowVotingButtons
 	user.ShowFeedTags = s.ShowFeedTags
+	user.DuplicateEntriesAction = s.DuplicateEntriesAction
+	user.EntryRules = s.EntryRules
 
 	MarkReadOnView, MarkReadOnMediaPlayerCompletion := extractMarkAsReadBehavior(s.MarkReadBehavior)
 	user.MarkReadOnView = MarkReadOnView
@@ -220,5 +224,7 @@ func NewSettingsForm(r *http.Request) *SettingsForm {
 		OpenExternalLinksInNewTab: r.FormValue("open_external_links_in_new_tab") == "1",
 		ShowVotingButtons:         r.FormValue("show_voting_buttons") == "1",
 		ShowFeedTags:              r.FormValue("show_feed_tags") == "1",
+		DuplicateEntriesAction:    r.FormValue("duplicate_entries_action"),
+		EntryRules:                r.FormValue("entry_rules"),
 	}
 }
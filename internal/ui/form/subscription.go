@@ -8,6 +8,7 @@ import (
 	"strconv"
 
 	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
 	"miniflux.app/v2/internal/urllib"
 	"miniflux.app/v2/internal/validator"
 )
@@ -93,3 +94,20 @@ func NewSubscriptionForm(r *http.Request) *SubscriptionForm {
 		ProxyURL:                    r.FormValue("proxy_url"),
 	}
 }
+
+// CrawlerOverride returns the crawler setting of the new feed, the setting of the category is used unless the option is checked.
+func (s *SubscriptionForm) CrawlerOverride() string {
+	return checkedOrInherited(s.Crawler)
+}
+
+// FetchViaProxyOverride returns the proxy setting of the new feed, the setting of the category is used unless the option is checked.
+func (s *SubscriptionForm) FetchViaProxyOverride() string {
+	return checkedOrInherited(s.FetchViaProxy)
+}
+
+func checkedOrInherited(checked bool) string {
+	if checked {
+		return model.FeedSettingOn
+	}
+	return model.FeedSettingInherit
+}
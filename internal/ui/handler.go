@@ -6,6 +6,7 @@ package ui // import "miniflux.app/v2/internal/ui"
 import (
 	"fmt"
 
+	"miniflux.app/v2/internal/mediaproxy"
 	"miniflux.app/v2/internal/storage"
 	"miniflux.app/v2/internal/template"
 	"miniflux.app/v2/internal/ui/static"
@@ -17,6 +18,9 @@ type handler struct {
 	store    *storage.Storage
 	tpl      *template.Engine
 	pool     *worker.Pool
+
+	// mediaCache is nil when the media proxy cache is disabled.
+	mediaCache *mediaproxy.Cache
 }
 
 func (h *handler) routePath(format string, args ...any) string {
@@ -25,6 +25,7 @@ func (h *handler) showHistoryPage(w http.ResponseWriter, r *http.Request) {
 	builder.WithSorting("changed_at", "DESC")
 	builder.WithSorting("published_at", "DESC")
 	builder.WithoutContent()
+	builder.WithEnclosures()
 	builder.WithOffset(offset)
 	builder.WithLimit(user.EntriesPerPage)
 
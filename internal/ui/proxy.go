@@ -4,13 +4,16 @@
 package ui // import "miniflux.app/v2/internal/ui"
 
 import (
-	"crypto/hmac"
-	"crypto/sha256"
+	"bytes"
 	"encoding/base64"
 	"errors"
+	"fmt"
+	"io"
 	"log/slog"
+	"mime"
 	"net/http"
 	"net/url"
+	"os"
 	"path"
 	"strconv"
 	"time"
@@ -19,7 +22,8 @@ import (
 	"miniflux.app/v2/internal/crypto"
 	"miniflux.app/v2/internal/http/request"
 	"miniflux.app/v2/internal/http/response"
-
+	"miniflux.app/v2/internal/mediaproxy"
+	"miniflux.app/v2/internal/metric"
 	"miniflux.app/v2/internal/reader/fetcher"
 	"miniflux.app/v2/internal/reader/rewrite"
 )
@@ -50,11 +54,13 @@ func (h *handler) mediaProxy(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	mac := hmac.New(sha256.New, config.Opts.MediaProxyPrivateKey())
-	mac.Write(decodedURL)
-	expectedMAC := mac.Sum(nil)
+	imageOptions, err := mediaproxy.ParseImageOptions(r.URL.Query())
+	if err != nil {
+		response.HTMLBadRequest(w, r, err)
+		return
+	}
 
-	if !hmac.Equal(decodedDigest, expectedMAC) {
+	if !mediaproxy.VerifyMediaURL(string(decodedURL), imageOptions, decodedDigest) {
 		response.HTMLForbidden(w, r)
 		return
 	}
@@ -81,20 +87,30 @@ func (h *handler) mediaProxy(w http.ResponseWriter, r *http.Request) {
 	}
 
 	mediaURL := string(decodedURL)
+	etag := crypto.HashFromBytes(decodedURL)
+
+	var filename string
+	if baseName := path.Base(parsedMediaURL.Path); baseName != "" && baseName != "." && baseName != "/" {
+		filename = baseName
+	}
+
+	if !imageOptions.IsZero() {
+		h.proxyResizedImage(w, r, mediaURL, imageOptions, filename)
+		return
+	}
+
+	if h.mediaCache != nil {
+		if !h.mediaCache.IsTooLarge(mediaURL) && h.proxyCachedMedia(w, r, mediaURL, etag, filename) {
+			return
+		}
+		metric.MediaProxyCacheRequests.WithLabelValues(metric.CacheStatusBypass).Inc()
+	}
 
 	slog.Debug("MediaProxy: Fetching remote resource",
 		slog.String("media_url", mediaURL),
 	)
 
-	requestBuilder := fetcher.NewRequestBuilder()
-	requestBuilder.WithTimeout(config.Opts.MediaProxyHTTPClientTimeout())
-
-	// Disable compression for the media proxy requests (not implemented).
-	requestBuilder.WithoutCompression()
-
-	if referer := rewrite.GetRefererForURL(mediaURL); referer != "" {
-		requestBuilder.WithHeader("Referer", referer)
-	}
+	requestBuilder := newMediaRequestBuilder(mediaURL)
 
 	forwardedRequestHeader := [...]string{"Range", "Accept", "Accept-Encoding", "User-Agent"}
 	for _, requestHeaderName := range forwardedRequestHeader {
@@ -105,20 +121,7 @@ func (h *handler) mediaProxy(w http.ResponseWriter, r *http.Request) {
 
 	resp, err := requestBuilder.ExecuteRequest(mediaURL)
 	if err != nil {
-		if errors.Is(err, fetcher.ErrPrivateNetworkHost) || errors.Is(err, fetcher.ErrHostnameResolution) {
-			slog.Warn("MediaProxy: Refused remote resource",
-				slog.String("media_url", mediaURL),
-				slog.Any("error", err),
-			)
-			response.HTMLForbidden(w, r)
-			return
-		}
-
-		slog.Error("MediaProxy: Unable to initialize HTTP client",
-			slog.String("media_url", mediaURL),
-			slog.Any("error", err),
-		)
-		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		writeMediaFetchError(w, r, mediaURL, err)
 		return
 	}
 	defer resp.Body.Close()
@@ -132,24 +135,273 @@ func (h *handler) mediaProxy(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusPartialContent {
-		slog.Warn("MediaProxy: Unexpected response status code",
+		writeMediaStatusError(w, mediaURL, resp.StatusCode)
+		return
+	}
+
+	writeProxiedMedia(w, r, resp, etag, filename)
+}
+
+// proxyCachedMedia serves the media from the cache, fetching it from the origin when it is missing or expired.
+// The whole media is fetched and stored before being sent, so that range requests are served from the cache.
+// It returns false when the media cannot be cached and must be proxied directly.
+func (h *handler) proxyCachedMedia(w http.ResponseWriter, r *http.Request, mediaURL, etag, filename string) bool {
+	media, file, err := h.mediaCache.Open(mediaURL)
+	if err != nil {
+		slog.Warn("MediaProxy: Unable to read the cached media",
 			slog.String("media_url", mediaURL),
-			slog.Int("status_code", resp.StatusCode),
+			slog.Any("error", err),
 		)
+	}
+	if file != nil {
+		defer file.Close()
+	}
+
+	if media != nil && !media.IsExpired() {
+		metric.MediaProxyCacheRequests.WithLabelValues(metric.CacheStatusHit).Inc()
+		writeCachedMedia(w, r, media, file, etag, filename)
+		return true
+	}
+
+	slog.Debug("MediaProxy: Fetching remote resource for the cache",
+		slog.String("media_url", mediaURL),
+	)
+
+	requestBuilder := newMediaRequestBuilder(mediaURL)
+	if userAgent := r.Header.Get("User-Agent"); userAgent != "" {
+		requestBuilder.WithHeader("User-Agent", userAgent)
+	}
+	if media != nil && media.CanBeRevalidated() {
+		if media.ETag != "" {
+			requestBuilder.WithHeader("If-None-Match", media.ETag)
+		}
+		if media.LastModified != "" {
+			requestBuilder.WithHeader("If-Modified-Since", media.LastModified)
+		}
+	}
+
+	resp, err := requestBuilder.ExecuteRequest(mediaURL)
+	if err != nil {
+		if media != nil && !errors.Is(err, fetcher.ErrPrivateNetworkHost) && !errors.Is(err, fetcher.ErrHostnameResolution) {
+			h.serveStaleMedia(w, r, media, file, etag, filename, err)
+			return true
+		}
+		writeMediaFetchError(w, r, mediaURL, err)
+		return true
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && media != nil:
+		if expiresAt, cacheable := mediaproxy.CacheExpiration(resp.Header, time.Now()); cacheable {
+			if err := h.mediaCache.Refresh(mediaURL, expiresAt); err != nil {
+				slog.Warn("MediaProxy: Unable to refresh the cached media",
+					slog.String("media_url", mediaURL),
+					slog.Any("error", err),
+				)
+			}
+		} else {
+			h.mediaCache.Remove(mediaURL)
+			h.updateMediaCacheMetrics()
+		}
+
+		metric.MediaProxyCacheRequests.WithLabelValues(metric.CacheStatusRevalidated).Inc()
+		writeCachedMedia(w, r, media, file, etag, filename)
+		return true
+
+	case resp.StatusCode == http.StatusOK:
+		expiresAt, cacheable := mediaproxy.CacheExpiration(resp.Header, time.Now())
+		if !cacheable {
+			h.mediaCache.Remove(mediaURL)
+			h.updateMediaCacheMetrics()
+
+			// The origin sent the whole media, the range requests must be forwarded to the origin.
+			if r.Header.Get("Range") != "" {
+				return false
+			}
+
+			metric.MediaProxyCacheRequests.WithLabelValues(metric.CacheStatusBypass).Inc()
+			writeProxiedMedia(w, r, resp, etag, filename)
+			return true
+		}
+
+		body := &trackedReader{reader: resp.Body}
+		storedMedia, err := h.mediaCache.Store(mediaURL, resp.Header, expiresAt, body)
+		if err != nil {
+			if !errors.Is(err, mediaproxy.ErrMediaTooLarge) {
+				slog.Warn("MediaProxy: Unable to store the media in the cache",
+					slog.String("media_url", mediaURL),
+					slog.Any("error", err),
+				)
+			}
+
+			// The media cannot be sent anymore once the cache has consumed a part of the response,
+			// too large media are remembered by the cache so this only happens once.
+			if body.read {
+				return false
+			}
+
+			metric.MediaProxyCacheRequests.WithLabelValues(metric.CacheStatusBypass).Inc()
+			writeProxiedMedia(w, r, resp, etag, filename)
+			return true
+		}
+		h.updateMediaCacheMetrics()
+
+		storedMedia, storedFile, err := h.mediaCache.Open(mediaURL)
+		if err != nil || storedMedia == nil {
+			return false
+		}
+		defer storedFile.Close()
+
+		metric.MediaProxyCacheRequests.WithLabelValues(metric.CacheStatusMiss).Inc()
+		writeCachedMedia(w, r, storedMedia, storedFile, etag, filename)
+		return true
+
+	case media != nil && (resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests):
+		h.serveStaleMedia(w, r, media, file, etag, filename, fmt.Errorf("origin status code is %d", resp.StatusCode))
+		return true
+
+	default:
+		writeMediaStatusError(w, mediaURL, resp.StatusCode)
+		return true
+	}
+}
+
+// proxyResizedImage downscales and re-encodes an image, the result is stored in the cache when it is enabled.
+func (h *handler) proxyResizedImage(w http.ResponseWriter, r *http.Request, mediaURL string, options mediaproxy.ImageOptions, filename string) {
+	// The variants of an image are cached separately, the newline cannot be part of a valid URL.
+	cacheKey := mediaURL + "\n" + options.Encode()
+	etag := crypto.HashFromBytes([]byte(cacheKey))
+
+	var media *mediaproxy.CachedMedia
+	var file *os.File
+	if h.mediaCache != nil {
+		var err error
+		media, file, err = h.mediaCache.Open(cacheKey)
+		if err != nil {
+			slog.Warn("MediaProxy: Unable to read the cached media",
+				slog.String("media_url", mediaURL),
+				slog.Any("error", err),
+			)
+		}
+		if file != nil {
+			defer file.Close()
+		}
+
+		if media != nil && !media.IsExpired() {
+			metric.MediaProxyCacheRequests.WithLabelValues(metric.CacheStatusHit).Inc()
+			writeCachedMedia(w, r, media, file, etag, filename)
+			return
+		}
+	}
 
-		// Forward the status code from the origin.
-		http.Error(w, "Origin status code is "+strconv.Itoa(resp.StatusCode), resp.StatusCode)
+	slog.Debug("MediaProxy: Fetching remote image to resize",
+		slog.String("media_url", mediaURL),
+		slog.Int("width", options.Width),
+		slog.Int("quality", options.Quality),
+	)
+
+	requestBuilder := newMediaRequestBuilder(mediaURL)
+	if userAgent := r.Header.Get("User-Agent"); userAgent != "" {
+		requestBuilder.WithHeader("User-Agent", userAgent)
+	}
+
+	resp, err := requestBuilder.ExecuteRequest(mediaURL)
+	if err != nil {
+		if media != nil && !errors.Is(err, fetcher.ErrPrivateNetworkHost) && !errors.Is(err, fetcher.ErrHostnameResolution) {
+			h.serveStaleMedia(w, r, media, file, etag, filename, err)
+			return
+		}
+		writeMediaFetchError(w, r, mediaURL, err)
 		return
 	}
+	defer resp.Body.Close()
 
-	etag := crypto.HashFromBytes(decodedURL)
+	if resp.StatusCode != http.StatusOK {
+		if media != nil && (resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests) {
+			h.serveStaleMedia(w, r, media, file, etag, filename, fmt.Errorf("origin status code is %d", resp.StatusCode))
+			return
+		}
+		writeMediaStatusError(w, mediaURL, resp.StatusCode)
+		return
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, mediaproxy.MaxSourceImageSize+1))
+	if err != nil {
+		slog.Warn("MediaProxy: Unable to read the remote image",
+			slog.String("media_url", mediaURL),
+			slog.Any("error", err),
+		)
+		http.Error(w, http.StatusText(http.StatusBadGateway), http.StatusBadGateway)
+		return
+	}
+
+	// The image is too large to be resized in memory, the original image is proxied instead.
+	if len(data) > mediaproxy.MaxSourceImageSize {
+		http.Redirect(w, r, mediaproxy.ProxifyRelativeURL(mediaURL), http.StatusFound)
+		return
+	}
+
+	contentType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	resizedImage, resizedContentType, err := mediaproxy.TransformImage(data, contentType, options)
+	if err != nil {
+		slog.Debug("MediaProxy: Unable to resize the image, the original image is sent",
+			slog.String("media_url", mediaURL),
+			slog.Any("error", err),
+		)
+	}
+
+	header := http.Header{}
+	header.Set("Content-Type", resp.Header.Get("Content-Type"))
+	if resizedContentType != contentType {
+		header.Set("Content-Type", resizedContentType)
+
+		// The extension of the original file would be misleading.
+		filename = ""
+	}
+	header.Set("Last-Modified", resp.Header.Get("Last-Modified"))
+
+	if h.mediaCache != nil {
+		if expiresAt, cacheable := mediaproxy.CacheExpiration(resp.Header, time.Now()); cacheable {
+			if _, err := h.mediaCache.Store(cacheKey, header, expiresAt, bytes.NewReader(resizedImage)); err != nil && !errors.Is(err, mediaproxy.ErrMediaTooLarge) {
+				slog.Warn("MediaProxy: Unable to store the media in the cache",
+					slog.String("media_url", mediaURL),
+					slog.Any("error", err),
+				)
+			}
+			h.updateMediaCacheMetrics()
+			metric.MediaProxyCacheRequests.WithLabelValues(metric.CacheStatusMiss).Inc()
+		} else {
+			metric.MediaProxyCacheRequests.WithLabelValues(metric.CacheStatusBypass).Inc()
+		}
+	}
+
+	writeMediaContent(w, r, header.Get("Content-Type"), header.Get("Last-Modified"), bytes.NewReader(resizedImage), etag, filename)
+}
+
+// serveStaleMedia sends an expired media when the origin is unavailable or rate-limits the requests.
+func (h *handler) serveStaleMedia(w http.ResponseWriter, r *http.Request, media *mediaproxy.CachedMedia, file io.ReadSeeker, etag, filename string, err error) {
+	slog.Warn("MediaProxy: Serving stale media from the cache",
+		slog.String("media_url", media.URL),
+		slog.Any("error", err),
+	)
+	metric.MediaProxyCacheRequests.WithLabelValues(metric.CacheStatusStale).Inc()
+	writeCachedMedia(w, r, media, file, etag, filename)
+}
+
+func (h *handler) updateMediaCacheMetrics() {
+	items, size := h.mediaCache.Stats()
+	metric.MediaProxyCacheItems.Set(float64(items))
+	metric.MediaProxyCacheSize.Set(float64(size))
+}
 
+func writeProxiedMedia(w http.ResponseWriter, r *http.Request, resp *http.Response, etag, filename string) {
 	response.NewBuilder(w, r).WithCaching(etag, 72*time.Hour, func(b *response.Builder) {
 		b.WithStatus(resp.StatusCode)
 		b.WithHeader("Content-Security-Policy", response.ContentSecurityPolicyForUntrustedContent)
 		b.WithHeader("Content-Type", resp.Header.Get("Content-Type"))
 
-		if filename := path.Base(parsedMediaURL.Path); filename != "" && filename != "." && filename != "/" {
+		if filename != "" {
 			b.WithInline(filename)
 		}
 
@@ -164,3 +416,78 @@ func (h *handler) mediaProxy(w http.ResponseWriter, r *http.Request) {
 		b.Write()
 	})
 }
+
+// trackedReader records whether the wrapped reader has been read.
+type trackedReader struct {
+	reader io.Reader
+	read   bool
+}
+
+func (t *trackedReader) Read(p []byte) (int, error) {
+	t.read = true
+	return t.reader.Read(p)
+}
+
+func writeCachedMedia(w http.ResponseWriter, r *http.Request, media *mediaproxy.CachedMedia, file io.ReadSeeker, etag, filename string) {
+	writeMediaContent(w, r, media.ContentType, media.LastModified, file, etag, filename)
+}
+
+func writeMediaContent(w http.ResponseWriter, r *http.Request, contentType, lastModified string, content io.ReadSeeker, etag, filename string) {
+	response.NewBuilder(w, r).WithCaching(etag, 72*time.Hour, func(b *response.Builder) {
+		b.WithHeader("Content-Security-Policy", response.ContentSecurityPolicyForUntrustedContent)
+		if contentType != "" {
+			b.WithHeader("Content-Type", contentType)
+		}
+
+		if filename != "" {
+			b.WithInline(filename)
+		}
+
+		var modTime time.Time
+		if parsedTime, err := http.ParseTime(lastModified); err == nil {
+			modTime = parsedTime
+		}
+		b.WriteContent(modTime, content)
+	})
+}
+
+func newMediaRequestBuilder(mediaURL string) *fetcher.RequestBuilder {
+	requestBuilder := fetcher.NewRequestBuilder()
+	requestBuilder.WithTimeout(config.Opts.MediaProxyHTTPClientTimeout())
+
+	// Disable compression for the media proxy requests (not implemented).
+	requestBuilder.WithoutCompression()
+
+	if referer := rewrite.GetRefererForURL(mediaURL); referer != "" {
+		requestBuilder.WithHeader("Referer", referer)
+	}
+
+	return requestBuilder
+}
+
+func writeMediaFetchError(w http.ResponseWriter, r *http.Request, mediaURL string, err error) {
+	if errors.Is(err, fetcher.ErrPrivateNetworkHost) || errors.Is(err, fetcher.ErrHostnameResolution) {
+		slog.Warn("MediaProxy: Refused remote resource",
+			slog.String("media_url", mediaURL),
+			slog.Any("error", err),
+		)
+		response.HTMLForbidden(w, r)
+		return
+	}
+
+	slog.Error("MediaProxy: Unable to initialize HTTP client",
+		slog.String("media_url", mediaURL),
+		slog.Any("error", err),
+	)
+	http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+}
+
+func writeMediaStatusError(w http.ResponseWriter, mediaURL string, statusCode int) {
+	slog.Warn("MediaProxy: Unexpected response status code",
+		slog.String("media_url", mediaURL),
+		slog.Int("status_code", statusCode),
+	)
+
+	// Forward the status code from the origin.
+	http.Error(w, "Origin status code is "+strconv.Itoa(statusCode), statusCode)
+}
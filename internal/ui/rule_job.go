@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response"
+	"miniflux.app/v2/internal/ui/view"
+)
+
+func (h *handler) showRuleJobPage(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		response.HTMLServerError(w, r, err)
+		return
+	}
+
+	job, err := h.store.RuleJobByID(user.ID, request.RouteInt64Param(r, "jobID"))
+	if err != nil {
+		response.HTMLServerError(w, r, err)
+		return
+	}
+
+	if job == nil {
+		response.HTMLNotFound(w, r)
+		return
+	}
+
+	view := view.New(h.tpl, r)
+
+	switch {
+	case job.FeedID != 0:
+		feed, err := h.store.FeedByID(user.ID, job.FeedID)
+		if err != nil {
+			response.HTMLServerError(w, r, err)
+			return
+		}
+		view.Set("feed", feed)
+	case job.CategoryID != 0:
+		category, err := h.store.Category(user.ID, job.CategoryID)
+		if err != nil {
+			response.HTMLServerError(w, r, err)
+			return
+		}
+		view.Set("category", category)
+	}
+
+	view.Set("job", job)
+	view.Set("menu", "settings")
+	view.Set("user", user)
+	view.Set("countUnread", h.store.CountUnreadEntries(user.ID))
+	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
+
+	response.HTML(w, r, view.Render("rule_job"))
+}
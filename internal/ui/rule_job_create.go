@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response"
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/processor"
+	"miniflux.app/v2/internal/storage"
+	"miniflux.app/v2/internal/validator"
+)
+
+func (h *handler) applyRules(w http.ResponseWriter, r *http.Request) {
+	sess := request.WebSession(r)
+	userID := request.UserID(r)
+
+	ruleJobRequest := &model.RuleJobRequest{
+		FeedID:     request.RouteInt64Param(r, "feedID"),
+		CategoryID: request.RouteInt64Param(r, "categoryID"),
+		Action:     r.FormValue("action"),
+	}
+
+	if validationErr := validator.ValidateRuleJobRequest(h.store, userID, ruleJobRequest); validationErr != nil {
+		sess.SetErrorMessage(validationErr.Translate(sess.Language()))
+		response.HTMLRedirect(w, r, h.ruleJobOriginPath(ruleJobRequest))
+		return
+	}
+
+	job, err := h.store.CreateRuleJob(userID, ruleJobRequest)
+	if errors.Is(err, storage.ErrRuleJobAlreadyRunning) {
+		sess.SetErrorMessage(locale.NewPrinter(sess.Language()).Printf("error.rule_job_already_running"))
+		response.HTMLRedirect(w, r, h.ruleJobOriginPath(ruleJobRequest))
+		return
+	}
+	if err != nil {
+		response.HTMLServerError(w, r, err)
+		return
+	}
+
+	slog.Info("Triggered the application of the rules to stored entries from the web ui",
+		slog.Int64("user_id", userID),
+		slog.Int64("rule_job_id", job.ID),
+		slog.String("action", job.Action),
+	)
+
+	processor.RequestRuleJobs()
+
+	response.HTMLRedirect(w, r, h.routePath("/rules/jobs/%d", job.ID))
+}
+
+func (h *handler) ruleJobOriginPath(ruleJobRequest *model.RuleJobRequest) string {
+	switch {
+	case ruleJobRequest.FeedID != 0:
+		return h.routePath("/feed/%d/edit", ruleJobRequest.FeedID)
+	case ruleJobRequest.CategoryID != 0:
+		return h.routePath("/category/%d/edit", ruleJobRequest.CategoryID)
+	default:
+		return h.routePath("/settings")
+	}
+}
@@ -29,6 +29,7 @@ func (h *handler) showSavedForLaterPage(w http.ResponseWriter, r *http.Request)
 	builder.WithLimit(user.EntriesPerPage)
 	builder.WithGloballyVisible()
 	builder.WithoutContent()
+	builder.WithEnclosures()
 
 	entries, count, err := builder.GetEntriesWithCount()
 	if err != nil {
@@ -46,6 +47,7 @@ func (h *handler) showSavedForLaterPage(w http.ResponseWriter, r *http.Request)
 		builder.WithLimit(user.EntriesPerPage)
 		builder.WithGloballyVisible()
 		builder.WithoutContent()
+		builder.WithEnclosures()
 
 		entries, count, err = builder.GetEntriesWithCount()
 		if err != nil {
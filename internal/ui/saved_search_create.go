@@ -0,0 +1,35 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response"
+	"miniflux.app/v2/internal/ui/form"
+	"miniflux.app/v2/internal/ui/view"
+)
+
+func (h *handler) showCreateSavedSearchPage(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		response.HTMLServerError(w, r, err)
+		return
+	}
+
+	// The search page links here with the current query so it can be saved as is.
+	savedSearchForm := form.SavedSearchForm{
+		Query: request.QueryStringParam(r, "q", ""),
+	}
+
+	v := view.New(h.tpl, r)
+	v.Set("form", savedSearchForm)
+	v.Set("menu", "saved_searches")
+	v.Set("user", user)
+	v.Set("countUnread", h.store.CountUnreadEntries(user.ID))
+	v.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
+
+	response.HTML(w, r, v.Render("create_saved_search"))
+}
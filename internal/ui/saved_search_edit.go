@@ -0,0 +1,47 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response"
+	"miniflux.app/v2/internal/ui/form"
+	"miniflux.app/v2/internal/ui/view"
+)
+
+func (h *handler) showEditSavedSearchPage(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		response.HTMLServerError(w, r, err)
+		return
+	}
+
+	savedSearch, err := h.store.SavedSearchByID(user.ID, request.RouteInt64Param(r, "savedSearchID"))
+	if err != nil {
+		response.HTMLServerError(w, r, err)
+		return
+	}
+
+	if savedSearch == nil {
+		response.HTMLNotFound(w, r)
+		return
+	}
+
+	savedSearchForm := form.SavedSearchForm{
+		Title: savedSearch.Title,
+		Query: savedSearch.Query,
+	}
+
+	v := view.New(h.tpl, r)
+	v.Set("form", savedSearchForm)
+	v.Set("savedSearch", savedSearch)
+	v.Set("menu", "saved_searches")
+	v.Set("user", user)
+	v.Set("countUnread", h.store.CountUnreadEntries(user.ID))
+	v.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
+
+	response.HTML(w, r, v.Render("edit_saved_search"))
+}
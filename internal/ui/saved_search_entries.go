@@ -0,0 +1,62 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response"
+	"miniflux.app/v2/internal/ui/view"
+)
+
+func (h *handler) showSavedSearchEntriesPage(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		response.HTMLServerError(w, r, err)
+		return
+	}
+
+	savedSearch, err := h.store.SavedSearchByID(user.ID, request.RouteInt64Param(r, "savedSearchID"))
+	if err != nil {
+		response.HTMLServerError(w, r, err)
+		return
+	}
+
+	if savedSearch == nil {
+		response.HTMLNotFound(w, r)
+		return
+	}
+
+	offset := request.QueryIntParam(r, "offset", 0)
+	builder := h.store.NewEntryQueryBuilder(user.ID)
+	builder.WithSorting("status", "asc")
+	builder.WithSorting(user.EntryOrder, user.EntryDirection)
+	builder.WithSorting("id", user.EntryDirection)
+	builder.WithSearchQuery(savedSearch.Query)
+	builder.WithoutContent()
+	builder.WithEnclosures()
+	builder.WithOffset(offset)
+	builder.WithLimit(user.EntriesPerPage)
+
+	entries, count, err := builder.GetEntriesWithCount()
+	if err != nil {
+		response.HTMLServerError(w, r, err)
+		return
+	}
+
+	v := view.New(h.tpl, r)
+	v.Set("savedSearch", savedSearch)
+	v.Set("total", count)
+	v.Set("entries", entries)
+	v.Set("pagination", getPagination(h.routePath("/saved-search/%d/entries", savedSearch.ID), count, offset, user.EntriesPerPage))
+	v.Set("menu", "saved_searches")
+	v.Set("user", user)
+	v.Set("countUnread", h.store.CountUnreadEntries(user.ID))
+	v.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
+	v.Set("hasSaveEntry", h.store.HasSaveEntry(user.ID))
+	v.Set("showOnlyUnreadEntries", false)
+
+	response.HTML(w, r, v.Render("saved_search_entries"))
+}
@@ -0,0 +1,28 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response"
+)
+
+func (h *handler) removeSavedSearch(w http.ResponseWriter, r *http.Request) {
+	userID := request.UserID(r)
+	savedSearchID := request.RouteInt64Param(r, "savedSearchID")
+
+	if !h.store.SavedSearchIDExists(userID, savedSearchID) {
+		response.HTMLNotFound(w, r)
+		return
+	}
+
+	if err := h.store.RemoveSavedSearch(userID, savedSearchID); err != nil {
+		response.HTMLServerError(w, r, err)
+		return
+	}
+
+	response.HTMLRedirect(w, r, h.routePath("/saved-searches"))
+}
@@ -0,0 +1,51 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/ui/form"
+	"miniflux.app/v2/internal/ui/view"
+	"miniflux.app/v2/internal/validator"
+)
+
+func (h *handler) saveSavedSearch(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		response.HTMLServerError(w, r, err)
+		return
+	}
+
+	savedSearchForm := form.NewSavedSearchForm(r)
+
+	v := view.New(h.tpl, r)
+	v.Set("form", savedSearchForm)
+	v.Set("menu", "saved_searches")
+	v.Set("user", user)
+	v.Set("countUnread", h.store.CountUnreadEntries(user.ID))
+	v.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
+
+	savedSearchCreationRequest := &model.SavedSearchCreationRequest{
+		Title: savedSearchForm.Title,
+		Query: savedSearchForm.Query,
+	}
+
+	if validationErr := validator.ValidateSavedSearchCreation(h.store, user.ID, savedSearchCreationRequest); validationErr != nil {
+		v.Set("errorMessage", validationErr.Translate(user.Language))
+		response.HTML(w, r, v.Render("create_saved_search"))
+		return
+	}
+
+	savedSearch, err := h.store.CreateSavedSearch(user.ID, savedSearchCreationRequest)
+	if err != nil {
+		response.HTMLServerError(w, r, err)
+		return
+	}
+
+	response.HTMLRedirect(w, r, h.routePath("/saved-search/%d/entries", savedSearch.ID))
+}
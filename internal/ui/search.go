@@ -29,10 +29,13 @@ func (h *handler) showSearchPage(w http.ResponseWriter, r *http.Request) {
 	if searchQuery != "" {
 		builder := h.store.NewEntryQueryBuilder(user.ID)
 		builder.WithSearchQuery(searchQuery)
+		builder.WithSorting(model.RelevanceSortingOrder, "DESC")
+		builder.WithSorting("published_at", "DESC")
 		if unreadOnly {
 			builder.WithStatus(model.EntryStatusUnread)
 		}
 		builder.WithoutContent()
+		builder.WithEnclosures()
 		builder.WithOffset(offset)
 		builder.WithLimit(user.EntriesPerPage)
 
@@ -0,0 +1,53 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"net/http"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/processor"
+	"miniflux.app/v2/internal/ui/form"
+	"miniflux.app/v2/internal/validator"
+)
+
+func (h *handler) previewSettingsRules(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		response.HTMLServerError(w, r, err)
+		return
+	}
+
+	settingsForm := form.NewSettingsForm(r)
+	view, err := h.settingsView(r, user, settingsForm)
+	if err != nil {
+		response.HTMLServerError(w, r, err)
+		return
+	}
+
+	rulePreviewRequest := &model.RulePreviewRequest{
+		User: &model.UserModificationRequest{
+			BlockFilterEntryRules: new(settingsForm.BlockFilterEntryRules),
+			KeepFilterEntryRules:  new(settingsForm.KeepFilterEntryRules),
+			EntryRules:            new(settingsForm.EntryRules),
+		},
+	}
+
+	if validationErr := validator.ValidateRulePreview(h.store, user.ID, rulePreviewRequest); validationErr != nil {
+		view.Set("errorMessage", validationErr.Translate(user.Language))
+		response.HTML(w, r, view.Render("settings"))
+		return
+	}
+
+	rulePreview, err := processor.PreviewRules(h.store, user.ID, rulePreviewRequest)
+	if err != nil {
+		response.HTMLServerError(w, r, err)
+		return
+	}
+
+	view.Set("rulePreview", rulePreview)
+	response.HTML(w, r, view.Render("settings"))
+}
@@ -22,7 +22,7 @@ func (h *handler) showSettingsPage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	settingsForm := form.SettingsForm{
+	settingsForm := &form.SettingsForm{
 		Username:                  user.Username,
 		Theme:                     user.Theme,
 		Language:                  user.Language,
@@ -51,33 +51,45 @@ func (h *handler) showSettingsPage(w http.ResponseWriter, r *http.Request) {
 		OpenExternalLinksInNewTab: user.OpenExternalLinksInNewTab,
 		ShowVotingButtons:         user.ShowVotingButtons,
 		ShowFeedTags:              user.ShowFeedTags,
+		DuplicateEntriesAction:    user.DuplicateEntriesAction,
+		EntryRules:                user.EntryRules,
 	}
 
-	creds, err := h.store.WebAuthnCredentialsByUserID(user.ID)
+	view, err := h.settingsView(r, user, settingsForm)
 	if err != nil {
 		response.HTMLServerError(w, r, err)
 		return
 	}
 
-	view := view.New(h.tpl, r)
-	view.Set("form", settingsForm)
-	view.Set("readBehaviors", map[string]any{
+	response.HTML(w, r, view.Render("settings"))
+}
+
+// settingsView returns the settings page view with the given form.
+func (h *handler) settingsView(r *http.Request, user *model.User, settingsForm *form.SettingsForm) (*view.View, error) {
+	creds, err := h.store.WebAuthnCredentialsByUserID(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	v := view.New(h.tpl, r)
+	v.Set("form", settingsForm)
+	v.Set("readBehaviors", map[string]any{
 		"NoAutoMarkAsRead":                           form.NoAutoMarkAsRead,
 		"MarkAsReadOnView":                           form.MarkAsReadOnView,
 		"MarkAsReadOnViewButWaitForPlayerCompletion": form.MarkAsReadOnViewButWaitForPlayerCompletion,
 		"MarkAsReadOnlyOnPlayerCompletion":           form.MarkAsReadOnlyOnPlayerCompletion,
 	})
-	view.Set("themes", model.Themes())
-	view.Set("languages", locale.AvailableLanguages)
-	view.Set("timezones", timezone.AvailableTimezones())
-	view.Set("menu", "settings")
-	view.Set("user", user)
-	view.Set("countUnread", h.store.CountUnreadEntries(user.ID))
-	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
-	view.Set("default_home_pages", model.HomePages())
-	view.Set("categories_sorting_options", model.CategoriesSortingOptions())
-	view.Set("countWebAuthnCerts", h.store.CountWebAuthnCredentialsByUserID(user.ID))
-	view.Set("webAuthnCerts", creds)
-
-	response.HTML(w, r, view.Render("settings"))
+	v.Set("themes", model.Themes())
+	v.Set("languages", locale.AvailableLanguages)
+	v.Set("timezones", timezone.AvailableTimezones())
+	v.Set("menu", "settings")
+	v.Set("user", user)
+	v.Set("countUnread", h.store.CountUnreadEntries(user.ID))
+	v.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
+	v.Set("default_home_pages", model.HomePages())
+	v.Set("categories_sorting_options", model.CategoriesSortingOptions())
+	v.Set("duplicate_entries_actions", model.DuplicateEntriesActions())
+	v.Set("countWebAuthnCerts", h.store.CountWebAuthnCredentialsByUserID(user.ID))
+	v.Set("webAuthnCerts", creds)
+	return v, nil
 }
@@ -10,9 +10,7 @@ import (
 	"miniflux.app/v2/internal/http/response"
 	"miniflux.app/v2/internal/locale"
 	"miniflux.app/v2/internal/model"
-	"miniflux.app/v2/internal/timezone"
 	"miniflux.app/v2/internal/ui/form"
-	"miniflux.app/v2/internal/ui/view"
 	"miniflux.app/v2/internal/validator"
 )
 
@@ -23,34 +21,13 @@ func (h *handler) updateSettings(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	creds, err := h.store.WebAuthnCredentialsByUserID(user.ID)
+	settingsForm := form.NewSettingsForm(r)
+	view, err := h.settingsView(r, user, settingsForm)
 	if err != nil {
 		response.HTMLServerError(w, r, err)
 		return
 	}
 
-	settingsForm := form.NewSettingsForm(r)
-
-	view := view.New(h.tpl, r)
-	view.Set("form", settingsForm)
-	view.Set("readBehaviors", map[string]any{
-		"NoAutoMarkAsRead":                           form.NoAutoMarkAsRead,
-		"MarkAsReadOnView":                           form.MarkAsReadOnView,
-		"MarkAsReadOnViewButWaitForPlayerCompletion": form.MarkAsReadOnViewButWaitForPlayerCompletion,
-		"MarkAsReadOnlyOnPlayerCompletion":           form.MarkAsReadOnlyOnPlayerCompletion,
-	})
-	view.Set("themes", model.Themes())
-	view.Set("languages", locale.AvailableLanguages)
-	view.Set("timezones", timezone.AvailableTimezones())
-	view.Set("menu", "settings")
-	view.Set("user", user)
-	view.Set("countUnread", h.store.CountUnreadEntries(user.ID))
-	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
-	view.Set("default_home_pages", model.HomePages())
-	view.Set("categories_sorting_options", model.CategoriesSortingOptions())
-	view.Set("countWebAuthnCerts", h.store.CountWebAuthnCredentialsByUserID(user.ID))
-	view.Set("webAuthnCerts", creds)
-
 	if validationErr := settingsForm.Validate(); validationErr != nil {
 		view.Set("errorMessage", validationErr.Translate(user.Language))
 		response.HTML(w, r, view.Render("settings"))
@@ -69,6 +46,7 @@ func (h *handler) updateSettings(w http.ResponseWriter, r *http.Request) {
 		CategoriesSortingOrder: model.OptionalString(settingsForm.CategoriesSortingOrder),
 		DisplayMode:            model.OptionalString(settingsForm.DisplayMode),
 		GestureNav:             model.OptionalString(settingsForm.GestureNav),
+		DuplicateEntriesAction: model.OptionalString(settingsForm.DuplicateEntriesAction),
 		DefaultReadingSpeed:    model.OptionalNumber(settingsForm.DefaultReadingSpeed),
 		CJKReadingSpeed:        model.OptionalNumber(settingsForm.CJKReadingSpeed),
 		DefaultHomePage:        model.OptionalString(settingsForm.DefaultHomePage),
@@ -76,6 +54,7 @@ func (h *handler) updateSettings(w http.ResponseWriter, r *http.Request) {
 		BlockFilterEntryRules:  model.OptionalString(settingsForm.BlockFilterEntryRules),
 		KeepFilterEntryRules:   model.OptionalString(settingsForm.KeepFilterEntryRules),
 		ExternalFontHosts:      model.OptionalString(settingsForm.ExternalFontHosts),
+		EntryRules:             model.OptionalString(settingsForm.EntryRules),
 	}
 
 	if validationErr := validator.ValidateUserModification(h.store, user.ID, userModificationRequest); validationErr != nil {
@@ -26,6 +26,7 @@ func (h *handler) showStarredPage(w http.ResponseWriter, r *http.Request) {
 	builder.WithOffset(offset)
 	builder.WithLimit(user.EntriesPerPage)
 	builder.WithoutContent()
+	builder.WithEnclosures()
 
 	entries, count, err := builder.GetEntriesWithCount()
 	if err != nil {
@@ -854,6 +854,32 @@ function handleFetchOriginalContentAction() {
     });
 }
 
+/**
+ * Handle summarizing an entry.
+ *
+ * @returns {void}
+ */
+function handleSummarizeEntryAction() {
+    if (isListView()) return;
+
+    const buttonElement = document.querySelector(":is(a, button)[data-summarize-entry]");
+    if (!buttonElement) return;
+
+    const originalButtonElement = setButtonToLoadingState(buttonElement);
+
+    sendPOSTRequest(buttonElement.dataset.summarizeUrl).then((response) => {
+        restoreButtonState(buttonElement, originalButtonElement);
+
+        response.json().then((data) => {
+            const summaryElement = document.querySelector(".entry-summary");
+            if (data.summary && summaryElement) {
+                summaryElement.querySelector("p").textContent = data.summary;
+                summaryElement.hidden = false;
+            }
+        });
+    });
+}
+
 /**
  * Open the original link of an entry.
  *
@@ -1400,6 +1426,7 @@ function initializeClickHandlers() {
     onClick(":is(a, button)[data-toggle-starred]", (event) => handleStarAction(event.target));
     onClick(":is(a, button)[data-toggle-status]", (event) => handleEntryStatus("next", event.target));
     onClick(":is(a, button)[data-fetch-content-entry]", handleFetchOriginalContentAction);
+    onClick(":is(a, button)[data-summarize-entry]", handleSummarizeEntryAction);
     onClick(":is(a, button)[data-share-status]", handleEntryShareAction);
     onClick(":is(a, button)[data-vote-entry]", (event) => handleVoteAction(event.target));
     onClick("button[data-save-tags-url]", (event) => handleSaveUserTags(event.target));
@@ -48,6 +48,7 @@ func (h *handler) showChooseSubscriptionPage(w http.ResponseWriter, r *http.Requ
 		CategoryID:                  subscriptionForm.CategoryID,
 		FeedURL:                     subscriptionForm.URL,
 		Crawler:                     subscriptionForm.Crawler,
+		CrawlerOverride:             subscriptionForm.CrawlerOverride(),
 		IgnoreEntryUpdates:          subscriptionForm.IgnoreEntryUpdates,
 		AllowSelfSignedCertificates: subscriptionForm.AllowSelfSignedCertificates,
 		UserAgent:                   subscriptionForm.UserAgent,
@@ -62,6 +63,7 @@ func (h *handler) showChooseSubscriptionPage(w http.ResponseWriter, r *http.Requ
 		KeepFilterEntryRules:        subscriptionForm.KeepFilterEntryRules,
 		BlockFilterEntryRules:       subscriptionForm.BlockFilterEntryRules,
 		FetchViaProxy:               subscriptionForm.FetchViaProxy,
+		FetchViaProxyOverride:       subscriptionForm.FetchViaProxyOverride(),
 		DisableHTTP2:                subscriptionForm.DisableHTTP2,
 		ProxyURL:                    subscriptionForm.ProxyURL,
 	})
@@ -97,6 +97,7 @@ func (h *handler) submitSubscription(w http.ResponseWriter, r *http.Request) {
 				FeedURL:                     subscriptions[0].URL,
 				AllowSelfSignedCertificates: subscriptionForm.AllowSelfSignedCertificates,
 				Crawler:                     subscriptionForm.Crawler,
+				CrawlerOverride:             subscriptionForm.CrawlerOverride(),
 				IgnoreEntryUpdates:          subscriptionForm.IgnoreEntryUpdates,
 				UserAgent:                   subscriptionForm.UserAgent,
 				Cookie:                      subscriptionForm.Cookie,
@@ -110,6 +111,7 @@ func (h *handler) submitSubscription(w http.ResponseWriter, r *http.Request) {
 				KeepFilterEntryRules:        subscriptionForm.KeepFilterEntryRules,
 				BlockFilterEntryRules:       subscriptionForm.BlockFilterEntryRules,
 				FetchViaProxy:               subscriptionForm.FetchViaProxy,
+				FetchViaProxyOverride:       subscriptionForm.FetchViaProxyOverride(),
 				DisableHTTP2:                subscriptionForm.DisableHTTP2,
 				ProxyURL:                    subscriptionForm.ProxyURL,
 			},
@@ -127,6 +129,7 @@ func (h *handler) submitSubscription(w http.ResponseWriter, r *http.Request) {
 			CategoryID:                  subscriptionForm.CategoryID,
 			FeedURL:                     subscriptions[0].URL,
 			Crawler:                     subscriptionForm.Crawler,
+			CrawlerOverride:             subscriptionForm.CrawlerOverride(),
 			IgnoreEntryUpdates:          subscriptionForm.IgnoreEntryUpdates,
 			AllowSelfSignedCertificates: subscriptionForm.AllowSelfSignedCertificates,
 			UserAgent:                   subscriptionForm.UserAgent,
@@ -141,6 +144,7 @@ func (h *handler) submitSubscription(w http.ResponseWriter, r *http.Request) {
 			KeepFilterEntryRules:        subscriptionForm.KeepFilterEntryRules,
 			BlockFilterEntryRules:       subscriptionForm.BlockFilterEntryRules,
 			FetchViaProxy:               subscriptionForm.FetchViaProxy,
+			FetchViaProxyOverride:       subscriptionForm.FetchViaProxyOverride(),
 			DisableHTTP2:                subscriptionForm.DisableHTTP2,
 			ProxyURL:                    subscriptionForm.ProxyURL,
 		})
@@ -32,6 +32,7 @@ func (h *handler) showToReviewPage(w http.ResponseWriter, r *http.Request) {
 	builder.WithLimit(user.EntriesPerPage)
 	builder.WithGloballyVisible()
 	builder.WithoutContent()
+	builder.WithEnclosures()
 
 	entries, count, err := builder.GetEntriesWithCount()
 	if err != nil {
@@ -50,6 +51,7 @@ func (h *handler) showToReviewPage(w http.ResponseWriter, r *http.Request) {
 		builder.WithLimit(user.EntriesPerPage)
 		builder.WithGloballyVisible()
 		builder.WithoutContent()
+		builder.WithEnclosures()
 
 		entries, count, err = builder.GetEntriesWithCount()
 		if err != nil {
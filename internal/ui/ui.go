@@ -4,9 +4,11 @@
 package ui // import "miniflux.app/v2/internal/ui"
 
 import (
+	"log/slog"
 	"net/http"
 
 	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/mediaproxy"
 	"miniflux.app/v2/internal/storage"
 	"miniflux.app/v2/internal/template"
 	"miniflux.app/v2/internal/worker"
@@ -23,7 +25,20 @@ func Serve(store *storage.Storage, pool *worker.Pool) http.Handler {
 	templateEngine := template.NewEngine(basePath)
 	templateEngine.ParseTemplates()
 
-	handler := &handler{basePath, store, templateEngine, pool}
+	handler := &handler{basePath: basePath, store: store, tpl: templateEngine, pool: pool}
+
+	if cacheDir := config.Opts.MediaProxyCacheDir(); cacheDir != "" {
+		mediaCache, err := mediaproxy.NewCache(cacheDir, config.Opts.MediaProxyCacheMaxSize(), config.Opts.MediaProxyCacheMaxItemSize())
+		if err != nil {
+			slog.Error("Unable to initialize the media proxy cache, the media will be proxied without caching",
+				slog.String("directory", cacheDir),
+				slog.Any("error", err),
+			)
+		} else {
+			handler.mediaCache = mediaCache
+			handler.updateMediaCacheMetrics()
+		}
+	}
 
 	mux := http.NewServeMux()
 
@@ -76,6 +91,10 @@ func Serve(store *storage.Storage, pool *worker.Pool) http.Handler {
 	mux.HandleFunc("GET /feed/{feedID}/edit", handler.showEditFeedPage)
 	mux.HandleFunc("POST /feed/{feedID}/remove", handler.removeFeed)
 	mux.HandleFunc("POST /feed/{feedID}/update", handler.updateFeed)
+	mux.HandleFunc("POST /feed/{feedID}/rules/preview", handler.previewFeedRules)
+	mux.HandleFunc("POST /feed/{feedID}/scraper/preview", handler.previewFeedScraper)
+	mux.HandleFunc("POST /feed/{feedID}/rules/apply", handler.applyRules)
+	mux.HandleFunc("POST /feed/{feedID}/blocked-entries/{blockedEntryID}/rescue", handler.rescueBlockedEntry)
 	mux.HandleFunc("GET /feed/{feedID}/entries", handler.showFeedEntriesPage)
 	mux.HandleFunc("GET /feed/{feedID}/entries/all", handler.showFeedEntriesAllPage)
 	mux.HandleFunc("GET /feed/{feedID}/entry/{entryID}", handler.showFeedEntryPage)
@@ -100,6 +119,8 @@ func Serve(store *storage.Storage, pool *worker.Pool) http.Handler {
 	mux.HandleFunc("GET /category/{categoryID}/entries/starred", handler.showCategoryEntriesStarredPage)
 	mux.HandleFunc("GET /category/{categoryID}/edit", handler.showEditCategoryPage)
 	mux.HandleFunc("POST /category/{categoryID}/update", handler.updateCategory)
+	mux.HandleFunc("POST /category/{categoryID}/rules/preview", handler.previewCategoryRules)
+	mux.HandleFunc("POST /category/{categoryID}/rules/apply", handler.applyRules)
 	mux.HandleFunc("POST /category/{categoryID}/remove", handler.removeCategory)
 	mux.HandleFunc("POST /category/{categoryID}/mark-all-as-read", handler.markCategoryAsRead)
 
@@ -117,6 +138,14 @@ func Serve(store *storage.Storage, pool *worker.Pool) http.Handler {
 	mux.HandleFunc("POST /user-tag/{userTagID}/update", handler.updateUserTag)
 	mux.HandleFunc("POST /user-tag/{userTagID}/remove", handler.removeUserTag)
 	mux.HandleFunc("POST /entry/user-tags/{entryID}", handler.updateEntryUserTags)
+	mux.HandleFunc("GET /saved-searches", handler.showSavedSearchesPage)
+	mux.HandleFunc("GET /saved-searches/create", handler.showCreateSavedSearchPage)
+	mux.HandleFunc("POST /saved-searches/save", handler.saveSavedSearch)
+	mux.HandleFunc("GET /saved-search/{savedSearchID}/entries", handler.showSavedSearchEntriesPage)
+	mux.HandleFunc("GET /saved-search/{savedSearchID}/entry/{entryID}", handler.showSavedSearchEntryPage)
+	mux.HandleFunc("GET /saved-search/{savedSearchID}/edit", handler.showEditSavedSearchPage)
+	mux.HandleFunc("POST /saved-search/{savedSearchID}/update", handler.updateSavedSearch)
+	mux.HandleFunc("POST /saved-search/{savedSearchID}/remove", handler.removeSavedSearch)
 
 	// Entry pages.
 	mux.HandleFunc("POST /entry/status", handler.updateEntriesStatus)
@@ -124,11 +153,16 @@ func Serve(store *storage.Storage, pool *worker.Pool) http.Handler {
 	mux.HandleFunc("POST /entry/save-for-later/{entryID}", handler.saveEntryForLater)
 	mux.HandleFunc("POST /entry/enclosure/{enclosureID}/save-progression", handler.saveEnclosureProgression)
 	mux.HandleFunc("POST /entry/download/{entryID}", handler.fetchContent)
+	mux.HandleFunc("POST /entry/summarize/{entryID}", handler.summarizeEntry)
 	mux.HandleFunc("POST /entry/star/{entryID}", handler.toggleStarred)
 	mux.HandleFunc("POST /entry/vote/{entryID}/{vote}", handler.updateEntryVote)
+	mux.HandleFunc("GET /entry/{entryID}/revisions", handler.showEntryRevisionsPage)
+	mux.HandleFunc("GET /entry/{entryID}/snapshot", handler.showEntrySnapshot)
+	mux.HandleFunc("POST /entry/{entryID}/snapshot", handler.createEntrySnapshot)
 
 	// Media proxy.
 	mux.HandleFunc("GET /proxy/{encodedDigest}/{encodedURL}", handler.mediaProxy)
+	mux.HandleFunc("GET /mirror/{encodedDigest}/{enclosureID}", handler.showEnclosureMirror)
 
 	// Share pages.
 	mux.HandleFunc("POST /entry/share/{entryID}", handler.createSharedEntry)
@@ -147,6 +181,9 @@ func Serve(store *storage.Storage, pool *worker.Pool) http.Handler {
 	// Settings pages.
 	mux.HandleFunc("GET /settings", handler.showSettingsPage)
 	mux.HandleFunc("POST /settings", handler.updateSettings)
+	mux.HandleFunc("POST /settings/rules/preview", handler.previewSettingsRules)
+	mux.HandleFunc("POST /settings/rules/apply", handler.applyRules)
+	mux.HandleFunc("GET /rules/jobs/{jobID}", handler.showRuleJobPage)
 	mux.HandleFunc("GET /integrations", handler.showIntegrationPage)
 	mux.HandleFunc("POST /integration", handler.updateIntegration)
 	mux.HandleFunc("GET /about", handler.showAboutPage)
@@ -155,6 +192,9 @@ func Serve(store *storage.Storage, pool *worker.Pool) http.Handler {
 	mux.HandleFunc("GET /sessions", handler.showSessionsPage)
 	mux.HandleFunc("POST /sessions/{sessionID}/remove", handler.removeSession)
 
+	// Statistics page.
+	mux.HandleFunc("GET /stats", handler.showStatsPage)
+
 	// API Keys pages.
 	if config.Opts.HasAPI() {
 		mux.HandleFunc("GET /keys", handler.showAPIKeysPage)
@@ -28,6 +28,7 @@ func (h *handler) showUnreadPage(w http.ResponseWriter, r *http.Request) {
 	builder.WithLimit(user.EntriesPerPage)
 	builder.WithGloballyVisible()
 	builder.WithoutContent()
+	builder.WithEnclosures()
 
 	entries, countUnread, err := builder.GetEntriesWithCount()
 	if err != nil {
@@ -44,6 +45,7 @@ func (h *handler) showUnreadPage(w http.ResponseWriter, r *http.Request) {
 		builder.WithLimit(user.EntriesPerPage)
 		builder.WithGloballyVisible()
 		builder.WithoutContent()
+		builder.WithEnclosures()
 
 		entries, countUnread, err = builder.GetEntriesWithCount()
 		if err != nil {
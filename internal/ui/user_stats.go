@@ -0,0 +1,61 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package ui // import "miniflux.app/v2/internal/ui"
+
+import (
+	"net/http"
+	"slices"
+
+	"miniflux.app/v2/internal/http/request"
+	"miniflux.app/v2/internal/http/response"
+	"miniflux.app/v2/internal/ui/view"
+)
+
+type unreadAgePercentile struct {
+	Label string
+	Days  int
+	Hours int
+}
+
+func (h *handler) showStatsPage(w http.ResponseWriter, r *http.Request) {
+	user, err := h.store.UserByID(request.UserID(r))
+	if err != nil {
+		response.HTMLServerError(w, r, err)
+		return
+	}
+
+	stats, err := h.store.UserStats(user.ID, user.Timezone)
+	if err != nil {
+		response.HTMLServerError(w, r, err)
+		return
+	}
+
+	// The most recent periods are shown first.
+	slices.Reverse(stats.Days)
+	slices.Reverse(stats.Weeks)
+	slices.Reverse(stats.Months)
+
+	unreadAge := make([]unreadAgePercentile, 0, 3)
+	for _, percentile := range []struct {
+		label   string
+		seconds int64
+	}{
+		{"page.stats.unread_age.median", stats.UnreadAge.P50},
+		{"page.stats.unread_age.p90", stats.UnreadAge.P90},
+		{"page.stats.unread_age.p99", stats.UnreadAge.P99},
+	} {
+		hours := int(percentile.seconds / 3600)
+		unreadAge = append(unreadAge, unreadAgePercentile{Label: percentile.label, Days: hours / 24, Hours: hours % 24})
+	}
+
+	view := view.New(h.tpl, r)
+	view.Set("stats", stats)
+	view.Set("unreadAge", unreadAge)
+	view.Set("menu", "settings")
+	view.Set("user", user)
+	view.Set("countUnread", h.store.CountUnreadEntries(user.ID))
+	view.Set("countErrorFeeds", h.store.CountUserFeedsWithErrors(user.ID))
+
+	response.HTML(w, r, view.Render("stats"))
+}
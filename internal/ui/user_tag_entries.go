@@ -38,6 +38,7 @@ func (h *handler) showUserTagEntriesPage(w http.ResponseWriter, r *http.Request)
 	builder.WithSorting("id", user.EntryDirection)
 	builder.WithOffset(offset)
 	builder.WithLimit(user.EntriesPerPage)
+	builder.WithEnclosures()
 
 	entries, err := builder.GetEntries()
 	if err != nil {
@@ -12,30 +12,30 @@ import (
 	"miniflux.app/v2/internal/ui/static"
 )
 
-// view wraps template argument building.
-type view struct {
+// View wraps template argument building.
+type View struct {
 	tpl    *template.Engine
 	r      *http.Request
 	params map[string]any
 }
 
 // Set adds a new template argument.
-func (v *view) Set(param string, value any) *view {
+func (v *View) Set(param string, value any) *View {
 	v.params[param] = value
 	return v
 }
 
 // Render executes the template with arguments.
-func (v *view) Render(template string) []byte {
+func (v *View) Render(template string) []byte {
 	return v.tpl.Render(template+".html", v.params)
 }
 
 // New returns a new view with default parameters.
-func New(tpl *template.Engine, r *http.Request) *view {
+func New(tpl *template.Engine, r *http.Request) *View {
 	webSession := request.WebSession(r)
 	theme := webSession.Theme()
 	flashSuccessMessage, flashErrorMessage := webSession.ConsumeMessages()
-	return &view{tpl, r, map[string]any{
+	return &View{tpl, r, map[string]any{
 		"menu":                "",
 		"csrf":                webSession.CSRF(),
 		"flashSuccessMessage": flashSuccessMessage,
@@ -6,9 +6,11 @@ package urllib // import "miniflux.app/v2/internal/urllib"
 import (
 	"errors"
 	"fmt"
+	"mime"
 	"net"
 	"net/netip"
 	"net/url"
+	"path"
 	"strings"
 )
 
@@ -157,6 +159,18 @@ func JoinBaseURLAndPath(baseURL, path string) (string, error) {
 	return finalURL, nil
 }
 
+// ImageMimeType guesses the type of an image from the extension of its URL.
+func ImageMimeType(imageURL string) string {
+	if parsedURL, err := url.Parse(imageURL); err == nil {
+		mimeType, _, _ := strings.Cut(mime.TypeByExtension(strings.ToLower(path.Ext(parsedURL.Path))), ";")
+		if strings.HasPrefix(mimeType, "image/") {
+			return mimeType
+		}
+	}
+
+	return "application/octet-stream"
+}
+
 // IsNonPublicIP returns true if the given IP is private, loopback,
 // link-local, multicast, or unspecified.
 func IsNonPublicIP(ip net.IP) bool {
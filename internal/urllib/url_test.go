@@ -224,6 +224,24 @@ func TestJoinBaseURLAndPath(t *testing.T) {
 	}
 }
 
+func TestImageMimeType(t *testing.T) {
+	scenarios := map[string]string{
+		"https://example.org/image.jpg":          "image/jpeg",
+		"https://example.org/image.PNG":          "image/png",
+		"https://example.org/image.webp?w=200":   "image/webp",
+		"https://example.org/image.svg":          "image/svg+xml",
+		"https://example.org/image":              "application/octet-stream",
+		"https://example.org/document.pdf":       "application/octet-stream",
+		"https://example.org/photos/12345/large": "application/octet-stream",
+	}
+
+	for input, expected := range scenarios {
+		if result := ImageMimeType(input); result != expected {
+			t.Errorf(`Unexpected result for %q, got %q instead of %q`, input, result, expected)
+		}
+	}
+}
+
 func TestIsNonPublicIP(t *testing.T) {
 	testCases := []struct {
 		name     string
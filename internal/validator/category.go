@@ -6,7 +6,9 @@ package validator // import "miniflux.app/v2/internal/validator"
 import (
 	"miniflux.app/v2/internal/locale"
 	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/summary"
 	"miniflux.app/v2/internal/storage"
+	"miniflux.app/v2/internal/urllib"
 )
 
 // ValidateCategoryCreation validates category creation.
@@ -19,7 +21,20 @@ func ValidateCategoryCreation(store *storage.Storage, userID int64, request *mod
 		return locale.NewLocalizedError("error.category_already_exists")
 	}
 
-	return nil
+	if err := isValidEntryRules(request.EntryRules); err != nil {
+		return err
+	}
+
+	return validateCategoryRules(&model.CategoryModificationRequest{
+		BlocklistRules:        &request.BlocklistRules,
+		KeeplistRules:         &request.KeeplistRules,
+		BlockFilterEntryRules: &request.BlockFilterEntryRules,
+		KeepFilterEntryRules:  &request.KeepFilterEntryRules,
+		ScraperRules:          &request.ScraperRules,
+		ProxyURL:              &request.ProxyURL,
+		EnrichmentProcessors:  &request.EnrichmentProcessors,
+		SummaryPrompt:         &request.SummaryPrompt,
+	})
 }
 
 // ValidateCategoryModification validates category modification.
@@ -34,5 +49,50 @@ func ValidateCategoryModification(store *storage.Storage, userID, categoryID int
 		}
 	}
 
+	if request.EntryRules != nil {
+		if err := isValidEntryRules(*request.EntryRules); err != nil {
+			return err
+		}
+	}
+
+	return validateCategoryRules(request)
+}
+
+// validateCategoryRules validates the rules, the proxy URL, the enrichment processors and the summary prompt of a category.
+func validateCategoryRules(request *model.CategoryModificationRequest) *locale.LocalizedError {
+	if request.BlocklistRules != nil && !IsValidRegex(*request.BlocklistRules) {
+		return locale.NewLocalizedError("error.feed_invalid_blocklist_rule")
+	}
+
+	if request.KeeplistRules != nil && !IsValidRegex(*request.KeeplistRules) {
+		return locale.NewLocalizedError("error.feed_invalid_keeplist_rule")
+	}
+
+	if err := validateCandidateFilterRules(request.BlockFilterEntryRules, request.KeepFilterEntryRules); err != nil {
+		return err
+	}
+
+	if request.ScraperRules != nil {
+		if err := isValidScraperRules(*request.ScraperRules); err != nil {
+			return err
+		}
+	}
+
+	if request.ProxyURL != nil && *request.ProxyURL != "" && !urllib.IsAbsoluteURL(*request.ProxyURL) {
+		return locale.NewLocalizedError("error.invalid_feed_proxy_url")
+	}
+
+	if request.EnrichmentProcessors != nil {
+		if err := isValidEnrichmentProcessors(*request.EnrichmentProcessors); err != nil {
+			return err
+		}
+	}
+
+	if request.SummaryPrompt != nil {
+		if err := summary.ValidatePromptTemplate(*request.SummaryPrompt); err != nil {
+			return locale.NewLocalizedError("error.invalid_summary_prompt", err)
+		}
+	}
+
 	return nil
 }
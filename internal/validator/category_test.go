@@ -0,0 +1,68 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package validator // import "miniflux.app/v2/internal/validator"
+
+import (
+	"testing"
+
+	"miniflux.app/v2/internal/config"
+	"miniflux.app/v2/internal/model"
+)
+
+func TestValidateCategoryRules(t *testing.T) {
+	valid := &model.CategoryModificationRequest{
+		BlocklistRules:        new("(?i)sponsored"),
+		KeeplistRules:         new(""),
+		BlockFilterEntryRules: new("EntryTitle=(?i)rust"),
+		KeepFilterEntryRules:  new(""),
+		ProxyURL:              new("http://proxy:3128"),
+	}
+	if err := validateCategoryRules(valid); err != nil {
+		t.Errorf(`Valid category rules should not generate an error: %v`, err)
+	}
+
+	scenarios := []*model.CategoryModificationRequest{
+		{BlocklistRules: new("[")},
+		{KeeplistRules: new("(")},
+		{BlockFilterEntryRules: new("Title=golang")},
+		{KeepFilterEntryRules: new("EntryTitle=[")},
+		{ProxyURL: new("not a URL")},
+	}
+	for _, request := range scenarios {
+		if err := validateCategoryRules(request); err == nil {
+			t.Errorf(`Invalid category rules should generate an error: %+v`, request)
+		}
+	}
+}
+
+func TestValidateCategoryEnrichmentProcessors(t *testing.T) {
+	configParser := config.NewConfigParser()
+	options, err := configParser.ParseEnvironmentVariables()
+	if err != nil {
+		t.Fatal(err)
+	}
+	config.Opts = options
+
+	if err := validateCategoryRules(&model.CategoryModificationRequest{EnrichmentProcessors: new("")}); err != nil {
+		t.Errorf(`An empty list of processors should not generate an error: %v`, err)
+	}
+
+	if err := validateCategoryRules(&model.CategoryModificationRequest{EnrichmentProcessors: new("summarizer")}); err == nil {
+		t.Error(`An unknown processor should generate an error`)
+	}
+}
+
+func TestValidateCategorySummaryPrompt(t *testing.T) {
+	if err := validateCategoryRules(&model.CategoryModificationRequest{SummaryPrompt: new("Summarize {{ .Title }}: {{ .Content }}")}); err != nil {
+		t.Errorf(`A valid prompt template should not generate an error: %v`, err)
+	}
+
+	if err := validateCategoryRules(&model.CategoryModificationRequest{SummaryPrompt: new("Summarize {{ .Title ")}); err == nil {
+		t.Error(`An invalid prompt template should generate an error`)
+	}
+
+	if err := validateCategoryRules(&model.CategoryModificationRequest{SummaryPrompt: new("Summarize {{ .Unknown }}")}); err == nil {
+		t.Error(`A prompt template with an unknown field should generate an error`)
+	}
+}
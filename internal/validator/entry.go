@@ -39,6 +39,16 @@ func ValidateEntryOrder(order string) error {
 	return errors.New(`invalid entry order, valid order values are: "id", "status", "changed_at", "published_at", "created_at", "category_title", "category_id", "title", "author", "score"`)
 }
 
+// ValidateEntryQueryOrder makes sure the sorting order of an entry query is valid.
+// Queries also accept the relevance order, which ranks entries by the search text.
+func ValidateEntryQueryOrder(order string) error {
+	if order == model.RelevanceSortingOrder {
+		return nil
+	}
+
+	return ValidateEntryOrder(order)
+}
+
 // ValidateEntryModification makes sure the entry modification is valid.
 func ValidateEntryModification(request *model.EntryUpdateRequest) error {
 	if request.Title != nil && *request.Title == "" {
@@ -62,6 +62,22 @@ func TestValidateEntryOrder(t *testing.T) {
 	}
 }
 
+func TestValidateEntryQueryOrder(t *testing.T) {
+	for _, order := range []string{"relevance", "published_at", "score"} {
+		if err := ValidateEntryQueryOrder(order); err != nil {
+			t.Errorf(`The order %q should be valid for entry queries`, order)
+		}
+	}
+
+	if err := ValidateEntryOrder("relevance"); err == nil {
+		t.Error(`The relevance order should not be accepted as a user preference`)
+	}
+
+	if err := ValidateEntryQueryOrder("invalid"); err == nil {
+		t.Error(`An invalid order should generate a error`)
+	}
+}
+
 func TestValidateEntryModification(t *testing.T) {
 	// Accepts no-op update.
 	if err := ValidateEntryModification(&model.EntryUpdateRequest{}); err != nil {
@@ -4,6 +4,7 @@
 package validator // import "miniflux.app/v2/internal/validator"
 
 import (
+	"miniflux.app/v2/internal/config"
 	"miniflux.app/v2/internal/locale"
 	"miniflux.app/v2/internal/model"
 	"miniflux.app/v2/internal/storage"
@@ -36,10 +37,22 @@ func ValidateFeedCreation(store *storage.Storage, userID int64, request *model.F
 		return locale.NewLocalizedError("error.feed_invalid_keeplist_rule")
 	}
 
+	if err := isValidScraperRules(request.ScraperRules); err != nil {
+		return err
+	}
+
 	if request.ProxyURL != "" && !urllib.IsAbsoluteURL(request.ProxyURL) {
 		return locale.NewLocalizedError("error.invalid_feed_proxy_url")
 	}
 
+	if err := isValidFeedSettingOverride(request.CrawlerOverride); err != nil {
+		return err
+	}
+
+	if err := isValidFeedSettingOverride(request.FetchViaProxyOverride); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -93,6 +106,22 @@ func ValidateFeedModification(store *storage.Storage, userID, feedID int64, requ
 		}
 	}
 
+	if request.EntryRules != nil {
+		if err := isValidEntryRules(*request.EntryRules); err != nil {
+			return err
+		}
+	}
+
+	if request.ScraperRules != nil {
+		if err := isValidScraperRules(*request.ScraperRules); err != nil {
+			return err
+		}
+	}
+
+	if request.MirrorEnclosuresLimit != nil && *request.MirrorEnclosuresLimit < 0 {
+		return locale.NewLocalizedError("error.feed_invalid_mirror_enclosures_limit")
+	}
+
 	if request.ProxyURL != nil {
 		if *request.ProxyURL == "" {
 			return locale.NewLocalizedError("error.proxy_url_not_empty")
@@ -103,5 +132,49 @@ func ValidateFeedModification(store *storage.Storage, userID, feedID int64, requ
 		}
 	}
 
+	if request.CrawlerOverride != nil {
+		if err := isValidFeedSettingOverride(*request.CrawlerOverride); err != nil {
+			return err
+		}
+	}
+
+	if request.FetchViaProxyOverride != nil {
+		if err := isValidFeedSettingOverride(*request.FetchViaProxyOverride); err != nil {
+			return err
+		}
+	}
+
+	if request.EnrichmentProcessors != nil {
+		if err := isValidEnrichmentProcessors(*request.EnrichmentProcessors); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// isValidEnrichmentProcessors makes sure the enrichment processors are defined in the configuration.
+func isValidEnrichmentProcessors(value string) *locale.LocalizedError {
+	names := model.ParseEnrichmentProcessorNames(value)
+	if len(names) == 0 {
+		return nil
+	}
+
+	processors := config.Opts.EnrichmentProcessors()
+	for _, name := range names {
+		if _, found := processors[name]; !found {
+			return locale.NewLocalizedError("error.unknown_enrichment_processor", name)
+		}
+	}
+
+	return nil
+}
+
+// isValidFeedSettingOverride makes sure a feed setting override is either empty, inherit, on or off.
+func isValidFeedSettingOverride(override string) *locale.LocalizedError {
+	if override != "" && !model.IsValidFeedSettingOverride(override) {
+		return locale.NewLocalizedError("error.feed_invalid_setting_override")
+	}
+
 	return nil
 }
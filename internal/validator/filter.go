@@ -4,15 +4,17 @@
 package validator // import "miniflux.app/v2/internal/validator"
 
 import (
+	"errors"
 	"slices"
 	"strings"
 
 	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/reader/rules"
 )
 
 func isValidFilterRules(filterEntryRules string, filterType string) *locale.LocalizedError {
 	// Valid Format: FieldName=RegEx\nFieldName=RegEx...
-	fieldNames := []string{"EntryTitle", "EntryURL", "EntryCommentsURL", "EntryContent", "EntryAuthor", "EntryTag", "EntryDate"}
+	fieldNames := []string{"EntryTitle", "EntryURL", "EntryCommentsURL", "EntryContent", "EntryAuthor", "EntryTag", "EntryLanguage", "EntryDate"}
 
 	rules := strings.Split(filterEntryRules, "\n")
 	for i, rule := range rules {
@@ -41,3 +43,14 @@ func isValidFilterRules(filterEntryRules string, filterType string) *locale.Loca
 	}
 	return nil
 }
+
+func isValidEntryRules(entryRules string) *locale.LocalizedError {
+	if _, err := rules.Parse(entryRules); err != nil {
+		var parseErr *rules.ParseError
+		if errors.As(err, &parseErr) {
+			return locale.NewLocalizedError("error.invalid_entry_rules", parseErr.Line, parseErr.Err)
+		}
+		return locale.NewLocalizedError("error.invalid_entry_rules", 0, err)
+	}
+	return nil
+}
@@ -21,6 +21,11 @@ func TestIsValidFilterRules(t *testing.T) {
 			rules:   "EntryTitle=foo\nEntryContent=bar",
 			wantErr: false,
 		},
+		{
+			name:    "valid language rule",
+			rules:   "EntryLanguage=^(de|fr)$",
+			wantErr: false,
+		},
 		{
 			name:    "invalid field name",
 			rules:   "Title=foo",
@@ -53,3 +58,17 @@ func TestIsValidFilterRules(t *testing.T) {
 		})
 	}
 }
+
+func TestIsValidEntryRules(t *testing.T) {
+	if err := isValidEntryRules("# Comment\nif title ~ \"(?i)sponsored\" then block\n"); err != nil {
+		t.Fatalf("expected valid rules, got %v", err)
+	}
+
+	if err := isValidEntryRules("if title ~ \"a\" then block\nif title ~ \"[\" then block"); err == nil {
+		t.Fatal("expected an error for an invalid regex")
+	}
+
+	if err := isValidEntryRules("if title ~ \"a\" then explode"); err == nil {
+		t.Fatal("expected an error for an unknown action")
+	}
+}
@@ -0,0 +1,33 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package validator // import "miniflux.app/v2/internal/validator"
+
+import (
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/storage"
+)
+
+// ValidateRuleJobRequest validates a request to apply the rules to the stored entries.
+func ValidateRuleJobRequest(store *storage.Storage, userID int64, request *model.RuleJobRequest) *locale.LocalizedError {
+	switch request.Action {
+	case model.RuleJobActionMarkRead, model.RuleJobActionRemove:
+	default:
+		return locale.NewLocalizedError("error.invalid_rule_job_action")
+	}
+
+	if request.FeedID != 0 && request.CategoryID != 0 {
+		return locale.NewLocalizedError("error.invalid_rule_job_scope")
+	}
+
+	if request.FeedID != 0 && !store.FeedExists(userID, request.FeedID) {
+		return locale.NewLocalizedError("error.feed_not_found")
+	}
+
+	if request.CategoryID != 0 && !store.CategoryIDExists(userID, request.CategoryID) {
+		return locale.NewLocalizedError("error.category_not_found")
+	}
+
+	return nil
+}
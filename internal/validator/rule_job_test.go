@@ -0,0 +1,25 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package validator // import "miniflux.app/v2/internal/validator"
+
+import (
+	"testing"
+
+	"miniflux.app/v2/internal/model"
+)
+
+func TestValidateRuleJobRequestWithInvalidAction(t *testing.T) {
+	for _, action := range []string{"", "delete", "block"} {
+		if err := ValidateRuleJobRequest(nil, 1, &model.RuleJobRequest{Action: action}); err == nil {
+			t.Errorf(`The action %q should generate an error`, action)
+		}
+	}
+}
+
+func TestValidateRuleJobRequestWithFeedAndCategory(t *testing.T) {
+	request := &model.RuleJobRequest{FeedID: 1, CategoryID: 2, Action: model.RuleJobActionMarkRead}
+	if err := ValidateRuleJobRequest(nil, 1, request); err == nil {
+		t.Error(`A request with both a feed and a category should generate an error`)
+	}
+}
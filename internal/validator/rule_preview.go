@@ -0,0 +1,91 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package validator // import "miniflux.app/v2/internal/validator"
+
+import (
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/storage"
+)
+
+// ValidateRulePreview validates a rule preview request and the candidate rules.
+func ValidateRulePreview(store *storage.Storage, userID int64, request *model.RulePreviewRequest) *locale.LocalizedError {
+	if request.FeedID != 0 && !store.FeedExists(userID, request.FeedID) {
+		return locale.NewLocalizedError("error.feed_not_found")
+	}
+
+	if request.CategoryID != 0 && !store.CategoryIDExists(userID, request.CategoryID) {
+		return locale.NewLocalizedError("error.category_not_found")
+	}
+
+	if request.Feed != nil && request.FeedID == 0 {
+		return locale.NewLocalizedError("error.feed_not_found")
+	}
+
+	if request.Category != nil && request.CategoryID == 0 {
+		return locale.NewLocalizedError("error.category_not_found")
+	}
+
+	if request.User != nil {
+		if err := validateCandidateFilterRules(request.User.BlockFilterEntryRules, request.User.KeepFilterEntryRules); err != nil {
+			return err
+		}
+
+		if request.User.EntryRules != nil {
+			if err := isValidEntryRules(*request.User.EntryRules); err != nil {
+				return err
+			}
+		}
+	}
+
+	if request.Category != nil {
+		if err := validateCategoryRules(request.Category); err != nil {
+			return err
+		}
+
+		if request.Category.EntryRules != nil {
+			if err := isValidEntryRules(*request.Category.EntryRules); err != nil {
+				return err
+			}
+		}
+	}
+
+	if request.Feed != nil {
+		if request.Feed.BlocklistRules != nil && !IsValidRegex(*request.Feed.BlocklistRules) {
+			return locale.NewLocalizedError("error.feed_invalid_blocklist_rule")
+		}
+
+		if request.Feed.KeeplistRules != nil && !IsValidRegex(*request.Feed.KeeplistRules) {
+			return locale.NewLocalizedError("error.feed_invalid_keeplist_rule")
+		}
+
+		if err := validateCandidateFilterRules(request.Feed.BlockFilterEntryRules, request.Feed.KeepFilterEntryRules); err != nil {
+			return err
+		}
+
+		if request.Feed.EntryRules != nil {
+			if err := isValidEntryRules(*request.Feed.EntryRules); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateCandidateFilterRules(blockFilterEntryRules, keepFilterEntryRules *string) *locale.LocalizedError {
+	if blockFilterEntryRules != nil && *blockFilterEntryRules != "" {
+		if err := isValidFilterRules(*blockFilterEntryRules, "block"); err != nil {
+			return err
+		}
+	}
+
+	if keepFilterEntryRules != nil && *keepFilterEntryRules != "" {
+		if err := isValidFilterRules(*keepFilterEntryRules, "keep"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
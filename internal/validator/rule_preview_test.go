@@ -0,0 +1,48 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package validator // import "miniflux.app/v2/internal/validator"
+
+import (
+	"testing"
+
+	"miniflux.app/v2/internal/model"
+)
+
+func TestValidateRulePreviewWithValidUserRules(t *testing.T) {
+	request := &model.RulePreviewRequest{
+		User: &model.UserModificationRequest{
+			BlockFilterEntryRules: new("EntryTitle=(?i)sponsored"),
+			KeepFilterEntryRules:  new(""),
+			EntryRules:            new(`if tag = "golang" then star`),
+		},
+	}
+
+	if err := ValidateRulePreview(nil, 1, request); err != nil {
+		t.Fatalf(`Valid candidate rules should not generate an error: %v`, err)
+	}
+}
+
+func TestValidateRulePreviewWithInvalidUserRules(t *testing.T) {
+	scenarios := []*model.UserModificationRequest{
+		{BlockFilterEntryRules: new("EntryTitle=[")},
+		{KeepFilterEntryRules: new("Title=golang")},
+		{EntryRules: new(`if title ~ "a" then explode`)},
+	}
+
+	for _, userRequest := range scenarios {
+		if err := ValidateRulePreview(nil, 1, &model.RulePreviewRequest{User: userRequest}); err == nil {
+			t.Errorf(`Invalid candidate rules should generate an error: %+v`, userRequest)
+		}
+	}
+}
+
+func TestValidateRulePreviewRequiresScope(t *testing.T) {
+	if err := ValidateRulePreview(nil, 1, &model.RulePreviewRequest{Feed: &model.FeedModificationRequest{}}); err == nil {
+		t.Error(`Feed rules without a feed should generate an error`)
+	}
+
+	if err := ValidateRulePreview(nil, 1, &model.RulePreviewRequest{Category: &model.CategoryModificationRequest{}}); err == nil {
+		t.Error(`Category rules without a category should generate an error`)
+	}
+}
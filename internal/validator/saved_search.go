@@ -0,0 +1,48 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package validator // import "miniflux.app/v2/internal/validator"
+
+import (
+	"strings"
+
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/storage"
+)
+
+// ValidateSavedSearchCreation validates saved search creation.
+func ValidateSavedSearchCreation(store *storage.Storage, userID int64, request *model.SavedSearchCreationRequest) *locale.LocalizedError {
+	if request.Title == "" {
+		return locale.NewLocalizedError("error.saved_search_title_required")
+	}
+
+	if strings.TrimSpace(request.Query) == "" {
+		return locale.NewLocalizedError("error.saved_search_query_required")
+	}
+
+	if store.SavedSearchTitleExists(userID, request.Title) {
+		return locale.NewLocalizedError("error.saved_search_already_exists")
+	}
+
+	return nil
+}
+
+// ValidateSavedSearchModification validates saved search modification.
+func ValidateSavedSearchModification(store *storage.Storage, userID, savedSearchID int64, request *model.SavedSearchModificationRequest) *locale.LocalizedError {
+	if request.Query != nil && strings.TrimSpace(*request.Query) == "" {
+		return locale.NewLocalizedError("error.saved_search_query_required")
+	}
+
+	if request.Title != nil {
+		if *request.Title == "" {
+			return locale.NewLocalizedError("error.saved_search_title_required")
+		}
+
+		if store.AnotherSavedSearchExists(userID, savedSearchID, *request.Title) {
+			return locale.NewLocalizedError("error.saved_search_already_exists")
+		}
+	}
+
+	return nil
+}
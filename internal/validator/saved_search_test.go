@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package validator // import "miniflux.app/v2/internal/validator"
+
+import (
+	"testing"
+
+	"miniflux.app/v2/internal/model"
+)
+
+func TestValidateSavedSearchCreationWithEmptyTitle(t *testing.T) {
+	request := &model.SavedSearchCreationRequest{Title: "", Query: "golang"}
+	err := ValidateSavedSearchCreation(nil, 1, request)
+	if err == nil {
+		t.Fatal(`An empty title should generate an error`)
+	}
+}
+
+func TestValidateSavedSearchCreationWithEmptyQuery(t *testing.T) {
+	request := &model.SavedSearchCreationRequest{Title: "Go", Query: "   "}
+	err := ValidateSavedSearchCreation(nil, 1, request)
+	if err == nil {
+		t.Fatal(`An empty query should generate an error`)
+	}
+}
+
+func TestValidateSavedSearchModificationWithEmptyQuery(t *testing.T) {
+	emptyQuery := ""
+	request := &model.SavedSearchModificationRequest{Query: &emptyQuery}
+	err := ValidateSavedSearchModification(nil, 1, 1, request)
+	if err == nil {
+		t.Fatal(`An empty query should generate an error`)
+	}
+}
+
+func TestValidateSavedSearchModificationWithEmptyTitle(t *testing.T) {
+	emptyTitle := ""
+	request := &model.SavedSearchModificationRequest{Title: &emptyTitle}
+	err := ValidateSavedSearchModification(nil, 1, 1, request)
+	if err == nil {
+		t.Fatal(`An empty title should generate an error`)
+	}
+}
+
+func TestValidateSavedSearchModificationWithNilFields(t *testing.T) {
+	request := &model.SavedSearchModificationRequest{}
+	err := ValidateSavedSearchModification(nil, 1, 1, request)
+	if err != nil {
+		t.Fatal(`Nil fields should not generate an error`)
+	}
+}
@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package validator // import "miniflux.app/v2/internal/validator"
+
+import (
+	"miniflux.app/v2/internal/locale"
+	"miniflux.app/v2/internal/model"
+	"miniflux.app/v2/internal/reader/scraper"
+	"miniflux.app/v2/internal/storage"
+	"miniflux.app/v2/internal/urllib"
+)
+
+// ValidateScraperPreview validates a scraper preview request.
+func ValidateScraperPreview(store *storage.Storage, userID int64, request *model.ScraperPreviewRequest) *locale.LocalizedError {
+	if !urllib.IsAbsoluteURL(request.URL) {
+		return locale.NewLocalizedError("error.invalid_scraper_preview_url")
+	}
+
+	if err := isValidScraperRules(request.ScraperRules); err != nil {
+		return err
+	}
+
+	if request.ProxyURL != "" && !urllib.IsAbsoluteURL(request.ProxyURL) {
+		return locale.NewLocalizedError("error.invalid_feed_proxy_url")
+	}
+
+	if err := isValidFeedSettingOverride(request.FetchViaProxyOverride); err != nil {
+		return err
+	}
+
+	if request.FeedID != 0 && !store.FeedExists(userID, request.FeedID) {
+		return locale.NewLocalizedError("error.feed_not_found")
+	}
+
+	return nil
+}
+
+func isValidScraperRules(scraperRules string) *locale.LocalizedError {
+	if scraperRules == "" {
+		return nil
+	}
+
+	if err := scraper.ValidateRules(scraperRules); err != nil {
+		return locale.NewLocalizedError("error.invalid_scraper_rules", err)
+	}
+
+	return nil
+}
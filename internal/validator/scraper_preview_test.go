@@ -0,0 +1,35 @@
+// SPDX-FileCopyrightText: Copyright The Miniflux Authors. All rights reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package validator // import "miniflux.app/v2/internal/validator"
+
+import (
+	"testing"
+
+	"miniflux.app/v2/internal/model"
+)
+
+func TestValidateScraperPreview(t *testing.T) {
+	scenarios := []struct {
+		request *model.ScraperPreviewRequest
+		valid   bool
+	}{
+		{&model.ScraperPreviewRequest{URL: "https://example.org/article"}, true},
+		{&model.ScraperPreviewRequest{URL: "https://example.org/article", ProxyURL: "http://proxy:3128"}, true},
+		{&model.ScraperPreviewRequest{URL: ""}, false},
+		{&model.ScraperPreviewRequest{URL: "/article"}, false},
+		{&model.ScraperPreviewRequest{URL: "https://example.org/article", ProxyURL: "proxy"}, false},
+		{&model.ScraperPreviewRequest{URL: "https://example.org/article", ScraperRules: "article -.ads, meta[property=og:image]@content"}, true},
+		{&model.ScraperPreviewRequest{URL: "https://example.org/article", ScraperRules: "//div/text()"}, false},
+	}
+
+	for _, scenario := range scenarios {
+		err := ValidateScraperPreview(nil, 1, scenario.request)
+		if scenario.valid && err != nil {
+			t.Errorf(`The request %+v should be valid: %v`, scenario.request, err)
+		}
+		if !scenario.valid && err == nil {
+			t.Errorf(`The request %+v should be invalid`, scenario.request)
+		}
+	}
+}
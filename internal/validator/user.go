@@ -104,6 +104,12 @@ func ValidateUserModification(store *storage.Storage, userID int64, changes *mod
 		}
 	}
 
+	if changes.DuplicateEntriesAction != nil {
+		if err := validateDuplicateEntriesAction(*changes.DuplicateEntriesAction); err != nil {
+			return err
+		}
+	}
+
 	if changes.DefaultReadingSpeed != nil {
 		if err := validateReadingSpeed(*changes.DefaultReadingSpeed); err != nil {
 			return err
@@ -144,6 +150,12 @@ func ValidateUserModification(store *storage.Storage, userID int64, changes *mod
 		}
 	}
 
+	if changes.EntryRules != nil {
+		if err := isValidEntryRules(*changes.EntryRules); err != nil {
+			return err
+		}
+	}
+
 	if changes.ExternalFontHosts != nil {
 		if !IsValidDomainList(*changes.ExternalFontHosts) {
 			return locale.NewLocalizedError("error.settings_invalid_domain_list")
@@ -239,6 +251,13 @@ func validateGestureNav(gestureNav string) *locale.LocalizedError {
 	return nil
 }
 
+func validateDuplicateEntriesAction(action string) *locale.LocalizedError {
+	if _, found := model.DuplicateEntriesActions()[action]; !found {
+		return locale.NewLocalizedError("error.invalid_duplicate_entries_action")
+	}
+	return nil
+}
+
 func validateDefaultHomePage(defaultHomePage string) *locale.LocalizedError {
 	defaultHomePages := model.HomePages()
 	if _, found := defaultHomePages[defaultHomePage]; !found {
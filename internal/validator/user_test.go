@@ -157,6 +157,18 @@ func TestValidateGestureNav(t *testing.T) {
 	}
 }
 
+func TestValidateDuplicateEntriesAction(t *testing.T) {
+	for _, action := range []string{"none", "annotate", "mark_as_read", "merge"} {
+		if err := validateDuplicateEntriesAction(action); err != nil {
+			t.Errorf("expected valid action %q to pass, got %v", action, err)
+		}
+	}
+
+	if err := validateDuplicateEntriesAction("delete"); err == nil {
+		t.Error("expected invalid action to fail")
+	}
+}
+
 func TestValidateDefaultHomePage(t *testing.T) {
 	if err := validateDefaultHomePage("unread"); err != nil {
 		t.Errorf("expected valid home page to pass, got %v", err)